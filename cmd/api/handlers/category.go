@@ -0,0 +1,11 @@
+package handlers
+
+import "net/http"
+
+type CategoryHandler interface {
+	CreateCategory(w http.ResponseWriter, r *http.Request) error
+	GetCategory(w http.ResponseWriter, r *http.Request) error
+	UpdateCategory(w http.ResponseWriter, r *http.Request) error
+	DeleteCategory(w http.ResponseWriter, r *http.Request) error
+	ListCategories(w http.ResponseWriter, r *http.Request) error
+}
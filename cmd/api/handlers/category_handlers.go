@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/service"
+)
+
+// categoryHandler serves the /categories routes
+type categoryHandler struct {
+	service service.CategoryService
+	parser  parser
+}
+
+// NewCategoryHandler creates a new instance of categoryHandler
+func NewCategoryHandler(service service.CategoryService) CategoryHandler {
+	return &categoryHandler{
+		service: service,
+		parser:  parser{},
+	}
+}
+
+// categoryID returns the categoryId path variable of the /categories/{categoryId} routes
+func categoryID(r *http.Request) string {
+	return mux.Vars(r)["categoryId"]
+}
+
+// CreateCategory handles the creation of a new category
+func (h *categoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request) error {
+	var category Category
+
+	ctx := r.Context()
+
+	err := json.NewDecoder(r.Body).Decode(&category)
+	if err != nil {
+		return NewDecodeRequestError(err)
+	}
+
+	createdCategory, err := h.service.CreateCategory(ctx, domain.Category{Name: category.Name, Position: category.Position})
+	if err != nil {
+		return err
+	}
+
+	return writeJSONResponse(w, http.StatusCreated, h.parser.toApiCategory(createdCategory))
+}
+
+// GetCategory handles the retrieval of a category by its categoryId
+func (h *categoryHandler) GetCategory(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	category, err := h.service.GetCategory(ctx, categoryID(r))
+	if err != nil {
+		return err
+	}
+
+	return writeJSONResponse(w, http.StatusOK, h.parser.toApiCategory(category))
+}
+
+// UpdateCategory handles the renaming and moving of a category
+func (h *categoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request) error {
+	var category Category
+
+	ctx := r.Context()
+
+	err := json.NewDecoder(r.Body).Decode(&category)
+	if err != nil {
+		return NewDecodeRequestError(err)
+	}
+
+	updatedCategory, err := h.service.UpdateCategory(ctx, domain.Category{ID: categoryID(r), Name: category.Name, Position: category.Position})
+	if err != nil {
+		return err
+	}
+
+	return writeJSONResponse(w, http.StatusOK, h.parser.toApiCategory(updatedCategory))
+}
+
+// DeleteCategory handles the removal of a category; its items are kept without a category
+func (h *categoryHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	err := h.service.DeleteCategory(ctx, categoryID(r))
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
+// ListCategories handles the listing of every category
+func (h *categoryHandler) ListCategories(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	categories, err := h.service.ListCategories(ctx)
+	if err != nil {
+		return err
+	}
+
+	apiCategories := make([]Category, len(categories))
+	for i, category := range categories {
+		apiCategories[i] = h.parser.toApiCategory(category)
+	}
+
+	return writeJSONResponse(w, http.StatusOK, ListCategoriesResponse{Categories: apiCategories})
+}
@@ -0,0 +1,323 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucaspereirasilva0/list-manager-api/cmd/api/handlers"
+	"github.com/lucaspereirasilva0/list-manager-api/cmd/api/handlers/middleware"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/service"
+)
+
+const (
+	_categoryID = "category-1"
+)
+
+var (
+	errCategoryNotFound    = service.NewErrorService(repository.NewCategoryNotFoundError(), "category not found", service.RepositorySource, http.StatusNotFound)
+	errAPICategoryNotFound = handlers.ErrorAPI{Cause: repository.NewCategoryNotFoundError().Error(), Message: "category not found", HTTP: http.StatusNotFound}
+)
+
+func TestCreateCategory(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenBody       string
+		givenServiceErr error
+		wantCategory    *domain.Category
+		wantHTTPStatus  int
+		wantErr         error
+	}{
+		{
+			name:           "Given_NameAndPosition_When_CreateCategory_Then_ExpectedHTTPStatusCreated",
+			givenBody:      `{"name":"Dairy","position":2}`,
+			wantCategory:   &domain.Category{Name: "Dairy", Position: 2},
+			wantHTTPStatus: http.StatusCreated,
+		},
+		{
+			name:            "Given_NegativePosition_When_CreateCategory_Then_ExpectedHTTPStatusUnprocessableEntity",
+			givenBody:       `{"name":"Dairy","position":-1}`,
+			givenServiceErr: service.NewErrorInvalidCategory(domain.ErrInvalidCategoryPosition),
+			wantCategory:    &domain.Category{Name: "Dairy", Position: -1},
+			wantHTTPStatus:  http.StatusUnprocessableEntity,
+			wantErr:         handlers.ErrorAPI{Cause: domain.ErrInvalidCategoryPosition.Error(), Message: domain.ErrInvalidCategoryPosition.Error(), HTTP: http.StatusUnprocessableEntity},
+		},
+		{
+			name:           "Given_InvalidJson_When_CreateCategory_Then_ExpectedHTTPStatusBadRequest",
+			givenBody:      `"Dairy"`,
+			wantHTTPStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(service.CategoryServiceMock)
+			if tt.wantCategory != nil {
+				serviceMock.On("CreateCategory", mock.Anything, *tt.wantCategory).Return(mockServiceCategory(), tt.givenServiceErr)
+			}
+
+			h := handlers.NewCategoryHandler(serviceMock)
+			handlerWithMiddleware := middleware.ErrorHandlingMiddleware(h.CreateCategory)
+
+			req := httptest.NewRequest(http.MethodPost, "/categories", strings.NewReader(tt.givenBody))
+			rec := httptest.NewRecorder()
+
+			handlerWithMiddleware.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantHTTPStatus, rec.Code)
+			if tt.wantErr != nil {
+				require.Equal(t, parserAPIErr(t, tt.wantErr), parserAPIErrFromBody(t, rec.Body.Bytes()))
+			} else if tt.wantHTTPStatus == http.StatusCreated {
+				require.Equal(t, mockAPICategory(), parserAPICategory(t, rec.Body.Bytes()))
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetCategory(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenServiceErr error
+		wantHTTPStatus  int
+		wantErr         error
+	}{
+		{
+			name:           "Given_ExistingCategory_When_GetCategory_Then_ExpectedHTTPStatusOK",
+			wantHTTPStatus: http.StatusOK,
+		},
+		{
+			name:            "Given_MissingCategory_When_GetCategory_Then_ExpectedHTTPStatusNotFound",
+			givenServiceErr: errCategoryNotFound,
+			wantHTTPStatus:  http.StatusNotFound,
+			wantErr:         errAPICategoryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(service.CategoryServiceMock)
+			serviceMock.On("GetCategory", mock.Anything, _categoryID).Return(mockServiceCategory(), tt.givenServiceErr)
+
+			h := handlers.NewCategoryHandler(serviceMock)
+			handlerWithMiddleware := middleware.ErrorHandlingMiddleware(h.GetCategory)
+
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/categories/"+_categoryID, nil), map[string]string{"categoryId": _categoryID})
+			rec := httptest.NewRecorder()
+
+			handlerWithMiddleware.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantHTTPStatus, rec.Code)
+			if tt.wantErr != nil {
+				require.Equal(t, parserAPIErr(t, tt.wantErr), parserAPIErrFromBody(t, rec.Body.Bytes()))
+			} else {
+				require.Equal(t, mockAPICategory(), parserAPICategory(t, rec.Body.Bytes()))
+			}
+		})
+	}
+}
+
+func TestUpdateCategory(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenServiceErr error
+		wantHTTPStatus  int
+		wantErr         error
+	}{
+		{
+			name:           "Given_NewPosition_When_UpdateCategory_Then_ExpectedHTTPStatusOK",
+			wantHTTPStatus: http.StatusOK,
+		},
+		{
+			name:            "Given_MissingCategory_When_UpdateCategory_Then_ExpectedHTTPStatusNotFound",
+			givenServiceErr: errCategoryNotFound,
+			wantHTTPStatus:  http.StatusNotFound,
+			wantErr:         errAPICategoryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(service.CategoryServiceMock)
+			serviceMock.On("UpdateCategory", mock.Anything, domain.Category{ID: _categoryID, Name: "Dairy", Position: 2}).Return(mockServiceCategory(), tt.givenServiceErr)
+
+			h := handlers.NewCategoryHandler(serviceMock)
+			handlerWithMiddleware := middleware.ErrorHandlingMiddleware(h.UpdateCategory)
+
+			req := httptest.NewRequest(http.MethodPut, "/categories/"+_categoryID, strings.NewReader(`{"id":"ignored","name":"Dairy","position":2}`))
+			req = mux.SetURLVars(req, map[string]string{"categoryId": _categoryID})
+			rec := httptest.NewRecorder()
+
+			handlerWithMiddleware.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantHTTPStatus, rec.Code)
+			if tt.wantErr != nil {
+				require.Equal(t, parserAPIErr(t, tt.wantErr), parserAPIErrFromBody(t, rec.Body.Bytes()))
+			} else {
+				require.Equal(t, mockAPICategory(), parserAPICategory(t, rec.Body.Bytes()))
+			}
+		})
+	}
+}
+
+func TestDeleteCategory(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenServiceErr error
+		wantHTTPStatus  int
+		wantErr         error
+	}{
+		{
+			name:           "Given_ExistingCategory_When_DeleteCategory_Then_ExpectedHTTPStatusNoContent",
+			wantHTTPStatus: http.StatusNoContent,
+		},
+		{
+			name:            "Given_MissingCategory_When_DeleteCategory_Then_ExpectedHTTPStatusNotFound",
+			givenServiceErr: errCategoryNotFound,
+			wantHTTPStatus:  http.StatusNotFound,
+			wantErr:         errAPICategoryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(service.CategoryServiceMock)
+			serviceMock.On("DeleteCategory", mock.Anything, _categoryID).Return(tt.givenServiceErr)
+
+			h := handlers.NewCategoryHandler(serviceMock)
+			handlerWithMiddleware := middleware.ErrorHandlingMiddleware(h.DeleteCategory)
+
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodDelete, "/categories/"+_categoryID, nil), map[string]string{"categoryId": _categoryID})
+			rec := httptest.NewRecorder()
+
+			handlerWithMiddleware.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantHTTPStatus, rec.Code)
+			if tt.wantErr != nil {
+				require.Equal(t, parserAPIErr(t, tt.wantErr), parserAPIErrFromBody(t, rec.Body.Bytes()))
+			}
+		})
+	}
+}
+
+func TestListCategories(t *testing.T) {
+	serviceMock := new(service.CategoryServiceMock)
+	serviceMock.On("ListCategories", mock.Anything).Return([]domain.Category{mockServiceCategory()}, nil)
+
+	h := handlers.NewCategoryHandler(serviceMock)
+	handlerWithMiddleware := middleware.ErrorHandlingMiddleware(h.ListCategories)
+
+	req := httptest.NewRequest(http.MethodGet, "/categories", nil)
+	rec := httptest.NewRecorder()
+
+	handlerWithMiddleware.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var response handlers.ListCategoriesResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Equal(t, handlers.ListCategoriesResponse{Categories: []handlers.Category{mockAPICategory()}}, response)
+}
+
+func TestGroupItems(t *testing.T) {
+	category := mockServiceCategory()
+	apiCategory := mockAPICategory()
+
+	tests := []struct {
+		name            string
+		givenTarget     string
+		givenServiceErr error
+		wantServiceCall bool
+		wantHTTPStatus  int
+		wantResponse    handlers.ListItemGroupsResponse
+		wantErr         error
+	}{
+		{
+			name:            "Given_GroupByCategory_When_ListItems_Then_ExpectedGroups",
+			givenTarget:     "/items?groupBy=category&active=true",
+			wantServiceCall: true,
+			wantHTTPStatus:  http.StatusOK,
+			wantResponse: handlers.ListItemGroupsResponse{Groups: []handlers.ItemGroup{
+				{Category: &apiCategory, Items: []handlers.Item{{ID: "1", Name: "Milk", CategoryID: _categoryID}}},
+				{Items: []handlers.Item{{ID: "2", Name: "Bread"}}},
+			}},
+		},
+		{
+			name:           "Given_UnknownGrouping_When_ListItems_Then_ExpectedHTTPStatusBadRequest",
+			givenTarget:    "/items?groupBy=unit",
+			wantHTTPStatus: http.StatusBadRequest,
+			wantErr:        handlers.NewInvalidQueryParamError("groupBy", errors.New(`"unit" is not a grouping, use category`)),
+		},
+		{
+			name:           "Given_GroupByWithLimit_When_ListItems_Then_ExpectedHTTPStatusBadRequest",
+			givenTarget:    "/items?groupBy=category&limit=10",
+			wantHTTPStatus: http.StatusBadRequest,
+			wantErr:        handlers.NewInvalidQueryParamError("groupBy", errors.New("grouped items are not paged, remove limit and cursor")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(service.ItemServiceMock)
+			if tt.wantServiceCall {
+				serviceMock.On("GroupItems", mock.Anything, domain.ListOptions{Filter: domain.ItemFilter{Active: ptr(true)}}).Return([]domain.ItemGroup{
+					{Category: &category, Items: []domain.Item{{ID: "1", Name: "Milk", CategoryID: _categoryID}}},
+					{Items: []domain.Item{{ID: "2", Name: "Bread"}}},
+				}, tt.givenServiceErr)
+			}
+
+			h := handlers.NewHandler(serviceMock)
+			handlerWithMiddleware := middleware.ErrorHandlingMiddleware(h.ListItems)
+
+			req := httptest.NewRequest(http.MethodGet, tt.givenTarget, nil)
+			rec := httptest.NewRecorder()
+
+			handlerWithMiddleware.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantHTTPStatus, rec.Code)
+			if tt.wantErr != nil {
+				require.Equal(t, parserAPIErr(t, tt.wantErr), parserAPIErrFromBody(t, rec.Body.Bytes()))
+			} else {
+				var response handlers.ListItemGroupsResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				require.Equal(t, tt.wantResponse, response)
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func mockServiceCategory() domain.Category {
+	return domain.Category{
+		ID:        _categoryID,
+		Name:      "Dairy",
+		Position:  2,
+		CreatedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func mockAPICategory() handlers.Category {
+	return handlers.Category{
+		ID:        _categoryID,
+		Name:      "Dairy",
+		Position:  2,
+		CreatedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func parserAPICategory(t *testing.T, body []byte) handlers.Category {
+	var category handlers.Category
+	require.NoError(t, json.Unmarshal(body, &category))
+	return category
+}
@@ -0,0 +1,107 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/lucaspereirasilva0/list-manager-api/cmd/api/handlers"
+	"github.com/lucaspereirasilva0/list-manager-api/cmd/api/handlers/middleware"
+	dbmongo "github.com/lucaspereirasilva0/list-manager-api/internal/database/mongodb"
+	mongorepo "github.com/lucaspereirasilva0/list-manager-api/internal/repository/mongodb"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/service"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/suggest"
+)
+
+// TestChaosErrorMapping runs the real repository, service and handler over a MongoDB
+// client injecting driver errors, to verify how each one reaches the API.
+func TestChaosErrorMapping(t *testing.T) {
+	tests := []struct {
+		name           string
+		givenErrorKind string
+		givenRequest   func() *http.Request
+		givenHandler   func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error
+		wantHTTPStatus int
+		wantMessage    string
+	}{
+		{
+			name:           "Given_DuplicateKeyError_When_CreateItem_Then_ExpectedHTTPStatusInternalServerError",
+			givenErrorKind: dbmongo.ChaosErrorDuplicateKey,
+			givenRequest: func() *http.Request {
+				body, _ := json.Marshal(mockItem())
+				return httptest.NewRequest(http.MethodPost, "/item", bytes.NewBuffer(body))
+			},
+			givenHandler:   func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error { return h.CreateItem },
+			wantHTTPStatus: http.StatusInternalServerError,
+			wantMessage:    "internal server error",
+		},
+		{
+			name:           "Given_TimeoutError_When_GetItem_Then_ExpectedHTTPStatusInternalServerError",
+			givenErrorKind: dbmongo.ChaosErrorTimeout,
+			givenRequest: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/item?id="+primitive.NewObjectID().Hex(), nil)
+			},
+			givenHandler:   func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error { return h.GetItem },
+			wantHTTPStatus: http.StatusInternalServerError,
+			wantMessage:    "internal server error",
+		},
+		{
+			name:           "Given_NetworkError_When_ListItems_Then_ExpectedHTTPStatusInternalServerError",
+			givenErrorKind: dbmongo.ChaosErrorNetwork,
+			givenRequest: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/items", nil)
+			},
+			givenHandler:   func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error { return h.ListItems },
+			wantHTTPStatus: http.StatusInternalServerError,
+			wantMessage:    "internal server error",
+		},
+		{
+			name:           "Given_WriteConflictError_When_DeleteItem_Then_ExpectedHTTPStatusInternalServerError",
+			givenErrorKind: dbmongo.ChaosErrorWriteConflict,
+			givenRequest: func() *http.Request {
+				return httptest.NewRequest(http.MethodDelete, "/item?id="+primitive.NewObjectID().Hex(), nil)
+			},
+			givenHandler:   func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error { return h.DeleteItem },
+			wantHTTPStatus: http.StatusInternalServerError,
+			wantMessage:    "internal server error",
+		},
+		{
+			name:           "Given_InvalidHexID_When_GetItem_Then_ExpectedHTTPStatusUnprocessableEntityBeforeAnyFault",
+			givenErrorKind: dbmongo.ChaosErrorTimeout,
+			givenRequest: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/item?id=invalid-hex-id", nil)
+			},
+			givenHandler:   func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error { return h.GetItem },
+			wantHTTPStatus: http.StatusUnprocessableEntity,
+			wantMessage:    "invalid hexadecimal representation of an ObjectID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientMock := new(dbmongo.MockClientOperations)
+			clientMock.On("GetCollection", mongorepo.CollectionItems).Return(new(dbmongo.MockMongoCollectionOperations))
+
+			chaosClient := dbmongo.NewChaosClient(clientMock, dbmongo.ChaosConfig{
+				ErrorRate: 1,
+				Errors:    []string{tt.givenErrorKind},
+			})
+			itemService := service.NewItemService(mongorepo.NewMongoDBItemRepository(chaosClient), mongorepo.NewMongoDBListRepository(chaosClient), mongorepo.NewMongoDBCategoryRepository(chaosClient), mongorepo.NewMongoTxManager(chaosClient), suggest.NewIndex())
+			h := handlers.NewHandler(itemService)
+
+			rec := httptest.NewRecorder()
+			middleware.ErrorHandlingMiddleware(tt.givenHandler(h)).ServeHTTP(rec, tt.givenRequest())
+
+			require.Equal(t, tt.wantHTTPStatus, rec.Code)
+			var errorResponse handlers.ErrorAPI
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+			require.Equal(t, tt.wantMessage, errorResponse.Message)
+			require.Equal(t, tt.wantHTTPStatus, errorResponse.HTTP)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/lucaspereirasilva0/list-manager-api/internal/service"
@@ -13,6 +14,9 @@ type ErrorAPI struct {
 	HTTP    int    `json:"http"`
 }
 
+// MaxRequestBodySize is the largest request body, in bytes, read by the API
+const MaxRequestBodySize = 1 << 20
+
 var (
 	ErrIDRequired = errors.New("id is required")
 )
@@ -24,7 +28,17 @@ func (e ErrorAPI) Error() string {
 	return e.Cause
 }
 
+// NewDecodeRequestError is returned when the request body cannot be read, with 413 when it
+// is over MaxRequestBodySize
 func NewDecodeRequestError(err error) ErrorAPI {
+	var errMaxBytes *http.MaxBytesError
+	if errors.As(err, &errMaxBytes) {
+		return ErrorAPI{
+			Cause:   fmt.Sprintf("the request body must have at most %d bytes", errMaxBytes.Limit),
+			Message: "request body too large",
+			HTTP:    http.StatusRequestEntityTooLarge,
+		}
+	}
 	return ErrorAPI{
 		Cause:   err.Error(),
 		Message: "failed to decode request",
@@ -32,6 +46,57 @@ func NewDecodeRequestError(err error) ErrorAPI {
 	}
 }
 
+func NewInvalidQueryParamError(param string, err error) ErrorAPI {
+	return ErrorAPI{
+		Cause:   err.Error(),
+		Message: fmt.Sprintf("invalid query parameter %q", param),
+		HTTP:    http.StatusBadRequest,
+	}
+}
+
+func NewInvalidHeaderError(header string, err error) ErrorAPI {
+	return ErrorAPI{
+		Cause:   err.Error(),
+		Message: fmt.Sprintf("invalid header %q", header),
+		HTTP:    http.StatusBadRequest,
+	}
+}
+
+// NewPreconditionFailedError is returned when the If-Match of a request is not the current ETag of the item
+func NewPreconditionFailedError(err error) ErrorAPI {
+	return ErrorAPI{
+		Cause:   err.Error(),
+		Message: "item was changed by someone else, read it again",
+		HTTP:    http.StatusPreconditionFailed,
+	}
+}
+
+// NewIdempotencyKeyReusedError is returned when an Idempotency-Key is sent again with another request body
+func NewIdempotencyKeyReusedError() ErrorAPI {
+	return ErrorAPI{
+		Cause:   "the key was already used with another request body",
+		Message: "idempotency key reused",
+		HTTP:    http.StatusUnprocessableEntity,
+	}
+}
+
+// NewIdempotencyKeyInProgressError is returned when a request with the same Idempotency-Key is still being processed
+func NewIdempotencyKeyInProgressError() ErrorAPI {
+	return ErrorAPI{
+		Cause:   "a request with the same key is still being processed, retry later",
+		Message: "idempotency key in use",
+		HTTP:    http.StatusConflict,
+	}
+}
+
+func NewUnsupportedMediaTypeError(contentType string) ErrorAPI {
+	return ErrorAPI{
+		Cause:   fmt.Sprintf("content type %q is not supported, use %s", contentType, MergePatchContentType),
+		Message: "unsupported media type",
+		HTTP:    http.StatusUnsupportedMediaType,
+	}
+}
+
 func NewInternalServerError(err error) ErrorAPI {
 	return ErrorAPI{
 		Cause:   err.Error(),
@@ -40,21 +105,27 @@ func NewInternalServerError(err error) ErrorAPI {
 	}
 }
 
-func HandleError(w http.ResponseWriter, err error) ErrorAPI {
+func HandleError(err error) ErrorAPI {
 	var (
-		errService service.ErrorService
-		errAPI     ErrorAPI
+		errService  service.ErrorService
+		errConflict service.ErrorConflict
+		errAPI      ErrorAPI
 	)
 
 	switch {
 	case errors.As(err, &errAPI):
 		return errAPI
+	case errors.As(err, &errConflict):
+		return NewPreconditionFailedError(errConflict.Cause)
 	case errors.As(err, &errService):
-		return ErrorAPI{
-			Cause:   errService.Cause.Error(),
+		errAPI := ErrorAPI{
 			Message: errService.Message,
 			HTTP:    errService.HTTP,
 		}
+		if errService.Cause != nil {
+			errAPI.Cause = errService.Cause.Error()
+		}
+		return errAPI
 	}
 
 	return NewInternalServerError(err)
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const headerIfMatch = "If-Match"
+
+var (
+	errIfMatchList = errors.New("only one entity tag is supported")
+	errStaleETag   = errors.New("the entity tag is not the current one")
+)
+
+// setETag sends the version of an item as its strong entity tag
+func setETag(w http.ResponseWriter, version int64) {
+	w.Header().Set("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// parseIfMatch returns the version required by the If-Match header of r, or 0
+// when any version is accepted (no header or "*"). Weak and foreign entity tags
+// never match, as If-Match uses the strong comparison.
+func parseIfMatch(r *http.Request) (int64, error) {
+	value := strings.TrimSpace(r.Header.Get(headerIfMatch))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+	if strings.Contains(value, ",") {
+		return 0, NewInvalidHeaderError(headerIfMatch, errIfMatchList)
+	}
+
+	// A weak tag (W/"1") does not start with a quote
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return 0, NewPreconditionFailedError(errStaleETag)
+	}
+	version, err := strconv.ParseInt(value[1:len(value)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, NewPreconditionFailedError(errStaleETag)
+	}
+
+	return version, nil
+}
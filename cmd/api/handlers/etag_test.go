@@ -0,0 +1,185 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucaspereirasilva0/list-manager-api/cmd/api/handlers"
+	"github.com/lucaspereirasilva0/list-manager-api/cmd/api/handlers/middleware"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/service"
+)
+
+func TestItemETag(t *testing.T) {
+	name := "updated-name"
+	storedItem := mockServiceItem()
+	storedItem.Version = 4
+
+	tests := []struct {
+		name           string
+		givenIfMatch   string
+		givenRequest   func() *http.Request
+		givenHandler   func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error
+		givenMock      func(serviceMock *service.ItemServiceMock)
+		wantHTTPStatus int
+		wantETag       string
+		wantErr        error
+	}{
+		{
+			name: "Given_Item_When_GetItem_Then_VersionIsSentAsETag",
+			givenRequest: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/item?id=any-id", nil)
+			},
+			givenHandler: func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error { return h.GetItem },
+			givenMock: func(serviceMock *service.ItemServiceMock) {
+				serviceMock.On("GetItem", mock.Anything, "any-id").Return(storedItem, nil)
+			},
+			wantHTTPStatus: http.StatusOK,
+			wantETag:       `"4"`,
+		},
+		{
+			name:         "Given_IfMatch_When_UpdateItem_Then_ItsVersionIsRequiredAndNewETagIsSent",
+			givenIfMatch: `"3"`,
+			givenRequest: func() *http.Request {
+				return httptest.NewRequest(http.MethodPut, "/item", strings.NewReader(`{"id":"any-id","name":"any name","active":true,"version":99}`))
+			},
+			givenHandler: func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error { return h.UpdateItem },
+			givenMock: func(serviceMock *service.ItemServiceMock) {
+				serviceMock.On("UpdateItem", mock.Anything, domain.Item{ID: "any-id", Name: "any name", Active: true, Version: 3}).Return(storedItem, nil)
+			},
+			wantHTTPStatus: http.StatusOK,
+			wantETag:       `"4"`,
+		},
+		{
+			name: "Given_NoIfMatch_When_UpdateItem_Then_AnyVersionIsAccepted",
+			givenRequest: func() *http.Request {
+				return httptest.NewRequest(http.MethodPut, "/item", strings.NewReader(`{"id":"any-id","name":"any name","active":true}`))
+			},
+			givenHandler: func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error { return h.UpdateItem },
+			givenMock: func(serviceMock *service.ItemServiceMock) {
+				serviceMock.On("UpdateItem", mock.Anything, domain.Item{ID: "any-id", Name: "any name", Active: true}).Return(storedItem, nil)
+			},
+			wantHTTPStatus: http.StatusOK,
+			wantETag:       `"4"`,
+		},
+		{
+			name:         "Given_StaleIfMatch_When_UpdateItem_Then_ExpectedHTTPStatusPreconditionFailed",
+			givenIfMatch: `"3"`,
+			givenRequest: func() *http.Request {
+				return httptest.NewRequest(http.MethodPut, "/item", strings.NewReader(`{"id":"any-id","name":"any name","active":true}`))
+			},
+			givenHandler: func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error { return h.UpdateItem },
+			givenMock: func(serviceMock *service.ItemServiceMock) {
+				serviceMock.On("UpdateItem", mock.Anything, mock.Anything).
+					Return(domain.Item{}, service.NewErrorConflict(repository.NewVersionConflictError()))
+			},
+			wantHTTPStatus: http.StatusPreconditionFailed,
+			wantErr:        handlers.NewPreconditionFailedError(repository.NewVersionConflictError()),
+		},
+		{
+			name:         "Given_IfMatch_When_PatchItem_Then_ItsVersionIsRequiredAndNewETagIsSent",
+			givenIfMatch: `"3"`,
+			givenRequest: func() *http.Request {
+				return httptest.NewRequest(http.MethodPatch, "/item?id=any-id", strings.NewReader(`{"name":"updated-name"}`))
+			},
+			givenHandler: func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error { return h.PatchItem },
+			givenMock: func(serviceMock *service.ItemServiceMock) {
+				serviceMock.On("PatchItem", mock.Anything, "any-id", domain.ItemPatch{Name: &name, Version: 3}).Return(storedItem, nil)
+			},
+			wantHTTPStatus: http.StatusOK,
+			wantETag:       `"4"`,
+		},
+		{
+			name:         "Given_IfMatch_When_DeleteItem_Then_ItsVersionIsRequired",
+			givenIfMatch: `"4"`,
+			givenRequest: func() *http.Request {
+				return httptest.NewRequest(http.MethodDelete, "/item?id=any-id", nil)
+			},
+			givenHandler: func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error { return h.DeleteItem },
+			givenMock: func(serviceMock *service.ItemServiceMock) {
+				serviceMock.On("DeleteItem", mock.Anything, "any-id", int64(4)).Return(nil)
+			},
+			wantHTTPStatus: http.StatusNoContent,
+		},
+		{
+			name:         "Given_WildcardIfMatch_When_DeleteItem_Then_AnyVersionIsAccepted",
+			givenIfMatch: "*",
+			givenRequest: func() *http.Request {
+				return httptest.NewRequest(http.MethodDelete, "/item?id=any-id", nil)
+			},
+			givenHandler: func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error { return h.DeleteItem },
+			givenMock: func(serviceMock *service.ItemServiceMock) {
+				serviceMock.On("DeleteItem", mock.Anything, "any-id", int64(0)).Return(nil)
+			},
+			wantHTTPStatus: http.StatusNoContent,
+		},
+		{
+			name:         "Given_StaleIfMatch_When_DeleteItem_Then_ExpectedHTTPStatusPreconditionFailed",
+			givenIfMatch: `"3"`,
+			givenRequest: func() *http.Request {
+				return httptest.NewRequest(http.MethodDelete, "/item?id=any-id", nil)
+			},
+			givenHandler: func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error { return h.DeleteItem },
+			givenMock: func(serviceMock *service.ItemServiceMock) {
+				serviceMock.On("DeleteItem", mock.Anything, "any-id", int64(3)).
+					Return(service.NewErrorConflict(repository.NewVersionConflictError()))
+			},
+			wantHTTPStatus: http.StatusPreconditionFailed,
+			wantErr:        handlers.NewPreconditionFailedError(repository.NewVersionConflictError()),
+		},
+		{
+			name:         "Given_WeakIfMatch_When_DeleteItem_Then_ExpectedHTTPStatusPreconditionFailed",
+			givenIfMatch: `W/"4"`,
+			givenRequest: func() *http.Request {
+				return httptest.NewRequest(http.MethodDelete, "/item?id=any-id", nil)
+			},
+			givenHandler:   func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error { return h.DeleteItem },
+			wantHTTPStatus: http.StatusPreconditionFailed,
+			wantErr:        handlers.NewPreconditionFailedError(errors.New("the entity tag is not the current one")),
+		},
+		{
+			name:         "Given_IfMatchList_When_UpdateItem_Then_ExpectedHTTPStatusBadRequest",
+			givenIfMatch: `"3", "4"`,
+			givenRequest: func() *http.Request {
+				return httptest.NewRequest(http.MethodPut, "/item", strings.NewReader(`{"id":"any-id","name":"any name"}`))
+			},
+			givenHandler:   func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error { return h.UpdateItem },
+			wantHTTPStatus: http.StatusBadRequest,
+			wantErr:        handlers.NewInvalidHeaderError("If-Match", errors.New("only one entity tag is supported")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(service.ItemServiceMock)
+			if tt.givenMock != nil {
+				tt.givenMock(serviceMock)
+			}
+
+			h := handlers.NewHandler(serviceMock)
+			handlerWithMiddleware := middleware.ErrorHandlingMiddleware(tt.givenHandler(h))
+
+			req := tt.givenRequest()
+			if tt.givenIfMatch != "" {
+				req.Header.Set("If-Match", tt.givenIfMatch)
+			}
+			rec := httptest.NewRecorder()
+
+			handlerWithMiddleware.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantHTTPStatus, rec.Code)
+			require.Equal(t, tt.wantETag, rec.Header().Get("ETag"))
+			if tt.wantErr != nil {
+				require.Equal(t, parserAPIErr(t, tt.wantErr), parserAPIErrFromBody(t, rec.Body.Bytes()))
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
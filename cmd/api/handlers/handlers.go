@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/gorilla/mux"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
 	"github.com/lucaspereirasilva0/list-manager-api/internal/service"
 )
 
@@ -21,6 +23,17 @@ func NewHandler(service service.ItemService) ItemHandler {
 	}
 }
 
+// listID is the listId of the /lists/{listId}/items routes, empty on the
+// routes that act on the default list
+func listID(r *http.Request) string {
+	return mux.Vars(r)["listId"]
+}
+
+// itemID returns the id path variable of the /items/{id} routes
+func itemID(r *http.Request) string {
+	return mux.Vars(r)["id"]
+}
+
 // CreateItem handles the creation of a new item
 func (h *handler) CreateItem(w http.ResponseWriter, r *http.Request) error {
 	var item Item
@@ -32,7 +45,10 @@ func (h *handler) CreateItem(w http.ResponseWriter, r *http.Request) error {
 		return NewDecodeRequestError(err)
 	}
 
-	createdItem, err := h.service.CreateItem(ctx, h.parser.toDomainModel(item))
+	domainItem := h.parser.toDomainModel(item)
+	domainItem.ListID = listID(r)
+
+	createdItem, err := h.service.CreateItem(ctx, domainItem)
 	if err != nil {
 		return err
 	}
@@ -57,32 +73,105 @@ func (h *handler) GetItem(w http.ResponseWriter, r *http.Request) error {
 	}
 
 	itemAPI := h.parser.toApiModel(item)
+	setETag(w, itemAPI.Version)
 
 	return writeJSONResponse(w, http.StatusOK, itemAPI)
 }
 
-// UpdateItem handles the update of an item
+// UpdateItem handles the update of an item, only if its ETag still matches If-Match when sent
 func (h *handler) UpdateItem(w http.ResponseWriter, r *http.Request) error {
 	var item Item
 
 	ctx := r.Context()
 
-	err := json.NewDecoder(r.Body).Decode(&item)
+	version, err := parseIfMatch(r)
+	if err != nil {
+		return err
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&item)
 	if err != nil {
 		return NewDecodeRequestError(err)
 	}
 
-	updatedItem, err := h.service.UpdateItem(ctx, h.parser.toDomainModel(item))
+	domainItem := h.parser.toDomainModel(item)
+	domainItem.Version = version
+
+	updatedItem, err := h.service.UpdateItem(ctx, domainItem)
 	if err != nil {
 		return err
 	}
 
 	itemAPI := h.parser.toApiModel(updatedItem)
+	setETag(w, itemAPI.Version)
 
 	return writeJSONResponse(w, http.StatusOK, itemAPI)
 }
 
-// DeleteItem handles the removal of an item
+// PatchItem handles the partial update of an item with a JSON merge patch (RFC 7396)
+func (h *handler) PatchItem(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		return NewDecodeRequestError(ErrIDRequired)
+	}
+
+	if contentType := r.Header.Get("Content-Type"); !isMergePatchContentType(contentType) {
+		return NewUnsupportedMediaTypeError(contentType)
+	}
+
+	version, err := parseIfMatch(r)
+	if err != nil {
+		return err
+	}
+
+	patch, err := decodeMergePatch(r.Body)
+	if err != nil {
+		return err
+	}
+	patch.Version = version
+
+	patchedItem, err := h.service.PatchItem(ctx, id, patch)
+	if err != nil {
+		return err
+	}
+
+	itemAPI := h.parser.toApiModel(patchedItem)
+	setETag(w, itemAPI.Version)
+
+	return writeJSONResponse(w, http.StatusOK, itemAPI)
+}
+
+// MoveItem handles the move of an item next to other items of its list, only if
+// its ETag still matches If-Match when sent. Only the position of the moved item changes.
+func (h *handler) MoveItem(w http.ResponseWriter, r *http.Request) error {
+	var req MoveItemRequest
+
+	ctx := r.Context()
+
+	version, err := parseIfMatch(r)
+	if err != nil {
+		return err
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return NewDecodeRequestError(err)
+	}
+
+	movedItem, err := h.service.MoveItem(ctx, itemID(r), domain.ItemMove{After: req.After, Before: req.Before, Version: version})
+	if err != nil {
+		return err
+	}
+
+	itemAPI := h.parser.toApiModel(movedItem)
+	setETag(w, itemAPI.Version)
+
+	return writeJSONResponse(w, http.StatusOK, itemAPI)
+}
+
+// DeleteItem handles the removal of an item, only if its ETag still matches If-Match when sent
 func (h *handler) DeleteItem(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 
@@ -91,7 +180,12 @@ func (h *handler) DeleteItem(w http.ResponseWriter, r *http.Request) error {
 		return NewDecodeRequestError(ErrIDRequired)
 	}
 
-	err := h.service.DeleteItem(ctx, id)
+	version, err := parseIfMatch(r)
+	if err != nil {
+		return err
+	}
+
+	err = h.service.DeleteItem(ctx, id, version)
 	if err != nil {
 		return err
 	}
@@ -101,11 +195,67 @@ func (h *handler) DeleteItem(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
-// ListItems handles the listing of all items
+// ListItems handles the listing of items, one page at a time (limit and cursor query
+// parameters), or all at once grouped by category (groupBy query parameter)
 func (h *handler) ListItems(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 
-	items, err := h.service.ListItems(ctx)
+	opts, err := parseListOptions(r.URL.Query())
+	if err != nil {
+		return err
+	}
+	opts.Filter.ListID = listID(r)
+
+	groupBy, err := parseGroupBy(r.URL.Query())
+	if err != nil {
+		return err
+	}
+	if groupBy == domain.GroupByCategory {
+		return h.groupItems(w, r, opts)
+	}
+
+	page, err := h.service.ListItems(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	apiItems := make([]Item, len(page.Items))
+	for i, item := range page.Items {
+		apiItems[i] = h.parser.toApiModel(item)
+	}
+
+	return writeJSONResponse(w, http.StatusOK, ListItemsResponse{
+		Items:      apiItems,
+		NextCursor: page.NextCursor,
+	})
+}
+
+// groupItems writes every item matching opts grouped by category
+func (h *handler) groupItems(w http.ResponseWriter, r *http.Request, opts domain.ListOptions) error {
+	groups, err := h.service.GroupItems(r.Context(), opts)
+	if err != nil {
+		return err
+	}
+
+	apiGroups := make([]ItemGroup, len(groups))
+	for i, group := range groups {
+		apiGroups[i] = h.parser.toApiItemGroup(group)
+	}
+
+	return writeJSONResponse(w, http.StatusOK, ListItemGroupsResponse{Groups: apiGroups})
+}
+
+// SearchItems handles the search of items by name and observation (q and limit
+// query parameters), in every list or in the one of the /lists/{listId} route
+func (h *handler) SearchItems(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	query, limit, err := parseSearchParams(r.URL.Query())
+	if err != nil {
+		return err
+	}
+
+	items, err := h.service.SearchItems(ctx, query, listID(r), limit)
 	if err != nil {
 		return err
 	}
@@ -115,10 +265,32 @@ func (h *handler) ListItems(w http.ResponseWriter, r *http.Request) error {
 		apiItems[i] = h.parser.toApiModel(item)
 	}
 
-	return writeJSONResponse(w, http.StatusOK, apiItems)
+	return writeJSONResponse(w, http.StatusOK, SearchItemsResponse{Items: apiItems})
+}
+
+// SuggestItemNames handles the autocomplete of item names (prefix and limit query parameters)
+func (h *handler) SuggestItemNames(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	prefix, limit, err := parseSuggestParams(r.URL.Query())
+	if err != nil {
+		return err
+	}
+
+	suggestions, err := h.service.SuggestItemNames(ctx, prefix, limit)
+	if err != nil {
+		return err
+	}
+
+	apiSuggestions := make([]Suggestion, len(suggestions))
+	for i, suggestion := range suggestions {
+		apiSuggestions[i] = Suggestion{Name: suggestion.Name, Count: suggestion.Count}
+	}
+
+	return writeJSONResponse(w, http.StatusOK, SuggestItemNamesResponse{Suggestions: apiSuggestions})
 }
 
-// BulkUpdateActive handles the bulk update of the active field for all items
+// BulkUpdateActive handles the bulk update of the active field of the selected items
 func (h *handler) BulkUpdateActive(w http.ResponseWriter, r *http.Request) error {
 	var req BulkActiveRequest
 
@@ -129,14 +301,128 @@ func (h *handler) BulkUpdateActive(w http.ResponseWriter, r *http.Request) error
 		return NewDecodeRequestError(err)
 	}
 
-	matchedCount, modifiedCount, err := h.service.BulkUpdateActive(ctx, req.Active)
+	update := h.parser.toDomainActiveUpdate(req)
+	update.Filter.ListID = listID(r)
+
+	result, err := h.service.BulkUpdateActive(ctx, update)
 	if err != nil {
 		return err
 	}
 
 	response := BulkActiveResponse{
-		MatchedCount:  matchedCount,
-		ModifiedCount: modifiedCount,
+		MatchedCount:  result.MatchedCount,
+		ModifiedCount: result.ModifiedCount,
+		NotFoundIDs:   result.NotFoundIDs,
+	}
+	if response.NotFoundIDs == nil {
+		response.NotFoundIDs = []string{}
+	}
+
+	return writeJSONResponse(w, http.StatusOK, response)
+}
+
+// DeleteItems handles the removal of the items matching the list filters (active,
+// name, hasObservation, createdAfter and updatedBefore query parameters)
+func (h *handler) DeleteItems(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	filter, err := parseItemFilter(r.URL.Query())
+	if err != nil {
+		return err
+	}
+	filter.ListID = listID(r)
+
+	deletedCount, err := h.service.DeleteItems(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return writeJSONResponse(w, http.StatusOK, DeleteItemsResponse{DeletedCount: deletedCount})
+}
+
+// TotalQuantities handles the sum of the quantities of the items matching the
+// filters of ListItems, converted to the base unit of each dimension
+func (h *handler) TotalQuantities(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	filter, err := parseItemFilter(r.URL.Query())
+	if err != nil {
+		return err
+	}
+	filter.ListID = listID(r)
+
+	quantities, err := h.service.TotalQuantities(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	totals := make([]Total, len(quantities))
+	for i, quantity := range quantities {
+		totals[i] = Total{Quantity: Quantity(quantity.Value), Unit: string(quantity.Unit)}
+	}
+
+	return writeJSONResponse(w, http.StatusOK, TotalsResponse{Totals: totals})
+}
+
+// SummarizePrices handles what the items matching the filters of ListItems cost,
+// per currency and category. Totals are exact sums rounded to the cent.
+func (h *handler) SummarizePrices(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	filter, err := parseItemFilter(r.URL.Query())
+	if err != nil {
+		return err
+	}
+	filter.ListID = listID(r)
+
+	summaries, err := h.service.SummarizePrices(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	response := SummaryResponse{Summaries: make([]PriceSummary, len(summaries))}
+	for i, summary := range summaries {
+		response.Summaries[i] = h.parser.toApiPriceSummary(summary)
+	}
+
+	return writeJSONResponse(w, http.StatusOK, response)
+}
+
+// BatchItems handles a batch of create, update and delete operations on the items
+// of one list. Each operation gets its own status code; with atomic=true they are
+// all applied or none is.
+func (h *handler) BatchItems(w http.ResponseWriter, r *http.Request) error {
+	var req BatchRequest
+
+	ctx := r.Context()
+
+	atomic, err := parseAtomic(r.URL.Query())
+	if err != nil {
+		return err
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return NewDecodeRequestError(err)
+	}
+
+	ops := make([]domain.BatchOperation, len(req.Operations))
+	for i, op := range req.Operations {
+		ops[i] = domain.BatchOperation{
+			Type: domain.BatchOperationType(op.Op),
+			Item: h.parser.toDomainModel(op.Item),
+		}
+		ops[i].Item.ListID = listID(r)
+	}
+
+	results, err := h.service.BatchItems(ctx, ops, atomic)
+	if err != nil {
+		return err
+	}
+
+	response := BatchResponse{Results: make([]BatchResult, len(results))}
+	for i, result := range results {
+		response.Results[i] = h.parser.toApiBatchResult(ops[i].Type, result)
 	}
 
 	return writeJSONResponse(w, http.StatusOK, response)
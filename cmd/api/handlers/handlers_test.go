@@ -4,10 +4,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/lucaspereirasilva0/list-manager-api/cmd/api/handlers"
 	"github.com/lucaspereirasilva0/list-manager-api/cmd/api/handlers/middleware"
 	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
@@ -50,6 +54,19 @@ func TestCreateItem(t *testing.T) {
 			wantHTTPStatus:   http.StatusBadRequest,
 			wantErr:          handlers.NewDecodeRequestError(errors.New("json: cannot unmarshal string into Go value of type handlers.Item")),
 		},
+		{
+			name:                   "Given_ItemWithUnitPrice_When_CreateItem_Then_ExpectedUnitPriceAsString",
+			givenRequestBody:       json.RawMessage(`{"name":"any name","active":true,"unitPrice":12.9}`),
+			givenMockedServiceItem: domain.Item{ID: "any-id", Name: "any name", Active: true, UnitPrice: ptr(domain.Amount(1290)), Currency: "BRL"},
+			wantAPIItem:            handlers.Item{ID: "any-id", Name: "any name", Active: true, UnitPrice: ptr(handlers.Price(1290)), Currency: "BRL"},
+			wantHTTPStatus:         http.StatusCreated,
+		},
+		{
+			name:             "Given_UnitPriceWithThreeDecimals_When_CreateItem_Then_ExpectedHTTPStatusBadRequest",
+			givenRequestBody: json.RawMessage(`{"name":"any name","active":true,"unitPrice":"12.999"}`),
+			wantHTTPStatus:   http.StatusBadRequest,
+			wantErr:          handlers.NewDecodeRequestError(domain.ErrInvalidAmount),
+		},
 		{
 			name:             "Given_ItemWithMockedServiceError_When_CreateItem_Then_ExpectedHTTPStatusInternalServerError",
 			givenRequestBody: mockItem(),
@@ -250,6 +267,310 @@ func TestUpdateItem(t *testing.T) {
 	}
 }
 
+func TestPatchItem(t *testing.T) {
+	name := "updated-name"
+	active := false
+
+	tests := []struct {
+		name                   string
+		givenItemID            string
+		givenContentType       string
+		givenRequestBody       string
+		givenServiceErr        error
+		givenMockedServiceItem domain.Item
+		wantPatch              domain.ItemPatch
+		wantAPIItem            handlers.Item
+		wantHTTPStatus         int
+		wantErr                error
+	}{
+		{
+			name:                   "Given_ChangedFields_When_PatchItem_Then_OnlyThoseFieldsArePatched",
+			givenItemID:            "any-id",
+			givenContentType:       handlers.MergePatchContentType,
+			givenRequestBody:       `{"name":"updated-name","active":false}`,
+			givenMockedServiceItem: mockServiceItem(),
+			wantPatch:              domain.ItemPatch{Name: &name, Active: &active},
+			wantAPIItem:            mockAPIItem(),
+			wantHTTPStatus:         http.StatusOK,
+		},
+		{
+			name:                   "Given_NullObservation_When_PatchItem_Then_ObservationIsRemoved",
+			givenItemID:            "any-id",
+			givenContentType:       "application/json",
+			givenRequestBody:       `{"observation":null}`,
+			givenMockedServiceItem: mockServiceItem(),
+			wantPatch:              domain.ItemPatch{SetObservation: true},
+			wantAPIItem:            mockAPIItem(),
+			wantHTTPStatus:         http.StatusOK,
+		},
+		{
+			name:                   "Given_QuantityAndUnit_When_PatchItem_Then_QuantityIsPatched",
+			givenItemID:            "any-id",
+			givenContentType:       handlers.MergePatchContentType,
+			givenRequestBody:       `{"quantity":1.5,"unit":"kg"}`,
+			givenMockedServiceItem: mockServiceItem(),
+			wantPatch:              domain.ItemPatch{Quantity: ptr(domain.Thousandths(1500)), Unit: domain.UnitKilogram, SetQuantity: true},
+			wantAPIItem:            mockAPIItem(),
+			wantHTTPStatus:         http.StatusOK,
+		},
+		{
+			name:                   "Given_NullQuantity_When_PatchItem_Then_QuantityIsRemoved",
+			givenItemID:            "any-id",
+			givenContentType:       handlers.MergePatchContentType,
+			givenRequestBody:       `{"quantity":null}`,
+			givenMockedServiceItem: mockServiceItem(),
+			wantPatch:              domain.ItemPatch{SetQuantity: true},
+			wantAPIItem:            mockAPIItem(),
+			wantHTTPStatus:         http.StatusOK,
+		},
+		{
+			name:                   "Given_NullCategoryID_When_PatchItem_Then_CategoryIsRemoved",
+			givenItemID:            "any-id",
+			givenContentType:       handlers.MergePatchContentType,
+			givenRequestBody:       `{"categoryId":null}`,
+			givenMockedServiceItem: mockServiceItem(),
+			wantPatch:              domain.ItemPatch{SetCategory: true},
+			wantAPIItem:            mockAPIItem(),
+			wantHTTPStatus:         http.StatusOK,
+		},
+		{
+			name:                   "Given_EmptyCategoryID_When_PatchItem_Then_CategoryIsRemoved",
+			givenItemID:            "any-id",
+			givenContentType:       handlers.MergePatchContentType,
+			givenRequestBody:       `{"categoryId":""}`,
+			givenMockedServiceItem: mockServiceItem(),
+			wantPatch:              domain.ItemPatch{SetCategory: true},
+			wantAPIItem:            mockAPIItem(),
+			wantHTTPStatus:         http.StatusOK,
+		},
+		{
+			name:                   "Given_UnitPriceAndCurrency_When_PatchItem_Then_PriceIsPatched",
+			givenItemID:            "any-id",
+			givenContentType:       handlers.MergePatchContentType,
+			givenRequestBody:       `{"unitPrice":"4.50","currency":"usd"}`,
+			givenMockedServiceItem: mockServiceItem(),
+			wantPatch:              domain.ItemPatch{UnitPrice: ptr(domain.Amount(450)), Currency: "usd", SetPrice: true},
+			wantAPIItem:            mockAPIItem(),
+			wantHTTPStatus:         http.StatusOK,
+		},
+		{
+			name:                   "Given_NullUnitPrice_When_PatchItem_Then_PriceIsRemoved",
+			givenItemID:            "any-id",
+			givenContentType:       handlers.MergePatchContentType,
+			givenRequestBody:       `{"unitPrice":null}`,
+			givenMockedServiceItem: mockServiceItem(),
+			wantPatch:              domain.ItemPatch{SetPrice: true},
+			wantAPIItem:            mockAPIItem(),
+			wantHTTPStatus:         http.StatusOK,
+		},
+		{
+			name:             "Given_InvalidUnitPrice_When_PatchItem_Then_ExpectedHTTPStatusBadRequest",
+			givenItemID:      "any-id",
+			givenContentType: handlers.MergePatchContentType,
+			givenRequestBody: `{"unitPrice":"R$ 4,50"}`,
+			wantHTTPStatus:   http.StatusBadRequest,
+			wantErr:          handlers.NewDecodeRequestError(errors.New(`"unitPrice" must be a decimal number with up to 2 decimal places or null`)),
+		},
+		{
+			name:             "Given_NullCurrency_When_PatchItem_Then_ExpectedHTTPStatusBadRequest",
+			givenItemID:      "any-id",
+			givenContentType: handlers.MergePatchContentType,
+			givenRequestBody: `{"currency":null}`,
+			wantHTTPStatus:   http.StatusBadRequest,
+			wantErr:          handlers.NewDecodeRequestError(errors.New(`"currency" is removed with the unit price`)),
+		},
+		{
+			name:             "Given_NumericCategoryID_When_PatchItem_Then_ExpectedHTTPStatusBadRequest",
+			givenItemID:      "any-id",
+			givenContentType: handlers.MergePatchContentType,
+			givenRequestBody: `{"categoryId":1}`,
+			wantHTTPStatus:   http.StatusBadRequest,
+			wantErr:          handlers.NewDecodeRequestError(errors.New(`"categoryId" must be a string or null`)),
+		},
+		{
+			name:             "Given_NullUnit_When_PatchItem_Then_ExpectedHTTPStatusBadRequest",
+			givenItemID:      "any-id",
+			givenContentType: handlers.MergePatchContentType,
+			givenRequestBody: `{"unit":null}`,
+			wantHTTPStatus:   http.StatusBadRequest,
+			wantErr:          handlers.NewDecodeRequestError(errors.New(`"unit" is removed with the quantity`)),
+		},
+		{
+			name:             "Given_NullName_When_PatchItem_Then_ExpectedHTTPStatusBadRequest",
+			givenItemID:      "any-id",
+			givenContentType: handlers.MergePatchContentType,
+			givenRequestBody: `{"name":null}`,
+			wantHTTPStatus:   http.StatusBadRequest,
+			wantErr:          handlers.NewDecodeRequestError(errors.New(`"name" cannot be removed`)),
+		},
+		{
+			name:             "Given_ReadOnlyField_When_PatchItem_Then_ExpectedHTTPStatusBadRequest",
+			givenItemID:      "any-id",
+			givenContentType: handlers.MergePatchContentType,
+			givenRequestBody: `{"createdAt":"2024-01-01T00:00:00Z"}`,
+			wantHTTPStatus:   http.StatusBadRequest,
+			wantErr:          handlers.NewDecodeRequestError(errors.New(`"createdAt" is read-only`)),
+		},
+		{
+			name:             "Given_WrongType_When_PatchItem_Then_ExpectedHTTPStatusBadRequest",
+			givenItemID:      "any-id",
+			givenContentType: handlers.MergePatchContentType,
+			givenRequestBody: `{"active":"yes"}`,
+			wantHTTPStatus:   http.StatusBadRequest,
+			wantErr:          handlers.NewDecodeRequestError(errors.New(`"active" must be a boolean`)),
+		},
+		{
+			name:             "Given_NonObjectBody_When_PatchItem_Then_ExpectedHTTPStatusBadRequest",
+			givenItemID:      "any-id",
+			givenContentType: handlers.MergePatchContentType,
+			givenRequestBody: `null`,
+			wantHTTPStatus:   http.StatusBadRequest,
+			wantErr:          handlers.NewDecodeRequestError(errors.New("the merge patch must be a JSON object")),
+		},
+		{
+			name:             "Given_UnsupportedContentType_When_PatchItem_Then_ExpectedHTTPStatusUnsupportedMediaType",
+			givenItemID:      "any-id",
+			givenContentType: "text/plain",
+			givenRequestBody: `{"name":"updated-name"}`,
+			wantHTTPStatus:   http.StatusUnsupportedMediaType,
+			wantErr:          handlers.NewUnsupportedMediaTypeError("text/plain"),
+		},
+		{
+			name:             "Given_MissingID_When_PatchItem_Then_ExpectedHTTPStatusBadRequest",
+			givenContentType: handlers.MergePatchContentType,
+			givenRequestBody: `{"name":"updated-name"}`,
+			wantHTTPStatus:   http.StatusBadRequest,
+			wantErr:          handlers.NewDecodeRequestError(handlers.ErrIDRequired),
+		},
+		{
+			name:             "Given_ServiceError_When_PatchItem_Then_ExpectedHTTPStatusInternalServerError",
+			givenItemID:      "any-id",
+			givenContentType: handlers.MergePatchContentType,
+			givenRequestBody: `{"name":"updated-name"}`,
+			givenServiceErr:  errDummy,
+			wantPatch:        domain.ItemPatch{Name: &name},
+			wantHTTPStatus:   http.StatusInternalServerError,
+			wantErr:          handlers.NewInternalServerError(errDummy),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(service.ItemServiceMock)
+			if tt.wantErr == nil || tt.givenServiceErr != nil {
+				serviceMock.On("PatchItem", mock.Anything, tt.givenItemID, tt.wantPatch).Return(tt.givenMockedServiceItem, tt.givenServiceErr)
+			}
+
+			h := handlers.NewHandler(serviceMock)
+			handlerWithMiddleware := middleware.ErrorHandlingMiddleware(h.PatchItem)
+
+			req := httptest.NewRequest(http.MethodPatch, "/item?id="+tt.givenItemID, strings.NewReader(tt.givenRequestBody))
+			req.Header.Set("Content-Type", tt.givenContentType)
+			rec := httptest.NewRecorder()
+
+			handlerWithMiddleware.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantHTTPStatus, rec.Code)
+			if tt.wantErr != nil {
+				require.Equal(t, parserAPIErr(t, tt.wantErr), parserAPIErrFromBody(t, rec.Body.Bytes()))
+			} else {
+				require.Equal(t, tt.wantAPIItem, parserAPIItem(t, rec.Body.Bytes()))
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMoveItem(t *testing.T) {
+	movedItem := mockServiceItem()
+	movedItem.Position = "i0i"
+	movedItem.Version = 5
+	wantAPIItem := mockAPIItem()
+	wantAPIItem.Position = "i0i"
+	wantAPIItem.Version = 5
+
+	tests := []struct {
+		name                   string
+		givenRequestBody       string
+		givenIfMatch           string
+		givenServiceErr        error
+		givenMockedServiceItem domain.Item
+		wantMove               domain.ItemMove
+		wantAPIItem            handlers.Item
+		wantHTTPStatus         int
+		wantErr                error
+	}{
+		{
+			name:                   "Given_After_When_MoveItem_Then_ExpectedHTTPStatusOK",
+			givenRequestBody:       `{"after":"first-id"}`,
+			givenMockedServiceItem: movedItem,
+			wantMove:               domain.ItemMove{After: "first-id"},
+			wantAPIItem:            wantAPIItem,
+			wantHTTPStatus:         http.StatusOK,
+		},
+		{
+			name:                   "Given_BeforeAndIfMatch_When_MoveItem_Then_VersionIsChecked",
+			givenRequestBody:       `{"before":"first-id"}`,
+			givenIfMatch:           `"4"`,
+			givenMockedServiceItem: movedItem,
+			wantMove:               domain.ItemMove{Before: "first-id", Version: 4},
+			wantAPIItem:            wantAPIItem,
+			wantHTTPStatus:         http.StatusOK,
+		},
+		{
+			name:             "Given_ReorderedNeighbours_When_MoveItem_Then_ExpectedHTTPStatusConflict",
+			givenRequestBody: `{"after":"second-id","before":"first-id"}`,
+			givenServiceErr:  service.NewErrorStaleNeighbours(),
+			wantMove:         domain.ItemMove{After: "second-id", Before: "first-id"},
+			wantHTTPStatus:   http.StatusConflict,
+			wantErr:          handlers.ErrorAPI{Message: "after no longer comes before before, read the list again", HTTP: http.StatusConflict},
+		},
+		{
+			name:             "Given_NoNeighbour_When_MoveItem_Then_ExpectedHTTPStatusUnprocessableEntity",
+			givenRequestBody: `{}`,
+			givenServiceErr:  service.NewErrorInvalidMove(domain.ErrMoveWithoutNeighbour),
+			wantHTTPStatus:   http.StatusUnprocessableEntity,
+			wantErr:          handlers.ErrorAPI{Cause: domain.ErrMoveWithoutNeighbour.Error(), Message: domain.ErrMoveWithoutNeighbour.Error(), HTTP: http.StatusUnprocessableEntity},
+		},
+		{
+			name:             "Given_TruncatedBody_When_MoveItem_Then_ExpectedHTTPStatusBadRequest",
+			givenRequestBody: `{"after":`,
+			wantHTTPStatus:   http.StatusBadRequest,
+			wantErr:          handlers.NewDecodeRequestError(io.ErrUnexpectedEOF),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(service.ItemServiceMock)
+			if tt.wantErr == nil || tt.givenServiceErr != nil {
+				serviceMock.On("MoveItem", mock.Anything, "any-id", tt.wantMove).Return(tt.givenMockedServiceItem, tt.givenServiceErr)
+			}
+
+			h := handlers.NewHandler(serviceMock)
+			handlerWithMiddleware := middleware.ErrorHandlingMiddleware(h.MoveItem)
+
+			req := httptest.NewRequest(http.MethodPost, "/items/any-id/move", strings.NewReader(tt.givenRequestBody))
+			req = mux.SetURLVars(req, map[string]string{"id": "any-id"})
+			if tt.givenIfMatch != "" {
+				req.Header.Set("If-Match", tt.givenIfMatch)
+			}
+			rec := httptest.NewRecorder()
+
+			handlerWithMiddleware.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantHTTPStatus, rec.Code)
+			if tt.wantErr != nil {
+				require.Equal(t, parserAPIErr(t, tt.wantErr), parserAPIErrFromBody(t, rec.Body.Bytes()))
+			} else {
+				require.Equal(t, tt.wantAPIItem, parserAPIItem(t, rec.Body.Bytes()))
+				require.Equal(t, `"5"`, rec.Header().Get("ETag"))
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
 func TestDeleteItem(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -289,7 +610,7 @@ func TestDeleteItem(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Setup
 			serviceMock := new(service.ItemServiceMock)
-			serviceMock.On("DeleteItem", mock.Anything, tt.givenItemID).Return(tt.givenServiceErr)
+			serviceMock.On("DeleteItem", mock.Anything, tt.givenItemID, int64(0)).Return(tt.givenServiceErr)
 
 			// Create handler with mock service
 			h := handlers.NewHandler(serviceMock)
@@ -318,24 +639,109 @@ func TestDeleteItem(t *testing.T) {
 
 func TestListItems(t *testing.T) {
 	tests := []struct {
-		name                    string
-		givenServiceErr         error
-		givenMockedServiceItems []domain.Item
-		wantAPIItems            []handlers.Item
-		wantHTTPStatus          int
-		wantErr                 error
+		name                   string
+		givenQuery             string
+		givenServiceErr        error
+		givenMockedServicePage domain.ItemPage
+		wantServiceOptions     domain.ListOptions
+		wantResponse           handlers.ListItemsResponse
+		wantHTTPStatus         int
+		wantErr                error
 	}{
 		{
-			name:                    "Given_Items_When_ListItems_Then_ExpectedHTTPStatusOK",
-			givenMockedServiceItems: []domain.Item{mockServiceItem()},
-			wantAPIItems:            []handlers.Item{mockAPIItem()},
-			wantHTTPStatus:          http.StatusOK,
+			name:                   "Given_Items_When_ListItems_Then_ExpectedHTTPStatusOK",
+			givenMockedServicePage: domain.ItemPage{Items: []domain.Item{mockServiceItem()}},
+			wantResponse:           handlers.ListItemsResponse{Items: []handlers.Item{mockAPIItem()}},
+			wantHTTPStatus:         http.StatusOK,
+		},
+		{
+			name:                   "Given_NoItems_When_ListItems_Then_ExpectedHTTPStatusOK",
+			givenMockedServicePage: domain.ItemPage{Items: []domain.Item{}},
+			wantResponse:           handlers.ListItemsResponse{Items: []handlers.Item{}},
+			wantHTTPStatus:         http.StatusOK,
+		},
+		{
+			name:                   "Given_LimitAndCursor_When_ListItems_Then_ExpectedPageWithNextCursor",
+			givenQuery:             "?limit=1&cursor=abc",
+			givenMockedServicePage: domain.ItemPage{Items: []domain.Item{mockServiceItem()}, NextCursor: "def"},
+			wantServiceOptions:     domain.ListOptions{Limit: 1, Cursor: "abc"},
+			wantResponse:           handlers.ListItemsResponse{Items: []handlers.Item{mockAPIItem()}, NextCursor: "def"},
+			wantHTTPStatus:         http.StatusOK,
+		},
+		{
+			name:                   "Given_Filters_When_ListItems_Then_ExpectedFilterPassedToService",
+			givenQuery:             "?active=false&name=milk&hasObservation=true&createdAfter=2025-01-02T03:04:05Z&updatedBefore=2025-02-01T00:00:00-03:00",
+			givenMockedServicePage: domain.ItemPage{Items: []domain.Item{mockServiceItem()}},
+			wantServiceOptions: domain.ListOptions{Filter: domain.ItemFilter{
+				Active:         ptr(false),
+				Name:           "milk",
+				HasObservation: ptr(true),
+				CreatedAfter:   ptr(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)),
+				UpdatedBefore:  ptr(time.Date(2025, 2, 1, 0, 0, 0, 0, time.FixedZone("", -3*60*60))),
+			}},
+			wantResponse:   handlers.ListItemsResponse{Items: []handlers.Item{mockAPIItem()}},
+			wantHTTPStatus: http.StatusOK,
+		},
+		{
+			name:                   "Given_SortWithFilterAndCursor_When_ListItems_Then_ExpectedSortPassedToService",
+			givenQuery:             "?sort=-updatedAt,name&active=true&limit=2&cursor=abc",
+			givenMockedServicePage: domain.ItemPage{Items: []domain.Item{mockServiceItem()}},
+			wantServiceOptions: domain.ListOptions{
+				Limit:  2,
+				Cursor: "abc",
+				Filter: domain.ItemFilter{Active: ptr(true)},
+				Sort:   []domain.SortField{{Field: domain.SortByUpdatedAt, Desc: true}, {Field: domain.SortByName}},
+			},
+			wantResponse:   handlers.ListItemsResponse{Items: []handlers.Item{mockAPIItem()}},
+			wantHTTPStatus: http.StatusOK,
+		},
+		{
+			name:           "Given_UnknownSortField_When_ListItems_Then_ExpectedHTTPStatusBadRequest",
+			givenQuery:     "?sort=observation",
+			wantHTTPStatus: http.StatusBadRequest,
+			wantErr:        handlers.NewInvalidQueryParamError("sort", errors.New(`"observation" is not sortable, use name, active, createdAt, updatedAt, position`)),
+		},
+		{
+			name:           "Given_RepeatedSortField_When_ListItems_Then_ExpectedHTTPStatusBadRequest",
+			givenQuery:     "?sort=name,-name",
+			wantHTTPStatus: http.StatusBadRequest,
+			wantErr:        handlers.NewInvalidQueryParamError("sort", errors.New(`"name" is repeated`)),
+		},
+		{
+			name:           "Given_InvalidActive_When_ListItems_Then_ExpectedHTTPStatusBadRequest",
+			givenQuery:     "?active=yes",
+			wantHTTPStatus: http.StatusBadRequest,
+			wantErr:        handlers.NewInvalidQueryParamError("active", errors.New(`"yes" is not a boolean (true or false)`)),
+		},
+		{
+			name:           "Given_InvalidHasObservation_When_ListItems_Then_ExpectedHTTPStatusBadRequest",
+			givenQuery:     "?hasObservation=maybe",
+			wantHTTPStatus: http.StatusBadRequest,
+			wantErr:        handlers.NewInvalidQueryParamError("hasObservation", errors.New(`"maybe" is not a boolean (true or false)`)),
+		},
+		{
+			name:           "Given_InvalidCreatedAfter_When_ListItems_Then_ExpectedHTTPStatusBadRequest",
+			givenQuery:     "?createdAfter=yesterday",
+			wantHTTPStatus: http.StatusBadRequest,
+			wantErr:        handlers.NewInvalidQueryParamError("createdAfter", errors.New(`"yesterday" is not an RFC 3339 timestamp`)),
+		},
+		{
+			name:           "Given_InvalidUpdatedBefore_When_ListItems_Then_ExpectedHTTPStatusBadRequest",
+			givenQuery:     "?updatedBefore=2025-13-01",
+			wantHTTPStatus: http.StatusBadRequest,
+			wantErr:        handlers.NewInvalidQueryParamError("updatedBefore", errors.New(`"2025-13-01" is not an RFC 3339 timestamp`)),
 		},
 		{
-			name:                    "Given_NoItems_When_ListItems_Then_ExpectedHTTPStatusOK",
-			givenMockedServiceItems: []domain.Item{},
-			wantAPIItems:            []handlers.Item{},
-			wantHTTPStatus:          http.StatusOK,
+			name:           "Given_NonNumericLimit_When_ListItems_Then_ExpectedHTTPStatusBadRequest",
+			givenQuery:     "?limit=ten",
+			wantHTTPStatus: http.StatusBadRequest,
+			wantErr:        handlers.NewInvalidQueryParamError("limit", errors.New(`strconv.Atoi: parsing "ten": invalid syntax`)),
+		},
+		{
+			name:           "Given_LimitOutOfRange_When_ListItems_Then_ExpectedHTTPStatusBadRequest",
+			givenQuery:     "?limit=0",
+			wantHTTPStatus: http.StatusBadRequest,
+			wantErr:        handlers.NewInvalidQueryParamError("limit", errors.New("limit must be between 1 and 500")),
 		},
 		{
 			name:            "Given_ServiceError_When_ListItems_Then_ExpectedHTTPStatusInternalServerError",
@@ -355,7 +761,7 @@ func TestListItems(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Setup
 			serviceMock := new(service.ItemServiceMock)
-			serviceMock.On("ListItems", mock.Anything).Return(tt.givenMockedServiceItems, tt.givenServiceErr)
+			serviceMock.On("ListItems", mock.Anything, tt.wantServiceOptions).Return(tt.givenMockedServicePage, tt.givenServiceErr)
 
 			// Create handler with mock service
 			h := handlers.NewHandler(serviceMock)
@@ -364,7 +770,7 @@ func TestListItems(t *testing.T) {
 			handlerWithMiddleware := middleware.ErrorHandlingMiddleware(h.ListItems)
 
 			// Create request
-			req := httptest.NewRequest(http.MethodGet, "/items", nil)
+			req := httptest.NewRequest(http.MethodGet, "/items"+tt.givenQuery, nil)
 			rec := httptest.NewRecorder()
 
 			// Execute request using the handler with middleware
@@ -376,12 +782,575 @@ func TestListItems(t *testing.T) {
 			if tt.wantErr != nil {
 				require.Equal(t, parserAPIErr(t, tt.wantErr), parserAPIErrFromBody(t, rec.Body.Bytes()))
 			} else {
-				require.Equal(t, tt.wantAPIItems, parserAPIItems(t, rec.Body.Bytes()))
+				require.Equal(t, tt.wantResponse, parserListItemsResponse(t, rec.Body.Bytes()))
 			}
 		})
 	}
 }
 
+func TestSearchItems(t *testing.T) {
+	tests := []struct {
+		name              string
+		givenQuery        string
+		givenListID       string
+		givenServiceErr   error
+		givenServiceItems []domain.Item
+		wantServiceQuery  string
+		wantServiceLimit  int
+		wantResponse      handlers.SearchItemsResponse
+		wantHTTPStatus    int
+		wantErr           error
+	}{
+		{
+			name:              "Given_Query_When_SearchItems_Then_ExpectedHTTPStatusOK",
+			givenQuery:        "?q=feijao&limit=10",
+			givenServiceItems: []domain.Item{mockServiceItem()},
+			wantServiceQuery:  "feijao",
+			wantServiceLimit:  10,
+			wantResponse:      handlers.SearchItemsResponse{Items: []handlers.Item{mockAPIItem()}},
+			wantHTTPStatus:    http.StatusOK,
+		},
+		{
+			name:              "Given_ListRoute_When_SearchItems_Then_ListIsSearched",
+			givenQuery:        "?q=feijao",
+			givenListID:       "list-id",
+			givenServiceItems: []domain.Item{mockServiceItem()},
+			wantServiceQuery:  "feijao",
+			wantResponse:      handlers.SearchItemsResponse{Items: []handlers.Item{mockAPIItem()}},
+			wantHTTPStatus:    http.StatusOK,
+		},
+		{
+			name:              "Given_NoMatches_When_SearchItems_Then_ExpectedEmptyItems",
+			givenQuery:        "?q=%20leite%20",
+			givenServiceItems: []domain.Item{},
+			wantServiceQuery:  "leite",
+			wantResponse:      handlers.SearchItemsResponse{Items: []handlers.Item{}},
+			wantHTTPStatus:    http.StatusOK,
+		},
+		{
+			name:           "Given_MissingQuery_When_SearchItems_Then_ExpectedHTTPStatusBadRequest",
+			givenQuery:     "?q=%20",
+			wantHTTPStatus: http.StatusBadRequest,
+			wantErr:        handlers.NewInvalidQueryParamError("q", errors.New("the text to search is required")),
+		},
+		{
+			name:           "Given_LimitAboveMax_When_SearchItems_Then_ExpectedHTTPStatusBadRequest",
+			givenQuery:     "?q=milk&limit=101",
+			wantHTTPStatus: http.StatusBadRequest,
+			wantErr:        handlers.NewInvalidQueryParamError("limit", errors.New("limit must be between 1 and 100")),
+		},
+		{
+			name:             "Given_ServiceError_When_SearchItems_Then_ExpectedServiceStatus",
+			givenQuery:       "?q=milk",
+			givenServiceErr:  mockServiceError(),
+			wantServiceQuery: "milk",
+			wantHTTPStatus:   http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(service.ItemServiceMock)
+			serviceMock.On("SearchItems", mock.Anything, tt.wantServiceQuery, tt.givenListID, tt.wantServiceLimit).Return(tt.givenServiceItems, tt.givenServiceErr)
+
+			h := handlers.NewHandler(serviceMock)
+			handlerWithMiddleware := middleware.ErrorHandlingMiddleware(h.SearchItems)
+
+			req := httptest.NewRequest(http.MethodGet, "/items/search"+tt.givenQuery, nil)
+			if tt.givenListID != "" {
+				req = mux.SetURLVars(req, map[string]string{"listId": tt.givenListID})
+			}
+			rec := httptest.NewRecorder()
+
+			handlerWithMiddleware.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantHTTPStatus, rec.Code)
+
+			switch {
+			case tt.wantErr != nil:
+				require.Equal(t, parserAPIErr(t, tt.wantErr), parserAPIErrFromBody(t, rec.Body.Bytes()))
+			case tt.wantHTTPStatus == http.StatusOK:
+				var response handlers.SearchItemsResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				require.Equal(t, tt.wantResponse, response)
+			}
+		})
+	}
+}
+
+func TestSuggestItemNames(t *testing.T) {
+	tests := []struct {
+		name                   string
+		givenQuery             string
+		givenServiceSuggestion []domain.Suggestion
+		wantServicePrefix      string
+		wantServiceLimit       int
+		wantResponse           handlers.SuggestItemNamesResponse
+		wantHTTPStatus         int
+		wantErr                error
+	}{
+		{
+			name:                   "Given_Prefix_When_SuggestItemNames_Then_ExpectedHTTPStatusOK",
+			givenQuery:             "?prefix=fei&limit=5",
+			givenServiceSuggestion: []domain.Suggestion{{Name: "Feijão", Count: 3}},
+			wantServicePrefix:      "fei",
+			wantServiceLimit:       5,
+			wantResponse:           handlers.SuggestItemNamesResponse{Suggestions: []handlers.Suggestion{{Name: "Feijão", Count: 3}}},
+			wantHTTPStatus:         http.StatusOK,
+		},
+		{
+			name:                   "Given_UnknownPrefix_When_SuggestItemNames_Then_ExpectedEmptySuggestions",
+			givenQuery:             "?prefix=xyz",
+			givenServiceSuggestion: []domain.Suggestion{},
+			wantServicePrefix:      "xyz",
+			wantResponse:           handlers.SuggestItemNamesResponse{Suggestions: []handlers.Suggestion{}},
+			wantHTTPStatus:         http.StatusOK,
+		},
+		{
+			name:           "Given_MissingPrefix_When_SuggestItemNames_Then_ExpectedHTTPStatusBadRequest",
+			wantHTTPStatus: http.StatusBadRequest,
+			wantErr:        handlers.NewInvalidQueryParamError("prefix", errors.New("the text typed so far is required")),
+		},
+		{
+			name:           "Given_LimitAboveMax_When_SuggestItemNames_Then_ExpectedHTTPStatusBadRequest",
+			givenQuery:     "?prefix=fei&limit=51",
+			wantHTTPStatus: http.StatusBadRequest,
+			wantErr:        handlers.NewInvalidQueryParamError("limit", errors.New("limit must be between 1 and 50")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(service.ItemServiceMock)
+			serviceMock.On("SuggestItemNames", mock.Anything, tt.wantServicePrefix, tt.wantServiceLimit).Return(tt.givenServiceSuggestion, nil)
+
+			h := handlers.NewHandler(serviceMock)
+			handlerWithMiddleware := middleware.ErrorHandlingMiddleware(h.SuggestItemNames)
+
+			req := httptest.NewRequest(http.MethodGet, "/items/suggest"+tt.givenQuery, nil)
+			rec := httptest.NewRecorder()
+
+			handlerWithMiddleware.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantHTTPStatus, rec.Code)
+
+			if tt.wantErr != nil {
+				require.Equal(t, parserAPIErr(t, tt.wantErr), parserAPIErrFromBody(t, rec.Body.Bytes()))
+			} else {
+				var response handlers.SuggestItemNamesResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				require.Equal(t, tt.wantResponse, response)
+			}
+		})
+	}
+}
+
+func TestBulkUpdateActive(t *testing.T) {
+	tests := []struct {
+		name               string
+		givenBody          string
+		givenServiceResult domain.ActiveUpdateResult
+		givenServiceErr    error
+		wantServiceUpdate  *domain.ActiveUpdate
+		wantHTTPStatus     int
+		wantResponse       handlers.BulkActiveResponse
+		wantErr            error
+	}{
+		{
+			name:               "Given_OnlyActive_When_BulkUpdateActive_Then_EveryItemIsSelected",
+			givenBody:          `{"active":true}`,
+			givenServiceResult: domain.ActiveUpdateResult{MatchedCount: 3, ModifiedCount: 3},
+			wantServiceUpdate:  &domain.ActiveUpdate{Active: true},
+			wantHTTPStatus:     http.StatusOK,
+			wantResponse:       handlers.BulkActiveResponse{MatchedCount: 3, ModifiedCount: 3, NotFoundIDs: []string{}},
+		},
+		{
+			name:               "Given_IDs_When_BulkUpdateActive_Then_NotFoundIDsAreReturned",
+			givenBody:          `{"active":false,"ids":["any-id","missing-id"]}`,
+			givenServiceResult: domain.ActiveUpdateResult{MatchedCount: 1, ModifiedCount: 1, NotFoundIDs: []string{"missing-id"}},
+			wantServiceUpdate:  &domain.ActiveUpdate{Active: false, IDs: []string{"any-id", "missing-id"}},
+			wantHTTPStatus:     http.StatusOK,
+			wantResponse:       handlers.BulkActiveResponse{MatchedCount: 1, ModifiedCount: 1, NotFoundIDs: []string{"missing-id"}},
+		},
+		{
+			name:               "Given_Filter_When_BulkUpdateActive_Then_FilterIsSentToService",
+			givenBody:          `{"active":false,"filter":{"name":"milk","active":true}}`,
+			givenServiceResult: domain.ActiveUpdateResult{MatchedCount: 2, ModifiedCount: 2},
+			wantServiceUpdate:  &domain.ActiveUpdate{Active: false, Filter: domain.ItemFilter{Name: "milk", Active: ptr(true)}},
+			wantHTTPStatus:     http.StatusOK,
+			wantResponse:       handlers.BulkActiveResponse{MatchedCount: 2, ModifiedCount: 2, NotFoundIDs: []string{}},
+		},
+		{
+			name:              "Given_IDsAndFilter_When_BulkUpdateActive_Then_ExpectedHTTPStatusBadRequest",
+			givenBody:         `{"active":false,"ids":["any-id"],"filter":{"name":"milk"}}`,
+			givenServiceErr:   service.NewErrorInvalidActiveUpdate(errors.New("ids and filter cannot be combined")),
+			wantServiceUpdate: &domain.ActiveUpdate{Active: false, IDs: []string{"any-id"}, Filter: domain.ItemFilter{Name: "milk"}},
+			wantHTTPStatus:    http.StatusBadRequest,
+			wantErr:           handlers.ErrorAPI{Cause: "ids and filter cannot be combined", Message: "invalid bulk active update", HTTP: http.StatusBadRequest},
+		},
+		{
+			name:           "Given_InvalidJSON_When_BulkUpdateActive_Then_ExpectedHTTPStatusBadRequest",
+			givenBody:      `{"active":`,
+			wantHTTPStatus: http.StatusBadRequest,
+			wantErr:        handlers.NewDecodeRequestError(errors.New("unexpected EOF")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(service.ItemServiceMock)
+			if tt.wantServiceUpdate != nil {
+				serviceMock.On("BulkUpdateActive", mock.Anything, *tt.wantServiceUpdate).Return(tt.givenServiceResult, tt.givenServiceErr)
+			}
+
+			h := handlers.NewHandler(serviceMock)
+			handlerWithMiddleware := middleware.ErrorHandlingMiddleware(h.BulkUpdateActive)
+
+			req := httptest.NewRequest(http.MethodPut, "/items/active", strings.NewReader(tt.givenBody))
+			rec := httptest.NewRecorder()
+
+			handlerWithMiddleware.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantHTTPStatus, rec.Code)
+
+			if tt.wantErr != nil {
+				require.Equal(t, parserAPIErr(t, tt.wantErr), parserAPIErrFromBody(t, rec.Body.Bytes()))
+			} else {
+				var response handlers.BulkActiveResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				require.Equal(t, tt.wantResponse, response)
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteItems(t *testing.T) {
+	tests := []struct {
+		name              string
+		givenQuery        string
+		givenDeletedCount int64
+		givenServiceErr   error
+		wantServiceFilter *domain.ItemFilter
+		wantHTTPStatus    int
+		wantResponse      handlers.DeleteItemsResponse
+		wantErr           error
+	}{
+		{
+			name:              "Given_InactiveFilter_When_DeleteItems_Then_ExpectedDeletedCount",
+			givenQuery:        "?active=false",
+			givenDeletedCount: 3,
+			wantServiceFilter: &domain.ItemFilter{Active: ptr(false)},
+			wantHTTPStatus:    http.StatusOK,
+			wantResponse:      handlers.DeleteItemsResponse{DeletedCount: 3},
+		},
+		{
+			name:              "Given_NoFilter_When_DeleteItems_Then_ExpectedHTTPStatusBadRequest",
+			givenServiceErr:   service.NewErrorEmptyFilter(),
+			wantServiceFilter: &domain.ItemFilter{},
+			wantHTTPStatus:    http.StatusBadRequest,
+			wantErr:           handlers.ErrorAPI{Message: "at least one filter is required to delete items", HTTP: http.StatusBadRequest},
+		},
+		{
+			name:           "Given_InvalidFilter_When_DeleteItems_Then_ExpectedHTTPStatusBadRequest",
+			givenQuery:     "?active=yes",
+			wantHTTPStatus: http.StatusBadRequest,
+			wantErr:        handlers.NewInvalidQueryParamError("active", errors.New(`"yes" is not a boolean (true or false)`)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(service.ItemServiceMock)
+			if tt.wantServiceFilter != nil {
+				serviceMock.On("DeleteItems", mock.Anything, *tt.wantServiceFilter).Return(tt.givenDeletedCount, tt.givenServiceErr)
+			}
+
+			h := handlers.NewHandler(serviceMock)
+			handlerWithMiddleware := middleware.ErrorHandlingMiddleware(h.DeleteItems)
+
+			req := httptest.NewRequest(http.MethodDelete, "/items"+tt.givenQuery, nil)
+			rec := httptest.NewRecorder()
+
+			handlerWithMiddleware.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantHTTPStatus, rec.Code)
+
+			if tt.wantErr != nil {
+				require.Equal(t, parserAPIErr(t, tt.wantErr), parserAPIErrFromBody(t, rec.Body.Bytes()))
+			} else {
+				var response handlers.DeleteItemsResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				require.Equal(t, tt.wantResponse, response)
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTotalQuantities(t *testing.T) {
+	tests := []struct {
+		name              string
+		givenQuery        string
+		givenTotals       []domain.Quantity
+		givenServiceErr   error
+		wantServiceFilter *domain.ItemFilter
+		wantHTTPStatus    int
+		wantResponse      handlers.TotalsResponse
+		wantErr           error
+	}{
+		{
+			name:              "Given_ActiveFilter_When_TotalQuantities_Then_ExpectedTotalPerUnit",
+			givenQuery:        "?active=true",
+			givenTotals:       []domain.Quantity{{Value: 1500, Unit: domain.UnitKilogram}, {Value: 2000, Unit: domain.UnitLiter}},
+			wantServiceFilter: &domain.ItemFilter{Active: ptr(true)},
+			wantHTTPStatus:    http.StatusOK,
+			wantResponse:      handlers.TotalsResponse{Totals: []handlers.Total{{Quantity: 1500, Unit: "kg"}, {Quantity: 2000, Unit: "L"}}},
+		},
+		{
+			name:              "Given_NoQuantities_When_TotalQuantities_Then_ExpectedEmptyTotals",
+			givenTotals:       []domain.Quantity{},
+			wantServiceFilter: &domain.ItemFilter{},
+			wantHTTPStatus:    http.StatusOK,
+			wantResponse:      handlers.TotalsResponse{Totals: []handlers.Total{}},
+		},
+		{
+			name:           "Given_InvalidFilter_When_TotalQuantities_Then_ExpectedHTTPStatusBadRequest",
+			givenQuery:     "?active=yes",
+			wantHTTPStatus: http.StatusBadRequest,
+			wantErr:        handlers.NewInvalidQueryParamError("active", errors.New(`"yes" is not a boolean (true or false)`)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(service.ItemServiceMock)
+			if tt.wantServiceFilter != nil {
+				serviceMock.On("TotalQuantities", mock.Anything, *tt.wantServiceFilter).Return(tt.givenTotals, tt.givenServiceErr)
+			}
+
+			h := handlers.NewHandler(serviceMock)
+			handlerWithMiddleware := middleware.ErrorHandlingMiddleware(h.TotalQuantities)
+
+			req := httptest.NewRequest(http.MethodGet, "/items/totals"+tt.givenQuery, nil)
+			rec := httptest.NewRecorder()
+
+			handlerWithMiddleware.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantHTTPStatus, rec.Code)
+
+			if tt.wantErr != nil {
+				require.Equal(t, parserAPIErr(t, tt.wantErr), parserAPIErrFromBody(t, rec.Body.Bytes()))
+			} else {
+				var response handlers.TotalsResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				require.Equal(t, tt.wantResponse, response)
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSummarizePrices(t *testing.T) {
+	tests := []struct {
+		name              string
+		givenQuery        string
+		givenSummaries    []domain.PriceSummary
+		givenServiceErr   error
+		wantServiceFilter *domain.ItemFilter
+		wantHTTPStatus    int
+		wantBody          string
+		wantErr           error
+	}{
+		{
+			name:       "Given_PricedItems_When_SummarizePrices_Then_ExpectedTotalsAsStrings",
+			givenQuery: "?name=milk",
+			givenSummaries: []domain.PriceSummary{{
+				Currency:  "BRL",
+				Estimated: 2435,
+				Checked:   500,
+				Categories: []domain.CategorySubtotal{
+					{CategoryID: "category-1", Estimated: 1935},
+					{Estimated: 500, Checked: 500},
+				},
+			}},
+			wantServiceFilter: &domain.ItemFilter{Name: "milk"},
+			wantHTTPStatus:    http.StatusOK,
+			wantBody: `{"summaries":[{"currency":"BRL","estimatedTotal":"24.35","checkedTotal":"5.00","categories":[` +
+				`{"categoryId":"category-1","estimatedTotal":"19.35","checkedTotal":"0.00"},` +
+				`{"categoryId":null,"estimatedTotal":"5.00","checkedTotal":"5.00"}]}]}`,
+		},
+		{
+			name:              "Given_NoPricedItem_When_SummarizePrices_Then_ExpectedEmptySummaries",
+			givenSummaries:    []domain.PriceSummary{},
+			wantServiceFilter: &domain.ItemFilter{},
+			wantHTTPStatus:    http.StatusOK,
+			wantBody:          `{"summaries":[]}`,
+		},
+		{
+			name:              "Given_ServiceError_When_SummarizePrices_Then_ExpectedHTTPStatusInternalServerError",
+			givenServiceErr:   errDummy,
+			wantServiceFilter: &domain.ItemFilter{},
+			wantHTTPStatus:    http.StatusInternalServerError,
+			wantErr:           handlers.NewInternalServerError(errDummy),
+		},
+		{
+			name:           "Given_InvalidFilter_When_SummarizePrices_Then_ExpectedHTTPStatusBadRequest",
+			givenQuery:     "?active=yes",
+			wantHTTPStatus: http.StatusBadRequest,
+			wantErr:        handlers.NewInvalidQueryParamError("active", errors.New(`"yes" is not a boolean (true or false)`)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(service.ItemServiceMock)
+			if tt.wantServiceFilter != nil {
+				serviceMock.On("SummarizePrices", mock.Anything, *tt.wantServiceFilter).Return(tt.givenSummaries, tt.givenServiceErr)
+			}
+
+			h := handlers.NewHandler(serviceMock)
+			handlerWithMiddleware := middleware.ErrorHandlingMiddleware(h.SummarizePrices)
+
+			req := httptest.NewRequest(http.MethodGet, "/items/summary"+tt.givenQuery, nil)
+			rec := httptest.NewRecorder()
+
+			handlerWithMiddleware.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantHTTPStatus, rec.Code)
+
+			if tt.wantErr != nil {
+				require.Equal(t, parserAPIErr(t, tt.wantErr), parserAPIErrFromBody(t, rec.Body.Bytes()))
+			} else {
+				require.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBatchItems(t *testing.T) {
+	createdItem := mockAPIItem()
+	tests := []struct {
+		name               string
+		givenQuery         string
+		givenListID        string
+		givenBody          string
+		givenServiceResult []domain.BatchResult
+		givenServiceErr    error
+		wantServiceOps     []domain.BatchOperation
+		wantServiceAtomic  bool
+		wantHTTPStatus     int
+		wantResponse       handlers.BatchResponse
+		wantErr            error
+	}{
+		{
+			name: "Given_MixedOperations_When_BatchItems_Then_ExpectedStatusPerOperation",
+			givenBody: `{"operations":[
+				{"op":"create","item":{"name":"any name","active":true,"observation":"mock observation"}},
+				{"op":"update","item":{"id":"other-id","name":"other name"}},
+				{"op":"delete","item":{"id":"any-id"}}
+			]}`,
+			givenServiceResult: []domain.BatchResult{
+				{Item: mockServiceItem()},
+				{Err: service.NewErrorService(errors.New("not found"), "item not found", service.RepositorySource, http.StatusNotFound)},
+				{},
+			},
+			wantServiceOps: []domain.BatchOperation{
+				{Type: domain.BatchCreate, Item: domain.Item{Name: "any name", Active: true, Observation: ptr("mock observation")}},
+				{Type: domain.BatchUpdate, Item: domain.Item{ID: "other-id", Name: "other name"}},
+				{Type: domain.BatchDelete, Item: domain.Item{ID: "any-id"}},
+			},
+			wantHTTPStatus: http.StatusOK,
+			wantResponse: handlers.BatchResponse{Results: []handlers.BatchResult{
+				{Status: http.StatusCreated, Item: &createdItem},
+				{Status: http.StatusNotFound, Error: &handlers.ErrorAPI{Cause: "not found", Message: "item not found", HTTP: http.StatusNotFound}},
+				{Status: http.StatusNoContent},
+			}},
+		},
+		{
+			name:       "Given_AtomicBatch_When_BatchItems_Then_NotAppliedOperationsAreFailedDependency",
+			givenQuery: "?atomic=true",
+			givenBody:  `{"operations":[{"op":"delete","item":{"id":"any-id"}},{"op":"delete","item":{}}]}`,
+			givenServiceResult: []domain.BatchResult{
+				{Err: service.NewErrorFailedDependency()},
+				{Err: service.NewErrorEmptyItem()},
+			},
+			wantServiceOps: []domain.BatchOperation{
+				{Type: domain.BatchDelete, Item: domain.Item{ID: "any-id"}},
+				{Type: domain.BatchDelete},
+			},
+			wantServiceAtomic: true,
+			wantHTTPStatus:    http.StatusOK,
+			wantResponse: handlers.BatchResponse{Results: []handlers.BatchResult{
+				{Status: http.StatusFailedDependency, Error: &handlers.ErrorAPI{Message: "not applied because another operation of the atomic batch failed", HTTP: http.StatusFailedDependency}},
+				{Status: http.StatusBadRequest, Error: &handlers.ErrorAPI{Message: "item is empty", HTTP: http.StatusBadRequest}},
+			}},
+		},
+		{
+			name:               "Given_ListID_When_BatchItems_Then_OperationsAreOnThatList",
+			givenListID:        "list-id",
+			givenBody:          `{"operations":[{"op":"create","item":{"name":"any name","active":true}},{"op":"delete","item":{"id":"any-id"}}]}`,
+			givenServiceResult: []domain.BatchResult{{Item: mockServiceItem()}, {}},
+			wantServiceOps: []domain.BatchOperation{
+				{Type: domain.BatchCreate, Item: domain.Item{ListID: "list-id", Name: "any name", Active: true}},
+				{Type: domain.BatchDelete, Item: domain.Item{ListID: "list-id", ID: "any-id"}},
+			},
+			wantHTTPStatus: http.StatusOK,
+			wantResponse: handlers.BatchResponse{Results: []handlers.BatchResult{
+				{Status: http.StatusCreated, Item: &createdItem},
+				{Status: http.StatusNoContent},
+			}},
+		},
+		{
+			name:           "Given_InvalidAtomic_When_BatchItems_Then_ExpectedHTTPStatusBadRequest",
+			givenQuery:     "?atomic=maybe",
+			givenBody:      `{"operations":[]}`,
+			wantHTTPStatus: http.StatusBadRequest,
+			wantErr:        handlers.NewInvalidQueryParamError("atomic", errors.New(`"maybe" is not a boolean (true or false)`)),
+		},
+		{
+			name:            "Given_EmptyBatch_When_BatchItems_Then_ExpectedHTTPStatusBadRequest",
+			givenBody:       `{"operations":[]}`,
+			givenServiceErr: service.NewErrorInvalidBatch(errors.New("the batch has no operations")),
+			wantServiceOps:  []domain.BatchOperation{},
+			wantHTTPStatus:  http.StatusBadRequest,
+			wantErr:         handlers.ErrorAPI{Cause: "the batch has no operations", Message: "invalid batch", HTTP: http.StatusBadRequest},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(service.ItemServiceMock)
+			if tt.wantServiceOps != nil {
+				serviceMock.On("BatchItems", mock.Anything, tt.wantServiceOps, tt.wantServiceAtomic).Return(tt.givenServiceResult, tt.givenServiceErr)
+			}
+
+			h := handlers.NewHandler(serviceMock)
+			handlerWithMiddleware := middleware.ErrorHandlingMiddleware(h.BatchItems)
+
+			req := httptest.NewRequest(http.MethodPost, "/items/batch"+tt.givenQuery, strings.NewReader(tt.givenBody))
+			if tt.givenListID != "" {
+				req = mux.SetURLVars(req, map[string]string{"listId": tt.givenListID})
+			}
+			rec := httptest.NewRecorder()
+
+			handlerWithMiddleware.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantHTTPStatus, rec.Code)
+
+			if tt.wantErr != nil {
+				require.Equal(t, parserAPIErr(t, tt.wantErr), parserAPIErrFromBody(t, rec.Body.Bytes()))
+			} else {
+				var response handlers.BatchResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				require.Equal(t, tt.wantResponse, response)
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
 func mockItem() handlers.Item {
 	obs := "mock observation"
 	return handlers.Item{
@@ -466,11 +1435,11 @@ func parserAPIErrFromBody(t *testing.T, body []byte) handlers.ErrorAPI {
 	return errorResponse
 }
 
-func parserAPIItems(t *testing.T, body []byte) []handlers.Item {
-	var items []handlers.Item
-	err := json.Unmarshal(body, &items)
+func parserListItemsResponse(t *testing.T, body []byte) handlers.ListItemsResponse {
+	var response handlers.ListItemsResponse
+	err := json.Unmarshal(body, &response)
 	require.NoError(t, err)
-	return items
+	return response
 }
 
 func assertAPIErrorContains(t *testing.T, wantErr error, body []byte) {
@@ -486,3 +1455,7 @@ func assertAPIItemEquals(t *testing.T, item handlers.Item, b []byte) {
 	require.NoError(t, err)
 	require.Equal(t, item, itemResponse)
 }
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -6,7 +6,15 @@ type ItemHandler interface {
 	CreateItem(w http.ResponseWriter, r *http.Request) error
 	GetItem(w http.ResponseWriter, r *http.Request) error
 	UpdateItem(w http.ResponseWriter, r *http.Request) error
+	PatchItem(w http.ResponseWriter, r *http.Request) error
+	MoveItem(w http.ResponseWriter, r *http.Request) error
 	DeleteItem(w http.ResponseWriter, r *http.Request) error
 	ListItems(w http.ResponseWriter, r *http.Request) error
+	SearchItems(w http.ResponseWriter, r *http.Request) error
+	SuggestItemNames(w http.ResponseWriter, r *http.Request) error
 	BulkUpdateActive(w http.ResponseWriter, r *http.Request) error
+	DeleteItems(w http.ResponseWriter, r *http.Request) error
+	BatchItems(w http.ResponseWriter, r *http.Request) error
+	TotalQuantities(w http.ResponseWriter, r *http.Request) error
+	SummarizePrices(w http.ResponseWriter, r *http.Request) error
 }
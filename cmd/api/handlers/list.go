@@ -0,0 +1,11 @@
+package handlers
+
+import "net/http"
+
+type ListHandler interface {
+	CreateList(w http.ResponseWriter, r *http.Request) error
+	GetList(w http.ResponseWriter, r *http.Request) error
+	UpdateList(w http.ResponseWriter, r *http.Request) error
+	DeleteList(w http.ResponseWriter, r *http.Request) error
+	ListLists(w http.ResponseWriter, r *http.Request) error
+}
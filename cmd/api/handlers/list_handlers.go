@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/service"
+)
+
+// listHandler serves the /lists routes; the items of a list are served by handler
+type listHandler struct {
+	service service.ListService
+	parser  parser
+}
+
+// NewListHandler creates a new instance of listHandler
+func NewListHandler(service service.ListService) ListHandler {
+	return &listHandler{
+		service: service,
+		parser:  parser{},
+	}
+}
+
+// CreateList handles the creation of a new list
+func (h *listHandler) CreateList(w http.ResponseWriter, r *http.Request) error {
+	var list List
+
+	ctx := r.Context()
+
+	err := json.NewDecoder(r.Body).Decode(&list)
+	if err != nil {
+		return NewDecodeRequestError(err)
+	}
+
+	createdList, err := h.service.CreateList(ctx, domain.List{Name: list.Name})
+	if err != nil {
+		return err
+	}
+
+	return writeJSONResponse(w, http.StatusCreated, h.parser.toApiList(createdList))
+}
+
+// GetList handles the retrieval of a list by its listId
+func (h *listHandler) GetList(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	list, err := h.service.GetList(ctx, listID(r))
+	if err != nil {
+		return err
+	}
+
+	return writeJSONResponse(w, http.StatusOK, h.parser.toApiList(list))
+}
+
+// UpdateList handles the renaming of a list
+func (h *listHandler) UpdateList(w http.ResponseWriter, r *http.Request) error {
+	var list List
+
+	ctx := r.Context()
+
+	err := json.NewDecoder(r.Body).Decode(&list)
+	if err != nil {
+		return NewDecodeRequestError(err)
+	}
+
+	updatedList, err := h.service.UpdateList(ctx, domain.List{ID: listID(r), Name: list.Name})
+	if err != nil {
+		return err
+	}
+
+	return writeJSONResponse(w, http.StatusOK, h.parser.toApiList(updatedList))
+}
+
+// DeleteList handles the removal of a list and of its items
+func (h *listHandler) DeleteList(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	err := h.service.DeleteList(ctx, listID(r))
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
+// ListLists handles the listing of every list
+func (h *listHandler) ListLists(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	lists, err := h.service.ListLists(ctx)
+	if err != nil {
+		return err
+	}
+
+	apiLists := make([]List, len(lists))
+	for i, list := range lists {
+		apiLists[i] = h.parser.toApiList(list)
+	}
+
+	return writeJSONResponse(w, http.StatusOK, ListListsResponse{Lists: apiLists})
+}
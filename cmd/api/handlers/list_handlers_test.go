@@ -0,0 +1,350 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucaspereirasilva0/list-manager-api/cmd/api/handlers"
+	"github.com/lucaspereirasilva0/list-manager-api/cmd/api/handlers/middleware"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/service"
+)
+
+const (
+	_listID = "list-1"
+)
+
+var (
+	errListNotFound    = service.NewErrorService(repository.NewListNotFoundError(), "list not found", service.RepositorySource, http.StatusNotFound)
+	errAPIListNotFound = handlers.ErrorAPI{Cause: repository.NewListNotFoundError().Error(), Message: "list not found", HTTP: http.StatusNotFound}
+)
+
+func TestItemsOfList(t *testing.T) {
+	listFilter := domain.ItemFilter{ListID: _listID}
+
+	tests := []struct {
+		name            string
+		givenMethod     string
+		givenTarget     string
+		givenBody       string
+		givenServiceErr error
+		wantHTTPStatus  int
+		wantErr         error
+		serve           func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error
+		setup           func(serviceMock *service.ItemServiceMock, err error)
+	}{
+		{
+			name:           "Given_ListID_When_CreateItem_Then_ItemIsCreatedInList",
+			givenMethod:    http.MethodPost,
+			givenTarget:    "/lists/" + _listID + "/items",
+			givenBody:      `{"name":"Aspirin","listId":"ignored"}`,
+			wantHTTPStatus: http.StatusCreated,
+			serve:          func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error { return h.CreateItem },
+			setup: func(serviceMock *service.ItemServiceMock, err error) {
+				serviceMock.On("CreateItem", mock.Anything, domain.Item{ListID: _listID, Name: "Aspirin"}).Return(domain.Item{ID: "1", ListID: _listID, Name: "Aspirin"}, err)
+			},
+		},
+		{
+			name:           "Given_ListID_When_ListItems_Then_FilterIsScopedToList",
+			givenMethod:    http.MethodGet,
+			givenTarget:    "/lists/" + _listID + "/items",
+			wantHTTPStatus: http.StatusOK,
+			serve:          func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error { return h.ListItems },
+			setup: func(serviceMock *service.ItemServiceMock, err error) {
+				serviceMock.On("ListItems", mock.Anything, domain.ListOptions{Filter: listFilter}).Return(domain.ItemPage{}, err)
+			},
+		},
+		{
+			name:           "Given_ListID_When_BulkUpdateActive_Then_FilterIsScopedToList",
+			givenMethod:    http.MethodPut,
+			givenTarget:    "/lists/" + _listID + "/items/active",
+			givenBody:      `{"active":true,"ids":["1"]}`,
+			wantHTTPStatus: http.StatusOK,
+			serve:          func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error { return h.BulkUpdateActive },
+			setup: func(serviceMock *service.ItemServiceMock, err error) {
+				serviceMock.On("BulkUpdateActive", mock.Anything, domain.ActiveUpdate{Active: true, IDs: []string{"1"}, Filter: listFilter}).Return(domain.ActiveUpdateResult{MatchedCount: 1}, err)
+			},
+		},
+		{
+			name:           "Given_ListID_When_DeleteItems_Then_FilterIsScopedToList",
+			givenMethod:    http.MethodDelete,
+			givenTarget:    "/lists/" + _listID + "/items?active=true",
+			wantHTTPStatus: http.StatusOK,
+			serve:          func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error { return h.DeleteItems },
+			setup: func(serviceMock *service.ItemServiceMock, err error) {
+				serviceMock.On("DeleteItems", mock.Anything, domain.ItemFilter{ListID: _listID, Active: ptr(true)}).Return(int64(2), err)
+			},
+		},
+		{
+			name:            "Given_MissingList_When_ListItems_Then_ExpectedHTTPStatusNotFound",
+			givenMethod:     http.MethodGet,
+			givenTarget:     "/lists/" + _listID + "/items",
+			givenServiceErr: errListNotFound,
+			wantHTTPStatus:  http.StatusNotFound,
+			wantErr:         errAPIListNotFound,
+			serve:           func(h handlers.ItemHandler) func(http.ResponseWriter, *http.Request) error { return h.ListItems },
+			setup: func(serviceMock *service.ItemServiceMock, err error) {
+				serviceMock.On("ListItems", mock.Anything, domain.ListOptions{Filter: listFilter}).Return(domain.ItemPage{}, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(service.ItemServiceMock)
+			tt.setup(serviceMock, tt.givenServiceErr)
+
+			h := handlers.NewHandler(serviceMock)
+			handlerWithMiddleware := middleware.ErrorHandlingMiddleware(tt.serve(h))
+
+			req := httptest.NewRequest(tt.givenMethod, tt.givenTarget, strings.NewReader(tt.givenBody))
+			req = mux.SetURLVars(req, map[string]string{"listId": _listID})
+			rec := httptest.NewRecorder()
+
+			handlerWithMiddleware.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantHTTPStatus, rec.Code)
+			if tt.wantErr != nil {
+				require.Equal(t, parserAPIErr(t, tt.wantErr), parserAPIErrFromBody(t, rec.Body.Bytes()))
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateList(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenBody       string
+		givenServiceErr error
+		wantServiceCall bool
+		wantHTTPStatus  int
+		wantList        handlers.List
+		wantErr         error
+	}{
+		{
+			name:            "Given_Name_When_CreateList_Then_ExpectedHTTPStatusCreated",
+			givenBody:       `{"name":"Pharmacy"}`,
+			wantServiceCall: true,
+			wantHTTPStatus:  http.StatusCreated,
+			wantList:        mockAPIList(),
+		},
+		{
+			name:            "Given_EmptyName_When_CreateList_Then_ExpectedHTTPStatusUnprocessableEntity",
+			givenBody:       `{"name":"Pharmacy"}`,
+			givenServiceErr: service.NewErrorInvalidList(domain.ErrEmptyListName),
+			wantServiceCall: true,
+			wantHTTPStatus:  http.StatusUnprocessableEntity,
+			wantErr:         handlers.ErrorAPI{Cause: domain.ErrEmptyListName.Error(), Message: domain.ErrEmptyListName.Error(), HTTP: http.StatusUnprocessableEntity},
+		},
+		{
+			name:           "Given_InvalidJson_When_CreateList_Then_ExpectedHTTPStatusBadRequest",
+			givenBody:      `"Pharmacy"`,
+			wantHTTPStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(service.ListServiceMock)
+			if tt.wantServiceCall {
+				serviceMock.On("CreateList", mock.Anything, domain.List{Name: "Pharmacy"}).Return(mockServiceList(), tt.givenServiceErr)
+			}
+
+			h := handlers.NewListHandler(serviceMock)
+			handlerWithMiddleware := middleware.ErrorHandlingMiddleware(h.CreateList)
+
+			req := httptest.NewRequest(http.MethodPost, "/lists", strings.NewReader(tt.givenBody))
+			rec := httptest.NewRecorder()
+
+			handlerWithMiddleware.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantHTTPStatus, rec.Code)
+			if tt.wantErr != nil {
+				require.Equal(t, parserAPIErr(t, tt.wantErr), parserAPIErrFromBody(t, rec.Body.Bytes()))
+			} else if tt.wantHTTPStatus == http.StatusCreated {
+				require.Equal(t, tt.wantList, parserAPIList(t, rec.Body.Bytes()))
+			}
+			serviceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetList(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenServiceErr error
+		wantHTTPStatus  int
+		wantErr         error
+	}{
+		{
+			name:           "Given_ExistingList_When_GetList_Then_ExpectedHTTPStatusOK",
+			wantHTTPStatus: http.StatusOK,
+		},
+		{
+			name:            "Given_MissingList_When_GetList_Then_ExpectedHTTPStatusNotFound",
+			givenServiceErr: errListNotFound,
+			wantHTTPStatus:  http.StatusNotFound,
+			wantErr:         errAPIListNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(service.ListServiceMock)
+			serviceMock.On("GetList", mock.Anything, _listID).Return(mockServiceList(), tt.givenServiceErr)
+
+			h := handlers.NewListHandler(serviceMock)
+			handlerWithMiddleware := middleware.ErrorHandlingMiddleware(h.GetList)
+
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/lists/"+_listID, nil), map[string]string{"listId": _listID})
+			rec := httptest.NewRecorder()
+
+			handlerWithMiddleware.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantHTTPStatus, rec.Code)
+			if tt.wantErr != nil {
+				require.Equal(t, parserAPIErr(t, tt.wantErr), parserAPIErrFromBody(t, rec.Body.Bytes()))
+			} else {
+				require.Equal(t, mockAPIList(), parserAPIList(t, rec.Body.Bytes()))
+			}
+		})
+	}
+}
+
+func TestUpdateList(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenServiceErr error
+		wantHTTPStatus  int
+		wantErr         error
+	}{
+		{
+			name:           "Given_NewName_When_UpdateList_Then_ExpectedHTTPStatusOK",
+			wantHTTPStatus: http.StatusOK,
+		},
+		{
+			name:            "Given_MissingList_When_UpdateList_Then_ExpectedHTTPStatusNotFound",
+			givenServiceErr: errListNotFound,
+			wantHTTPStatus:  http.StatusNotFound,
+			wantErr:         errAPIListNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(service.ListServiceMock)
+			serviceMock.On("UpdateList", mock.Anything, domain.List{ID: _listID, Name: "Pharmacy"}).Return(mockServiceList(), tt.givenServiceErr)
+
+			h := handlers.NewListHandler(serviceMock)
+			handlerWithMiddleware := middleware.ErrorHandlingMiddleware(h.UpdateList)
+
+			req := httptest.NewRequest(http.MethodPut, "/lists/"+_listID, strings.NewReader(`{"id":"ignored","name":"Pharmacy"}`))
+			req = mux.SetURLVars(req, map[string]string{"listId": _listID})
+			rec := httptest.NewRecorder()
+
+			handlerWithMiddleware.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantHTTPStatus, rec.Code)
+			if tt.wantErr != nil {
+				require.Equal(t, parserAPIErr(t, tt.wantErr), parserAPIErrFromBody(t, rec.Body.Bytes()))
+			} else {
+				require.Equal(t, mockAPIList(), parserAPIList(t, rec.Body.Bytes()))
+			}
+		})
+	}
+}
+
+func TestDeleteList(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenListID     string
+		givenServiceErr error
+		wantHTTPStatus  int
+		wantErr         error
+	}{
+		{
+			name:           "Given_ExistingList_When_DeleteList_Then_ExpectedHTTPStatusNoContent",
+			givenListID:    _listID,
+			wantHTTPStatus: http.StatusNoContent,
+		},
+		{
+			name:            "Given_DefaultList_When_DeleteList_Then_ExpectedHTTPStatusConflict",
+			givenListID:     domain.DefaultListID,
+			givenServiceErr: service.NewErrorDefaultList(),
+			wantHTTPStatus:  http.StatusConflict,
+			wantErr:         handlers.ErrorAPI{Message: "the default list cannot be deleted", HTTP: http.StatusConflict},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(service.ListServiceMock)
+			serviceMock.On("DeleteList", mock.Anything, tt.givenListID).Return(tt.givenServiceErr)
+
+			h := handlers.NewListHandler(serviceMock)
+			handlerWithMiddleware := middleware.ErrorHandlingMiddleware(h.DeleteList)
+
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodDelete, "/lists/"+tt.givenListID, nil), map[string]string{"listId": tt.givenListID})
+			rec := httptest.NewRecorder()
+
+			handlerWithMiddleware.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantHTTPStatus, rec.Code)
+			if tt.wantErr != nil {
+				require.Equal(t, parserAPIErr(t, tt.wantErr), parserAPIErrFromBody(t, rec.Body.Bytes()))
+			}
+		})
+	}
+}
+
+func TestListLists(t *testing.T) {
+	serviceMock := new(service.ListServiceMock)
+	serviceMock.On("ListLists", mock.Anything).Return([]domain.List{mockServiceList()}, nil)
+
+	h := handlers.NewListHandler(serviceMock)
+	handlerWithMiddleware := middleware.ErrorHandlingMiddleware(h.ListLists)
+
+	req := httptest.NewRequest(http.MethodGet, "/lists", nil)
+	rec := httptest.NewRecorder()
+
+	handlerWithMiddleware.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var response handlers.ListListsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Equal(t, handlers.ListListsResponse{Lists: []handlers.List{mockAPIList()}}, response)
+}
+
+func mockServiceList() domain.List {
+	return domain.List{
+		ID:        _listID,
+		Name:      "Pharmacy",
+		CreatedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func mockAPIList() handlers.List {
+	return handlers.List{
+		ID:        _listID,
+		Name:      "Pharmacy",
+		CreatedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func parserAPIList(t *testing.T, body []byte) handlers.List {
+	var list handlers.List
+	require.NoError(t, json.Unmarshal(body, &list))
+	return list
+}
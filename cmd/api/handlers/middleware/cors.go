@@ -26,8 +26,10 @@ func CORSMiddleware(allowedOrigins []string) func(http.Handler) http.Handler {
 			}
 
 			w.Header().Set("Vary", "Origin")
-			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, ngrok-skip-browser-warning")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, If-Match, Idempotency-Key, ngrok-skip-browser-warning")
+			// Browsers only let the frontend read these headers if they are exposed
+			w.Header().Set("Access-Control-Expose-Headers", "ETag, Idempotent-Replayed")
 			w.Header().Set("Access-Control-Allow-Credentials", "true")
 
 			// Handle pre-flight request
@@ -128,8 +128,9 @@ func TestCORSMiddleware(t *testing.T) {
 				require.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"), "Access-Control-Allow-Origin header should be empty")
 			}
 
-			require.Equal(t, "GET, POST, PUT, DELETE, OPTIONS", rr.Header().Get("Access-Control-Allow-Methods"), "Access-Control-Allow-Methods header mismatch")
-			require.Equal(t, "Content-Type, Authorization, ngrok-skip-browser-warning", rr.Header().Get("Access-Control-Allow-Headers"), "Access-Control-Allow-Headers header mismatch")
+			require.Equal(t, "GET, POST, PUT, PATCH, DELETE, OPTIONS", rr.Header().Get("Access-Control-Allow-Methods"), "Access-Control-Allow-Methods header mismatch")
+			require.Equal(t, "Content-Type, Authorization, If-Match, Idempotency-Key, ngrok-skip-browser-warning", rr.Header().Get("Access-Control-Allow-Headers"), "Access-Control-Allow-Headers header mismatch")
+			require.Equal(t, "ETag, Idempotent-Replayed", rr.Header().Get("Access-Control-Expose-Headers"), "Access-Control-Expose-Headers header mismatch")
 			require.Equal(t, "true", rr.Header().Get("Access-Control-Allow-Credentials"), "Access-Control-Allow-Credentials header mismatch")
 			if tt.wantVaryHeader {
 				require.Equal(t, "Origin", rr.Header().Get("Vary"), "Vary header mismatch")
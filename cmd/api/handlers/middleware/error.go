@@ -9,10 +9,15 @@ import (
 )
 
 // ErrorHandlingMiddleware handles errors that occur during request processing.
+// The handlers read at most handlers.MaxRequestBodySize bytes of the request body.
 func ErrorHandlingMiddleware(handler func(http.ResponseWriter, *http.Request) error) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var err error
 
+		if r.Body != nil {
+			r.Body = http.MaxBytesReader(w, r.Body, handlers.MaxRequestBodySize)
+		}
+
 		wrappedWriter := wrapResponseWriter(w)
 
 		defer func() {
@@ -23,23 +28,24 @@ func ErrorHandlingMiddleware(handler func(http.ResponseWriter, *http.Request) er
 					errFromPanic = fmt.Errorf("%v", recoverableError)
 				}
 				if !wrappedWriter.wroteHeader {
-					errAPI := handlers.HandleError(wrappedWriter, errFromPanic)
-					wrappedWriter.Header().Set("Content-Type", "application/json")
-					wrappedWriter.WriteHeader(errAPI.HTTP)
-					_ = json.NewEncoder(wrappedWriter).Encode(errAPI)
+					writeErrorAPI(wrappedWriter, handlers.HandleError(errFromPanic))
 				}
 				return
 			}
 
 			// If the handler returned an error (and no panic occurred), handle it here
 			if err != nil && !wrappedWriter.wroteHeader {
-				errAPI := handlers.HandleError(wrappedWriter, err)
-				wrappedWriter.Header().Set("Content-Type", "application/json")
-				wrappedWriter.WriteHeader(errAPI.HTTP)
-				_ = json.NewEncoder(wrappedWriter).Encode(errAPI)
+				writeErrorAPI(wrappedWriter, handlers.HandleError(err))
 			}
 		}()
 
 		err = handler(wrappedWriter, r)
 	}
 }
+
+// writeErrorAPI sends errAPI as the JSON body of the response
+func writeErrorAPI(w http.ResponseWriter, errAPI handlers.ErrorAPI) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(errAPI.HTTP)
+	_ = json.NewEncoder(w).Encode(errAPI)
+}
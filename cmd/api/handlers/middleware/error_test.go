@@ -1,9 +1,11 @@
 package middleware
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/lucaspereirasilva0/list-manager-api/cmd/api/handlers"
@@ -91,3 +93,22 @@ func TestErrorHandlingMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorHandlingMiddlewareBodySize(t *testing.T) {
+	t.Run("Given_TooLargeBody_When_HandlerDecodesIt_Then_RequestEntityTooLarge", func(t *testing.T) {
+		mw := ErrorHandlingMiddleware(func(w http.ResponseWriter, r *http.Request) error {
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				return handlers.NewDecodeRequestError(err)
+			}
+			return nil
+		})
+
+		body := `{"name":"` + strings.Repeat("a", handlers.MaxRequestBodySize) + `"}`
+		rr := httptest.NewRecorder()
+		mw.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/item", strings.NewReader(body)))
+
+		require.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+		require.Contains(t, rr.Body.String(), "request body too large")
+	})
+}
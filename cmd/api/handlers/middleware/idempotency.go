@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/lucaspereirasilva0/list-manager-api/cmd/api/handlers"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header that makes retries of a request safe
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on the responses replayed from the store
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// DefaultIdempotencyTTL is how long a response is replayed for retries of its request
+	DefaultIdempotencyTTL = 24 * time.Hour
+	// IdempotencyLease is how long a key is held for a request still being processed,
+	// well over the server WriteTimeout
+	IdempotencyLease = time.Minute
+
+	maxIdempotencyKeyLength = 255
+)
+
+var errIdempotencyKeyTooLong = errors.New("the key must have at most 255 characters")
+
+// replayedHeaders are the response headers stored and sent again with the response body
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// IdempotencyMiddleware makes the retries of a request sent with an Idempotency-Key
+// safe: the first response is stored for ttl and replayed verbatim to the retries with
+// the same key, method, path and body. A key reused with another request, such as the
+// same body sent to another list, returns 422, and a retry sent while the first request
+// is still being processed returns 409. The key is only held for IdempotencyLease until
+// the response is stored, so a request interrupted by a crash can be retried once the
+// lease ends. Server errors are not stored, so the request can be retried with the same key.
+func IdempotencyMiddleware(store repository.IdempotencyStore, ttl time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				writeErrorAPI(w, handlers.NewInvalidHeaderError(IdempotencyKeyHeader, errIdempotencyKeyTooLong))
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, handlers.MaxRequestBodySize))
+			if err != nil {
+				writeErrorAPI(w, handlers.NewDecodeRequestError(err))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			record := repository.IdempotencyRecord{
+				Key:         key,
+				RequestHash: requestHash(r, body),
+				ExpiresAt:   time.Now().Add(IdempotencyLease),
+			}
+
+			// The response must be stored even if the client is already gone: that is when it retries
+			ctx := context.WithoutCancel(r.Context())
+
+			stored, reserved, err := store.Reserve(ctx, record)
+			if err != nil {
+				writeErrorAPI(w, handlers.NewInternalServerError(err))
+				return
+			}
+			if !reserved {
+				switch {
+				case stored.RequestHash != record.RequestHash:
+					writeErrorAPI(w, handlers.NewIdempotencyKeyReusedError())
+				case !stored.Completed:
+					writeErrorAPI(w, handlers.NewIdempotencyKeyInProgressError())
+				default:
+					replayResponse(w, stored)
+				}
+				return
+			}
+
+			wrappedWriter := wrapResponseWriter(w)
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					releaseIdempotencyKey(ctx, store, key)
+					panic(recovered)
+				}
+			}()
+			next.ServeHTTP(wrappedWriter, r)
+
+			status := wrappedWriter.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			if status >= http.StatusInternalServerError {
+				releaseIdempotencyKey(ctx, store, key)
+				return
+			}
+
+			record.Completed = true
+			record.StatusCode = status
+			record.Header = make(http.Header)
+			for _, name := range replayedHeaders {
+				if values := wrappedWriter.Header().Values(name); len(values) > 0 {
+					record.Header[name] = values
+				}
+			}
+			record.Body = wrappedWriter.body.Bytes()
+			record.ExpiresAt = time.Now().Add(ttl)
+			if err := store.Complete(ctx, record); err != nil {
+				log.Printf("failed to store the response of idempotency key %s: %v", key, err)
+			}
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// requestHash identifies a request by its method, its path, which names the list
+// of the list-scoped routes, and its body
+func requestHash(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	_, _ = fmt.Fprintf(hash, "%s %s\n", r.Method, r.URL.Path)
+	_, _ = hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// replayResponse sends a stored response again
+func replayResponse(w http.ResponseWriter, record repository.IdempotencyRecord) {
+	for name, values := range record.Header {
+		w.Header()[name] = values
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(record.StatusCode)
+	_, _ = w.Write(record.Body)
+}
+
+func releaseIdempotencyKey(ctx context.Context, store repository.IdempotencyStore, key string) {
+	if err := store.Release(ctx, key); err != nil {
+		log.Printf("failed to release idempotency key %s: %v", key, err)
+	}
+}
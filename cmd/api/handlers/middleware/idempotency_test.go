@@ -0,0 +1,271 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucaspereirasilva0/list-manager-api/cmd/api/handlers"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository/local"
+)
+
+// failingIdempotencyStore fails every operation
+type failingIdempotencyStore struct{}
+
+func (failingIdempotencyStore) Reserve(context.Context, repository.IdempotencyRecord) (repository.IdempotencyRecord, bool, error) {
+	return repository.IdempotencyRecord{}, false, errors.New("store unavailable")
+}
+
+func (failingIdempotencyStore) Complete(context.Context, repository.IdempotencyRecord) error {
+	return errors.New("store unavailable")
+}
+
+func (failingIdempotencyStore) Release(context.Context, string) error {
+	return errors.New("store unavailable")
+}
+
+// recordingIdempotencyStore records the records reserved and completed in a local store
+type recordingIdempotencyStore struct {
+	*local.LocalIdempotencyStore
+	reserved  []repository.IdempotencyRecord
+	completed []repository.IdempotencyRecord
+}
+
+func (s *recordingIdempotencyStore) Reserve(ctx context.Context, record repository.IdempotencyRecord) (repository.IdempotencyRecord, bool, error) {
+	s.reserved = append(s.reserved, record)
+	return s.LocalIdempotencyStore.Reserve(ctx, record)
+}
+
+func (s *recordingIdempotencyStore) Complete(ctx context.Context, record repository.IdempotencyRecord) error {
+	s.completed = append(s.completed, record)
+	return s.LocalIdempotencyStore.Complete(ctx, record)
+}
+
+func newIdempotencyRequest(key, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/item", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	return req
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	// createdHandler answers like POST /item and counts the items created
+	createdHandler := func(created *atomic.Int32) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			n := created.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Not-Replayed", "true")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"n":%d,"body":%s}`, n, body)
+		})
+	}
+
+	t.Run("Given_NoKey_When_RequestIsRetried_Then_HandlerRunsEveryTime", func(t *testing.T) {
+		var created atomic.Int32
+		handler := IdempotencyMiddleware(local.NewLocalIdempotencyStore(), time.Hour)(createdHandler(&created))
+
+		for i := 0; i < 2; i++ {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, newIdempotencyRequest("", `{"name":"Milk"}`))
+			require.Equal(t, http.StatusCreated, rec.Code)
+		}
+		require.Equal(t, int32(2), created.Load())
+	})
+
+	t.Run("Given_SameKeyAndBody_When_RequestIsRetried_Then_FirstResponseIsReplayed", func(t *testing.T) {
+		var created atomic.Int32
+		handler := IdempotencyMiddleware(local.NewLocalIdempotencyStore(), time.Hour)(createdHandler(&created))
+
+		first := httptest.NewRecorder()
+		handler.ServeHTTP(first, newIdempotencyRequest("key-1", `{"name":"Milk"}`))
+		require.Equal(t, http.StatusCreated, first.Code)
+		require.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+
+		retry := httptest.NewRecorder()
+		handler.ServeHTTP(retry, newIdempotencyRequest("key-1", `{"name":"Milk"}`))
+
+		require.Equal(t, int32(1), created.Load())
+		require.Equal(t, http.StatusCreated, retry.Code)
+		require.Equal(t, first.Body.String(), retry.Body.String())
+		require.Equal(t, "application/json", retry.Header().Get("Content-Type"))
+		require.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+		require.Empty(t, retry.Header().Get("X-Not-Replayed"))
+	})
+
+	t.Run("Given_SameKeyAndOtherBody_When_RequestIsSent_Then_UnprocessableEntity", func(t *testing.T) {
+		var created atomic.Int32
+		handler := IdempotencyMiddleware(local.NewLocalIdempotencyStore(), time.Hour)(createdHandler(&created))
+
+		handler.ServeHTTP(httptest.NewRecorder(), newIdempotencyRequest("key-1", `{"name":"Milk"}`))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newIdempotencyRequest("key-1", `{"name":"Bread"}`))
+
+		require.Equal(t, int32(1), created.Load())
+		require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		requireErrorAPI(t, handlers.NewIdempotencyKeyReusedError(), rec)
+	})
+
+	t.Run("Given_SameKeyAndBodyOnAnotherList_When_RequestIsSent_Then_UnprocessableEntity", func(t *testing.T) {
+		var created atomic.Int32
+		handler := IdempotencyMiddleware(local.NewLocalIdempotencyStore(), time.Hour)(createdHandler(&created))
+
+		handler.ServeHTTP(httptest.NewRecorder(), newIdempotencyRequest("key-1", `{"name":"Milk"}`))
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/lists/groceries/items", strings.NewReader(`{"name":"Milk"}`))
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		handler.ServeHTTP(rec, req)
+
+		require.Equal(t, int32(1), created.Load())
+		require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		requireErrorAPI(t, handlers.NewIdempotencyKeyReusedError(), rec)
+	})
+
+	t.Run("Given_KeyInProgress_When_RequestIsRetried_Then_Conflict", func(t *testing.T) {
+		store := local.NewLocalIdempotencyStore()
+
+		var created atomic.Int32
+		handler := IdempotencyMiddleware(store, time.Hour)(createdHandler(&created))
+
+		inFlight := make(chan struct{})
+		release := make(chan struct{})
+		slowHandler := IdempotencyMiddleware(store, time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(inFlight)
+			<-release
+			w.WriteHeader(http.StatusCreated)
+		}))
+		go slowHandler.ServeHTTP(httptest.NewRecorder(), newIdempotencyRequest("key-1", `{"name":"Milk"}`))
+		<-inFlight
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newIdempotencyRequest("key-1", `{"name":"Milk"}`))
+		close(release)
+
+		require.Equal(t, int32(0), created.Load())
+		require.Equal(t, http.StatusConflict, rec.Code)
+		requireErrorAPI(t, handlers.NewIdempotencyKeyInProgressError(), rec)
+	})
+
+	t.Run("Given_ServerError_When_RequestIsRetried_Then_HandlerRunsAgain", func(t *testing.T) {
+		var calls atomic.Int32
+		handler := IdempotencyMiddleware(local.NewLocalIdempotencyStore(), time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+		first := httptest.NewRecorder()
+		handler.ServeHTTP(first, newIdempotencyRequest("key-1", `{"name":"Milk"}`))
+		require.Equal(t, http.StatusInternalServerError, first.Code)
+
+		retry := httptest.NewRecorder()
+		handler.ServeHTTP(retry, newIdempotencyRequest("key-1", `{"name":"Milk"}`))
+		require.Equal(t, http.StatusCreated, retry.Code)
+		require.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("Given_ExpiredKey_When_RequestIsRetried_Then_HandlerRunsAgain", func(t *testing.T) {
+		var created atomic.Int32
+		handler := IdempotencyMiddleware(local.NewLocalIdempotencyStore(), -time.Second)(createdHandler(&created))
+
+		for i := 0; i < 2; i++ {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, newIdempotencyRequest("key-1", `{"name":"Milk"}`))
+			require.Equal(t, http.StatusCreated, rec.Code)
+			require.Empty(t, rec.Header().Get(IdempotentReplayedHeader))
+		}
+		require.Equal(t, int32(2), created.Load())
+	})
+
+	t.Run("Given_NewKey_When_RequestIsSent_Then_KeyIsLeasedUntilTheResponseIsStored", func(t *testing.T) {
+		store := &recordingIdempotencyStore{LocalIdempotencyStore: local.NewLocalIdempotencyStore()}
+		var created atomic.Int32
+		handler := IdempotencyMiddleware(store, 24*time.Hour)(createdHandler(&created))
+
+		start := time.Now()
+		handler.ServeHTTP(httptest.NewRecorder(), newIdempotencyRequest("key-1", `{"name":"Milk"}`))
+
+		require.Len(t, store.reserved, 1)
+		require.False(t, store.reserved[0].ExpiresAt.After(time.Now().Add(IdempotencyLease)))
+		require.Len(t, store.completed, 1)
+		require.False(t, store.completed[0].ExpiresAt.Before(start.Add(24*time.Hour)))
+	})
+
+	t.Run("Given_KeyWhoseLeaseEnded_When_RequestIsRetried_Then_HandlerRuns", func(t *testing.T) {
+		store := local.NewLocalIdempotencyStore()
+		// A request that reserved the key and never completed, e.g. because the server crashed
+		_, _, err := store.Reserve(context.Background(), repository.IdempotencyRecord{Key: "key-1", ExpiresAt: time.Now().Add(-time.Second)})
+		require.NoError(t, err)
+
+		var created atomic.Int32
+		handler := IdempotencyMiddleware(store, time.Hour)(createdHandler(&created))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newIdempotencyRequest("key-1", `{"name":"Milk"}`))
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		require.Equal(t, int32(1), created.Load())
+	})
+
+	t.Run("Given_TooLargeBody_When_RequestIsSent_Then_RequestEntityTooLarge", func(t *testing.T) {
+		store := &recordingIdempotencyStore{LocalIdempotencyStore: local.NewLocalIdempotencyStore()}
+		var created atomic.Int32
+		handler := IdempotencyMiddleware(store, time.Hour)(createdHandler(&created))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newIdempotencyRequest("key-1", strings.Repeat("a", handlers.MaxRequestBodySize+1)))
+
+		require.Equal(t, int32(0), created.Load())
+		require.Empty(t, store.reserved)
+		require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	})
+
+	t.Run("Given_TooLongKey_When_RequestIsSent_Then_BadRequest", func(t *testing.T) {
+		var created atomic.Int32
+		handler := IdempotencyMiddleware(local.NewLocalIdempotencyStore(), time.Hour)(createdHandler(&created))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newIdempotencyRequest(strings.Repeat("k", 256), `{"name":"Milk"}`))
+
+		require.Equal(t, int32(0), created.Load())
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		requireErrorAPI(t, handlers.NewInvalidHeaderError(IdempotencyKeyHeader, errIdempotencyKeyTooLong), rec)
+	})
+
+	t.Run("Given_StoreError_When_RequestIsSent_Then_InternalServerError", func(t *testing.T) {
+		var created atomic.Int32
+		handler := IdempotencyMiddleware(failingIdempotencyStore{}, time.Hour)(createdHandler(&created))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newIdempotencyRequest("key-1", `{"name":"Milk"}`))
+
+		require.Equal(t, int32(0), created.Load())
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func requireErrorAPI(t *testing.T, want handlers.ErrorAPI, rec *httptest.ResponseRecorder) {
+	t.Helper()
+
+	var got handlers.ErrorAPI
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Equal(t, want, got)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+}
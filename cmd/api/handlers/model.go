@@ -3,12 +3,129 @@ package handlers
 import "time"
 
 type Item struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Active      bool      `json:"active"`
-	Observation *string   `json:"observation,omitempty"`
-	CreatedAt   time.Time `json:"createdAt" bson:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt" bson:"updatedAt"`
+	ID string `json:"id"`
+	// ListID is the list of the item; it is ignored in request bodies, use the /lists/{listId}/items routes
+	ListID      string  `json:"listId,omitempty"`
+	Name        string  `json:"name"`
+	Active      bool    `json:"active"`
+	Observation *string `json:"observation,omitempty"`
+	// Quantity is in Unit, which defaults to un when only the quantity is sent
+	Quantity *Quantity `json:"quantity,omitempty"`
+	Unit     string    `json:"unit,omitempty"`
+	// CategoryID is the category of the item; on PUT an empty one keeps the stored category
+	CategoryID string `json:"categoryId,omitempty"`
+	// UnitPrice is the price of one unit of the item, in Currency, which defaults
+	// to BRL; on PUT no unit price keeps the stored price
+	UnitPrice *Price `json:"unitPrice,omitempty"`
+	Currency  string `json:"currency,omitempty"`
+	// Position orders the items of the list, compared as strings; it is ignored
+	// in request bodies, use POST /items/{id}/move
+	Position  string    `json:"position,omitempty"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
+	// Version is also sent as the ETag of the item; it is ignored in request bodies, use If-Match
+	Version int64 `json:"version"`
+}
+
+// List is a named list of items; only name is read from request bodies
+type List struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ListListsResponse holds every list, in creation order
+type ListListsResponse struct {
+	Lists []List `json:"lists"`
+}
+
+// Category is a kind of item; position orders the categories, starting at 1.
+// A category created without a position goes after the last one, and an update
+// without a position keeps the stored one.
+type Category struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ListCategoriesResponse holds every category, by position
+type ListCategoriesResponse struct {
+	Categories []Category `json:"categories"`
+}
+
+// ListItemGroupsResponse holds the items of GET /items?groupBy=category, one
+// group per category by position and a last group with a null category for the
+// uncategorized items. Categories without matching items are left out.
+type ListItemGroupsResponse struct {
+	Groups []ItemGroup `json:"groups"`
+}
+
+// ItemGroup is a category and its items
+type ItemGroup struct {
+	Category *Category `json:"category"`
+	Items    []Item    `json:"items"`
+}
+
+// ListItemsResponse is a page of items; NextCursor is passed as the cursor
+// query parameter to get the next page and is omitted on the last one
+type ListItemsResponse struct {
+	Items      []Item `json:"items"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// TotalsResponse holds the sum of the quantities of the items, one total per
+// base unit (kg, L, un and pack)
+type TotalsResponse struct {
+	Totals []Total `json:"totals"`
+}
+
+// Total is the sum of the quantities in one unit
+type Total struct {
+	Quantity Quantity `json:"quantity"`
+	Unit     string   `json:"unit"`
+}
+
+// SummaryResponse holds what the priced items cost, one summary per currency in
+// alphabetical order; items without a unit price are left out
+type SummaryResponse struct {
+	Summaries []PriceSummary `json:"summaries"`
+}
+
+// PriceSummary is what the priced items of one currency cost: EstimatedTotal
+// sums the active items, still to be bought, and CheckedTotal the ones already
+// checked off. An item costs its unit price times its quantity.
+type PriceSummary struct {
+	Currency       string             `json:"currency"`
+	EstimatedTotal Price              `json:"estimatedTotal"`
+	CheckedTotal   Price              `json:"checkedTotal"`
+	Categories     []CategorySubtotal `json:"categories"`
+}
+
+// CategorySubtotal is what the priced items of one category cost, by category
+// position, with a last subtotal with a null categoryId for the uncategorized items
+type CategorySubtotal struct {
+	CategoryID     *string `json:"categoryId"`
+	EstimatedTotal Price   `json:"estimatedTotal"`
+	CheckedTotal   Price   `json:"checkedTotal"`
+}
+
+// SearchItemsResponse holds the items found by a search, most relevant first
+type SearchItemsResponse struct {
+	Items []Item `json:"items"`
+}
+
+// Suggestion is an item name suggested while typing and how many items used it
+type Suggestion struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// SuggestItemNamesResponse holds the suggested names, closest and most used first
+type SuggestItemNamesResponse struct {
+	Suggestions []Suggestion `json:"suggestions"`
 }
 
 type HealthCheckResponse struct {
@@ -32,13 +149,65 @@ const (
 	HealthStatusDown     HealthStatus = "down"
 )
 
+// MoveItemRequest is the body of POST /items/{id}/move: the item goes right
+// after the item with id after, or right before the item with id before. When both
+// are sent, before must still come after after, or the move is rejected with 409.
+type MoveItemRequest struct {
+	After  string `json:"after,omitempty"`
+	Before string `json:"before,omitempty"`
+}
+
+// BulkActiveRequest sets active on the items with the given ids, or on the ones
+// matching filter; on every item when there are neither
 type BulkActiveRequest struct {
-	Active bool `json:"active"`
+	Active bool              `json:"active"`
+	IDs    []string          `json:"ids,omitempty"`
+	Filter *BulkActiveFilter `json:"filter,omitempty"`
+}
+
+// BulkActiveFilter selects the items of a bulk active update, like the filters of GET /items
+type BulkActiveFilter struct {
+	Active         *bool      `json:"active,omitempty"`
+	Name           string     `json:"name,omitempty"`
+	HasObservation *bool      `json:"hasObservation,omitempty"`
+	CreatedAfter   *time.Time `json:"createdAfter,omitempty"`
+	UpdatedBefore  *time.Time `json:"updatedBefore,omitempty"`
 }
 
+// BulkActiveResponse counts the updated items; NotFoundIDs lists the requested ids that don't exist
 type BulkActiveResponse struct {
-	MatchedCount  int64 `json:"matchedCount"`
-	ModifiedCount int64 `json:"modifiedCount"`
+	MatchedCount  int64    `json:"matchedCount"`
+	ModifiedCount int64    `json:"modifiedCount"`
+	NotFoundIDs   []string `json:"notFoundIds"`
+}
+
+// DeleteItemsResponse holds the number of items removed by DELETE /items
+type DeleteItemsResponse struct {
+	DeletedCount int64 `json:"deletedCount"`
+}
+
+// BatchRequest is the body of POST /items/batch
+type BatchRequest struct {
+	Operations []BatchOperation `json:"operations"`
+}
+
+// BatchOperation is one change of a batch: op is create, update or delete, and
+// item is the item to create or update, or the item to delete of which only id is used
+type BatchOperation struct {
+	Op   string `json:"op"`
+	Item Item   `json:"item"`
+}
+
+// BatchResponse holds the result of every operation, in the order they were sent
+type BatchResponse struct {
+	Results []BatchResult `json:"results"`
+}
+
+// BatchResult is the outcome of one operation, with the status code it would have on its own
+type BatchResult struct {
+	Status int       `json:"status"`
+	Item   *Item     `json:"item,omitempty"`
+	Error  *ErrorAPI `json:"error,omitempty"`
 }
 
 type ComponentStatus string
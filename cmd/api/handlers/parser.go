@@ -1,17 +1,29 @@
 package handlers
 
-import "github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+import (
+	"net/http"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+)
 
 type parser struct{}
 
 func (p parser) toApiModel(item domain.Item) Item {
 	return Item{
 		ID:          item.ID,
+		ListID:      item.ListID,
 		Name:        item.Name,
 		Active:      item.Active,
 		Observation: item.Observation,
+		Quantity:    (*Quantity)(item.Quantity),
+		Unit:        string(item.Unit),
+		CategoryID:  item.CategoryID,
+		UnitPrice:   (*Price)(item.UnitPrice),
+		Currency:    string(item.Currency),
+		Position:    item.Position,
 		CreatedAt:   item.CreatedAt,
 		UpdatedAt:   item.UpdatedAt,
+		Version:     item.Version,
 	}
 }
 
@@ -21,7 +33,95 @@ func (p parser) toDomainModel(item Item) domain.Item {
 		Name:        item.Name,
 		Active:      item.Active,
 		Observation: item.Observation,
+		Quantity:    (*domain.Thousandths)(item.Quantity),
+		Unit:        domain.Unit(item.Unit),
+		CategoryID:  item.CategoryID,
+		UnitPrice:   (*domain.Amount)(item.UnitPrice),
+		Currency:    domain.Currency(item.Currency),
 		CreatedAt:   item.CreatedAt,
 		UpdatedAt:   item.UpdatedAt,
 	}
 }
+
+func (p parser) toDomainActiveUpdate(req BulkActiveRequest) domain.ActiveUpdate {
+	update := domain.ActiveUpdate{
+		Active: req.Active,
+		IDs:    req.IDs,
+	}
+	if req.Filter != nil {
+		update.Filter = domain.ItemFilter{
+			Active:         req.Filter.Active,
+			Name:           req.Filter.Name,
+			HasObservation: req.Filter.HasObservation,
+			CreatedAfter:   req.Filter.CreatedAfter,
+			UpdatedBefore:  req.Filter.UpdatedBefore,
+		}
+	}
+	return update
+}
+
+// toApiBatchResult gives result the status code its operation would have on its own
+func (p parser) toApiBatchResult(opType domain.BatchOperationType, result domain.BatchResult) BatchResult {
+	if result.Err != nil {
+		errAPI := HandleError(result.Err)
+		return BatchResult{Status: errAPI.HTTP, Error: &errAPI}
+	}
+
+	switch opType {
+	case domain.BatchCreate:
+		item := p.toApiModel(result.Item)
+		return BatchResult{Status: http.StatusCreated, Item: &item}
+	case domain.BatchDelete:
+		return BatchResult{Status: http.StatusNoContent}
+	}
+	item := p.toApiModel(result.Item)
+	return BatchResult{Status: http.StatusOK, Item: &item}
+}
+
+func (p parser) toApiList(list domain.List) List {
+	return List{
+		ID:        list.ID,
+		Name:      list.Name,
+		CreatedAt: list.CreatedAt,
+		UpdatedAt: list.UpdatedAt,
+	}
+}
+
+func (p parser) toApiCategory(category domain.Category) Category {
+	return Category{
+		ID:        category.ID,
+		Name:      category.Name,
+		Position:  category.Position,
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}
+}
+
+func (p parser) toApiItemGroup(group domain.ItemGroup) ItemGroup {
+	apiGroup := ItemGroup{Items: make([]Item, len(group.Items))}
+	if group.Category != nil {
+		category := p.toApiCategory(*group.Category)
+		apiGroup.Category = &category
+	}
+	for i, item := range group.Items {
+		apiGroup.Items[i] = p.toApiModel(item)
+	}
+	return apiGroup
+}
+
+func (p parser) toApiPriceSummary(summary domain.PriceSummary) PriceSummary {
+	apiSummary := PriceSummary{
+		Currency:       string(summary.Currency),
+		EstimatedTotal: Price(summary.Estimated),
+		CheckedTotal:   Price(summary.Checked),
+		Categories:     make([]CategorySubtotal, len(summary.Categories)),
+	}
+	for i, subtotal := range summary.Categories {
+		apiSummary.Categories[i] = CategorySubtotal{EstimatedTotal: Price(subtotal.Estimated), CheckedTotal: Price(subtotal.Checked)}
+		if subtotal.CategoryID != "" {
+			categoryID := subtotal.CategoryID
+			apiSummary.Categories[i].CategoryID = &categoryID
+		}
+	}
+	return apiSummary
+}
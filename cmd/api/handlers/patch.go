@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+)
+
+// MergePatchContentType is the media type of a JSON merge patch (RFC 7396)
+const MergePatchContentType = "application/merge-patch+json"
+
+var errPatchNotObject = errors.New("the merge patch must be a JSON object")
+
+// isMergePatchContentType reports whether a PATCH body with the given Content-Type is accepted.
+// Plain JSON is accepted too, since clients often send it for merge patches.
+func isMergePatchContentType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == MergePatchContentType || mediaType == "application/json"
+}
+
+// decodeMergePatch reads a JSON merge patch of an item: absent members are kept
+// and null removes the member, like an empty categoryId. Only observation,
+// quantity, with its unit, categoryId and unitPrice, with its currency, can be
+// removed, and read-only or unknown members are rejected instead of silently
+// ignored.
+func decodeMergePatch(body io.Reader) (domain.ItemPatch, error) {
+	var (
+		members map[string]json.RawMessage
+		patch   domain.ItemPatch
+	)
+
+	if err := json.NewDecoder(body).Decode(&members); err != nil {
+		return domain.ItemPatch{}, NewDecodeRequestError(err)
+	}
+	if members == nil {
+		return domain.ItemPatch{}, NewDecodeRequestError(errPatchNotObject)
+	}
+
+	for member, value := range members {
+		isNull := bytes.Equal(bytes.TrimSpace(value), []byte("null"))
+
+		switch member {
+		case "name":
+			if isNull {
+				return domain.ItemPatch{}, NewDecodeRequestError(fmt.Errorf("%q cannot be removed", member))
+			}
+			if err := json.Unmarshal(value, &patch.Name); err != nil {
+				return domain.ItemPatch{}, NewDecodeRequestError(fmt.Errorf("%q must be a string", member))
+			}
+		case "active":
+			if isNull {
+				return domain.ItemPatch{}, NewDecodeRequestError(fmt.Errorf("%q cannot be removed", member))
+			}
+			if err := json.Unmarshal(value, &patch.Active); err != nil {
+				return domain.ItemPatch{}, NewDecodeRequestError(fmt.Errorf("%q must be a boolean", member))
+			}
+		case "observation":
+			if err := json.Unmarshal(value, &patch.Observation); err != nil {
+				return domain.ItemPatch{}, NewDecodeRequestError(fmt.Errorf("%q must be a string or null", member))
+			}
+			patch.SetObservation = true
+		case "quantity":
+			var quantity *Quantity
+			if err := json.Unmarshal(value, &quantity); err != nil {
+				return domain.ItemPatch{}, NewDecodeRequestError(fmt.Errorf("%q must be a decimal number with up to 3 decimal places or null", member))
+			}
+			patch.Quantity = (*domain.Thousandths)(quantity)
+			patch.SetQuantity = true
+		case "unit":
+			if isNull {
+				return domain.ItemPatch{}, NewDecodeRequestError(fmt.Errorf("%q is removed with the quantity", member))
+			}
+			if err := json.Unmarshal(value, &patch.Unit); err != nil {
+				return domain.ItemPatch{}, NewDecodeRequestError(fmt.Errorf("%q must be a string", member))
+			}
+		case "categoryId":
+			if err := json.Unmarshal(value, &patch.CategoryID); err != nil {
+				return domain.ItemPatch{}, NewDecodeRequestError(fmt.Errorf("%q must be a string or null", member))
+			}
+			if patch.CategoryID != nil && *patch.CategoryID == "" {
+				patch.CategoryID = nil
+			}
+			patch.SetCategory = true
+		case "unitPrice":
+			var unitPrice *Price
+			if err := json.Unmarshal(value, &unitPrice); err != nil {
+				return domain.ItemPatch{}, NewDecodeRequestError(fmt.Errorf("%q must be a decimal number with up to 2 decimal places or null", member))
+			}
+			patch.UnitPrice = (*domain.Amount)(unitPrice)
+			patch.SetPrice = true
+		case "currency":
+			if isNull {
+				return domain.ItemPatch{}, NewDecodeRequestError(fmt.Errorf("%q is removed with the unit price", member))
+			}
+			if err := json.Unmarshal(value, &patch.Currency); err != nil {
+				return domain.ItemPatch{}, NewDecodeRequestError(fmt.Errorf("%q must be a string", member))
+			}
+		case "id", "position", "createdAt", "updatedAt", "version":
+			return domain.ItemPatch{}, NewDecodeRequestError(fmt.Errorf("%q is read-only", member))
+		default:
+			return domain.ItemPatch{}, NewDecodeRequestError(fmt.Errorf("unknown field %q", member))
+		}
+	}
+
+	return patch, nil
+}
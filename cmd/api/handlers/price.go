@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+)
+
+// Price is an amount of money written as a string with 2 decimal places, like
+// "12.90". It is read from a number or a string without going through a
+// float64, so that it is never rounded.
+type Price domain.Amount
+
+// MarshalJSON writes p as a string, like "12.90"
+func (p Price) MarshalJSON() ([]byte, error) {
+	return json.Marshal(domain.Amount(p).String())
+}
+
+// UnmarshalJSON reads p from a number, like 12.9, or a string, like "12.90"
+func (p *Price) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	amount, err := domain.ParseAmount(s)
+	if err != nil {
+		return err
+	}
+	*p = Price(amount)
+	return nil
+}
@@ -0,0 +1,69 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucaspereirasilva0/list-manager-api/cmd/api/handlers"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+)
+
+func TestPrice_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenJSON string
+		wantPrice handlers.Price
+		wantErr   error
+	}{
+		{
+			name:      "Given_Number_When_UnmarshalJSON_Then_ExpectedCents",
+			givenJSON: `12.9`,
+			wantPrice: 1290,
+		},
+		{
+			name:      "Given_String_When_UnmarshalJSON_Then_ExpectedCents",
+			givenJSON: `"0.07"`,
+			wantPrice: 7,
+		},
+		{
+			name:      "Given_NumberThatIsNotExactInFloat64_When_UnmarshalJSON_Then_ExpectedExactCents",
+			givenJSON: `90071992547409.93`,
+			wantPrice: 9007199254740993,
+		},
+		{
+			name:      "Given_Exponent_When_UnmarshalJSON_Then_ExpectedInvalidAmountError",
+			givenJSON: `1e3`,
+			wantErr:   domain.ErrInvalidAmount,
+		},
+		{
+			name:      "Given_Boolean_When_UnmarshalJSON_Then_ExpectedInvalidAmountError",
+			givenJSON: `true`,
+			wantErr:   domain.ErrInvalidAmount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var price handlers.Price
+			err := json.Unmarshal([]byte(tt.givenJSON), &price)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantPrice, price)
+			}
+		})
+	}
+}
+
+func TestPrice_MarshalJSON(t *testing.T) {
+	body, err := json.Marshal(struct {
+		UnitPrice handlers.Price `json:"unitPrice"`
+	}{UnitPrice: 1290})
+
+	require.NoError(t, err)
+	require.JSONEq(t, `{"unitPrice":"12.90"}`, string(body))
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+)
+
+// Quantity is a quantity written as a number with up to 3 decimal places, like
+// 1.5. It is read from a number or a string without going through a float64,
+// so that it is never rounded.
+type Quantity domain.Thousandths
+
+// MarshalJSON writes q as a number, like 1.5
+func (q Quantity) MarshalJSON() ([]byte, error) {
+	return []byte(domain.Thousandths(q).String()), nil
+}
+
+// UnmarshalJSON reads q from a number, like 1.5, or a string, like "1.5"
+func (q *Quantity) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	thousandths, err := domain.ParseThousandths(s)
+	if err != nil {
+		return err
+	}
+	*q = Quantity(thousandths)
+	return nil
+}
@@ -0,0 +1,64 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucaspereirasilva0/list-manager-api/cmd/api/handlers"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+)
+
+func TestQuantity_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name         string
+		givenJSON    string
+		wantQuantity handlers.Quantity
+		wantErr      error
+	}{
+		{
+			name:         "Given_Number_When_UnmarshalJSON_Then_ExpectedThousandths",
+			givenJSON:    `1.5`,
+			wantQuantity: 1500,
+		},
+		{
+			name:         "Given_String_When_UnmarshalJSON_Then_ExpectedThousandths",
+			givenJSON:    `"0.001"`,
+			wantQuantity: 1,
+		},
+		{
+			name:      "Given_TooManyDecimals_When_UnmarshalJSON_Then_ExpectedInvalidThousandthsError",
+			givenJSON: `1.2345`,
+			wantErr:   domain.ErrInvalidThousandths,
+		},
+		{
+			name:      "Given_Boolean_When_UnmarshalJSON_Then_ExpectedInvalidThousandthsError",
+			givenJSON: `true`,
+			wantErr:   domain.ErrInvalidThousandths,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var quantity handlers.Quantity
+			err := json.Unmarshal([]byte(tt.givenJSON), &quantity)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantQuantity, quantity)
+			}
+		})
+	}
+}
+
+func TestQuantity_MarshalJSON(t *testing.T) {
+	body, err := json.Marshal(struct {
+		Quantity handlers.Quantity `json:"quantity"`
+	}{Quantity: 1500})
+
+	require.NoError(t, err)
+	require.JSONEq(t, `{"quantity":1.5}`, string(body))
+}
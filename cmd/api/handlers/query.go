@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+)
+
+// parseListOptions reads the listing query parameters:
+//   - limit (1 to domain.MaxListLimit, domain.DefaultListLimit by default) and cursor
+//   - the filters active, name, hasObservation, createdAfter and updatedBefore (RFC 3339)
+//   - sort, comma-separated fields of domain.SortableFields, "-" prefixed for descending order
+func parseListOptions(query url.Values) (domain.ListOptions, error) {
+	opts := domain.ListOptions{
+		Cursor: query.Get("cursor"),
+	}
+
+	limit, err := parseLimitParam(query, domain.MaxListLimit)
+	if err != nil {
+		return domain.ListOptions{}, err
+	}
+	opts.Limit = limit
+
+	filter, err := parseItemFilter(query)
+	if err != nil {
+		return domain.ListOptions{}, err
+	}
+	opts.Filter = filter
+
+	if opts.Sort, err = parseSort(query.Get("sort")); err != nil {
+		return domain.ListOptions{}, err
+	}
+
+	return opts, nil
+}
+
+// parseGroupBy reads the groupBy query parameter, empty when the items are not
+// grouped. Grouped items are not paged, so limit and cursor cannot be sent with it.
+func parseGroupBy(query url.Values) (string, error) {
+	groupBy := query.Get("groupBy")
+	switch {
+	case groupBy == "":
+		return "", nil
+	case groupBy != domain.GroupByCategory:
+		return "", NewInvalidQueryParamError("groupBy", fmt.Errorf("%q is not a grouping, use %s", groupBy, domain.GroupByCategory))
+	case query.Has("limit") || query.Has("cursor"):
+		return "", NewInvalidQueryParamError("groupBy", errors.New("grouped items are not paged, remove limit and cursor"))
+	}
+	return groupBy, nil
+}
+
+// parseSort reads a sort such as "-updatedAt,name"; an empty value keeps the default order
+func parseSort(value string) ([]domain.SortField, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var (
+		sort []domain.SortField
+		seen = map[string]bool{}
+	)
+	for _, field := range strings.Split(value, ",") {
+		s := domain.SortField{Field: strings.TrimSpace(field)}
+		if strings.HasPrefix(s.Field, "-") {
+			s.Field, s.Desc = s.Field[1:], true
+		}
+
+		if !slices.Contains(domain.SortableFields, s.Field) {
+			return nil, NewInvalidQueryParamError("sort",
+				fmt.Errorf("%q is not sortable, use %s", s.Field, strings.Join(domain.SortableFields, ", ")))
+		}
+		if seen[s.Field] {
+			return nil, NewInvalidQueryParamError("sort", fmt.Errorf("%q is repeated", s.Field))
+		}
+		seen[s.Field] = true
+		sort = append(sort, s)
+	}
+
+	return sort, nil
+}
+
+// parseSearchParams reads the search query parameters: q, the required text to
+// search, and limit (1 to domain.MaxSearchLimit, domain.DefaultSearchLimit by default)
+func parseSearchParams(query url.Values) (string, int, error) {
+	q := strings.TrimSpace(query.Get("q"))
+	if q == "" {
+		return "", 0, NewInvalidQueryParamError("q", errors.New("the text to search is required"))
+	}
+
+	limit, err := parseLimitParam(query, domain.MaxSearchLimit)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return q, limit, nil
+}
+
+// parseSuggestParams reads the autocomplete query parameters: prefix, the required
+// text typed so far, and limit (1 to domain.MaxSuggestLimit, domain.DefaultSuggestLimit by default)
+func parseSuggestParams(query url.Values) (string, int, error) {
+	prefix := strings.TrimSpace(query.Get("prefix"))
+	if prefix == "" {
+		return "", 0, NewInvalidQueryParamError("prefix", errors.New("the text typed so far is required"))
+	}
+
+	limit, err := parseLimitParam(query, domain.MaxSuggestLimit)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return prefix, limit, nil
+}
+
+// parseAtomic reads the atomic query parameter of a batch, false when missing
+func parseAtomic(query url.Values) (bool, error) {
+	atomic, err := parseBoolParam(query, "atomic")
+	if err != nil || atomic == nil {
+		return false, err
+	}
+	return *atomic, nil
+}
+
+// parseLimitParam returns 0 (the default limit) when the parameter is missing
+func parseLimitParam(query url.Values, maxLimit int) (int, error) {
+	limitStr := query.Get("limit")
+	if limitStr == "" {
+		return 0, nil
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil {
+		return 0, NewInvalidQueryParamError("limit", err)
+	}
+	if limit < 1 || limit > maxLimit {
+		return 0, NewInvalidQueryParamError("limit", fmt.Errorf("limit must be between 1 and %d", maxLimit))
+	}
+	return limit, nil
+}
+
+func parseItemFilter(query url.Values) (domain.ItemFilter, error) {
+	var (
+		filter domain.ItemFilter
+		err    error
+	)
+
+	if filter.Active, err = parseBoolParam(query, "active"); err != nil {
+		return domain.ItemFilter{}, err
+	}
+	if filter.HasObservation, err = parseBoolParam(query, "hasObservation"); err != nil {
+		return domain.ItemFilter{}, err
+	}
+	if filter.CreatedAfter, err = parseTimeParam(query, "createdAfter"); err != nil {
+		return domain.ItemFilter{}, err
+	}
+	if filter.UpdatedBefore, err = parseTimeParam(query, "updatedBefore"); err != nil {
+		return domain.ItemFilter{}, err
+	}
+	filter.Name = query.Get("name")
+
+	return filter, nil
+}
+
+// parseBoolParam returns nil when the parameter is missing
+func parseBoolParam(query url.Values, param string) (*bool, error) {
+	value := query.Get(param)
+	if value == "" {
+		return nil, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, NewInvalidQueryParamError(param, fmt.Errorf("%q is not a boolean (true or false)", value))
+	}
+	return &b, nil
+}
+
+// parseTimeParam returns nil when the parameter is missing
+func parseTimeParam(query url.Values, param string) (*time.Time, error) {
+	value := query.Get(param)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, NewInvalidQueryParamError(param, fmt.Errorf("%q is not an RFC 3339 timestamp", value))
+	}
+	return &t, nil
+}
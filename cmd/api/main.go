@@ -2,24 +2,57 @@ package main
 
 import (
 	"context"
+	"errors"
+	"expvar"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"strconv"
 	"time"
 
 	"github.com/lucaspereirasilva0/list-manager-api/cmd/api/handlers"
+	"github.com/lucaspereirasilva0/list-manager-api/cmd/api/handlers/middleware"
 	"github.com/lucaspereirasilva0/list-manager-api/cmd/api/server"
 	dbmongo "github.com/lucaspereirasilva0/list-manager-api/internal/database/mongodb"
+	dbpostgres "github.com/lucaspereirasilva0/list-manager-api/internal/database/postgres"
+	dbsqlite "github.com/lucaspereirasilva0/list-manager-api/internal/database/sqlite"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository/cache"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository/local"
 	repositorymongo "github.com/lucaspereirasilva0/list-manager-api/internal/repository/mongodb"
+	repositorypostgres "github.com/lucaspereirasilva0/list-manager-api/internal/repository/postgres"
+	repositorysqlite "github.com/lucaspereirasilva0/list-manager-api/internal/repository/sqlite"
 	"github.com/lucaspereirasilva0/list-manager-api/internal/service"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/suggest"
 	"go.uber.org/zap"
 )
 
+const (
+	driverMongoDB  = "mongodb"
+	driverPostgres = "postgres"
+	driverSQLite   = "sqlite"
+	driverMemory   = "memory"
+
+	// mongoMigrationsRetryInterval is how long the startup waits before trying
+	// again the MongoDB migrations locked by another instance
+	mongoMigrationsRetryInterval = 2 * time.Second
+
+	// defaultMongoMigrateTimeout bounds the MongoDB migrations, including the wait
+	// for another instance holding their lock. It is longer than the 10 minutes
+	// after which the lock of a crashed instance is taken over.
+	defaultMongoMigrateTimeout = 15 * time.Minute
+
+	// nameIndexLoadTimeout bounds the scan of the stored items filling the
+	// autocomplete index
+	nameIndexLoadTimeout = 5 * time.Minute
+)
+
 var (
 	defaultPort = 8085
 	mongoURI    = ""
 	mongoDBName = ""
+	sqlitePath  = "listmanager.db"
 )
 
 func main() {
@@ -41,8 +74,10 @@ func main() {
 		}
 	}
 
+	scope := os.Getenv("SCOPE")
+
 	// Get MongoDB URI from environment variable
-	if scope := os.Getenv("SCOPE"); scope == "local" {
+	if scope == "local" {
 		mongoURI = "mongodb://localhost:27017"
 	} else {
 		if uri := os.Getenv("MONGO_URI"); uri != "" {
@@ -56,34 +91,171 @@ func main() {
 		mongoDBName = "listmanager"
 	}
 
-	// Create context for MongoDB connection
+	// Get SQLite database file path from environment variable
+	if path := os.Getenv("SQLITE_PATH"); path != "" {
+		sqlitePath = path
+	}
+
+	// Get database driver from environment variable (mongodb by default)
+	dbDriver := os.Getenv("DB_DRIVER")
+	if scope == "memory" {
+		dbDriver = driverMemory
+	}
+
+	var (
+		itemRepository     repository.ItemRepository
+		listRepository     repository.ListRepository
+		categoryRepository repository.CategoryRepository
+		txManager          repository.TxManager
+		idempotencyStore   repository.IdempotencyStore
+		pingClient         dbmongo.PingClientOperations
+	)
+
+	// Create context for database connection
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// Create MongoDB client
-	mongoClient, err := createMongoClient(ctx, mongoURI, mongoDBName)
-	if err != nil {
-		logger.Fatal("Failed to create MongoDB client", zap.Error(err))
+	switch dbDriver {
+	case driverMemory:
+		// In-memory repository, no database required (data is lost on restart)
+		localRepository := local.NewLocalItemRepository()
+		localListRepository := local.NewLocalListRepository()
+		localCategoryRepository := local.NewLocalCategoryRepository()
+		itemRepository = localRepository
+		listRepository = localListRepository
+		categoryRepository = localCategoryRepository
+		// Deleting a list or a category also changes the items, in one transaction
+		txManager = local.NewLocalTxManager(localRepository, localListRepository, localCategoryRepository)
+		pingClient = localRepository
+
+		logger.Warn("using in-memory repository, data will not be persisted")
+	case driverPostgres:
+		// Create PostgreSQL client (applies pending migrations)
+		postgresClient, err := dbpostgres.NewClient(ctx, os.Getenv("POSTGRES_DSN"))
+		if err != nil {
+			logger.Fatal("Failed to create PostgreSQL client", zap.Error(err))
+		}
+		defer func() {
+			if err := postgresClient.Disconnect(context.Background()); err != nil {
+				logger.Error("Failed to disconnect PostgreSQL client", zap.Error(err))
+			}
+		}()
+
+		//Create repository
+		itemRepository = repositorypostgres.NewPostgresItemRepository(postgresClient.DB())
+		listRepository = repositorypostgres.NewPostgresListRepository(postgresClient.DB())
+		categoryRepository = repositorypostgres.NewPostgresCategoryRepository(postgresClient.DB())
+		txManager = postgresClient.TxManager()
+		pingClient = postgresClient
+	case driverSQLite:
+		// Open SQLite database file (applies pending migrations)
+		sqliteClient, err := dbsqlite.NewClient(ctx, sqlitePath)
+		if err != nil {
+			logger.Fatal("Failed to create SQLite client", zap.Error(err))
+		}
+		defer func() {
+			if err := sqliteClient.Disconnect(context.Background()); err != nil {
+				logger.Error("Failed to disconnect SQLite client", zap.Error(err))
+			}
+		}()
+
+		//Create repository
+		itemRepository = repositorysqlite.NewSQLiteItemRepository(sqliteClient.DB())
+		listRepository = repositorysqlite.NewSQLiteListRepository(sqliteClient.DB())
+		categoryRepository = repositorysqlite.NewSQLiteCategoryRepository(sqliteClient.DB())
+		txManager = sqliteClient.TxManager()
+		pingClient = sqliteClient
+	case "", driverMongoDB:
+		// Create MongoDB client
+		mongoClient, err := createMongoClient(ctx, mongoURI, mongoDBName)
+		if err != nil {
+			logger.Fatal("Failed to create MongoDB client", zap.Error(err))
+		}
+		defer func() {
+			if err := mongoClient.Disconnect(context.Background()); err != nil {
+				logger.Error("Failed to disconnect MongoDB client", zap.Error(err))
+			}
+		}()
+
+		// Apply pending schema migrations (disable with MONGO_AUTO_MIGRATE=false),
+		// within their own timeout as they can outlast the connection one
+		if os.Getenv("MONGO_AUTO_MIGRATE") != "false" {
+			migrateCtx, cancelMigrate := context.WithTimeout(context.Background(), mongoMigrateTimeout(logger))
+			err := migrateMongo(migrateCtx, mongoClient, logger)
+			cancelMigrate()
+			if err != nil {
+				logger.Fatal("Failed to apply MongoDB migrations", zap.Error(err))
+			}
+		}
+
+		// Optionally inject faults in non-production environments (CHAOS_ENABLED=true)
+		var client dbmongo.ClientOperations = mongoClient
+		pingClient = mongoClient
+		if chaosClient := newChaosClient(mongoClient, logger); chaosClient != nil {
+			client = chaosClient
+			pingClient = chaosClient
+		}
+
+		//Create repository
+		itemRepository = repositorymongo.NewMongoDBItemRepository(client)
+		listRepository = repositorymongo.NewMongoDBListRepository(client)
+		categoryRepository = repositorymongo.NewMongoDBCategoryRepository(client)
+		txManager = repositorymongo.NewMongoTxManager(client)
+		idempotencyStore = repositorymongo.NewMongoIdempotencyStore(client)
+	default:
+		logger.Fatal("unsupported DB_DRIVER", zap.String("driver", dbDriver))
 	}
-	defer func() {
-		if err := mongoClient.Disconnect(context.Background()); err != nil {
-			logger.Error("Failed to disconnect MongoDB client", zap.Error(err))
+
+	// Only MongoDB persists the idempotency keys, the other backends keep them in memory
+	if idempotencyStore == nil {
+		idempotencyStore = local.NewLocalIdempotencyStore()
+	}
+
+	// Optionally cache item reads in memory (CACHE_ENABLED=true), around any backend.
+	// Its counters are served in /debug/vars, which is left out in production as it
+	// also exposes the command line and the memory statistics.
+	var debugVars http.Handler
+	if os.Getenv("CACHE_ENABLED") == "true" {
+		cachedRepository := newCachedRepository(itemRepository, logger)
+		itemRepository = cachedRepository
+		txManager = cachedRepository.TxManager(txManager)
+		if !isProduction() {
+			debugVars = expvar.Handler()
 		}
-	}()
+	}
 
-	//Create repository
-	repository := repositorymongo.NewMongoDBItemRepository(mongoClient)
+	// Load the stored item names in the autocomplete index in the background, within
+	// their own timeout: until then it only suggests the names written since the start
+	names := suggest.NewIndex()
+	go func() {
+		loadCtx, cancelLoad := context.WithTimeout(context.Background(), nameIndexLoadTimeout)
+		defer cancelLoad()
+		if err := service.LoadNames(loadCtx, itemRepository, names); err != nil {
+			logger.Error("Failed to load the item names index", zap.Error(err))
+			return
+		}
+		logger.Info("Item names index loaded", zap.Int("names", names.Len()))
+	}()
 
 	//Create item service
-	itemService := service.NewItemService(repository)
+	itemService := service.NewItemService(itemRepository, listRepository, categoryRepository, txManager, names)
 	//Create handler
 	handler := handlers.NewHandler(itemService)
 
+	//Create list service and handler
+	listService := service.NewListService(listRepository, itemRepository, txManager)
+	listHandler := handlers.NewListHandler(listService)
+
+	//Create category service and handler
+	categoryService := service.NewCategoryService(categoryRepository, itemRepository, txManager)
+	categoryHandler := handlers.NewCategoryHandler(categoryService)
+
 	//Create health handler
-	healthHandler := handlers.NewHealthHandler(mongoClient, logger)
+	healthHandler := handlers.NewHealthHandler(pingClient, logger)
 
 	//Create server
-	srv := server.NewServer(handler, healthHandler, logger, defaultPort)
+	idempotency := middleware.IdempotencyMiddleware(idempotencyStore, idempotencyTTL(logger))
+	srv := server.NewServer(handler, listHandler, categoryHandler, healthHandler, idempotency, debugVars, logger, defaultPort)
 	if err := srv.Start(); err != nil {
 		logger.Fatal("server error", zap.Error(err))
 	}
@@ -102,3 +274,120 @@ func createMongoClient(ctx context.Context, mongoURI, mongoDBName string) (*dbmo
 
 	return mongoClient, nil
 }
+
+// migrateMongo applies the pending MongoDB migrations. While another instance
+// holds the migrations lock it tries again, so that the API never serves a
+// database whose migrations are still running, and fails when ctx ends first.
+func migrateMongo(ctx context.Context, mongoClient *dbmongo.ClientWrapper, logger *zap.Logger) error {
+	migrator, err := dbmongo.NewMigrator(mongoClient.Database(), repositorymongo.Migrations())
+	if err != nil {
+		return err
+	}
+
+	for {
+		applied, err := migrator.Up(ctx)
+		if errors.Is(err, dbmongo.ErrMigrationLocked) {
+			logger.Info("MongoDB migrations are locked by another instance, waiting",
+				zap.Duration("retryIn", mongoMigrationsRetryInterval))
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("%w, gave up waiting: %w", err, ctx.Err())
+			case <-time.After(mongoMigrationsRetryInterval):
+				continue
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		logger.Info("MongoDB migrations applied", zap.Ints("versions", applied))
+		return nil
+	}
+}
+
+// newChaosClient wraps mongoClient with fault injection when CHAOS_ENABLED=true.
+// It returns nil when chaos is disabled, misconfigured or the environment is production.
+func newChaosClient(mongoClient *dbmongo.ClientWrapper, logger *zap.Logger) *dbmongo.ChaosClient {
+	if os.Getenv("CHAOS_ENABLED") != "true" {
+		return nil
+	}
+	if isProduction() {
+		logger.Warn("CHAOS_ENABLED is ignored in production")
+		return nil
+	}
+
+	config, err := dbmongo.ParseChaosConfig(os.Getenv)
+	if err != nil {
+		logger.Fatal("Invalid chaos configuration", zap.Error(err))
+	}
+
+	logger.Warn("MongoDB fault injection enabled",
+		zap.Duration("latency", config.Latency),
+		zap.Duration("jitter", config.Jitter),
+		zap.Float64("errorRate", config.ErrorRate),
+		zap.Any("operationErrorRates", config.OperationErrorRates),
+		zap.Strings("errors", config.Errors),
+	)
+	return dbmongo.NewChaosClient(mongoClient, config)
+}
+
+// isProduction reports whether the API runs in production (ENVIRONMENT=production or on Render)
+func isProduction() bool {
+	return os.Getenv("ENVIRONMENT") == "production" || os.Getenv("RENDER") != ""
+}
+
+// newCachedRepository wraps itemRepository with an in-memory LRU cache configured by
+// CACHE_SIZE and CACHE_TTL, and publishes its counters in /debug/vars.
+func newCachedRepository(itemRepository repository.ItemRepository, logger *zap.Logger) *cache.CachedItemRepository {
+	options := cache.Options{Capacity: cache.DefaultCapacity, TTL: cache.DefaultTTL}
+	if sizeStr := os.Getenv("CACHE_SIZE"); sizeStr != "" {
+		size, err := strconv.Atoi(sizeStr)
+		if err != nil {
+			logger.Fatal("Invalid CACHE_SIZE", zap.Error(err))
+		}
+		options.Capacity = size
+	}
+	if ttlStr := os.Getenv("CACHE_TTL"); ttlStr != "" {
+		ttl, err := time.ParseDuration(ttlStr)
+		if err != nil {
+			logger.Fatal("Invalid CACHE_TTL", zap.Error(err))
+		}
+		options.TTL = ttl
+	}
+
+	cachedRepository := cache.NewCachedItemRepository(itemRepository, options)
+	expvar.Publish("itemCache", expvar.Func(func() any { return cachedRepository.Stats() }))
+
+	logger.Info("item cache enabled", zap.Int("size", options.Capacity), zap.Duration("ttl", options.TTL))
+	return cachedRepository
+}
+
+// idempotencyTTL returns how long the responses of the requests sent with an
+// Idempotency-Key are replayed, from IDEMPOTENCY_TTL (24h by default).
+func idempotencyTTL(logger *zap.Logger) time.Duration {
+	ttlStr := os.Getenv("IDEMPOTENCY_TTL")
+	if ttlStr == "" {
+		return middleware.DefaultIdempotencyTTL
+	}
+
+	ttl, err := time.ParseDuration(ttlStr)
+	if err != nil || ttl <= 0 {
+		logger.Fatal("Invalid IDEMPOTENCY_TTL", zap.String("ttl", ttlStr), zap.Error(err))
+	}
+	return ttl
+}
+
+// mongoMigrateTimeout returns how long the startup waits for the MongoDB
+// migrations, from MONGO_MIGRATE_TIMEOUT (15m by default).
+func mongoMigrateTimeout(logger *zap.Logger) time.Duration {
+	timeoutStr := os.Getenv("MONGO_MIGRATE_TIMEOUT")
+	if timeoutStr == "" {
+		return defaultMongoMigrateTimeout
+	}
+
+	timeout, err := time.ParseDuration(timeoutStr)
+	if err != nil || timeout <= 0 {
+		logger.Fatal("Invalid MONGO_MIGRATE_TIMEOUT", zap.String("timeout", timeoutStr), zap.Error(err))
+	}
+	return timeout
+}
@@ -17,18 +17,29 @@ import (
 
 // Server encapsulates the HTTP server configuration
 type Server struct {
-	handler       handlers.ItemHandler
-	healthHandler handlers.HealthHandler
-	logger        *zap.Logger
-	server        *http.Server
+	handler     handlers.ItemHandler
+	listHandler handlers.ListHandler
+	// categoryHandler serves the categories the items are grouped by
+	categoryHandler handlers.CategoryHandler
+	healthHandler   handlers.HealthHandler
+	// idempotency replays the responses of the item creations retried with the same Idempotency-Key
+	idempotency func(http.Handler) http.Handler
+	// debugVars serves the runtime and cache counters; nil leaves /debug/vars out
+	debugVars http.Handler
+	logger    *zap.Logger
+	server    *http.Server
 }
 
 // NewServer creates a new server instance
-func NewServer(handler handlers.ItemHandler, healthHandler handlers.HealthHandler, logger *zap.Logger, port int) *Server {
+func NewServer(handler handlers.ItemHandler, listHandler handlers.ListHandler, categoryHandler handlers.CategoryHandler, healthHandler handlers.HealthHandler, idempotency func(http.Handler) http.Handler, debugVars http.Handler, logger *zap.Logger, port int) *Server {
 	return &Server{
-		handler:       handler,
-		healthHandler: healthHandler,
-		logger:        logger,
+		handler:         handler,
+		listHandler:     listHandler,
+		categoryHandler: categoryHandler,
+		healthHandler:   healthHandler,
+		idempotency:     idempotency,
+		debugVars:       debugVars,
+		logger:          logger,
 		server: &http.Server{
 			Addr:         fmt.Sprintf(":%d", port),
 			ReadTimeout:  10 * time.Second,
@@ -44,13 +55,51 @@ func (s *Server) setupRoutes() {
 	// Health check endpoint
 	router.Handle("/healthz", middleware.ErrorHandlingMiddleware(s.healthHandler.HealthCheck)).Methods("GET")
 
-	// Routes for item operations
-	router.Handle("/item", middleware.ErrorHandlingMiddleware(s.handler.CreateItem)).Methods("POST")
+	// Routes for item operations. The routes taking an item id (/item and
+	// /items/{id}/move) act on the item whatever its list, as ids are unique
+	// across lists; /items/search and /items/suggest cover every list and the
+	// other /items routes act on the default list.
+	router.Handle("/item", s.idempotency(middleware.ErrorHandlingMiddleware(s.handler.CreateItem))).Methods("POST")
 	router.Handle("/item", middleware.ErrorHandlingMiddleware(s.handler.GetItem)).Methods("GET")
 	router.Handle("/item", middleware.ErrorHandlingMiddleware(s.handler.UpdateItem)).Methods("PUT")
+	router.Handle("/item", middleware.ErrorHandlingMiddleware(s.handler.PatchItem)).Methods("PATCH")
 	router.Handle("/item", middleware.ErrorHandlingMiddleware(s.handler.DeleteItem)).Methods("DELETE")
 	router.Handle("/items", middleware.ErrorHandlingMiddleware(s.handler.ListItems)).Methods("GET")
+	router.Handle("/items", middleware.ErrorHandlingMiddleware(s.handler.DeleteItems)).Methods("DELETE")
+	router.Handle("/items/search", middleware.ErrorHandlingMiddleware(s.handler.SearchItems)).Methods("GET")
+	router.Handle("/items/suggest", middleware.ErrorHandlingMiddleware(s.handler.SuggestItemNames)).Methods("GET")
 	router.Handle("/items/active", middleware.ErrorHandlingMiddleware(s.handler.BulkUpdateActive)).Methods("PUT")
+	router.Handle("/items/batch", middleware.ErrorHandlingMiddleware(s.handler.BatchItems)).Methods("POST")
+	router.Handle("/items/totals", middleware.ErrorHandlingMiddleware(s.handler.TotalQuantities)).Methods("GET")
+	router.Handle("/items/summary", middleware.ErrorHandlingMiddleware(s.handler.SummarizePrices)).Methods("GET")
+	router.Handle("/items/{id}/move", middleware.ErrorHandlingMiddleware(s.handler.MoveItem)).Methods("POST")
+
+	// Routes for list operations
+	router.Handle("/lists", middleware.ErrorHandlingMiddleware(s.listHandler.ListLists)).Methods("GET")
+	router.Handle("/lists", middleware.ErrorHandlingMiddleware(s.listHandler.CreateList)).Methods("POST")
+	router.Handle("/lists/{listId}", middleware.ErrorHandlingMiddleware(s.listHandler.GetList)).Methods("GET")
+	router.Handle("/lists/{listId}", middleware.ErrorHandlingMiddleware(s.listHandler.UpdateList)).Methods("PUT")
+	router.Handle("/lists/{listId}", middleware.ErrorHandlingMiddleware(s.listHandler.DeleteList)).Methods("DELETE")
+	router.Handle("/lists/{listId}/items", s.idempotency(middleware.ErrorHandlingMiddleware(s.handler.CreateItem))).Methods("POST")
+	router.Handle("/lists/{listId}/items", middleware.ErrorHandlingMiddleware(s.handler.ListItems)).Methods("GET")
+	router.Handle("/lists/{listId}/items", middleware.ErrorHandlingMiddleware(s.handler.DeleteItems)).Methods("DELETE")
+	router.Handle("/lists/{listId}/items/active", middleware.ErrorHandlingMiddleware(s.handler.BulkUpdateActive)).Methods("PUT")
+	router.Handle("/lists/{listId}/items/search", middleware.ErrorHandlingMiddleware(s.handler.SearchItems)).Methods("GET")
+	router.Handle("/lists/{listId}/items/batch", middleware.ErrorHandlingMiddleware(s.handler.BatchItems)).Methods("POST")
+	router.Handle("/lists/{listId}/totals", middleware.ErrorHandlingMiddleware(s.handler.TotalQuantities)).Methods("GET")
+	router.Handle("/lists/{listId}/summary", middleware.ErrorHandlingMiddleware(s.handler.SummarizePrices)).Methods("GET")
+
+	// Routes for category operations
+	router.Handle("/categories", middleware.ErrorHandlingMiddleware(s.categoryHandler.ListCategories)).Methods("GET")
+	router.Handle("/categories", middleware.ErrorHandlingMiddleware(s.categoryHandler.CreateCategory)).Methods("POST")
+	router.Handle("/categories/{categoryId}", middleware.ErrorHandlingMiddleware(s.categoryHandler.GetCategory)).Methods("GET")
+	router.Handle("/categories/{categoryId}", middleware.ErrorHandlingMiddleware(s.categoryHandler.UpdateCategory)).Methods("PUT")
+	router.Handle("/categories/{categoryId}", middleware.ErrorHandlingMiddleware(s.categoryHandler.DeleteCategory)).Methods("DELETE")
+
+	// Runtime and cache counters (expvar), only when enabled
+	if s.debugVars != nil {
+		router.Handle("/debug/vars", s.debugVars).Methods("GET")
+	}
 
 	// Route for application version (for PWA auto-update)
 	router.HandleFunc("/_app/version.json", handlers.GetVersion).Methods("GET")
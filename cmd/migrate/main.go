@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	dbmongo "github.com/lucaspereirasilva0/list-manager-api/internal/database/mongodb"
+	repositorymongo "github.com/lucaspereirasilva0/list-manager-api/internal/repository/mongodb"
+)
+
+const usage = `Usage: migrate <command>
+
+Commands:
+  up        apply all pending migrations
+  down [N]  revert the last N applied migrations (default 1)
+  status    list migrations and whether they were applied
+
+Environment: SCOPE=local, MONGO_URI, MONGO_DB_NAME (same as the API)
+`
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := migrate(flag.Args()); err != nil {
+		log.Fatalf("Migration failed: %v", err)
+	}
+}
+
+func migrate(args []string) error {
+	mongoURI := os.Getenv("MONGO_URI")
+	if os.Getenv("SCOPE") == "local" {
+		mongoURI = "mongodb://localhost:27017"
+	}
+	mongoDBName := os.Getenv("MONGO_DB_NAME")
+	if mongoDBName == "" {
+		mongoDBName = "listmanager"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	mongoClient, err := dbmongo.NewClient(ctx, mongoURI, mongoDBName)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := mongoClient.Disconnect(context.Background()); err != nil {
+			log.Printf("Failed to disconnect MongoDB client: %v", err)
+		}
+	}()
+
+	migrator, err := dbmongo.NewMigrator(mongoClient.Database(), repositorymongo.Migrations())
+	if err != nil {
+		return err
+	}
+
+	return run(ctx, migrator, args)
+}
+
+func run(ctx context.Context, migrator *dbmongo.Migrator, args []string) error {
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Applied migrations: %v\n", applied)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+			steps = n
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Reverted migrations: %v\n", reverted)
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range status {
+			applied := "pending"
+			if s.Applied {
+				applied = "applied " + s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%4d  %-40s %s\n", s.Version, s.Description, applied)
+		}
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+	return nil
+}
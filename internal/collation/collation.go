@@ -0,0 +1,61 @@
+// Package collation orders item names the way users expect for Portuguese:
+// ignoring case and accents ("açúcar" sorts with and equals "Acucar").
+// Every backend uses the same rules: MongoDB through Locale and Strength,
+// SQLite and the in-memory repository through Compare, and PostgreSQL through
+// an equivalent ICU collation created by its migrations.
+package collation
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// Name is the SQL collation name registered in SQLite and created in PostgreSQL
+	Name = "pt_ci_ai"
+	// FoldFunction is the name of the SQL function folding a string like Fold,
+	// registered in SQLite and created in PostgreSQL
+	FoldFunction = "pt_fold"
+	// Locale and Strength are the MongoDB collation options (strength 1 compares base letters only)
+	Locale   = "pt"
+	Strength = 1
+)
+
+var (
+	// collate.Collator is not safe for concurrent use
+	mu       sync.Mutex
+	collator = collate.New(language.Portuguese, collate.IgnoreCase, collate.IgnoreDiacritics, collate.IgnoreWidth)
+)
+
+// Compare returns -1, 0 or +1 depending on whether a sorts before, equal to or after b.
+func Compare(a, b string) int {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return collator.CompareString(a, b)
+}
+
+// Fold lowercases s and removes its diacritics, so that folded strings can be
+// compared with == or strings functions ("Feijão" becomes "feijao").
+func Fold(s string) string {
+	// A new transformer per call: transform.Chain keeps state
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s)
+	if err != nil {
+		folded = s
+	}
+	return strings.ToLower(folded)
+}
+
+// Words splits s into its folded words, dropping punctuation.
+func Words(s string) []string {
+	return strings.FieldsFunc(Fold(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
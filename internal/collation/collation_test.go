@@ -0,0 +1,52 @@
+package collation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/collation"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name  string
+		given [2]string
+		want  int
+	}{
+		{name: "Given_DifferentCase_When_Compare_Then_Equal", given: [2]string{"arroz", "ARROZ"}, want: 0},
+		{name: "Given_DifferentAccents_When_Compare_Then_Equal", given: [2]string{"açúcar", "Acucar"}, want: 0},
+		{name: "Given_AccentedFirstLetter_When_Compare_Then_SortsWithBaseLetter", given: [2]string{"Água", "banana"}, want: -1},
+		{name: "Given_LowercaseAfterUppercase_When_Compare_Then_AlphabeticalOrder", given: [2]string{"zebra", "Maçã"}, want: 1},
+		{name: "Given_Cedilla_When_Compare_Then_SortsAsBaseLetter", given: [2]string{"maçã", "macarrão"}, want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, collation.Compare(tt.given[0], tt.given[1]))
+		})
+	}
+}
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		name  string
+		given string
+		want  string
+	}{
+		{name: "Given_Tilde_When_Fold_Then_BaseLetter", given: "Feijão", want: "feijao"},
+		{name: "Given_CedillaAndAcute_When_Fold_Then_BaseLetters", given: "AÇÚCAR", want: "acucar"},
+		{name: "Given_PlainText_When_Fold_Then_Lowercased", given: "Arroz 5kg", want: "arroz 5kg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, collation.Fold(tt.given))
+		})
+	}
+}
+
+func TestWords(t *testing.T) {
+	require.Equal(t, []string{"pao", "de", "queijo", "2", "pacotes"}, collation.Words("Pão-de-queijo (2 pacotes)!"))
+	require.Empty(t, collation.Words(" ?! "))
+}
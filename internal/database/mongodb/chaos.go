@@ -0,0 +1,348 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Kinds of driver errors the chaos decorators can inject.
+const (
+	ChaosErrorTimeout       = "timeout"
+	ChaosErrorNetwork       = "network"
+	ChaosErrorDuplicateKey  = "duplicate_key"
+	ChaosErrorWriteConflict = "write_conflict"
+)
+
+// ChaosConfig configures the fault injection of ChaosClient and ChaosCollection.
+// It is meant for non-production environments only.
+type ChaosConfig struct {
+	// Latency is added before every operation, plus a random value up to Jitter.
+	Latency time.Duration
+	Jitter  time.Duration
+	// ErrorRate is the probability (0..1) of failing any operation.
+	ErrorRate float64
+	// OperationErrorRates overrides ErrorRate per operation (e.g. "InsertOne", "Find", "Ping").
+	OperationErrorRates map[string]float64
+	// Errors lists the error kinds injected, picked at random (ChaosErrorTimeout by default).
+	Errors []string
+	// Seed makes the injected faults reproducible; 0 uses the current time.
+	Seed int64
+}
+
+// ParseChaosConfig reads the chaos configuration from the CHAOS_* variables returned by getenv:
+//
+//	CHAOS_LATENCY=200ms CHAOS_JITTER=100ms CHAOS_ERROR_RATE=0.1
+//	CHAOS_ERROR_RATES=InsertOne=0.5,Find=0.2 CHAOS_ERRORS=timeout,duplicate_key CHAOS_SEED=42
+func ParseChaosConfig(getenv func(string) string) (ChaosConfig, error) {
+	var (
+		config ChaosConfig
+		err    error
+	)
+
+	if v := getenv("CHAOS_LATENCY"); v != "" {
+		if config.Latency, err = time.ParseDuration(v); err != nil {
+			return ChaosConfig{}, fmt.Errorf("invalid CHAOS_LATENCY: %w", err)
+		}
+	}
+	if v := getenv("CHAOS_JITTER"); v != "" {
+		if config.Jitter, err = time.ParseDuration(v); err != nil {
+			return ChaosConfig{}, fmt.Errorf("invalid CHAOS_JITTER: %w", err)
+		}
+	}
+	if v := getenv("CHAOS_ERROR_RATE"); v != "" {
+		if config.ErrorRate, err = parseRate(v); err != nil {
+			return ChaosConfig{}, fmt.Errorf("invalid CHAOS_ERROR_RATE: %w", err)
+		}
+	}
+	if v := getenv("CHAOS_ERROR_RATES"); v != "" {
+		config.OperationErrorRates = make(map[string]float64)
+		for _, pair := range strings.Split(v, ",") {
+			operation, rateStr, found := strings.Cut(strings.TrimSpace(pair), "=")
+			if !found {
+				return ChaosConfig{}, fmt.Errorf("invalid CHAOS_ERROR_RATES entry %q, expected Operation=rate", pair)
+			}
+			rate, err := parseRate(rateStr)
+			if err != nil {
+				return ChaosConfig{}, fmt.Errorf("invalid CHAOS_ERROR_RATES entry %q: %w", pair, err)
+			}
+			config.OperationErrorRates[operation] = rate
+		}
+	}
+	if v := getenv("CHAOS_ERRORS"); v != "" {
+		for _, kind := range strings.Split(v, ",") {
+			kind = strings.TrimSpace(kind)
+			if chaosError(kind) == nil {
+				return ChaosConfig{}, fmt.Errorf("invalid CHAOS_ERRORS kind %q", kind)
+			}
+			config.Errors = append(config.Errors, kind)
+		}
+	}
+	if v := getenv("CHAOS_SEED"); v != "" {
+		if config.Seed, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return ChaosConfig{}, fmt.Errorf("invalid CHAOS_SEED: %w", err)
+		}
+	}
+
+	return config, nil
+}
+
+func parseRate(v string) (float64, error) {
+	rate, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, err
+	}
+	if rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("rate %v out of range [0, 1]", rate)
+	}
+	return rate, nil
+}
+
+// chaosError builds the driver error of the given kind, shaped like the real ones
+// so the repository and service error mapping is exercised.
+func chaosError(kind string) error {
+	switch kind {
+	case ChaosErrorTimeout:
+		return mongo.CommandError{
+			Message: "chaos: operation timed out",
+			Labels:  []string{"NetworkError"},
+			Wrapped: context.DeadlineExceeded,
+		}
+	case ChaosErrorNetwork:
+		return mongo.CommandError{
+			Message: "chaos: connection reset by peer",
+			Labels:  []string{"NetworkError"},
+		}
+	case ChaosErrorDuplicateKey:
+		return mongo.WriteException{
+			WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "chaos: E11000 duplicate key error"}},
+		}
+	case ChaosErrorWriteConflict:
+		return mongo.CommandError{
+			Code:    112,
+			Name:    "WriteConflict",
+			Message: "chaos: write conflict",
+			Labels:  []string{"TransientTransactionError"},
+		}
+	}
+	return nil
+}
+
+// chaos decides which faults to inject. It is shared by the decorators built from the same config.
+type chaos struct {
+	config ChaosConfig
+	mu     sync.Mutex
+	rand   *rand.Rand
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+func newChaos(config ChaosConfig) *chaos {
+	if len(config.Errors) == 0 {
+		config.Errors = []string{ChaosErrorTimeout}
+	}
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &chaos{
+		config: config,
+		rand:   rand.New(rand.NewSource(seed)),
+		sleep:  sleepContext,
+	}
+}
+
+// inject applies the configured latency and returns the error to fail operation with, if any.
+func (c *chaos) inject(ctx context.Context, operation string) error {
+	c.mu.Lock()
+	delay := c.config.Latency
+	if c.config.Jitter > 0 {
+		delay += time.Duration(c.rand.Int63n(int64(c.config.Jitter)))
+	}
+	rate, ok := c.config.OperationErrorRates[operation]
+	if !ok {
+		rate = c.config.ErrorRate
+	}
+	fail := rate > 0 && c.rand.Float64() < rate
+	kind := c.config.Errors[c.rand.Intn(len(c.config.Errors))]
+	c.mu.Unlock()
+
+	if delay > 0 {
+		if err := c.sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+	if fail {
+		return chaosError(kind)
+	}
+	return nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// ChaosClient decorates ClientOperations (and PingClientOperations) with fault injection.
+type ChaosClient struct {
+	client ClientOperations
+	chaos  *chaos
+}
+
+// NewChaosClient wraps client so every collection it returns, and Ping, inject the configured faults.
+func NewChaosClient(client ClientOperations, config ChaosConfig) *ChaosClient {
+	return &ChaosClient{
+		client: client,
+		chaos:  newChaos(config),
+	}
+}
+
+// GetCollection returns the collection wrapped with fault injection.
+func (cc *ChaosClient) GetCollection(collectionName string) MongoCollectionOperations {
+	return &ChaosCollection{collection: cc.client.GetCollection(collectionName), chaos: cc.chaos}
+}
+
+// Disconnect closes the underlying client; no fault is injected.
+func (cc *ChaosClient) Disconnect(ctx context.Context) error {
+	return cc.client.Disconnect(ctx)
+}
+
+// Client returns the underlying client; sessions are not affected.
+func (cc *ChaosClient) Client() MongoClientOperations {
+	return cc.client.Client()
+}
+
+// Ping injects faults before pinging the underlying client, when it supports it.
+func (cc *ChaosClient) Ping(ctx context.Context) error {
+	if err := cc.chaos.inject(ctx, "Ping"); err != nil {
+		return err
+	}
+	if pinger, ok := cc.client.(PingClientOperations); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+// ChaosCollection decorates MongoCollectionOperations with fault injection.
+type ChaosCollection struct {
+	collection MongoCollectionOperations
+	chaos      *chaos
+}
+
+// NewChaosCollection wraps collection with the faults described by config.
+func NewChaosCollection(collection MongoCollectionOperations, config ChaosConfig) *ChaosCollection {
+	return &ChaosCollection{
+		collection: collection,
+		chaos:      newChaos(config),
+	}
+}
+
+// errorSingleResult builds a SingleResult whose Decode/Err return err.
+func errorSingleResult(err error) *mongo.SingleResult {
+	return mongo.NewSingleResultFromDocument(bson.D{}, err, nil)
+}
+
+func (cc *ChaosCollection) InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error) {
+	if err := cc.chaos.inject(ctx, "InsertOne"); err != nil {
+		return nil, err
+	}
+	return cc.collection.InsertOne(ctx, document, opts...)
+}
+
+func (cc *ChaosCollection) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult {
+	if err := cc.chaos.inject(ctx, "FindOne"); err != nil {
+		return errorSingleResult(err)
+	}
+	return cc.collection.FindOne(ctx, filter, opts...)
+}
+
+func (cc *ChaosCollection) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult {
+	if err := cc.chaos.inject(ctx, "FindOneAndUpdate"); err != nil {
+		return errorSingleResult(err)
+	}
+	return cc.collection.FindOneAndUpdate(ctx, filter, update, opts...)
+}
+
+func (cc *ChaosCollection) FindOneAndReplace(ctx context.Context, filter interface{}, replacement interface{}, opts ...*options.FindOneAndReplaceOptions) *mongo.SingleResult {
+	if err := cc.chaos.inject(ctx, "FindOneAndReplace"); err != nil {
+		return errorSingleResult(err)
+	}
+	return cc.collection.FindOneAndReplace(ctx, filter, replacement, opts...)
+}
+
+func (cc *ChaosCollection) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	if err := cc.chaos.inject(ctx, "UpdateOne"); err != nil {
+		return nil, err
+	}
+	return cc.collection.UpdateOne(ctx, filter, update, opts...)
+}
+
+func (cc *ChaosCollection) UpdateMany(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	if err := cc.chaos.inject(ctx, "UpdateMany"); err != nil {
+		return nil, err
+	}
+	return cc.collection.UpdateMany(ctx, filter, update, opts...)
+}
+
+func (cc *ChaosCollection) DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	if err := cc.chaos.inject(ctx, "DeleteOne"); err != nil {
+		return nil, err
+	}
+	return cc.collection.DeleteOne(ctx, filter, opts...)
+}
+
+func (cc *ChaosCollection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (MongoCursorOperations, error) {
+	if err := cc.chaos.inject(ctx, "Find"); err != nil {
+		return nil, err
+	}
+	return cc.collection.Find(ctx, filter, opts...)
+}
+
+func (cc *ChaosCollection) Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (MongoCursorOperations, error) {
+	if err := cc.chaos.inject(ctx, "Aggregate"); err != nil {
+		return nil, err
+	}
+	return cc.collection.Aggregate(ctx, pipeline, opts...)
+}
+
+func (cc *ChaosCollection) DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	if err := cc.chaos.inject(ctx, "DeleteMany"); err != nil {
+		return nil, err
+	}
+	return cc.collection.DeleteMany(ctx, filter, opts...)
+}
+
+func (cc *ChaosCollection) BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+	if err := cc.chaos.inject(ctx, "BulkWrite"); err != nil {
+		return nil, err
+	}
+	return cc.collection.BulkWrite(ctx, models, opts...)
+}
+
+func (cc *ChaosCollection) CreateIndexes(ctx context.Context, models []mongo.IndexModel, opts ...*options.CreateIndexesOptions) ([]string, error) {
+	if err := cc.chaos.inject(ctx, "CreateIndexes"); err != nil {
+		return nil, err
+	}
+	return cc.collection.CreateIndexes(ctx, models, opts...)
+}
+
+func (cc *ChaosCollection) DropIndex(ctx context.Context, name string, opts ...*options.DropIndexesOptions) error {
+	if err := cc.chaos.inject(ctx, "DropIndex"); err != nil {
+		return err
+	}
+	return cc.collection.DropIndex(ctx, name, opts...)
+}
@@ -0,0 +1,191 @@
+package mongodb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/database/mongodb"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestParseChaosConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		givenEnv   map[string]string
+		wantConfig mongodb.ChaosConfig
+		wantErr    string
+	}{
+		{
+			name:       "Given_NoVariables_When_ParseChaosConfig_Then_ReturnsEmptyConfig",
+			givenEnv:   map[string]string{},
+			wantConfig: mongodb.ChaosConfig{},
+		},
+		{
+			name: "Given_AllVariables_When_ParseChaosConfig_Then_ReturnsConfig",
+			givenEnv: map[string]string{
+				"CHAOS_LATENCY":     "200ms",
+				"CHAOS_JITTER":      "50ms",
+				"CHAOS_ERROR_RATE":  "0.1",
+				"CHAOS_ERROR_RATES": "InsertOne=0.5, Find=1",
+				"CHAOS_ERRORS":      "timeout,duplicate_key",
+				"CHAOS_SEED":        "42",
+			},
+			wantConfig: mongodb.ChaosConfig{
+				Latency:             200 * time.Millisecond,
+				Jitter:              50 * time.Millisecond,
+				ErrorRate:           0.1,
+				OperationErrorRates: map[string]float64{"InsertOne": 0.5, "Find": 1},
+				Errors:              []string{mongodb.ChaosErrorTimeout, mongodb.ChaosErrorDuplicateKey},
+				Seed:                42,
+			},
+		},
+		{
+			name:     "Given_InvalidLatency_When_ParseChaosConfig_Then_ReturnsError",
+			givenEnv: map[string]string{"CHAOS_LATENCY": "soon"},
+			wantErr:  "invalid CHAOS_LATENCY",
+		},
+		{
+			name:     "Given_RateOutOfRange_When_ParseChaosConfig_Then_ReturnsError",
+			givenEnv: map[string]string{"CHAOS_ERROR_RATE": "1.5"},
+			wantErr:  "invalid CHAOS_ERROR_RATE",
+		},
+		{
+			name:     "Given_MalformedOperationRate_When_ParseChaosConfig_Then_ReturnsError",
+			givenEnv: map[string]string{"CHAOS_ERROR_RATES": "InsertOne"},
+			wantErr:  "invalid CHAOS_ERROR_RATES entry",
+		},
+		{
+			name:     "Given_UnknownErrorKind_When_ParseChaosConfig_Then_ReturnsError",
+			givenEnv: map[string]string{"CHAOS_ERRORS": "meteor"},
+			wantErr:  "invalid CHAOS_ERRORS kind",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := mongodb.ParseChaosConfig(func(key string) string { return tt.givenEnv[key] })
+
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantConfig, config)
+		})
+	}
+}
+
+func TestChaosCollection_InjectedErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenKind string
+		wantCheck func(err error) bool
+	}{
+		{
+			name:      "Given_TimeoutKind_When_InsertOne_Then_ReturnsTimeoutError",
+			givenKind: mongodb.ChaosErrorTimeout,
+			wantCheck: mongo.IsTimeout,
+		},
+		{
+			name:      "Given_NetworkKind_When_InsertOne_Then_ReturnsNetworkError",
+			givenKind: mongodb.ChaosErrorNetwork,
+			wantCheck: mongo.IsNetworkError,
+		},
+		{
+			name:      "Given_DuplicateKeyKind_When_InsertOne_Then_ReturnsDuplicateKeyError",
+			givenKind: mongodb.ChaosErrorDuplicateKey,
+			wantCheck: mongo.IsDuplicateKeyError,
+		},
+		{
+			name:      "Given_WriteConflictKind_When_InsertOne_Then_ReturnsTransientTransactionError",
+			givenKind: mongodb.ChaosErrorWriteConflict,
+			wantCheck: func(err error) bool {
+				var cmdErr mongo.CommandError
+				return errors.As(err, &cmdErr) && cmdErr.HasErrorLabel("TransientTransactionError")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionMock := new(mongodb.MockMongoCollectionOperations)
+			collection := mongodb.NewChaosCollection(collectionMock, mongodb.ChaosConfig{
+				ErrorRate: 1,
+				Errors:    []string{tt.givenKind},
+			})
+
+			result, err := collection.InsertOne(context.Background(), bson.M{"name": "test"})
+
+			require.Error(t, err)
+			require.Nil(t, result)
+			require.True(t, tt.wantCheck(err), "unexpected error %v", err)
+			collectionMock.AssertNotCalled(t, "InsertOne", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestChaosCollection_OperationErrorRates(t *testing.T) {
+	ctx := context.Background()
+	collectionMock := new(mongodb.MockMongoCollectionOperations)
+	cursorMock := new(mongodb.MockMongoCursorOperations)
+	collectionMock.On("Find", ctx, bson.M{}, mock.Anything).Return(cursorMock, nil)
+
+	collection := mongodb.NewChaosCollection(collectionMock, mongodb.ChaosConfig{
+		OperationErrorRates: map[string]float64{"FindOne": 1},
+	})
+
+	// FindOne always fails, through the SingleResult like the driver does
+	err := collection.FindOne(ctx, bson.M{}).Decode(&bson.M{})
+	require.True(t, mongo.IsTimeout(err))
+
+	// Find is not configured and ErrorRate is 0, so it reaches the collection
+	cursor, err := collection.Find(ctx, bson.M{})
+	require.NoError(t, err)
+	require.Same(t, cursorMock, cursor)
+	collectionMock.AssertExpectations(t)
+}
+
+func TestChaosCollection_Latency(t *testing.T) {
+	collectionMock := new(mongodb.MockMongoCollectionOperations)
+	collectionMock.On("DeleteOne", mock.Anything, mock.Anything).Return(&mongo.DeleteResult{DeletedCount: 1}, nil)
+
+	collection := mongodb.NewChaosCollection(collectionMock, mongodb.ChaosConfig{Latency: 30 * time.Millisecond})
+
+	t.Run("Given_Latency_When_DeleteOne_Then_OperationIsDelayed", func(t *testing.T) {
+		start := time.Now()
+		_, err := collection.DeleteOne(context.Background(), bson.M{})
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+	})
+
+	t.Run("Given_ContextDeadlineShorterThanLatency_When_DeleteOne_Then_ReturnsDeadlineExceeded", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+
+		_, err := collection.DeleteOne(ctx, bson.M{})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		collectionMock.AssertNumberOfCalls(t, "DeleteOne", 1)
+	})
+}
+
+func TestChaosClient(t *testing.T) {
+	collectionMock := new(mongodb.MockMongoCollectionOperations)
+	clientMock := new(mongodb.MockClientOperations)
+	clientMock.On("GetCollection", "items").Return(collectionMock)
+	clientMock.On("Disconnect", mock.Anything).Return(nil)
+
+	client := mongodb.NewChaosClient(clientMock, mongodb.ChaosConfig{
+		OperationErrorRates: map[string]float64{"Ping": 1},
+		Errors:              []string{mongodb.ChaosErrorNetwork},
+	})
+
+	require.IsType(t, &mongodb.ChaosCollection{}, client.GetCollection("items"))
+	require.True(t, mongo.IsNetworkError(client.Ping(context.Background())))
+	require.NoError(t, client.Disconnect(context.Background()))
+	clientMock.AssertExpectations(t)
+}
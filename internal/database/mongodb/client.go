@@ -63,6 +63,11 @@ func (cw *ClientWrapper) GetCollection(name string) MongoCollectionOperations {
 	return &mongoCollectionWrapper{collection: cw.mongoClient.Database(cw.DatabaseName).Collection(name)}
 }
 
+// Database returns the configured database, used by the schema migrations.
+func (cw *ClientWrapper) Database() MongoDatabaseOperations {
+	return &mongoDatabaseWrapper{database: cw.mongoClient.Database(cw.DatabaseName)}
+}
+
 // Client exposes the underlying *mongo.Client for advanced use cases.
 func (cw *ClientWrapper) Client() MongoClientOperations {
 	return &mongoClientWrapper{client: cw.mongoClient}
@@ -0,0 +1,233 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// MigrationsCollection stores one document per applied migration.
+	MigrationsCollection = "schema_migrations"
+	// MigrationsLockCollection holds the lock that serializes concurrent migrators.
+	MigrationsLockCollection = "schema_migrations_lock"
+
+	migrationsLockID = "lock"
+	// migrationsLockTTL is how long a lock is honored before it is considered
+	// abandoned (e.g. the process holding it crashed).
+	migrationsLockTTL = 10 * time.Minute
+)
+
+var (
+	// ErrMigrationLocked is returned when another instance is running the migrations.
+	ErrMigrationLocked = errors.New("migrations are locked by another instance")
+	// ErrIrreversibleMigration is returned by Down when a migration has no down step.
+	ErrIrreversibleMigration = errors.New("migration is irreversible")
+)
+
+// MigrationFunc applies (or reverts) a schema change on the given database.
+type MigrationFunc func(ctx context.Context, db MongoDatabaseOperations) error
+
+// Migration is a versioned schema change. Down is optional; migrations without
+// it cannot be reverted.
+type Migration struct {
+	Version     int
+	Description string
+	Up          MigrationFunc
+	Down        MigrationFunc
+}
+
+// MigrationStatus reports whether a known migration was applied.
+type MigrationStatus struct {
+	Version     int
+	Description string
+	Applied     bool
+	AppliedAt   time.Time
+}
+
+// MigrationRecord is the document stored in the schema_migrations collection.
+type MigrationRecord struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"appliedAt"`
+}
+
+// Migrator applies and reverts versioned migrations, recording them in the
+// schema_migrations collection.
+type Migrator struct {
+	db         MongoDatabaseOperations
+	migrations []Migration
+}
+
+// NewMigrator creates a new Migrator. Migrations are sorted by version and
+// must have unique, positive versions and an up step.
+func NewMigrator(db MongoDatabaseOperations, migrations []Migration) (*Migrator, error) {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+
+	for i, m := range sorted {
+		if m.Version <= 0 {
+			return nil, fmt.Errorf("invalid migration version %d", m.Version)
+		}
+		if m.Up == nil {
+			return nil, fmt.Errorf("migration %d has no up step", m.Version)
+		}
+		if i > 0 && sorted[i-1].Version == m.Version {
+			return nil, fmt.Errorf("duplicate migration version %d", m.Version)
+		}
+	}
+
+	return &Migrator{db: db, migrations: sorted}, nil
+}
+
+// Up applies every pending migration in version order and returns the applied versions.
+func (m *Migrator) Up(ctx context.Context) ([]int, error) {
+	applied := []int{}
+	err := m.withLock(ctx, func() error {
+		records, err := m.appliedRecords(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := records[migration.Version]; ok {
+				continue
+			}
+			if err = migration.Up(ctx, m.db); err != nil {
+				return fmt.Errorf("failed to apply migration %d (%s): %w", migration.Version, migration.Description, err)
+			}
+
+			record := MigrationRecord{
+				Version:     migration.Version,
+				Description: migration.Description,
+				AppliedAt:   time.Now().UTC().Truncate(time.Millisecond),
+			}
+			if _, err = m.db.Collection(MigrationsCollection).InsertOne(ctx, record); err != nil {
+				return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+			}
+			applied = append(applied, migration.Version)
+		}
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts the last steps applied migrations, newest first, and returns the reverted versions.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]int, error) {
+	if steps <= 0 {
+		return nil, fmt.Errorf("invalid number of steps %d", steps)
+	}
+
+	reverted := []int{}
+	err := m.withLock(ctx, func() error {
+		records, err := m.appliedRecords(ctx)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := records[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == nil {
+				return fmt.Errorf("migration %d (%s): %w", migration.Version, migration.Description, ErrIrreversibleMigration)
+			}
+			if err = migration.Down(ctx, m.db); err != nil {
+				return fmt.Errorf("failed to revert migration %d (%s): %w", migration.Version, migration.Description, err)
+			}
+			if _, err = m.db.Collection(MigrationsCollection).DeleteOne(ctx, bson.M{"_id": migration.Version}); err != nil {
+				return fmt.Errorf("failed to unrecord migration %d: %w", migration.Version, err)
+			}
+			reverted = append(reverted, migration.Version)
+		}
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status lists the known migrations in version order and whether they were applied.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	records, err := m.appliedRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		record, ok := records[migration.Version]
+		status = append(status, MigrationStatus{
+			Version:     migration.Version,
+			Description: migration.Description,
+			Applied:     ok,
+			AppliedAt:   record.AppliedAt,
+		})
+	}
+
+	return status, nil
+}
+
+func (m *Migrator) appliedRecords(ctx context.Context) (map[int]MigrationRecord, error) {
+	cursor, err := m.db.Collection(MigrationsCollection).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	var records []MigrationRecord
+	if err = cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	applied := make(map[int]MigrationRecord, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+
+	return applied, nil
+}
+
+// withLock runs fn while holding the migrations lock. The lock is a single
+// document taken with an upsert: when it is held (and not expired) the filter
+// does not match and the upsert fails with a duplicate key error.
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	locks := m.db.Collection(MigrationsLockCollection)
+	now := time.Now().UTC()
+
+	filter := bson.M{
+		"_id": migrationsLockID,
+		"$or": bson.A{
+			bson.M{"locked": false},
+			bson.M{"lockedAt": bson.M{"$lt": now.Add(-migrationsLockTTL)}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"locked": true, "lockedAt": now}}
+
+	_, err := locks.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrMigrationLocked
+		}
+		return fmt.Errorf("failed to acquire migrations lock: %w", err)
+	}
+
+	fnErr := fn()
+
+	// The lock is released even if ctx was canceled while migrating.
+	_, err = locks.UpdateOne(context.Background(), bson.M{"_id": migrationsLockID}, bson.M{"$set": bson.M{"locked": false}})
+	if err != nil && fnErr == nil {
+		return fmt.Errorf("failed to release migrations lock: %w", err)
+	}
+
+	return fnErr
+}
@@ -0,0 +1,252 @@
+package mongodb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/database/mongodb"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	errMigration = errors.New("migration failed")
+)
+
+// recorder builds migrations that record the order their steps ran in.
+type recorder struct {
+	calls []string
+}
+
+func (r *recorder) migration(version int, description string, upErr error, reversible bool) mongodb.Migration {
+	m := mongodb.Migration{
+		Version:     version,
+		Description: description,
+		Up: func(ctx context.Context, db mongodb.MongoDatabaseOperations) error {
+			r.calls = append(r.calls, "up:"+description)
+			return upErr
+		},
+	}
+	if reversible {
+		m.Down = func(ctx context.Context, db mongodb.MongoDatabaseOperations) error {
+			r.calls = append(r.calls, "down:"+description)
+			return nil
+		}
+	}
+	return m
+}
+
+func mockMigrationsDatabase(applied []mongodb.MigrationRecord, lockErr error) (*mongodb.MockMongoDatabaseOperations, *mongodb.MockMongoCollectionOperations, *mongodb.MockMongoCollectionOperations) {
+	db := new(mongodb.MockMongoDatabaseOperations)
+	migrations := new(mongodb.MockMongoCollectionOperations)
+	locks := new(mongodb.MockMongoCollectionOperations)
+	cursor := new(mongodb.MockMongoCursorOperations)
+
+	db.On("Collection", mongodb.MigrationsCollection, mock.Anything).Return(migrations)
+	db.On("Collection", mongodb.MigrationsLockCollection, mock.Anything).Return(locks)
+
+	migrations.On("Find", mock.Anything, bson.M{}, mock.Anything).Return(cursor, nil)
+	cursor.On("All", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		records := args.Get(1).(*[]mongodb.MigrationRecord)
+		*records = applied
+	}).Return(nil).Maybe()
+	migrations.On("InsertOne", mock.Anything, mock.Anything).Return(&mongo.InsertOneResult{}, nil).Maybe()
+	migrations.On("DeleteOne", mock.Anything, mock.Anything).Return(&mongo.DeleteResult{DeletedCount: 1}, nil).Maybe()
+
+	locks.On("UpdateOne", mock.Anything, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{}, lockErr).Once()
+	locks.On("UpdateOne", mock.Anything, bson.M{"_id": "lock"}, mock.Anything).Return(&mongo.UpdateResult{}, nil).Maybe()
+
+	return db, migrations, locks
+}
+
+func TestNewMigrator(t *testing.T) {
+	noop := func(ctx context.Context, db mongodb.MongoDatabaseOperations) error { return nil }
+
+	tests := []struct {
+		name            string
+		givenMigrations []mongodb.Migration
+		wantErr         bool
+	}{
+		{
+			name:            "Given_ValidMigrations_When_NewMigrator_Then_ExpectedSuccess",
+			givenMigrations: []mongodb.Migration{{Version: 2, Up: noop}, {Version: 1, Up: noop}},
+		},
+		{
+			name:            "Given_DuplicateVersion_When_NewMigrator_Then_ExpectedError",
+			givenMigrations: []mongodb.Migration{{Version: 1, Up: noop}, {Version: 1, Up: noop}},
+			wantErr:         true,
+		},
+		{
+			name:            "Given_InvalidVersion_When_NewMigrator_Then_ExpectedError",
+			givenMigrations: []mongodb.Migration{{Version: 0, Up: noop}},
+			wantErr:         true,
+		},
+		{
+			name:            "Given_MissingUpStep_When_NewMigrator_Then_ExpectedError",
+			givenMigrations: []mongodb.Migration{{Version: 1}},
+			wantErr:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrator, err := mongodb.NewMigrator(new(mongodb.MockMongoDatabaseOperations), tt.givenMigrations)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Nil(t, migrator)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, migrator)
+		})
+	}
+}
+
+func TestMigratorUp(t *testing.T) {
+	tests := []struct {
+		name         string
+		givenApplied []mongodb.MigrationRecord
+		givenUpErr   error
+		givenLockErr error
+		wantApplied  []int
+		wantCalls    []string
+		wantErr      error
+	}{
+		{
+			name:        "Given_NoAppliedMigrations_When_Up_Then_AppliesAllInOrder",
+			wantApplied: []int{1, 2},
+			wantCalls:   []string{"up:first", "up:second"},
+		},
+		{
+			name:         "Given_FirstMigrationApplied_When_Up_Then_AppliesOnlyPending",
+			givenApplied: []mongodb.MigrationRecord{{Version: 1, Description: "first"}},
+			wantApplied:  []int{2},
+			wantCalls:    []string{"up:second"},
+		},
+		{
+			name:         "Given_AllMigrationsApplied_When_Up_Then_NothingApplied",
+			givenApplied: []mongodb.MigrationRecord{{Version: 1}, {Version: 2}},
+			wantApplied:  []int{},
+		},
+		{
+			name:        "Given_FailingMigration_When_Up_Then_ExpectedErrorAndStops",
+			givenUpErr:  errMigration,
+			wantApplied: []int{},
+			wantCalls:   []string{"up:first"},
+			wantErr:     errMigration,
+		},
+		{
+			name:         "Given_LockHeldByAnotherInstance_When_Up_Then_ExpectedLockedError",
+			givenLockErr: mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000}}},
+			wantApplied:  []int{},
+			wantErr:      mongodb.ErrMigrationLocked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{}
+			db, migrations, _ := mockMigrationsDatabase(tt.givenApplied, tt.givenLockErr)
+
+			migrator, err := mongodb.NewMigrator(db, []mongodb.Migration{
+				rec.migration(2, "second", nil, true),
+				rec.migration(1, "first", tt.givenUpErr, true),
+			})
+			require.NoError(t, err)
+
+			applied, err := migrator.Up(context.Background())
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantApplied, applied)
+			require.Equal(t, tt.wantCalls, rec.calls)
+			migrations.AssertNumberOfCalls(t, "InsertOne", len(tt.wantApplied))
+		})
+	}
+}
+
+func TestMigratorDown(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenApplied    []mongodb.MigrationRecord
+		givenSteps      int
+		givenReversible bool
+		wantReverted    []int
+		wantCalls       []string
+		wantErr         error
+	}{
+		{
+			name:            "Given_TwoAppliedMigrations_When_DownOneStep_Then_RevertsNewest",
+			givenApplied:    []mongodb.MigrationRecord{{Version: 1}, {Version: 2}},
+			givenSteps:      1,
+			givenReversible: true,
+			wantReverted:    []int{2},
+			wantCalls:       []string{"down:second"},
+		},
+		{
+			name:            "Given_OneAppliedMigration_When_DownManySteps_Then_RevertsOnlyApplied",
+			givenApplied:    []mongodb.MigrationRecord{{Version: 1}},
+			givenSteps:      5,
+			givenReversible: true,
+			wantReverted:    []int{1},
+			wantCalls:       []string{"down:first"},
+		},
+		{
+			name:         "Given_IrreversibleMigration_When_Down_Then_ExpectedIrreversibleError",
+			givenApplied: []mongodb.MigrationRecord{{Version: 1}, {Version: 2}},
+			givenSteps:   1,
+			wantReverted: []int{},
+			wantErr:      mongodb.ErrIrreversibleMigration,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{}
+			db, migrations, _ := mockMigrationsDatabase(tt.givenApplied, nil)
+
+			migrator, err := mongodb.NewMigrator(db, []mongodb.Migration{
+				rec.migration(1, "first", nil, tt.givenReversible),
+				rec.migration(2, "second", nil, tt.givenReversible),
+			})
+			require.NoError(t, err)
+
+			reverted, err := migrator.Down(context.Background(), tt.givenSteps)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantReverted, reverted)
+			require.Equal(t, tt.wantCalls, rec.calls)
+			migrations.AssertNumberOfCalls(t, "DeleteOne", len(tt.wantReverted))
+		})
+	}
+}
+
+func TestMigratorStatus(t *testing.T) {
+	appliedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	rec := &recorder{}
+	db, _, _ := mockMigrationsDatabase([]mongodb.MigrationRecord{{Version: 1, Description: "first", AppliedAt: appliedAt}}, nil)
+
+	migrator, err := mongodb.NewMigrator(db, []mongodb.Migration{
+		rec.migration(1, "first", nil, true),
+		rec.migration(2, "second", nil, true),
+	})
+	require.NoError(t, err)
+
+	status, err := migrator.Status(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []mongodb.MigrationStatus{
+		{Version: 1, Description: "first", Applied: true, AppliedAt: appliedAt},
+		{Version: 2, Description: "second"},
+	}, status)
+}
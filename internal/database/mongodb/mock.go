@@ -55,6 +55,18 @@ func (m *MockMongoCollectionOperations) FindOne(ctx context.Context, filter inte
 	return args.Get(0).(*mongo.SingleResult)
 }
 
+// FindOneAndUpdate implements MongoCollectionOperations.
+func (m *MockMongoCollectionOperations) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult {
+	args := m.Called(ctx, filter, update)
+	return args.Get(0).(*mongo.SingleResult)
+}
+
+// FindOneAndReplace implements MongoCollectionOperations.
+func (m *MockMongoCollectionOperations) FindOneAndReplace(ctx context.Context, filter interface{}, replacement interface{}, opts ...*options.FindOneAndReplaceOptions) *mongo.SingleResult {
+	args := m.Called(ctx, filter, replacement)
+	return args.Get(0).(*mongo.SingleResult)
+}
+
 // UpdateOne implements MongoCollectionOperations.
 func (m *MockMongoCollectionOperations) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
 	args := m.Called(ctx, filter, update)
@@ -75,7 +87,13 @@ func (m *MockMongoCollectionOperations) DeleteOne(ctx context.Context, filter in
 
 // Find implements MongoCollectionOperations.
 func (m *MockMongoCollectionOperations) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (MongoCursorOperations, error) {
-	args := m.Called(ctx, filter)
+	args := m.Called(ctx, filter, opts)
+	return args.Get(0).(MongoCursorOperations), args.Error(1)
+}
+
+// Aggregate implements MongoCollectionOperations.
+func (m *MockMongoCollectionOperations) Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (MongoCursorOperations, error) {
+	args := m.Called(ctx, pipeline)
 	return args.Get(0).(MongoCursorOperations), args.Error(1)
 }
 
@@ -85,6 +103,24 @@ func (m *MockMongoCollectionOperations) DeleteMany(ctx context.Context, filter i
 	return args.Get(0).(*mongo.DeleteResult), args.Error(1)
 }
 
+// BulkWrite implements MongoCollectionOperations.
+func (m *MockMongoCollectionOperations) BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+	args := m.Called(ctx, models)
+	return args.Get(0).(*mongo.BulkWriteResult), args.Error(1)
+}
+
+// CreateIndexes implements MongoCollectionOperations.
+func (m *MockMongoCollectionOperations) CreateIndexes(ctx context.Context, models []mongo.IndexModel, opts ...*options.CreateIndexesOptions) ([]string, error) {
+	args := m.Called(ctx, models)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// DropIndex implements MongoCollectionOperations.
+func (m *MockMongoCollectionOperations) DropIndex(ctx context.Context, name string, opts ...*options.DropIndexesOptions) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
 // MockMongoDatabaseOperations is a mock for MongoDatabaseOperations.
 type MockMongoDatabaseOperations struct {
 	mock.Mock
@@ -101,12 +137,43 @@ type MockMongoClientOperations struct {
 	mock.Mock
 }
 
+// StartSession implements MongoClientOperations.
+func (m *MockMongoClientOperations) StartSession(opts ...*options.SessionOptions) (mongo.Session, error) {
+	args := m.Called()
+	session, _ := args.Get(0).(mongo.Session)
+	return session, args.Error(1)
+}
+
 // Database implements MongoClientOperations.
 func (m *MockMongoClientOperations) Database(name string, opts ...*options.DatabaseOptions) MongoDatabaseOperations {
 	args := m.Called(name, opts)
 	return args.Get(0).(MongoDatabaseOperations)
 }
 
+// MockSession is a mock for mongo.Session. mongo.Session cannot be implemented
+// outside the driver, so it is embedded and only the methods used by the
+// repositories are mocked; calling any other method panics.
+type MockSession struct {
+	mongo.Session
+	mock.Mock
+}
+
+// WithTransaction runs fn with a session context, like the driver does, and
+// returns the error configured for the call (or fn's error when none is).
+func (m *MockSession) WithTransaction(ctx context.Context, fn func(ctx mongo.SessionContext) (interface{}, error), opts ...*options.TransactionOptions) (interface{}, error) {
+	args := m.Called(ctx)
+	result, err := fn(mongo.NewSessionContext(ctx, m))
+	if args.Error(0) != nil {
+		return nil, args.Error(0)
+	}
+	return result, err
+}
+
+// EndSession implements mongo.Session.
+func (m *MockSession) EndSession(ctx context.Context) {
+	m.Called(ctx)
+}
+
 // MockClientOperations is a mock for ClientOperations.
 type MockClientOperations struct {
 	mock.Mock
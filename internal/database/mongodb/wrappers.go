@@ -21,6 +21,14 @@ func (mcw *mongoCollectionWrapper) FindOne(ctx context.Context, filter interface
 	return mcw.collection.FindOne(ctx, filter, opts...)
 }
 
+func (mcw *mongoCollectionWrapper) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult {
+	return mcw.collection.FindOneAndUpdate(ctx, filter, update, opts...)
+}
+
+func (mcw *mongoCollectionWrapper) FindOneAndReplace(ctx context.Context, filter interface{}, replacement interface{}, opts ...*options.FindOneAndReplaceOptions) *mongo.SingleResult {
+	return mcw.collection.FindOneAndReplace(ctx, filter, replacement, opts...)
+}
+
 func (mcw *mongoCollectionWrapper) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
 	return mcw.collection.UpdateOne(ctx, filter, update, opts...)
 }
@@ -37,10 +45,27 @@ func (mcw *mongoCollectionWrapper) Find(ctx context.Context, filter interface{},
 	return mcw.collection.Find(ctx, filter, opts...)
 }
 
+func (mcw *mongoCollectionWrapper) Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (MongoCursorOperations, error) {
+	return mcw.collection.Aggregate(ctx, pipeline, opts...)
+}
+
 func (mcw *mongoCollectionWrapper) DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
 	return mcw.collection.DeleteMany(ctx, filter, opts...)
 }
 
+func (mcw *mongoCollectionWrapper) BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+	return mcw.collection.BulkWrite(ctx, models, opts...)
+}
+
+func (mcw *mongoCollectionWrapper) CreateIndexes(ctx context.Context, models []mongo.IndexModel, opts ...*options.CreateIndexesOptions) ([]string, error) {
+	return mcw.collection.Indexes().CreateMany(ctx, models, opts...)
+}
+
+func (mcw *mongoCollectionWrapper) DropIndex(ctx context.Context, name string, opts ...*options.DropIndexesOptions) error {
+	_, err := mcw.collection.Indexes().DropOne(ctx, name, opts...)
+	return err
+}
+
 type mongoDatabaseWrapper struct {
 	database *mongo.Database
 }
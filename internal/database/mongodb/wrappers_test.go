@@ -7,7 +7,9 @@ import (
 	"github.com/lucaspereirasilva0/list-manager-api/internal/database/mongodb"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func TestCreateWrapper(t *testing.T) {
@@ -89,6 +91,27 @@ func TestFindWrapper(t *testing.T) {
 	})
 }
 
+func TestAggregateWrapper(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("test", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(1, "foo.bar", mtest.FirstBatch, bson.D{{Key: "_id", Value: "BRL"}, {Key: "total", Value: 1290}}),
+			mtest.CreateCursorResponse(0, "foo.bar", mtest.NextBatch),
+		)
+
+		collection := mongodb.NewMockCollectionWrapper(mt)
+		cursor, err := collection.Aggregate(context.Background(), mongo.Pipeline{})
+		require.NoError(t, err)
+		require.NotNil(t, cursor)
+
+		var results []bson.M
+		err = cursor.All(context.Background(), &results)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+	})
+}
+
 func TestDeleteManyWrapper(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
@@ -103,6 +126,22 @@ func TestDeleteManyWrapper(t *testing.T) {
 	})
 }
 
+func TestBulkWriteWrapper(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("test", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 2}})
+
+		collection := mongodb.NewMockCollectionWrapper(mt)
+		result, err := collection.BulkWrite(context.Background(), []mongo.WriteModel{
+			mongo.NewInsertOneModel().SetDocument(bson.D{{Key: "name", Value: "test"}}),
+			mongo.NewInsertOneModel().SetDocument(bson.D{{Key: "name", Value: "other"}}),
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(2), result.InsertedCount)
+	})
+}
+
 func TestDatabaseCollectionWrapper(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
@@ -126,3 +165,64 @@ func TestClientStartSessionWrapper(t *testing.T) {
 		defer session.EndSession(context.Background())
 	})
 }
+
+func TestCreateIndexesWrapper(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("test", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		collection := mongodb.NewMockCollectionWrapper(mt)
+		names, err := collection.CreateIndexes(context.Background(), []mongo.IndexModel{
+			{Keys: bson.D{{Key: "name", Value: 1}}, Options: options.Index().SetName("name_1")},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"name_1"}, names)
+	})
+}
+
+func TestDropIndexWrapper(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("test", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		collection := mongodb.NewMockCollectionWrapper(mt)
+		err := collection.DropIndex(context.Background(), "name_1")
+		require.NoError(t, err)
+	})
+}
+
+func TestFindOneAndUpdateWrapper(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("test", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "value", Value: bson.D{{Key: "name", Value: "new_test"}}}})
+
+		collection := mongodb.NewMockCollectionWrapper(mt)
+		result := collection.FindOneAndUpdate(context.Background(), bson.D{{Key: "name", Value: "test"}}, bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "new_test"}}}})
+		require.NotNil(t, result)
+
+		var doc bson.M
+		err := result.Decode(&doc)
+		require.NoError(t, err)
+		require.Equal(t, "new_test", doc["name"])
+	})
+}
+
+func TestFindOneAndReplaceWrapper(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("test", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "value", Value: bson.D{{Key: "name", Value: "replaced"}}}})
+
+		collection := mongodb.NewMockCollectionWrapper(mt)
+		result := collection.FindOneAndReplace(context.Background(), bson.D{{Key: "name", Value: "test"}}, bson.D{{Key: "name", Value: "replaced"}})
+		require.NotNil(t, result)
+
+		var doc bson.M
+		err := result.Decode(&doc)
+		require.NoError(t, err)
+		require.Equal(t, "replaced", doc["name"])
+	})
+}
@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	// Registers the "pgx" database/sql driver
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/database/sqldb"
+)
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// ClientWrapper holds the PostgreSQL connection pool and helper methods.
+type ClientWrapper struct {
+	db *sql.DB
+}
+
+// NewClient opens a PostgreSQL connection pool, pings the server and applies pending migrations.
+func NewClient(ctx context.Context, dsn string) (*ClientWrapper, error) {
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open PostgreSQL connection: %w", err)
+	}
+
+	if err = db.PingContext(ctx); err != nil {
+		if closeErr := db.Close(); closeErr != nil {
+			log.Printf("error closing PostgreSQL connection after failed ping: %v", closeErr)
+		}
+		return nil, fmt.Errorf("failed to ping PostgreSQL: %w", err)
+	}
+
+	client := &ClientWrapper{db: db}
+
+	if err = client.Migrate(ctx); err != nil {
+		if closeErr := db.Close(); closeErr != nil {
+			log.Printf("error closing PostgreSQL connection after failed migration: %v", closeErr)
+		}
+		return nil, err
+	}
+
+	fmt.Println("Successfully connected and migrated PostgreSQL!")
+
+	return client, nil
+}
+
+// DB returns the connection pool used by the repositories.
+func (cw *ClientWrapper) DB() sqldb.DBOperations {
+	return cw.db
+}
+
+// TxManager returns a transaction manager for the repositories using this pool.
+func (cw *ClientWrapper) TxManager() *sqldb.TxManager {
+	return sqldb.NewTxManager(cw.db, IsRetryable)
+}
+
+// IsRetryable reports whether err is a serialization failure or a deadlock,
+// after which PostgreSQL expects the whole transaction to be retried.
+func IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == "40001" || pgErr.Code == "40P01"
+}
+
+// Disconnect closes the PostgreSQL connection pool.
+func (cw *ClientWrapper) Disconnect(_ context.Context) error {
+	if cw.db == nil {
+		return nil
+	}
+
+	if err := cw.db.Close(); err != nil {
+		return fmt.Errorf("failed to disconnect from PostgreSQL: %w", err)
+	}
+	fmt.Println("Disconnected from PostgreSQL.")
+	return nil
+}
+
+// Migrate applies the embedded migrations that were not applied yet.
+// An advisory lock serializes migrations across instances.
+func (cw *ClientWrapper) Migrate(ctx context.Context) error {
+	return sqldb.Migrate(ctx, cw.db, migrationsFS, sqldb.MigrateOptions{
+		Lock:   "SELECT pg_advisory_lock(7331001)",
+		Unlock: "SELECT pg_advisory_unlock(7331001)",
+	})
+}
+
+// Ping verifies the connection to PostgreSQL.
+func (cw *ClientWrapper) Ping(ctx context.Context) error {
+	if cw.db == nil {
+		return fmt.Errorf("db is nil")
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return cw.db.PingContext(pingCtx)
+}
@@ -0,0 +1,61 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/database/postgres"
+)
+
+func TestPing(t *testing.T) {
+	t.Run("Given_NilDB_When_Ping_Then_ReturnsError", func(t *testing.T) {
+		client := &postgres.ClientWrapper{}
+		err := client.Ping(context.Background())
+		require.ErrorContains(t, err, "db is nil")
+	})
+}
+
+func TestDisconnect(t *testing.T) {
+	t.Run("Given_NilDB_When_Disconnect_Then_ReturnsNoError", func(t *testing.T) {
+		client := &postgres.ClientWrapper{}
+		require.NoError(t, client.Disconnect(context.Background()))
+	})
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		givenErr error
+		want     bool
+	}{
+		{
+			name:     "Given_SerializationFailure_When_IsRetryable_Then_ReturnsTrue",
+			givenErr: &pgconn.PgError{Code: "40001"},
+			want:     true,
+		},
+		{
+			name:     "Given_WrappedDeadlock_When_IsRetryable_Then_ReturnsTrue",
+			givenErr: fmt.Errorf("update failed: %w", &pgconn.PgError{Code: "40P01"}),
+			want:     true,
+		},
+		{
+			name:     "Given_UniqueViolation_When_IsRetryable_Then_ReturnsFalse",
+			givenErr: &pgconn.PgError{Code: "23505"},
+		},
+		{
+			name:     "Given_NonPostgresError_When_IsRetryable_Then_ReturnsFalse",
+			givenErr: errors.New("boom"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, postgres.IsRetryable(tt.givenErr))
+		})
+	}
+}
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS items (
+    id          CHAR(24)    PRIMARY KEY,
+    name        TEXT        NOT NULL,
+    active      BOOLEAN     NOT NULL DEFAULT TRUE,
+    observation TEXT,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_items_created_at ON items (created_at, id);
@@ -0,0 +1,5 @@
+-- Case and accent insensitive order for Portuguese names, same as MongoDB { locale: "pt", strength: 1 }
+CREATE COLLATION IF NOT EXISTS pt_ci_ai (provider = icu, locale = 'pt-u-ks-level1', deterministic = false);
+
+CREATE INDEX IF NOT EXISTS idx_items_name ON items (name COLLATE pt_ci_ai, id);
+CREATE INDEX IF NOT EXISTS idx_items_updated_at ON items (updated_at, id);
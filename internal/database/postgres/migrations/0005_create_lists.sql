@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS lists (
+    id         CHAR(24)    PRIMARY KEY,
+    name       TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Same ID as domain.DefaultListID; the existing items are moved into it
+INSERT INTO lists (id, name)
+VALUES ('000000000000000000000001', 'Default')
+ON CONFLICT (id) DO NOTHING;
+
+ALTER TABLE items ADD COLUMN IF NOT EXISTS list_id TEXT NOT NULL DEFAULT '000000000000000000000001';
+
+CREATE INDEX IF NOT EXISTS idx_items_list_created_at ON items (list_id, created_at, id);
@@ -0,0 +1,3 @@
+-- The quantity is stored exactly in thousandths (domain.Thousandths): 1.5 kg is 1500; unit is set only with a quantity
+ALTER TABLE items ADD COLUMN IF NOT EXISTS quantity BIGINT;
+ALTER TABLE items ADD COLUMN IF NOT EXISTS unit TEXT;
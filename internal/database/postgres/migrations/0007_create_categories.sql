@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS categories (
+    id         CHAR(24)    PRIMARY KEY,
+    name       TEXT        NOT NULL,
+    position   INTEGER     NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- NULL for the uncategorized items; cleared by the application when the category is deleted
+ALTER TABLE items ADD COLUMN IF NOT EXISTS category_id TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_items_category_id ON items (category_id);
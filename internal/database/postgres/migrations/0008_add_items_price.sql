@@ -0,0 +1,3 @@
+-- The unit price is stored in cents; currency is set only with a unit price
+ALTER TABLE items ADD COLUMN IF NOT EXISTS unit_price BIGINT;
+ALTER TABLE items ADD COLUMN IF NOT EXISTS currency TEXT;
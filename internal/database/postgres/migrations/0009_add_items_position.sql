@@ -0,0 +1,13 @@
+-- Fractional index ordering the items of a list by hand (see domain.PositionBetween).
+-- The "C" collation compares it byte by byte, as the application does.
+ALTER TABLE items ADD COLUMN IF NOT EXISTS position TEXT COLLATE "C" NOT NULL DEFAULT '';
+
+-- The existing items keep their creation order, between "i0" and "i1" as in the MongoDB migration
+UPDATE items
+SET position = 'i0' || lpad(ranked.n::text, 10, '0') || '1'
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY list_id ORDER BY created_at, id) AS n FROM items WHERE position = '') AS ranked
+WHERE items.id = ranked.id;
+
+-- Two items of a list never get the same position; an empty one is not checked
+CREATE UNIQUE INDEX IF NOT EXISTS idx_items_list_position ON items (list_id, position) WHERE position <> '';
+CREATE INDEX IF NOT EXISTS idx_items_list_position_sort ON items (list_id, position, created_at, id);
@@ -0,0 +1,6 @@
+-- Lowercases a text and removes the diacritics of the Portuguese letters, like
+-- collation.Fold, so that the item search can filter the rows with LIKE.
+-- translate runs first: lower only folds ASCII letters with the "C" locale.
+CREATE OR REPLACE FUNCTION pt_fold(s TEXT) RETURNS TEXT
+LANGUAGE SQL IMMUTABLE STRICT PARALLEL SAFE
+AS $$ SELECT lower(translate(s, 'ÁÀÂÃÄÉÈÊËÍÌÎÏÓÒÔÕÖÚÙÛÜÇÑáàâãäéèêëíìîïóòôõöúùûüçñ', 'AAAAAEEEEIIIIOOOOOUUUUCNaaaaaeeeeiiiiooooouuuucn')) $$;
@@ -0,0 +1,14 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+)
+
+// DBOperations define the database/sql operations used by the SQL repositories.
+// Both *sql.DB and *sql.Tx satisfy it.
+type DBOperations interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
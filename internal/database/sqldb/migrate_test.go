@@ -0,0 +1,137 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	tests := []struct {
+		name         string
+		givenFS      fstest.MapFS
+		wantVersions []int
+		wantErr      string
+	}{
+		{
+			name: "Given_UnorderedFiles_When_LoadMigrations_Then_ReturnsSortedByVersion",
+			givenFS: fstest.MapFS{
+				"migrations/0002_second.sql": {Data: []byte("SELECT 2;")},
+				"migrations/0010_tenth.sql":  {Data: []byte("SELECT 10;")},
+				"migrations/0001_first.sql":  {Data: []byte("SELECT 1;")},
+			},
+			wantVersions: []int{1, 2, 10},
+		},
+		{
+			name: "Given_FileWithoutName_When_LoadMigrations_Then_ReturnsError",
+			givenFS: fstest.MapFS{
+				"migrations/0001.sql": {Data: []byte("SELECT 1;")},
+			},
+			wantErr: "invalid migration file name",
+		},
+		{
+			name: "Given_FileWithInvalidVersion_When_LoadMigrations_Then_ReturnsError",
+			givenFS: fstest.MapFS{
+				"migrations/abc_first.sql": {Data: []byte("SELECT 1;")},
+			},
+			wantErr: "invalid migration version",
+		},
+		{
+			name: "Given_DuplicatedVersion_When_LoadMigrations_Then_ReturnsError",
+			givenFS: fstest.MapFS{
+				"migrations/0001_first.sql": {Data: []byte("SELECT 1;")},
+				"migrations/1_again.sql":    {Data: []byte("SELECT 1;")},
+			},
+			wantErr: "duplicate migration version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := loadMigrations(tt.givenFS)
+
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			versions := make([]int, len(migrations))
+			for i, m := range migrations {
+				versions[i] = m.version
+			}
+			require.Equal(t, tt.wantVersions, versions)
+		})
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	givenFS := fstest.MapFS{
+		"migrations/0001_create_things.sql": {Data: []byte("CREATE TABLE things (id INTEGER PRIMARY KEY, name TEXT NOT NULL);")},
+		"migrations/0002_add_thing.sql":     {Data: []byte("INSERT INTO things (id, name) VALUES (1, 'first');")},
+	}
+
+	tests := []struct {
+		name        string
+		givenRuns   int
+		wantApplied int
+		wantThings  int
+	}{
+		{
+			name:        "Given_NewDatabase_When_Migrate_Then_AppliesAllMigrations",
+			givenRuns:   1,
+			wantApplied: 2,
+			wantThings:  1,
+		},
+		{
+			name:        "Given_MigratedDatabase_When_MigrateAgain_Then_SkipsAppliedMigrations",
+			givenRuns:   2,
+			wantApplied: 2,
+			wantThings:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+			require.NoError(t, err)
+			defer db.Close()
+
+			for i := 0; i < tt.givenRuns; i++ {
+				require.NoError(t, Migrate(ctx, db, givenFS, MigrateOptions{}))
+			}
+
+			var applied, things int
+			require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM schema_migrations").Scan(&applied))
+			require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM things").Scan(&things))
+			require.Equal(t, tt.wantApplied, applied)
+			require.Equal(t, tt.wantThings, things)
+		})
+	}
+}
+
+func TestMigrate_FailedMigration(t *testing.T) {
+	t.Run("Given_InvalidSQL_When_Migrate_Then_ReturnsErrorAndDoesNotRecordVersion", func(t *testing.T) {
+		ctx := context.Background()
+
+		db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+		require.NoError(t, err)
+		defer db.Close()
+
+		err = Migrate(ctx, db, fstest.MapFS{
+			"migrations/0001_broken.sql": {Data: []byte("CREATE TABLE (;")},
+		}, MigrateOptions{})
+		require.ErrorContains(t, err, "failed to apply migration 1 (broken)")
+
+		var applied int
+		require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM schema_migrations").Scan(&applied))
+		require.Zero(t, applied)
+	})
+}
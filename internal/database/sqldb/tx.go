@@ -0,0 +1,81 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+)
+
+const (
+	defaultTxMaxAttempts = 3
+)
+
+type txKey struct{}
+
+// TxManager runs functions inside database/sql transactions. The transaction is
+// carried by the context so the repositories pick it up through Conn.
+type TxManager struct {
+	db          *sql.DB
+	isRetryable func(error) bool
+	maxAttempts int
+}
+
+// NewTxManager creates a new TxManager. isRetryable reports the dialect errors
+// (serialization failures, busy database) after which the whole transaction is
+// run again; it may be nil.
+func NewTxManager(db *sql.DB, isRetryable func(error) bool) *TxManager {
+	return &TxManager{
+		db:          db,
+		isRetryable: isRetryable,
+		maxAttempts: defaultTxMaxAttempts,
+	}
+}
+
+// WithinTransaction runs fn in a transaction, committing when it returns nil
+// and rolling back otherwise. If ctx already carries a transaction fn joins it.
+func (m *TxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	var err error
+	for attempt := 1; attempt <= m.maxAttempts; attempt++ {
+		err = m.runOnce(ctx, fn)
+		if err == nil || m.isRetryable == nil || !m.isRetryable(err) || ctx.Err() != nil {
+			return err
+		}
+		log.Printf("retrying transaction after transient error (attempt %d): %v", attempt, err)
+	}
+
+	return err
+}
+
+func (m *TxManager) runOnce(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			log.Printf("error rolling back transaction: %v", rollbackErr)
+		}
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Conn returns the transaction carried by ctx, or db when there is none.
+func Conn(ctx context.Context, db DBOperations) DBOperations {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
@@ -0,0 +1,130 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+var (
+	errTx        = errors.New("tx failed")
+	errRetryable = errors.New("serialization failure")
+)
+
+func newTxTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", "file:"+filepath.Join(t.TempDir(), "tx.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, err = db.Exec("CREATE TABLE counters (name TEXT PRIMARY KEY, value INTEGER NOT NULL)")
+	require.NoError(t, err)
+	return db
+}
+
+func countRows(t *testing.T, db *sql.DB) int {
+	t.Helper()
+	var count int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM counters").Scan(&count))
+	return count
+}
+
+func TestTxManager_WithinTransaction(t *testing.T) {
+	tests := []struct {
+		name         string
+		givenFnErrs  []error
+		wantErr      error
+		wantAttempts int
+		wantRows     int
+	}{
+		{
+			name:         "Given_SuccessfulFn_When_WithinTransaction_Then_ChangesAreCommitted",
+			givenFnErrs:  []error{nil},
+			wantAttempts: 1,
+			wantRows:     2,
+		},
+		{
+			name:         "Given_FailingFn_When_WithinTransaction_Then_ChangesAreRolledBack",
+			givenFnErrs:  []error{errTx},
+			wantErr:      errTx,
+			wantAttempts: 1,
+			wantRows:     0,
+		},
+		{
+			name:         "Given_TransientError_When_WithinTransaction_Then_TransactionIsRetried",
+			givenFnErrs:  []error{errRetryable, nil},
+			wantAttempts: 2,
+			wantRows:     2,
+		},
+		{
+			name:         "Given_PersistentTransientError_When_WithinTransaction_Then_GivesUpAfterMaxAttempts",
+			givenFnErrs:  []error{errRetryable, errRetryable, errRetryable},
+			wantErr:      errRetryable,
+			wantAttempts: defaultTxMaxAttempts,
+			wantRows:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTxTestDB(t)
+			txManager := NewTxManager(db, func(err error) bool { return errors.Is(err, errRetryable) })
+
+			attempts := 0
+			err := txManager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+				fnErr := tt.givenFnErrs[attempts]
+				attempts++
+
+				conn := Conn(ctx, db)
+				if _, err := conn.ExecContext(ctx, "INSERT INTO counters (name, value) VALUES ('a', 1)"); err != nil {
+					return err
+				}
+				if _, err := conn.ExecContext(ctx, "INSERT INTO counters (name, value) VALUES ('b', 2)"); err != nil {
+					return err
+				}
+				return fnErr
+			})
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantAttempts, attempts)
+			require.Equal(t, tt.wantRows, countRows(t, db))
+		})
+	}
+}
+
+func TestTxManager_NestedTransactionJoinsOuter(t *testing.T) {
+	db := newTxTestDB(t)
+	txManager := NewTxManager(db, nil)
+
+	err := txManager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		outerTx := Conn(ctx, db)
+		require.IsType(t, &sql.Tx{}, outerTx)
+
+		err := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			require.Same(t, outerTx, Conn(ctx, db))
+			_, err := Conn(ctx, db).ExecContext(ctx, "INSERT INTO counters (name, value) VALUES ('a', 1)")
+			return err
+		})
+		require.NoError(t, err)
+
+		// Failing the outer transaction also discards the inner changes
+		return errTx
+	})
+
+	require.ErrorIs(t, err, errTx)
+	require.Equal(t, 0, countRows(t, db))
+}
+
+func TestConn_WithoutTransaction(t *testing.T) {
+	db := newTxTestDB(t)
+	require.Same(t, db, Conn(context.Background(), db))
+}
@@ -0,0 +1,134 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"embed"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+
+	// Registers the pure-Go "sqlite" database/sql driver (no cgo required)
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/collation"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/database/sqldb"
+)
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// The item names are sorted with "COLLATE pt_ci_ai" and searched with
+// pt_fold, which every connection opened by the driver must know
+func init() {
+	sqlite.MustRegisterCollationUtf8(collation.Name, collation.Compare)
+	sqlite.MustRegisterDeterministicScalarFunction(collation.FoldFunction, 1, fold)
+}
+
+// fold is the SQL function pt_fold: collation.Fold of a text, NULL for NULL
+func fold(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+	switch s := args[0].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return collation.Fold(s), nil
+	default:
+		return nil, fmt.Errorf("%s: unexpected argument of type %T", collation.FoldFunction, s)
+	}
+}
+
+// ClientWrapper holds the SQLite database handle and helper methods.
+type ClientWrapper struct {
+	db *sql.DB
+}
+
+// NewClient opens (or creates) the SQLite database file in WAL mode and applies pending migrations.
+func NewClient(ctx context.Context, path string) (*ClientWrapper, error) {
+	db, err := sql.Open("sqlite", dsn(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+
+	if err = db.PingContext(ctx); err != nil {
+		if closeErr := db.Close(); closeErr != nil {
+			log.Printf("error closing SQLite database after failed ping: %v", closeErr)
+		}
+		return nil, fmt.Errorf("failed to ping SQLite: %w", err)
+	}
+
+	client := &ClientWrapper{db: db}
+
+	if err = client.Migrate(ctx); err != nil {
+		if closeErr := db.Close(); closeErr != nil {
+			log.Printf("error closing SQLite database after failed migration: %v", closeErr)
+		}
+		return nil, err
+	}
+
+	fmt.Println("Successfully opened and migrated SQLite!")
+
+	return client, nil
+}
+
+// DB returns the database handle used by the repositories.
+func (cw *ClientWrapper) DB() sqldb.DBOperations {
+	return cw.db
+}
+
+// TxManager returns a transaction manager for the repositories using this database.
+func (cw *ClientWrapper) TxManager() *sqldb.TxManager {
+	return sqldb.NewTxManager(cw.db, IsRetryable)
+}
+
+// IsRetryable reports whether err means the database was busy or locked by
+// another writer, in which case the transaction can be run again.
+func IsRetryable(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	// Extended result codes keep the primary code in the lowest byte
+	code := sqliteErr.Code() & 0xff
+	return code == sqlite3.SQLITE_BUSY || code == sqlite3.SQLITE_LOCKED
+}
+
+// Migrate applies the embedded migrations that were not applied yet.
+func (cw *ClientWrapper) Migrate(ctx context.Context) error {
+	return sqldb.Migrate(ctx, cw.db, migrationsFS, sqldb.MigrateOptions{})
+}
+
+// Disconnect closes the SQLite database.
+func (cw *ClientWrapper) Disconnect(_ context.Context) error {
+	if cw.db == nil {
+		return nil
+	}
+
+	if err := cw.db.Close(); err != nil {
+		return fmt.Errorf("failed to close SQLite database: %w", err)
+	}
+	fmt.Println("Closed SQLite database.")
+	return nil
+}
+
+// Ping verifies the database file is still accessible.
+func (cw *ClientWrapper) Ping(ctx context.Context) error {
+	if cw.db == nil {
+		return fmt.Errorf("db is nil")
+	}
+	return cw.db.PingContext(ctx)
+}
+
+// dsn builds the connection string enabling WAL mode, a busy timeout so
+// concurrent writers wait instead of failing, and foreign keys.
+func dsn(path string) string {
+	params := url.Values{}
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_txlock", "immediate")
+
+	return "file:" + path + "?" + params.Encode()
+}
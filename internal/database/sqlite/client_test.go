@@ -0,0 +1,93 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/database/sqlite"
+)
+
+func TestNewClient(t *testing.T) {
+	t.Run("Given_NewFile_When_NewClient_Then_OpensInWALModeAndMigrates", func(t *testing.T) {
+		ctx := context.Background()
+
+		client, err := sqlite.NewClient(ctx, filepath.Join(t.TempDir(), "listmanager.db"))
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, client.Disconnect(ctx))
+		}()
+
+		require.NoError(t, client.Ping(ctx))
+
+		var journalMode string
+		require.NoError(t, client.DB().QueryRowContext(ctx, "PRAGMA journal_mode").Scan(&journalMode))
+		require.Equal(t, "wal", journalMode)
+
+		var items int
+		require.NoError(t, client.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM items").Scan(&items))
+		require.Zero(t, items)
+	})
+
+	t.Run("Given_ExistingFile_When_NewClientAgain_Then_KeepsDataAndSkipsAppliedMigrations", func(t *testing.T) {
+		ctx := context.Background()
+		path := filepath.Join(t.TempDir(), "listmanager.db")
+
+		client, err := sqlite.NewClient(ctx, path)
+		require.NoError(t, err)
+		_, err = client.DB().ExecContext(ctx, "INSERT INTO items (id, name, active, created_at, updated_at) VALUES ('a', 'b', 1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)")
+		require.NoError(t, err)
+		require.NoError(t, client.Disconnect(ctx))
+
+		client, err = sqlite.NewClient(ctx, path)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, client.Disconnect(ctx))
+		}()
+
+		var items int
+		require.NoError(t, client.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM items").Scan(&items))
+		require.Equal(t, 1, items)
+	})
+}
+
+func TestPing(t *testing.T) {
+	t.Run("Given_NilDB_When_Ping_Then_ReturnsError", func(t *testing.T) {
+		client := &sqlite.ClientWrapper{}
+		err := client.Ping(context.Background())
+		require.ErrorContains(t, err, "db is nil")
+	})
+}
+
+func TestIsRetryable(t *testing.T) {
+	t.Run("Given_DatabaseLockedByAnotherWriter_When_IsRetryable_Then_ReturnsTrue", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "busy.db")
+
+		writer, err := sql.Open("sqlite", "file:"+path+"?_txlock=immediate")
+		require.NoError(t, err)
+		defer func() { _ = writer.Close() }()
+		_, err = writer.Exec("CREATE TABLE t (v INTEGER)")
+		require.NoError(t, err)
+
+		tx, err := writer.Begin()
+		require.NoError(t, err)
+		defer func() { _ = tx.Rollback() }()
+
+		other, err := sql.Open("sqlite", "file:"+path)
+		require.NoError(t, err)
+		defer func() { _ = other.Close() }()
+
+		_, err = other.Exec("INSERT INTO t (v) VALUES (1)")
+		require.Error(t, err)
+		require.True(t, sqlite.IsRetryable(fmt.Errorf("insert failed: %w", err)))
+	})
+
+	t.Run("Given_NonSQLiteError_When_IsRetryable_Then_ReturnsFalse", func(t *testing.T) {
+		require.False(t, sqlite.IsRetryable(errors.New("boom")))
+	})
+}
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS items (
+    id          TEXT     PRIMARY KEY,
+    name        TEXT     NOT NULL,
+    active      BOOLEAN  NOT NULL DEFAULT 1,
+    observation TEXT,
+    created_at  DATETIME NOT NULL,
+    updated_at  DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_items_created_at ON items (created_at, id);
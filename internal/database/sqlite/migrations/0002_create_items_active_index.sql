@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_items_active_created_at ON items (active, created_at, id);
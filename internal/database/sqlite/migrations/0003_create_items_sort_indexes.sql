@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_items_updated_at ON items (updated_at, id);
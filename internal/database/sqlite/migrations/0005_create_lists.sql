@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS lists (
+    id         TEXT     PRIMARY KEY,
+    name       TEXT     NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+-- Same ID as domain.DefaultListID; the existing items are moved into it
+INSERT INTO lists (id, name, created_at, updated_at)
+VALUES ('000000000000000000000001', 'Default', strftime('%Y-%m-%d %H:%M:%f', 'now'), strftime('%Y-%m-%d %H:%M:%f', 'now'))
+ON CONFLICT (id) DO NOTHING;
+
+ALTER TABLE items ADD COLUMN list_id TEXT NOT NULL DEFAULT '000000000000000000000001';
+
+CREATE INDEX IF NOT EXISTS idx_items_list_created_at ON items (list_id, created_at, id);
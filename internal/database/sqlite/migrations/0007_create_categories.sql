@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS categories (
+    id         TEXT     PRIMARY KEY,
+    name       TEXT     NOT NULL,
+    position   INTEGER  NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+-- NULL for the uncategorized items; cleared by the application when the category is deleted
+ALTER TABLE items ADD COLUMN category_id TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_items_category_id ON items (category_id);
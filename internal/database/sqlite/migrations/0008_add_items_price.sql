@@ -0,0 +1,3 @@
+-- The unit price is stored in cents; currency is set only with a unit price
+ALTER TABLE items ADD COLUMN unit_price INTEGER;
+ALTER TABLE items ADD COLUMN currency TEXT;
@@ -0,0 +1,19 @@
+package domain
+
+// MaxActiveUpdateIDs is the largest number of IDs a bulk active update can select
+const MaxActiveUpdateIDs = 500
+
+// ActiveUpdate sets the active field of several items: the ones with the given
+// IDs, or the ones matching Filter when there are no IDs
+type ActiveUpdate struct {
+	Active bool
+	IDs    []string
+	Filter ItemFilter
+}
+
+// ActiveUpdateResult counts the updated items and lists the requested IDs that don't exist
+type ActiveUpdateResult struct {
+	MatchedCount  int64
+	ModifiedCount int64
+	NotFoundIDs   []string
+}
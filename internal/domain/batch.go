@@ -0,0 +1,35 @@
+package domain
+
+// MaxBatchOperations is the largest number of operations a batch can have
+const MaxBatchOperations = 100
+
+// BatchOperationType is the kind of change made by a BatchOperation
+type BatchOperationType string
+
+const (
+	BatchCreate BatchOperationType = "create"
+	BatchUpdate BatchOperationType = "update"
+	BatchDelete BatchOperationType = "delete"
+)
+
+// IsValid tells whether t is one of the supported operations
+func (t BatchOperationType) IsValid() bool {
+	switch t {
+	case BatchCreate, BatchUpdate, BatchDelete:
+		return true
+	}
+	return false
+}
+
+// BatchOperation is one change of a batch: the item to create, the item to
+// update, or the item to delete, of which only the ID is used
+type BatchOperation struct {
+	Type BatchOperationType
+	Item Item
+}
+
+// BatchResult is the outcome of a BatchOperation: the created or updated item, or its error
+type BatchResult struct {
+	Item Item
+	Err  error
+}
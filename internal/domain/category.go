@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrEmptyCategoryName is returned when a category would be left without a name
+	ErrEmptyCategoryName = errors.New("category name cannot be empty")
+	// ErrInvalidCategoryPosition is returned for a negative position
+	ErrInvalidCategoryPosition = errors.New("category position cannot be negative")
+)
+
+// Category is a kind of item, like produce or dairy. Categories are shown by
+// Position, the order the aisles of the supermarket are walked in.
+type Category struct {
+	ID   string
+	Name string
+	// Position starts at 1; 0 puts a new category after the last one and keeps
+	// the position of an updated one
+	Position  int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewCategory creates a new instance of Category
+func NewCategory(name string, position int) Category {
+	return Category{
+		ID:        generateID(),
+		Name:      name,
+		Position:  position,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// Validate checks that the category has a name and a valid position
+func (c Category) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return ErrEmptyCategoryName
+	}
+	if c.Position < 0 {
+		return ErrInvalidCategoryPosition
+	}
+	return nil
+}
+
+// NextCategoryPosition returns the position after the last of categories
+func NextCategoryPosition(categories []Category) int {
+	next := 1
+	for _, category := range categories {
+		if category.Position >= next {
+			next = category.Position + 1
+		}
+	}
+	return next
+}
+
+// ItemGroup is a category and its items. Category is nil for the group of the
+// uncategorized items.
+type ItemGroup struct {
+	Category *Category
+	Items    []Item
+}
+
+// GroupItems groups items by category, in the order of categories, keeping
+// the order of the items inside each group. The items without a category, or
+// whose category is not in categories, are in a trailing group. Groups without
+// items are left out.
+func GroupItems(categories []Category, items []Item) []ItemGroup {
+	groups := make([]ItemGroup, len(categories)+1)
+	indexes := make(map[string]int, len(categories))
+	for i := range categories {
+		groups[i].Category = &categories[i]
+		indexes[categories[i].ID] = i
+	}
+
+	for _, item := range items {
+		i, ok := indexes[item.CategoryID]
+		if !ok {
+			i = len(categories)
+		}
+		groups[i].Items = append(groups[i].Items, item)
+	}
+
+	nonEmpty := groups[:0]
+	for _, group := range groups {
+		if len(group.Items) > 0 {
+			nonEmpty = append(nonEmpty, group)
+		}
+	}
+	return nonEmpty
+}
@@ -0,0 +1,78 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+)
+
+func TestCategory_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenCategory domain.Category
+		wantErr       error
+	}{
+		{name: "Given_NameAndPosition_When_Validate_Then_Valid", givenCategory: domain.Category{Name: "Dairy", Position: 2}},
+		{name: "Given_ZeroPosition_When_Validate_Then_Valid", givenCategory: domain.Category{Name: "Dairy"}},
+		{name: "Given_BlankName_When_Validate_Then_ExpectedEmptyNameError", givenCategory: domain.Category{Name: "  "}, wantErr: domain.ErrEmptyCategoryName},
+		{name: "Given_NegativePosition_When_Validate_Then_ExpectedInvalidPositionError", givenCategory: domain.Category{Name: "Dairy", Position: -1}, wantErr: domain.ErrInvalidCategoryPosition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.givenCategory.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestNextCategoryPosition(t *testing.T) {
+	require.Equal(t, 1, domain.NextCategoryPosition(nil))
+	require.Equal(t, 6, domain.NextCategoryPosition([]domain.Category{{Position: 5}, {Position: 2}}))
+}
+
+func TestGroupItems(t *testing.T) {
+	produce := domain.Category{ID: "produce", Name: "Produce", Position: 1}
+	dairy := domain.Category{ID: "dairy", Name: "Dairy", Position: 2}
+	cleaning := domain.Category{ID: "cleaning", Name: "Cleaning", Position: 3}
+
+	apple := domain.Item{ID: "1", Name: "Apple", CategoryID: "produce"}
+	milk := domain.Item{ID: "2", Name: "Milk", CategoryID: "dairy"}
+	banana := domain.Item{ID: "3", Name: "Banana", CategoryID: "produce"}
+	bread := domain.Item{ID: "4", Name: "Bread"}
+	orphan := domain.Item{ID: "5", Name: "Batteries", CategoryID: "deleted"}
+
+	tests := []struct {
+		name            string
+		givenCategories []domain.Category
+		givenItems      []domain.Item
+		wantGroups      []domain.ItemGroup
+	}{
+		{
+			name:            "Given_CategorizedItems_When_GroupItems_Then_GroupsFollowTheCategoryOrder",
+			givenCategories: []domain.Category{produce, dairy, cleaning},
+			givenItems:      []domain.Item{milk, apple, bread, banana, orphan},
+			wantGroups: []domain.ItemGroup{
+				{Category: &produce, Items: []domain.Item{apple, banana}},
+				{Category: &dairy, Items: []domain.Item{milk}},
+				{Items: []domain.Item{bread, orphan}},
+			},
+		},
+		{
+			name:            "Given_NoUncategorizedItem_When_GroupItems_Then_NoTrailingGroup",
+			givenCategories: []domain.Category{produce, dairy},
+			givenItems:      []domain.Item{milk},
+			wantGroups:      []domain.ItemGroup{{Category: &dairy, Items: []domain.Item{milk}}},
+		},
+		{
+			name:            "Given_NoItems_When_GroupItems_Then_ExpectedNoGroups",
+			givenCategories: []domain.Category{produce},
+			wantGroups:      []domain.ItemGroup{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantGroups, domain.GroupItems(tt.givenCategories, tt.givenItems))
+		})
+	}
+}
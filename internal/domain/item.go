@@ -9,14 +9,31 @@ import (
 	"github.com/google/uuid"
 )
 
-// Item represents the main domain entity
+// Item represents the main domain entity. ListID is the list it belongs to,
+// DefaultListID when empty.
 type Item struct {
 	ID          string
+	ListID      string
 	Name        string
 	Active      bool
 	Observation *string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// Quantity is optional; Unit is the unit of the catalog it is in, and is set only with a Quantity
+	Quantity *Thousandths
+	Unit     Unit
+	// CategoryID is the category of the item, uncategorized when empty
+	CategoryID string
+	// UnitPrice is optional, the price of one Unit of the item (of the item when
+	// it has no quantity); Currency is set only with a UnitPrice
+	UnitPrice *Amount
+	Currency  Currency
+	// Position orders the item by hand in its list (see PositionBetween); it is
+	// set when the item is created and only changed by a move
+	Position  string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// Version is incremented by every change. When updating, a non-zero
+	// Version is the one the change is based on and must still be current.
+	Version int64
 }
 
 // NewItem creates a new instance of Item
@@ -39,6 +56,50 @@ func (i Item) IsEmpty() bool {
 	return i.ID == ""
 }
 
+// NormalizeQuantity returns i with its unit as written in the catalog, UnitPiece
+// when it has a quantity but no unit, or an error when they are invalid
+func (i Item) NormalizeQuantity() (Item, error) {
+	unit, err := normalizeQuantity(i.Quantity, i.Unit)
+	if err != nil {
+		return Item{}, err
+	}
+	i.Unit = unit
+	return i, nil
+}
+
+// TotalQuantity returns the quantity of i, false when it has none
+func (i Item) TotalQuantity() (Quantity, bool) {
+	if i.Quantity == nil {
+		return Quantity{}, false
+	}
+	return Quantity{Value: *i.Quantity, Unit: i.Unit}, true
+}
+
+// normalizeQuantity validates quantity and unit and returns the unit they are stored with
+func normalizeQuantity(quantity *Thousandths, unit Unit) (Unit, error) {
+	if quantity == nil {
+		if unit != "" {
+			return "", ErrUnitWithoutQuantity
+		}
+		return "", nil
+	}
+
+	if unit == "" {
+		unit = UnitPiece
+	} else {
+		parsed, err := ParseUnit(string(unit))
+		if err != nil {
+			return "", err
+		}
+		unit = parsed
+	}
+
+	if err := (Quantity{Value: *quantity, Unit: unit}).Validate(); err != nil {
+		return "", err
+	}
+	return unit, nil
+}
+
 func generateID() string {
 	// MongoDB ObjectID tem 12 bytes.
 	// Geramos 12 bytes aleatórios e os convertemos para uma string hexadecimal de 24 caracteres.
@@ -0,0 +1,68 @@
+package domain
+
+import "time"
+
+const (
+	// DefaultListLimit is the page size used when the client does not ask for one
+	DefaultListLimit = 100
+	// MaxListLimit is the largest page size a client can ask for
+	MaxListLimit = 500
+
+	// DefaultSearchLimit is the number of search results returned when the client does not ask for one
+	DefaultSearchLimit = 20
+	// MaxSearchLimit is the largest number of search results a client can ask for
+	MaxSearchLimit = 100
+)
+
+// Fields the items can be sorted by
+const (
+	SortByName      = "name"
+	SortByActive    = "active"
+	SortByCreatedAt = "createdAt"
+	SortByUpdatedAt = "updatedAt"
+	SortByPosition  = "position"
+)
+
+// SortableFields is the whitelist of the fields accepted in a sort
+var SortableFields = []string{SortByName, SortByActive, SortByCreatedAt, SortByUpdatedAt, SortByPosition}
+
+// GroupByCategory is the only value of the groupBy parameter of the listings
+const GroupByCategory = "category"
+
+// ListOptions selects a page of items
+type ListOptions struct {
+	Limit  int
+	Cursor string
+	Filter ItemFilter
+	// Sort is the listing order, by position when empty. Items with equal keys are ordered by ID
+	Sort []SortField
+}
+
+// SortField is one key of the listing order
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ItemFilter restricts the listed items; nil or empty fields are ignored
+type ItemFilter struct {
+	// ListID scopes the filter to the items of a list, DefaultListID when empty
+	ListID         string
+	Active         *bool
+	Name           string
+	HasObservation *bool
+	CreatedAfter   *time.Time
+	UpdatedBefore  *time.Time
+}
+
+// IsEmpty tells whether the filter matches every item of its list
+func (f ItemFilter) IsEmpty() bool {
+	f.ListID = ""
+	return f == ItemFilter{}
+}
+
+// ItemPage is a page of items and the opaque cursor of the next one (empty on the last page)
+type ItemPage struct {
+	Items      []Item
+	NextCursor string
+}
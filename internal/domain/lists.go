@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultListID is the list of the items created before there were several
+	// lists, and of the items created through the routes that don't name one
+	DefaultListID = "000000000000000000000001"
+	// DefaultListName is the name the default list is created with
+	DefaultListName = "Default"
+)
+
+var (
+	// ErrEmptyListName is returned when a list would be left without a name
+	ErrEmptyListName = errors.New("list name cannot be empty")
+)
+
+// List groups the items bought together, like the ones of a given store
+type List struct {
+	ID        string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewList creates a new instance of List
+func NewList(name string) List {
+	return List{
+		ID:        generateID(),
+		Name:      name,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// Validate checks that the list has a name
+func (l List) Validate() error {
+	if strings.TrimSpace(l.Name) == "" {
+		return ErrEmptyListName
+	}
+	return nil
+}
+
+// IsDefault reports whether l is the default list, which cannot be deleted
+func (l List) IsDefault() bool {
+	return l.ID == DefaultListID
+}
+
+// ListIDOrDefault returns id, or DefaultListID when it is empty
+func ListIDOrDefault(id string) string {
+	if id == "" {
+		return DefaultListID
+	}
+	return id
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrEmptyName is returned when an item would be left without a name
+	ErrEmptyName = errors.New("name cannot be empty")
+)
+
+// ItemPatch is a partial update of an item: only the fields it sets are changed
+type ItemPatch struct {
+	Name   *string
+	Active *bool
+	// Observation replaces the observation when SetObservation is true; nil removes it
+	Observation    *string
+	SetObservation bool
+	// Quantity and Unit replace the stored ones together when SetQuantity is
+	// true; a nil Quantity removes both
+	Quantity    *Thousandths
+	Unit        Unit
+	SetQuantity bool
+	// CategoryID replaces the category when SetCategory is true; nil removes it
+	CategoryID  *string
+	SetCategory bool
+	// UnitPrice and Currency replace the stored ones together when SetPrice is
+	// true; a nil UnitPrice removes both
+	UnitPrice *Amount
+	Currency  Currency
+	SetPrice  bool
+	// Version, when not zero, must still be the current version of the item
+	Version int64
+}
+
+// IsEmpty reports whether the patch changes nothing
+func (p ItemPatch) IsEmpty() bool {
+	return p.Name == nil && p.Active == nil && !p.SetObservation && !p.SetQuantity && !p.SetCategory && !p.SetPrice
+}
+
+// Validate checks that the patched item stays valid
+func (p ItemPatch) Validate() error {
+	if p.Name != nil && strings.TrimSpace(*p.Name) == "" {
+		return ErrEmptyName
+	}
+	if !p.SetQuantity && p.Unit != "" {
+		return ErrUnitWithoutQuantity
+	}
+	if _, err := normalizeQuantity(p.Quantity, p.Unit); err != nil {
+		return err
+	}
+	if !p.SetPrice && p.Currency != "" {
+		return ErrCurrencyWithoutPrice
+	}
+	_, err := normalizePrice(p.UnitPrice, p.Currency)
+	return err
+}
+
+// NormalizeQuantity returns p with its unit as written in the catalog, UnitPiece
+// when it sets a quantity but no unit. p must be valid.
+func (p ItemPatch) NormalizeQuantity() ItemPatch {
+	p.Unit, _ = normalizeQuantity(p.Quantity, p.Unit)
+	return p
+}
+
+// NormalizePrice returns p with its currency in upper case, DefaultCurrency
+// when it sets a unit price but no currency. p must be valid.
+func (p ItemPatch) NormalizePrice() ItemPatch {
+	p.Currency, _ = normalizePrice(p.UnitPrice, p.Currency)
+	return p
+}
+
+// Apply returns item with the patch applied
+func (p ItemPatch) Apply(item Item) Item {
+	if p.Name != nil {
+		item.Name = *p.Name
+	}
+	if p.Active != nil {
+		item.Active = *p.Active
+	}
+	if p.SetObservation {
+		item.Observation = p.Observation
+	}
+	if p.SetQuantity {
+		item.Quantity = p.Quantity
+		item.Unit = p.Unit
+	}
+	if p.SetPrice {
+		item.UnitPrice = p.UnitPrice
+		item.Currency = p.Currency
+	}
+	if p.SetCategory {
+		item.CategoryID = ""
+		if p.CategoryID != nil {
+			item.CategoryID = *p.CategoryID
+		}
+	}
+	return item
+}
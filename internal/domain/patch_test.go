@@ -0,0 +1,88 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+)
+
+func TestItemPatch_Validate(t *testing.T) {
+	blank := "  "
+	name := "Milk"
+
+	tests := []struct {
+		name       string
+		givenPatch domain.ItemPatch
+		wantErr    error
+	}{
+		{name: "Given_EmptyPatch_When_Validate_Then_Valid", givenPatch: domain.ItemPatch{}},
+		{name: "Given_Name_When_Validate_Then_Valid", givenPatch: domain.ItemPatch{Name: &name}},
+		{name: "Given_BlankName_When_Validate_Then_ExpectedEmptyNameError", givenPatch: domain.ItemPatch{Name: &blank}, wantErr: domain.ErrEmptyName},
+		{name: "Given_ObservationRemoval_When_Validate_Then_Valid", givenPatch: domain.ItemPatch{SetObservation: true}},
+		{name: "Given_QuantityAndUnit_When_Validate_Then_Valid", givenPatch: domain.ItemPatch{Quantity: quantity(1500), Unit: "kg", SetQuantity: true}},
+		{name: "Given_QuantityRemoval_When_Validate_Then_Valid", givenPatch: domain.ItemPatch{SetQuantity: true}},
+		{name: "Given_UnitWithoutQuantity_When_Validate_Then_ExpectedUnitWithoutQuantityError", givenPatch: domain.ItemPatch{Unit: "kg"}, wantErr: domain.ErrUnitWithoutQuantity},
+		{name: "Given_UnknownUnit_When_Validate_Then_ExpectedUnknownUnitError", givenPatch: domain.ItemPatch{Quantity: quantity(1000), Unit: "oz", SetQuantity: true}, wantErr: domain.ErrUnknownUnit},
+		{name: "Given_UnitPriceAndCurrency_When_Validate_Then_Valid", givenPatch: domain.ItemPatch{UnitPrice: amount(1290), Currency: "usd", SetPrice: true}},
+		{name: "Given_CurrencyWithoutPrice_When_Validate_Then_ExpectedCurrencyWithoutPriceError", givenPatch: domain.ItemPatch{Currency: "USD"}, wantErr: domain.ErrCurrencyWithoutPrice},
+		{name: "Given_NegativePrice_When_Validate_Then_ExpectedInvalidPriceError", givenPatch: domain.ItemPatch{UnitPrice: amount(-1), SetPrice: true}, wantErr: domain.ErrInvalidPrice},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.givenPatch.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestItemPatch_Apply(t *testing.T) {
+	observation := "whole"
+	name := "Bread"
+	inactive := false
+	item := domain.Item{ID: "1", Name: "Milk", Active: true, Observation: &observation}
+
+	tests := []struct {
+		name       string
+		givenPatch domain.ItemPatch
+		wantItem   domain.Item
+		wantEmpty  bool
+	}{
+		{
+			name:      "Given_EmptyPatch_When_Apply_Then_ItemIsUnchanged",
+			wantItem:  item,
+			wantEmpty: true,
+		},
+		{
+			name:       "Given_NameAndActive_When_Apply_Then_OnlyThoseFieldsChange",
+			givenPatch: domain.ItemPatch{Name: &name, Active: &inactive},
+			wantItem:   domain.Item{ID: "1", Name: "Bread", Active: false, Observation: &observation},
+		},
+		{
+			name:       "Given_ObservationRemoval_When_Apply_Then_ObservationIsCleared",
+			givenPatch: domain.ItemPatch{SetObservation: true},
+			wantItem:   domain.Item{ID: "1", Name: "Milk", Active: true},
+		},
+		{
+			name:       "Given_QuantityAndUnit_When_Apply_Then_BothAreSet",
+			givenPatch: domain.ItemPatch{Quantity: quantity(2000), Unit: domain.UnitLiter, SetQuantity: true},
+			wantItem:   domain.Item{ID: "1", Name: "Milk", Active: true, Observation: &observation, Quantity: quantity(2000), Unit: domain.UnitLiter},
+		},
+		{
+			name:       "Given_Category_When_Apply_Then_CategoryIsSet",
+			givenPatch: domain.ItemPatch{CategoryID: ptr("dairy"), SetCategory: true},
+			wantItem:   domain.Item{ID: "1", Name: "Milk", Active: true, Observation: &observation, CategoryID: "dairy"},
+		},
+		{
+			name:       "Given_UnitPrice_When_Apply_Then_PriceAndCurrencyAreSet",
+			givenPatch: domain.ItemPatch{UnitPrice: amount(599), Currency: domain.DefaultCurrency, SetPrice: true},
+			wantItem:   domain.Item{ID: "1", Name: "Milk", Active: true, Observation: &observation, UnitPrice: amount(599), Currency: domain.DefaultCurrency},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantItem, tt.givenPatch.Apply(item))
+			require.Equal(t, tt.wantEmpty, tt.givenPatch.IsEmpty())
+		})
+	}
+}
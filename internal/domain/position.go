@@ -0,0 +1,228 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Positions order the items of a list by hand. They are fractional indexes:
+// strings compared byte by byte, so there is always a position between two others
+// and moving an item only changes its own position.
+//
+// A position is an integer part, a head digit telling its length and that many
+// digits minus one, and an optional fraction that never ends in 0. Heads i to z
+// are 2 to 19 digits long and count up from "i0", heads h to 0 are 2 to 19 digits
+// long and count down from "hz", so appending at either end keeps positions short.
+
+// positionDigits are the digits of the positions, in order. They are lower case,
+// so they sort the same in every collation.
+const positionDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// FirstPosition is the position of the first item of an empty list
+const FirstPosition = "i0"
+
+// smallestInteger is the smallest integer part, which is not a valid position so
+// that there is always room before any position
+var smallestInteger = strings.Repeat("0", 19)
+
+// ErrInvalidPosition is returned for a string that is not a position
+var ErrInvalidPosition = errors.New("invalid position")
+
+var (
+	// ErrMoveWithoutNeighbour is returned for a move that doesn't say where the item goes
+	ErrMoveWithoutNeighbour = errors.New("after or before is required")
+	// ErrMoveNextToItself is returned for a move of an item next to itself
+	ErrMoveNextToItself = errors.New("an item cannot be moved next to itself")
+	// ErrSameNeighbours is returned for a move after and before the same item
+	ErrSameNeighbours = errors.New("after and before must be different items")
+)
+
+// ItemMove places an item of a list next to other items of the list, given by
+// their IDs: right after After, or right before Before when there is no After.
+// When both are set, Before must still come after After.
+type ItemMove struct {
+	After  string
+	Before string
+	// Version, when not zero, must still be the current version of the moved item
+	Version int64
+}
+
+// Validate checks that the move of the item id says where it goes
+func (m ItemMove) Validate(id string) error {
+	switch {
+	case m.After == "" && m.Before == "":
+		return ErrMoveWithoutNeighbour
+	case m.After == id || m.Before == id:
+		return ErrMoveNextToItself
+	case m.After == m.Before:
+		return ErrSameNeighbours
+	}
+	return nil
+}
+
+// PositionBetween returns a position after lower and before upper, as short as
+// possible. An empty lower or upper is unbounded: PositionBetween("", "") is
+// FirstPosition and PositionBetween(last, "") goes after the last position.
+func PositionBetween(lower, upper string) (string, error) {
+	for _, position := range []string{lower, upper} {
+		if position != "" && !isPosition(position) {
+			return "", fmt.Errorf("%w %q", ErrInvalidPosition, position)
+		}
+	}
+	if lower != "" && upper != "" && lower >= upper {
+		return "", fmt.Errorf("%w: %q is not before %q", ErrInvalidPosition, lower, upper)
+	}
+
+	switch {
+	case lower == "" && upper == "":
+		return FirstPosition, nil
+	case lower == "":
+		integer, fraction := splitPosition(upper)
+		if integer == smallestInteger {
+			return integer + midpoint("", fraction), nil
+		}
+		if integer < upper {
+			return integer, nil
+		}
+		if previous, ok := decrementInteger(integer); ok {
+			return previous, nil
+		}
+		return "", fmt.Errorf("%w: no position before %q", ErrInvalidPosition, upper)
+	case upper == "":
+		integer, fraction := splitPosition(lower)
+		if next, ok := incrementInteger(integer); ok {
+			return next, nil
+		}
+		return integer + midpoint(fraction, ""), nil
+	}
+
+	lowerInteger, lowerFraction := splitPosition(lower)
+	upperInteger, upperFraction := splitPosition(upper)
+	if lowerInteger == upperInteger {
+		return lowerInteger + midpoint(lowerFraction, upperFraction), nil
+	}
+	if next, ok := incrementInteger(lowerInteger); ok && next < upper {
+		return next, nil
+	}
+	return lowerInteger + midpoint(lowerFraction, ""), nil
+}
+
+// midpoint returns a fraction between lower and upper, unbounded when empty.
+// They don't end in 0 and lower is before upper.
+func midpoint(lower, upper string) string {
+	if upper != "" {
+		n := 0
+		for n < len(upper) && digitAt(lower, n) == upper[n] {
+			n++
+		}
+		if n > 0 {
+			return upper[:n] + midpoint(tail(lower, n), upper[n:])
+		}
+	}
+
+	lowerDigit := strings.IndexByte(positionDigits, digitAt(lower, 0))
+	upperDigit := len(positionDigits)
+	if upper != "" {
+		upperDigit = strings.IndexByte(positionDigits, upper[0])
+	}
+	if upperDigit-lowerDigit > 1 {
+		return string(positionDigits[(lowerDigit+upperDigit+1)/2])
+	}
+	if len(upper) > 1 {
+		return upper[:1]
+	}
+	return string(positionDigits[lowerDigit]) + midpoint(tail(lower, 1), "")
+}
+
+// incrementInteger returns the integer part after integer, false after the largest one
+func incrementInteger(integer string) (string, bool) {
+	head, digits := integer[0], []byte(integer[1:])
+	for i := len(digits) - 1; i >= 0; i-- {
+		if d := strings.IndexByte(positionDigits, digits[i]) + 1; d < len(positionDigits) {
+			digits[i] = positionDigits[d]
+			return string(head) + string(digits), true
+		}
+		digits[i] = positionDigits[0]
+	}
+
+	switch head {
+	case 'h':
+		return FirstPosition, true
+	case 'z':
+		return "", false
+	}
+	head = positionDigits[strings.IndexByte(positionDigits, head)+1]
+	if head > 'i' {
+		digits = append(digits, positionDigits[0])
+	} else {
+		digits = digits[:len(digits)-1]
+	}
+	return string(head) + string(digits), true
+}
+
+// decrementInteger returns the integer part before integer, false before the smallest one
+func decrementInteger(integer string) (string, bool) {
+	last := positionDigits[len(positionDigits)-1]
+	head, digits := integer[0], []byte(integer[1:])
+	for i := len(digits) - 1; i >= 0; i-- {
+		if d := strings.IndexByte(positionDigits, digits[i]) - 1; d >= 0 {
+			digits[i] = positionDigits[d]
+			return string(head) + string(digits), true
+		}
+		digits[i] = last
+	}
+
+	switch head {
+	case 'i':
+		return "h" + string(last), true
+	case '0':
+		return "", false
+	}
+	head = positionDigits[strings.IndexByte(positionDigits, head)-1]
+	if head < 'h' {
+		digits = append(digits, last)
+	} else {
+		digits = digits[:len(digits)-1]
+	}
+	return string(head) + string(digits), true
+}
+
+// isPosition reports whether s is a valid position
+func isPosition(s string) bool {
+	if s == "" || s == smallestInteger || strings.Trim(s, positionDigits) != "" {
+		return false
+	}
+	length := integerLength(s[0])
+	return length <= len(s) && !strings.HasSuffix(s[length:], positionDigits[:1])
+}
+
+// splitPosition returns the integer part and the fraction of a valid position
+func splitPosition(position string) (integer, fraction string) {
+	length := integerLength(position[0])
+	return position[:length], position[length:]
+}
+
+// integerLength returns the length of the integer parts starting with head
+func integerLength(head byte) int {
+	d := strings.IndexByte(positionDigits, head)
+	if head >= 'i' {
+		return d - 16
+	}
+	return 19 - d
+}
+
+// digitAt returns the digit n of a fraction, which goes on with zeros
+func digitAt(fraction string, n int) byte {
+	if n < len(fraction) {
+		return fraction[n]
+	}
+	return positionDigits[0]
+}
+
+func tail(s string, n int) string {
+	if n >= len(s) {
+		return ""
+	}
+	return s[n:]
+}
@@ -0,0 +1,108 @@
+package domain_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+)
+
+func TestPositionBetween(t *testing.T) {
+	tests := []struct {
+		name         string
+		givenLower   string
+		givenUpper   string
+		wantPosition string
+		wantErr      error
+	}{
+		{name: "Given_EmptyList_When_PositionBetween_Then_ExpectedFirstPosition", wantPosition: domain.FirstPosition},
+		{name: "Given_OnlyLower_When_PositionBetween_Then_ExpectedNextInteger", givenLower: "i0", wantPosition: "i1"},
+		{name: "Given_LastDigit_When_PositionBetween_Then_ExpectedLongerInteger", givenLower: "iz", wantPosition: "j00"},
+		{name: "Given_OnlyUpper_When_PositionBetween_Then_ExpectedPreviousInteger", givenUpper: "i0", wantPosition: "hz"},
+		{name: "Given_UpperWithFraction_When_PositionBetween_Then_ExpectedItsInteger", givenUpper: "i0v", wantPosition: "i0"},
+		{name: "Given_NegativeUpper_When_PositionBetween_Then_ExpectedLongerInteger", givenUpper: "h0", wantPosition: "gzz"},
+		{name: "Given_ConsecutiveIntegers_When_PositionBetween_Then_ExpectedFraction", givenLower: "i0", givenUpper: "i1", wantPosition: "i0i"},
+		{name: "Given_DistantIntegers_When_PositionBetween_Then_ExpectedInteger", givenLower: "i0", givenUpper: "i5", wantPosition: "i1"},
+		{name: "Given_CloseFractions_When_PositionBetween_Then_ExpectedLongerFraction", givenLower: "i0i", givenUpper: "i0j", wantPosition: "i0ii"},
+		{name: "Given_EqualPositions_When_PositionBetween_Then_ExpectedInvalidPositionError", givenLower: "i1", givenUpper: "i1", wantErr: domain.ErrInvalidPosition},
+		{name: "Given_ReversedPositions_When_PositionBetween_Then_ExpectedInvalidPositionError", givenLower: "i2", givenUpper: "i1", wantErr: domain.ErrInvalidPosition},
+		{name: "Given_TrailingZero_When_PositionBetween_Then_ExpectedInvalidPositionError", givenLower: "i0a0", wantErr: domain.ErrInvalidPosition},
+		{name: "Given_ShortInteger_When_PositionBetween_Then_ExpectedInvalidPositionError", givenLower: "j1", wantErr: domain.ErrInvalidPosition},
+		{name: "Given_UpperCase_When_PositionBetween_Then_ExpectedInvalidPositionError", givenUpper: "iA", wantErr: domain.ErrInvalidPosition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			position, err := domain.PositionBetween(tt.givenLower, tt.givenUpper)
+
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.wantPosition, position)
+			if tt.wantErr == nil {
+				require.True(t, tt.givenLower == "" || tt.givenLower < position)
+				require.True(t, tt.givenUpper == "" || position < tt.givenUpper)
+			}
+		})
+	}
+}
+
+func TestPositionBetween_Sequences(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenNext func(positions []string) (lower, upper string)
+	}{
+		{
+			name:      "Given_AppendsAtTheEnd_When_PositionBetween_Then_PositionsStayOrderedAndShort",
+			givenNext: func(positions []string) (string, string) { return positions[len(positions)-1], "" },
+		},
+		{
+			name:      "Given_InsertsAtTheStart_When_PositionBetween_Then_PositionsStayOrderedAndShort",
+			givenNext: func(positions []string) (string, string) { return "", positions[0] },
+		},
+		{
+			name: "Given_InsertsAfterTheFirst_When_PositionBetween_Then_PositionsStayOrdered",
+			givenNext: func(positions []string) (string, string) {
+				if len(positions) == 1 {
+					return positions[0], ""
+				}
+				return positions[0], positions[1]
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			positions := []string{domain.FirstPosition}
+			for range 1000 {
+				lower, upper := tt.givenNext(positions)
+				position, err := domain.PositionBetween(lower, upper)
+				require.NoError(t, err)
+				require.True(t, lower == "" || lower < position)
+				require.True(t, upper == "" || position < upper)
+
+				positions = append(positions, position)
+				sort.Strings(positions)
+			}
+			require.LessOrEqual(t, len(positions[len(positions)/2]), 200)
+		})
+	}
+}
+
+func TestItemMove_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenMove domain.ItemMove
+		wantErr   error
+	}{
+		{name: "Given_After_When_Validate_Then_ExpectedSuccess", givenMove: domain.ItemMove{After: "b"}},
+		{name: "Given_Before_When_Validate_Then_ExpectedSuccess", givenMove: domain.ItemMove{Before: "b"}},
+		{name: "Given_AfterAndBefore_When_Validate_Then_ExpectedSuccess", givenMove: domain.ItemMove{After: "b", Before: "c"}},
+		{name: "Given_NoNeighbour_When_Validate_Then_ExpectedMoveWithoutNeighbourError", givenMove: domain.ItemMove{}, wantErr: domain.ErrMoveWithoutNeighbour},
+		{name: "Given_ItselfAsNeighbour_When_Validate_Then_ExpectedMoveNextToItselfError", givenMove: domain.ItemMove{Before: "a"}, wantErr: domain.ErrMoveNextToItself},
+		{name: "Given_SameNeighbours_When_Validate_Then_ExpectedSameNeighboursError", givenMove: domain.ItemMove{After: "b", Before: "b"}, wantErr: domain.ErrSameNeighbours},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.givenMove.Validate("a"), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Amount is an amount of money in cents, so that prices and totals are exact
+type Amount int64
+
+// Currency is the ISO 4217 code of the currency of a price, like BRL
+type Currency string
+
+const (
+	// MaxUnitPrice is the largest unit price an item can have (1000000.00)
+	MaxUnitPrice Amount = 100_000_000
+	// DefaultCurrency is the currency of a price sent without one
+	DefaultCurrency Currency = "BRL"
+)
+
+var (
+	// ErrInvalidAmount is returned for an amount that is not a decimal number with up to 2 decimal places
+	ErrInvalidAmount = errors.New("amount must be a decimal number with up to 2 decimal places")
+	// ErrInvalidPrice is returned for a unit price that is negative or too large
+	ErrInvalidPrice = fmt.Errorf("unit price must be between 0 and %s", MaxUnitPrice)
+	// ErrInvalidCurrency is returned for a currency that is not a 3-letter code
+	ErrInvalidCurrency = errors.New("currency must be a 3-letter ISO 4217 code")
+	// ErrAmountOutOfRange is returned for a total too large to be written in cents
+	ErrAmountOutOfRange = errors.New("amount is out of range")
+	// ErrCurrencyWithoutPrice is returned for an item with a currency but no unit price
+	ErrCurrencyWithoutPrice = errors.New("currency requires a unit price")
+)
+
+// ParseAmount reads an amount written as a decimal number, like "12.9" or "-3",
+// exactly: it never goes through a float64
+func ParseAmount(s string) (Amount, error) {
+	digits, negative := strings.CutPrefix(s, "-")
+	units, decimals, hasDecimals := strings.Cut(digits, ".")
+	if units == "" || (hasDecimals && decimals == "") || len(decimals) > 2 || !isDigits(units) || !isDigits(decimals) {
+		return 0, ErrInvalidAmount
+	}
+
+	cents, err := strconv.ParseInt(units+(decimals + "00")[:2], 10, 64)
+	if err != nil {
+		return 0, ErrInvalidAmount
+	}
+	if negative {
+		cents = -cents
+	}
+	return Amount(cents), nil
+}
+
+// String writes a with its 2 decimal places, like "12.90"
+func (a Amount) String() string {
+	sign, cents := "", int64(a)
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// RoundAmount rounds an exact amount of cents to the nearest cent, halves away
+// from zero. It returns ErrAmountOutOfRange when the result does not fit in Amount.
+func RoundAmount(cents *big.Rat) (Amount, error) {
+	rounded, err := strconv.ParseInt(cents.FloatString(0), 10, 64)
+	if err != nil {
+		return 0, ErrAmountOutOfRange
+	}
+	return Amount(rounded), nil
+}
+
+// ParseCurrency returns the currency written as s, in upper case ("brl" is BRL)
+func ParseCurrency(s string) (Currency, error) {
+	code := strings.ToUpper(strings.TrimSpace(s))
+	if len(code) != 3 || strings.Trim(code, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return "", fmt.Errorf("%w, not %q", ErrInvalidCurrency, s)
+	}
+	return Currency(code), nil
+}
+
+// NormalizePrice returns i with its currency in upper case, DefaultCurrency when
+// it has a unit price but no currency, or an error when they are invalid
+func (i Item) NormalizePrice() (Item, error) {
+	currency, err := normalizePrice(i.UnitPrice, i.Currency)
+	if err != nil {
+		return Item{}, err
+	}
+	i.Currency = currency
+	return i, nil
+}
+
+// PriceTotal is the exact sum, in cents, of the line totals of the priced items
+// of one currency, category and active state
+type PriceTotal struct {
+	Currency   Currency
+	CategoryID string
+	Active     bool
+	Cents      *big.Rat
+}
+
+// PriceSummary is what the priced items of one currency cost: Estimated sums
+// the active items, still to be bought, and Checked the ones already checked off
+type PriceSummary struct {
+	Currency   Currency
+	Estimated  Amount
+	Checked    Amount
+	Categories []CategorySubtotal
+}
+
+// CategorySubtotal is what the priced items of one category cost, uncategorized when CategoryID is empty
+type CategorySubtotal struct {
+	CategoryID string
+	Estimated  Amount
+	Checked    Amount
+}
+
+// SummarizePrices adds up totals per currency, in alphabetical order, and per
+// category, in the order of categories with the uncategorized items (and those
+// of unknown categories) last. Every amount is rounded to cents once, after the
+// exact sum, so the subtotals may not add up to the total to the cent, and
+// ErrAmountOutOfRange is returned when one does not fit in Amount.
+func SummarizePrices(categories []Category, totals []PriceTotal) ([]PriceSummary, error) {
+	known := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		known[category.ID] = true
+	}
+
+	type sums struct{ estimated, checked *big.Rat }
+	add := func(s *sums, total PriceTotal) {
+		if total.Active {
+			s.estimated.Add(s.estimated, total.Cents)
+		} else {
+			s.checked.Add(s.checked, total.Cents)
+		}
+	}
+	newSums := func() *sums { return &sums{estimated: new(big.Rat), checked: new(big.Rat)} }
+
+	byCurrency := make(map[Currency]*sums)
+	byCategory := make(map[Currency]map[string]*sums)
+	for _, total := range totals {
+		categoryID := total.CategoryID
+		if !known[categoryID] {
+			categoryID = ""
+		}
+		if byCurrency[total.Currency] == nil {
+			byCurrency[total.Currency] = newSums()
+			byCategory[total.Currency] = make(map[string]*sums)
+		}
+		if byCategory[total.Currency][categoryID] == nil {
+			byCategory[total.Currency][categoryID] = newSums()
+		}
+		add(byCurrency[total.Currency], total)
+		add(byCategory[total.Currency][categoryID], total)
+	}
+
+	categoryIDs := make([]string, 0, len(categories)+1)
+	for _, category := range categories {
+		categoryIDs = append(categoryIDs, category.ID)
+	}
+	categoryIDs = append(categoryIDs, "")
+
+	currencies := make([]Currency, 0, len(byCurrency))
+	for currency := range byCurrency {
+		currencies = append(currencies, currency)
+	}
+	slices.Sort(currencies)
+
+	round := func(s *sums) (estimated, checked Amount, err error) {
+		if estimated, err = RoundAmount(s.estimated); err != nil {
+			return 0, 0, err
+		}
+		if checked, err = RoundAmount(s.checked); err != nil {
+			return 0, 0, err
+		}
+		return estimated, checked, nil
+	}
+
+	summaries := make([]PriceSummary, 0, len(currencies))
+	for _, currency := range currencies {
+		summary := PriceSummary{Currency: currency}
+		var err error
+		if summary.Estimated, summary.Checked, err = round(byCurrency[currency]); err != nil {
+			return nil, fmt.Errorf("total in %s: %w", currency, err)
+		}
+		for _, categoryID := range categoryIDs {
+			if s, ok := byCategory[currency][categoryID]; ok {
+				subtotal := CategorySubtotal{CategoryID: categoryID}
+				if subtotal.Estimated, subtotal.Checked, err = round(s); err != nil {
+					return nil, fmt.Errorf("total in %s: %w", currency, err)
+				}
+				summary.Categories = append(summary.Categories, subtotal)
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+// normalizePrice validates unitPrice and currency and returns the currency they are stored with
+func normalizePrice(unitPrice *Amount, currency Currency) (Currency, error) {
+	if unitPrice == nil {
+		if currency != "" {
+			return "", ErrCurrencyWithoutPrice
+		}
+		return "", nil
+	}
+
+	if *unitPrice < 0 || *unitPrice > MaxUnitPrice {
+		return "", ErrInvalidPrice
+	}
+	if currency == "" {
+		return DefaultCurrency, nil
+	}
+	return ParseCurrency(string(currency))
+}
+
+func isDigits(s string) bool {
+	return strings.Trim(s, "0123456789") == ""
+}
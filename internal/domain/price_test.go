@@ -0,0 +1,196 @@
+package domain_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name        string
+		givenAmount string
+		wantAmount  domain.Amount
+		wantErr     error
+	}{
+		{name: "Given_TwoDecimals_When_ParseAmount_Then_ExpectedCents", givenAmount: "12.99", wantAmount: 1299},
+		{name: "Given_OneDecimal_When_ParseAmount_Then_ExpectedCents", givenAmount: "0.1", wantAmount: 10},
+		{name: "Given_Integer_When_ParseAmount_Then_ExpectedCents", givenAmount: "3", wantAmount: 300},
+		{name: "Given_Negative_When_ParseAmount_Then_ExpectedNegativeCents", givenAmount: "-3.5", wantAmount: -350},
+		{name: "Given_ThreeDecimals_When_ParseAmount_Then_ExpectedInvalidAmountError", givenAmount: "1.999", wantErr: domain.ErrInvalidAmount},
+		{name: "Given_Exponent_When_ParseAmount_Then_ExpectedInvalidAmountError", givenAmount: "1e2", wantErr: domain.ErrInvalidAmount},
+		{name: "Given_TrailingDot_When_ParseAmount_Then_ExpectedInvalidAmountError", givenAmount: "1.", wantErr: domain.ErrInvalidAmount},
+		{name: "Given_Empty_When_ParseAmount_Then_ExpectedInvalidAmountError", givenAmount: "", wantErr: domain.ErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, err := domain.ParseAmount(tt.givenAmount)
+
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.wantAmount, amount)
+		})
+	}
+}
+
+func TestAmount_String(t *testing.T) {
+	require.Equal(t, "12.90", domain.Amount(1290).String())
+	require.Equal(t, "0.05", domain.Amount(5).String())
+	require.Equal(t, "-3.50", domain.Amount(-350).String())
+}
+
+func TestRoundAmount(t *testing.T) {
+	tests := []struct {
+		name       string
+		givenCents *big.Rat
+		wantAmount domain.Amount
+		wantErr    error
+	}{
+		{name: "Given_HalfCent_When_RoundAmount_Then_ExpectedRoundedUp", givenCents: big.NewRat(2495, 10), wantAmount: 250},
+		{name: "Given_LessThanHalfCent_When_RoundAmount_Then_ExpectedRoundedDown", givenCents: big.NewRat(2494, 10), wantAmount: 249},
+		{name: "Given_WholeCents_When_RoundAmount_Then_ExpectedSameAmount", givenCents: big.NewRat(1290, 1), wantAmount: 1290},
+		{name: "Given_CentsOverflowingAmount_When_RoundAmount_Then_ExpectedAmountOutOfRangeError", givenCents: new(big.Rat).SetFrac(new(big.Int).Lsh(big.NewInt(1), 63), big.NewInt(1)), wantErr: domain.ErrAmountOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rounded, err := domain.RoundAmount(tt.givenCents)
+
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.wantAmount, rounded)
+		})
+	}
+}
+
+func TestItem_NormalizePrice(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenItem domain.Item
+		wantItem  domain.Item
+		wantErr   error
+	}{
+		{
+			name:      "Given_PriceWithoutCurrency_When_NormalizePrice_Then_ExpectedDefaultCurrency",
+			givenItem: domain.Item{Name: "Milk", UnitPrice: amount(599)},
+			wantItem:  domain.Item{Name: "Milk", UnitPrice: amount(599), Currency: domain.DefaultCurrency},
+		},
+		{
+			name:      "Given_LowercaseCurrency_When_NormalizePrice_Then_ExpectedUppercaseCurrency",
+			givenItem: domain.Item{Name: "Milk", UnitPrice: amount(599), Currency: "usd"},
+			wantItem:  domain.Item{Name: "Milk", UnitPrice: amount(599), Currency: "USD"},
+		},
+		{
+			name:      "Given_ZeroPrice_When_NormalizePrice_Then_Valid",
+			givenItem: domain.Item{Name: "Sample", UnitPrice: amount(0), Currency: "BRL"},
+			wantItem:  domain.Item{Name: "Sample", UnitPrice: amount(0), Currency: "BRL"},
+		},
+		{
+			name:      "Given_TooLargePrice_When_NormalizePrice_Then_ExpectedInvalidPriceError",
+			givenItem: domain.Item{Name: "Car", UnitPrice: amount(domain.MaxUnitPrice + 1)},
+			wantErr:   domain.ErrInvalidPrice,
+		},
+		{
+			name:      "Given_InvalidCurrency_When_NormalizePrice_Then_ExpectedInvalidCurrencyError",
+			givenItem: domain.Item{Name: "Milk", UnitPrice: amount(599), Currency: "R$"},
+			wantErr:   domain.ErrInvalidCurrency,
+		},
+		{
+			name:      "Given_CurrencyWithoutPrice_When_NormalizePrice_Then_ExpectedCurrencyWithoutPriceError",
+			givenItem: domain.Item{Name: "Milk", Currency: "BRL"},
+			wantErr:   domain.ErrCurrencyWithoutPrice,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := tt.givenItem.NormalizePrice()
+
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.wantItem, item)
+		})
+	}
+}
+
+func TestSummarizePrices(t *testing.T) {
+	categories := []domain.Category{{ID: "produce", Position: 1}, {ID: "dairy", Position: 2}}
+
+	tests := []struct {
+		name          string
+		givenTotals   []domain.PriceTotal
+		wantSummaries []domain.PriceSummary
+		wantErr       error
+	}{
+		{
+			name: "Given_TotalsOfTwoCurrencies_When_SummarizePrices_Then_ExpectedSummaryPerCurrency",
+			givenTotals: []domain.PriceTotal{
+				{Currency: "USD", CategoryID: "dairy", Active: true, Cents: big.NewRat(300, 1)},
+				{Currency: "BRL", CategoryID: "dairy", Active: true, Cents: big.NewRat(1000, 1)},
+				{Currency: "BRL", CategoryID: "dairy", Active: false, Cents: big.NewRat(250, 1)},
+				{Currency: "BRL", CategoryID: "produce", Active: true, Cents: big.NewRat(599, 1)},
+			},
+			wantSummaries: []domain.PriceSummary{
+				{Currency: "BRL", Estimated: 1599, Checked: 250, Categories: []domain.CategorySubtotal{
+					{CategoryID: "produce", Estimated: 599},
+					{CategoryID: "dairy", Estimated: 1000, Checked: 250},
+				}},
+				{Currency: "USD", Estimated: 300, Categories: []domain.CategorySubtotal{
+					{CategoryID: "dairy", Estimated: 300},
+				}},
+			},
+		},
+		{
+			name: "Given_UncategorizedAndUnknownCategory_When_SummarizePrices_Then_ExpectedOneLastSubtotal",
+			givenTotals: []domain.PriceTotal{
+				{Currency: "BRL", Active: true, Cents: big.NewRat(100, 1)},
+				{Currency: "BRL", CategoryID: "deleted", Active: true, Cents: big.NewRat(200, 1)},
+				{Currency: "BRL", CategoryID: "produce", Active: true, Cents: big.NewRat(50, 1)},
+			},
+			wantSummaries: []domain.PriceSummary{
+				{Currency: "BRL", Estimated: 350, Categories: []domain.CategorySubtotal{
+					{CategoryID: "produce", Estimated: 50},
+					{Estimated: 300},
+				}},
+			},
+		},
+		{
+			name: "Given_FractionsOfCents_When_SummarizePrices_Then_ExpectedRoundingAfterTheExactSum",
+			givenTotals: []domain.PriceTotal{
+				{Currency: "BRL", CategoryID: "produce", Active: true, Cents: big.NewRat(1, 3)},
+				{Currency: "BRL", CategoryID: "dairy", Active: true, Cents: big.NewRat(1, 3)},
+				{Currency: "BRL", Active: true, Cents: big.NewRat(1, 3)},
+			},
+			wantSummaries: []domain.PriceSummary{
+				{Currency: "BRL", Estimated: 1, Categories: []domain.CategorySubtotal{
+					{CategoryID: "produce"},
+					{CategoryID: "dairy"},
+					{},
+				}},
+			},
+		},
+		{
+			name:          "Given_NoTotals_When_SummarizePrices_Then_ExpectedEmptySlice",
+			wantSummaries: []domain.PriceSummary{},
+		},
+		{
+			name: "Given_SumOverflowingAmount_When_SummarizePrices_Then_ExpectedAmountOutOfRangeError",
+			givenTotals: []domain.PriceTotal{
+				{Currency: "BRL", Active: true, Cents: big.NewRat(math.MaxInt64, 1)},
+				{Currency: "BRL", CategoryID: "dairy", Active: true, Cents: big.NewRat(1, 1)},
+			},
+			wantErr: domain.ErrAmountOutOfRange,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summaries, err := domain.SummarizePrices(categories, tt.givenTotals)
+
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.wantSummaries, summaries)
+		})
+	}
+}
+
+func amount(cents domain.Amount) *domain.Amount {
+	return &cents
+}
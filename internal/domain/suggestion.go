@@ -0,0 +1,14 @@
+package domain
+
+const (
+	// DefaultSuggestLimit is the number of suggestions returned when the client does not ask for one
+	DefaultSuggestLimit = 10
+	// MaxSuggestLimit is the largest number of suggestions a client can ask for
+	MaxSuggestLimit = 50
+)
+
+// Suggestion is an item name suggested while typing, with how many items used it
+type Suggestion struct {
+	Name  string
+	Count int
+}
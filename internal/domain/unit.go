@@ -0,0 +1,239 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Unit is the unit of the quantity of an item, from the catalog of Units
+type Unit string
+
+// The units of the catalog
+const (
+	UnitPiece      Unit = "un"
+	UnitDozen      Unit = "dozen"
+	UnitKilogram   Unit = "kg"
+	UnitGram       Unit = "g"
+	UnitLiter      Unit = "L"
+	UnitMilliliter Unit = "mL"
+	UnitPack       Unit = "pack"
+)
+
+// Dimension groups the units that can be converted into each other
+type Dimension string
+
+const (
+	DimensionCount  Dimension = "count"
+	DimensionMass   Dimension = "mass"
+	DimensionVolume Dimension = "volume"
+	DimensionPack   Dimension = "pack"
+)
+
+const (
+	// MaxQuantity is the largest quantity an item can have
+	MaxQuantity = 1_000_000
+	// QuantityDecimals is the number of decimal places a quantity can have
+	QuantityDecimals = 3
+	// QuantityScale is the number of Thousandths in one unit of a quantity
+	QuantityScale = 1000
+)
+
+// Thousandths is the value of a quantity in thousandths of its unit, so that
+// quantities and their sums are exact: 1.5 kg is 1500
+type Thousandths int64
+
+var (
+	// ErrUnknownUnit is returned for a unit that is not in the catalog
+	ErrUnknownUnit = errors.New("unknown unit")
+	// ErrIncompatibleUnits is returned when converting between units of different dimensions
+	ErrIncompatibleUnits = errors.New("incompatible units")
+	// ErrInvalidThousandths is returned for a quantity that is not a decimal number with up to 3 decimal places
+	ErrInvalidThousandths = fmt.Errorf("quantity must be a decimal number with up to %d decimal places", QuantityDecimals)
+	// ErrInvalidQuantity is returned for a quantity that is not positive or too large
+	ErrInvalidQuantity = fmt.Errorf("quantity must be greater than 0 and at most %d", MaxQuantity)
+	// ErrQuantityOutOfRange is returned for a converted quantity or a sum too large to be stored
+	ErrQuantityOutOfRange = errors.New("quantity is out of range")
+	// ErrUnitWithoutQuantity is returned for an item with a unit but no quantity
+	ErrUnitWithoutQuantity = errors.New("unit requires a quantity")
+)
+
+// unitInfo places a unit in its dimension: factor is how many thousandths of
+// the base unit it is worth, QuantityScale for the base unit itself
+type unitInfo struct {
+	dimension Dimension
+	factor    int64
+}
+
+// Units is the catalog of units, the base unit of each dimension first
+var Units = []Unit{UnitPiece, UnitDozen, UnitKilogram, UnitGram, UnitLiter, UnitMilliliter, UnitPack}
+
+var units = map[Unit]unitInfo{
+	UnitPiece:      {dimension: DimensionCount, factor: 1000},
+	UnitDozen:      {dimension: DimensionCount, factor: 12_000},
+	UnitKilogram:   {dimension: DimensionMass, factor: 1000},
+	UnitGram:       {dimension: DimensionMass, factor: 1},
+	UnitLiter:      {dimension: DimensionVolume, factor: 1000},
+	UnitMilliliter: {dimension: DimensionVolume, factor: 1},
+	UnitPack:       {dimension: DimensionPack, factor: 1000},
+}
+
+// ParseThousandths reads a quantity written as a decimal number, like "1.5" or
+// "-3", exactly: it never goes through a float64
+func ParseThousandths(s string) (Thousandths, error) {
+	digits, negative := strings.CutPrefix(s, "-")
+	units, decimals, hasDecimals := strings.Cut(digits, ".")
+	if units == "" || (hasDecimals && decimals == "") || len(decimals) > QuantityDecimals || !isDigits(units) || !isDigits(decimals) {
+		return 0, ErrInvalidThousandths
+	}
+
+	thousandths, err := strconv.ParseInt(units+(decimals + "000")[:QuantityDecimals], 10, 64)
+	if err != nil {
+		return 0, ErrInvalidThousandths
+	}
+	if negative {
+		thousandths = -thousandths
+	}
+	return Thousandths(thousandths), nil
+}
+
+// String writes t as a decimal number without trailing zeros, like "1.5" or "2"
+func (t Thousandths) String() string {
+	sign, value := "", int64(t)
+	if value < 0 {
+		sign, value = "-", -value
+	}
+	decimals := strings.TrimRight(fmt.Sprintf("%03d", value%QuantityScale), "0")
+	if decimals == "" {
+		return fmt.Sprintf("%s%d", sign, value/QuantityScale)
+	}
+	return fmt.Sprintf("%s%d.%s", sign, value/QuantityScale, decimals)
+}
+
+// ParseUnit returns the unit of the catalog written as s, ignoring case ("ml" is mL)
+func ParseUnit(s string) (Unit, error) {
+	for _, unit := range Units {
+		if strings.EqualFold(string(unit), strings.TrimSpace(s)) {
+			return unit, nil
+		}
+	}
+	return "", fmt.Errorf("%w %q", ErrUnknownUnit, s)
+}
+
+// IsValid reports whether u is in the catalog
+func (u Unit) IsValid() bool {
+	_, ok := units[u]
+	return ok
+}
+
+// Dimension returns the dimension of u, empty when u is not in the catalog
+func (u Unit) Dimension() Dimension {
+	return units[u].dimension
+}
+
+// BaseUnit returns the unit the quantities of the dimension of u are summed in
+func (u Unit) BaseUnit() Unit {
+	for _, unit := range Units {
+		if info := units[unit]; info.dimension == u.Dimension() && info.factor == QuantityScale {
+			return unit
+		}
+	}
+	return u
+}
+
+// CanConvert reports whether a quantity in u can be expressed in to
+func (u Unit) CanConvert(to Unit) bool {
+	return u.IsValid() && to.IsValid() && u.Dimension() == to.Dimension()
+}
+
+// Quantity is an amount in a unit of the catalog
+type Quantity struct {
+	Value Thousandths
+	Unit  Unit
+}
+
+// Validate checks that the value of q is positive and not too large and that
+// its unit is in the catalog
+func (q Quantity) Validate() error {
+	if !q.Unit.IsValid() {
+		return fmt.Errorf("%w %q", ErrUnknownUnit, q.Unit)
+	}
+	if q.Value <= 0 || q.Value > MaxQuantity*QuantityScale {
+		return ErrInvalidQuantity
+	}
+	return nil
+}
+
+// In converts q to unit, rounded to thousandths (1 un is 0.083 dozen)
+func (q Quantity) In(unit Unit) (Quantity, error) {
+	if !q.Unit.CanConvert(unit) {
+		return Quantity{}, fmt.Errorf("%w: %s and %s", ErrIncompatibleUnits, q.Unit, unit)
+	}
+	value, err := roundThousandths(q.scaled(), units[unit].factor)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return Quantity{Value: value, Unit: unit}, nil
+}
+
+// Add returns the sum of q and other, in the unit of q
+func (q Quantity) Add(other Quantity) (Quantity, error) {
+	if !other.Unit.CanConvert(q.Unit) {
+		return Quantity{}, fmt.Errorf("%w: %s and %s", ErrIncompatibleUnits, other.Unit, q.Unit)
+	}
+	sum := new(big.Int).Add(q.scaled(), other.scaled())
+	value, err := roundThousandths(sum, units[q.Unit].factor)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return Quantity{Value: value, Unit: q.Unit}, nil
+}
+
+// SumByUnit sums the quantities of each dimension in its base unit (grams and
+// kilograms in kg, mL and liters in L, dozens and pieces in un), in the order of
+// Units. Quantities in units outside the catalog are skipped. Every sum is exact
+// and rounded to thousandths once, like the price totals, and ErrQuantityOutOfRange
+// is returned when one does not fit in Thousandths.
+func SumByUnit(quantities []Quantity) ([]Quantity, error) {
+	sums := make(map[Unit]*big.Int)
+	for _, q := range quantities {
+		if !q.Unit.IsValid() {
+			continue
+		}
+		base := q.Unit.BaseUnit()
+		if sums[base] == nil {
+			sums[base] = new(big.Int)
+		}
+		sums[base].Add(sums[base], q.scaled())
+	}
+
+	totals := make([]Quantity, 0, len(sums))
+	for _, unit := range Units {
+		if sum, ok := sums[unit]; ok {
+			value, err := roundThousandths(sum, units[unit].factor)
+			if err != nil {
+				return nil, fmt.Errorf("total in %s: %w", unit, err)
+			}
+			totals = append(totals, Quantity{Value: value, Unit: unit})
+		}
+	}
+	return totals, nil
+}
+
+// scaled returns q in millionths of its base unit, where every unit of the catalog is exact
+func (q Quantity) scaled() *big.Int {
+	return new(big.Int).Mul(big.NewInt(int64(q.Value)), big.NewInt(units[q.Unit].factor))
+}
+
+// roundThousandths divides millionths of a base unit by factor, the thousandths
+// of the base unit a unit is worth, rounding halves away from zero. It returns
+// ErrQuantityOutOfRange when the result does not fit in Thousandths.
+func roundThousandths(millionths *big.Int, factor int64) (Thousandths, error) {
+	rounded, err := strconv.ParseInt(new(big.Rat).SetFrac(millionths, big.NewInt(factor)).FloatString(0), 10, 64)
+	if err != nil {
+		return 0, ErrQuantityOutOfRange
+	}
+	return Thousandths(rounded), nil
+}
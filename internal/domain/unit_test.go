@@ -0,0 +1,255 @@
+package domain_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+)
+
+func TestParseUnit(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenUnit string
+		wantUnit  domain.Unit
+		wantErr   error
+	}{
+		{name: "Given_CatalogUnit_When_ParseUnit_Then_ExpectedUnit", givenUnit: "kg", wantUnit: domain.UnitKilogram},
+		{name: "Given_OtherCase_When_ParseUnit_Then_ExpectedCatalogSpelling", givenUnit: "ML", wantUnit: domain.UnitMilliliter},
+		{name: "Given_LowercaseLiter_When_ParseUnit_Then_ExpectedLiter", givenUnit: "l", wantUnit: domain.UnitLiter},
+		{name: "Given_UnknownUnit_When_ParseUnit_Then_ExpectedUnknownUnitError", givenUnit: "oz", wantErr: domain.ErrUnknownUnit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit, err := domain.ParseUnit(tt.givenUnit)
+
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.wantUnit, unit)
+		})
+	}
+}
+
+func TestQuantity_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenQuantity domain.Quantity
+		wantErr       error
+	}{
+		{name: "Given_DecimalQuantity_When_Validate_Then_Valid", givenQuantity: domain.Quantity{Value: 1255, Unit: domain.UnitKilogram}},
+		{name: "Given_ZeroQuantity_When_Validate_Then_ExpectedInvalidQuantityError", givenQuantity: domain.Quantity{Unit: domain.UnitPiece}, wantErr: domain.ErrInvalidQuantity},
+		{name: "Given_NegativeQuantity_When_Validate_Then_ExpectedInvalidQuantityError", givenQuantity: domain.Quantity{Value: -1000, Unit: domain.UnitPiece}, wantErr: domain.ErrInvalidQuantity},
+		{name: "Given_TooLargeQuantity_When_Validate_Then_ExpectedInvalidQuantityError", givenQuantity: domain.Quantity{Value: domain.MaxQuantity*domain.QuantityScale + 1, Unit: domain.UnitGram}, wantErr: domain.ErrInvalidQuantity},
+		{name: "Given_UnknownUnit_When_Validate_Then_ExpectedUnknownUnitError", givenQuantity: domain.Quantity{Value: 1000, Unit: "oz"}, wantErr: domain.ErrUnknownUnit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.givenQuantity.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestQuantity_In(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenQuantity domain.Quantity
+		givenUnit     domain.Unit
+		wantQuantity  domain.Quantity
+		wantErr       error
+	}{
+		{
+			name:          "Given_Grams_When_InKilograms_Then_ExpectedKilograms",
+			givenQuantity: domain.Quantity{Value: 250_000, Unit: domain.UnitGram},
+			givenUnit:     domain.UnitKilogram,
+			wantQuantity:  domain.Quantity{Value: 250, Unit: domain.UnitKilogram},
+		},
+		{
+			name:          "Given_Liters_When_InMilliliters_Then_ExpectedMilliliters",
+			givenQuantity: domain.Quantity{Value: 1500, Unit: domain.UnitLiter},
+			givenUnit:     domain.UnitMilliliter,
+			wantQuantity:  domain.Quantity{Value: 1_500_000, Unit: domain.UnitMilliliter},
+		},
+		{
+			name:          "Given_Dozens_When_InPieces_Then_ExpectedPieces",
+			givenQuantity: domain.Quantity{Value: 2000, Unit: domain.UnitDozen},
+			givenUnit:     domain.UnitPiece,
+			wantQuantity:  domain.Quantity{Value: 24_000, Unit: domain.UnitPiece},
+		},
+		{
+			name:          "Given_Pieces_When_InDozens_Then_ExpectedDozensRoundedToThousandths",
+			givenQuantity: domain.Quantity{Value: 1000, Unit: domain.UnitPiece},
+			givenUnit:     domain.UnitDozen,
+			wantQuantity:  domain.Quantity{Value: 83, Unit: domain.UnitDozen},
+		},
+		{
+			name:          "Given_Kilograms_When_InLiters_Then_ExpectedIncompatibleUnitsError",
+			givenQuantity: domain.Quantity{Value: 1000, Unit: domain.UnitKilogram},
+			givenUnit:     domain.UnitLiter,
+			wantErr:       domain.ErrIncompatibleUnits,
+		},
+		{
+			name:          "Given_TooManyDozens_When_InPieces_Then_ExpectedQuantityOutOfRangeError",
+			givenQuantity: domain.Quantity{Value: math.MaxInt64 / 2, Unit: domain.UnitDozen},
+			givenUnit:     domain.UnitPiece,
+			wantErr:       domain.ErrQuantityOutOfRange,
+		},
+		{
+			name:          "Given_Packs_When_InPieces_Then_ExpectedIncompatibleUnitsError",
+			givenQuantity: domain.Quantity{Value: 1000, Unit: domain.UnitPack},
+			givenUnit:     domain.UnitPiece,
+			wantErr:       domain.ErrIncompatibleUnits,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converted, err := tt.givenQuantity.In(tt.givenUnit)
+
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.wantQuantity, converted)
+		})
+	}
+}
+
+func TestQuantity_Add(t *testing.T) {
+	rice := domain.Quantity{Value: 1000, Unit: domain.UnitKilogram}
+
+	sum, err := rice.Add(domain.Quantity{Value: 500_000, Unit: domain.UnitGram})
+	require.NoError(t, err)
+	require.Equal(t, domain.Quantity{Value: 1500, Unit: domain.UnitKilogram}, sum)
+
+	_, err = rice.Add(domain.Quantity{Value: 1000, Unit: domain.UnitPack})
+	require.ErrorIs(t, err, domain.ErrIncompatibleUnits)
+
+	_, err = rice.Add(domain.Quantity{Value: math.MaxInt64, Unit: domain.UnitKilogram})
+	require.ErrorIs(t, err, domain.ErrQuantityOutOfRange)
+}
+
+func TestSumByUnit(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenQuantities []domain.Quantity
+		wantTotals      []domain.Quantity
+		wantErr         error
+	}{
+		{
+			name: "Given_CompatibleUnits_When_SumByUnit_Then_ExpectedTotalsInBaseUnits",
+			givenQuantities: []domain.Quantity{
+				{Value: 500_000, Unit: domain.UnitMilliliter},
+				{Value: 100, Unit: domain.UnitKilogram},
+				{Value: 1000, Unit: domain.UnitDozen},
+				{Value: 200, Unit: domain.UnitKilogram},
+				{Value: 2000, Unit: domain.UnitLiter},
+				{Value: 3000, Unit: domain.UnitPiece},
+				{Value: 2000, Unit: domain.UnitPack},
+			},
+			wantTotals: []domain.Quantity{
+				{Value: 15_000, Unit: domain.UnitPiece},
+				{Value: 300, Unit: domain.UnitKilogram},
+				{Value: 2500, Unit: domain.UnitLiter},
+				{Value: 2000, Unit: domain.UnitPack},
+			},
+		},
+		{
+			name: "Given_FractionsOfAThousandth_When_SumByUnit_Then_ExpectedSumRoundedOnce",
+			givenQuantities: []domain.Quantity{
+				{Value: 400, Unit: domain.UnitGram},
+				{Value: 400, Unit: domain.UnitGram},
+			},
+			wantTotals: []domain.Quantity{
+				{Value: 1, Unit: domain.UnitKilogram},
+			},
+		},
+		{
+			name:       "Given_NoQuantities_When_SumByUnit_Then_ExpectedNoTotals",
+			wantTotals: []domain.Quantity{},
+		},
+		{
+			name: "Given_SumOverflowingThousandths_When_SumByUnit_Then_ExpectedQuantityOutOfRangeError",
+			givenQuantities: []domain.Quantity{
+				{Value: math.MaxInt64, Unit: domain.UnitLiter},
+				{Value: 1000, Unit: domain.UnitLiter},
+			},
+			wantErr: domain.ErrQuantityOutOfRange,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			totals, err := domain.SumByUnit(tt.givenQuantities)
+
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.wantTotals, totals)
+		})
+	}
+}
+
+func TestItem_NormalizeQuantity(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenItem domain.Item
+		wantItem  domain.Item
+		wantErr   error
+	}{
+		{
+			name:      "Given_QuantityWithoutUnit_When_NormalizeQuantity_Then_ExpectedPieces",
+			givenItem: domain.Item{Name: "Eggs", Quantity: quantity(6000)},
+			wantItem:  domain.Item{Name: "Eggs", Quantity: quantity(6000), Unit: domain.UnitPiece},
+		},
+		{
+			name:      "Given_LowercaseUnit_When_NormalizeQuantity_Then_ExpectedCatalogSpelling",
+			givenItem: domain.Item{Name: "Milk", Quantity: quantity(2000), Unit: "l"},
+			wantItem:  domain.Item{Name: "Milk", Quantity: quantity(2000), Unit: domain.UnitLiter},
+		},
+		{
+			name:      "Given_NoQuantity_When_NormalizeQuantity_Then_ItemIsUnchanged",
+			givenItem: domain.Item{Name: "Bread"},
+			wantItem:  domain.Item{Name: "Bread"},
+		},
+		{
+			name:      "Given_UnitWithoutQuantity_When_NormalizeQuantity_Then_ExpectedUnitWithoutQuantityError",
+			givenItem: domain.Item{Name: "Rice", Unit: domain.UnitKilogram},
+			wantErr:   domain.ErrUnitWithoutQuantity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := tt.givenItem.NormalizeQuantity()
+
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.wantItem, item)
+		})
+	}
+}
+
+func TestParseThousandths(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenValue      string
+		wantThousandths domain.Thousandths
+		wantErr         error
+	}{
+		{name: "Given_Decimal_When_ParseThousandths_Then_ExpectedThousandths", givenValue: "1.5", wantThousandths: 1500},
+		{name: "Given_ThreeDecimals_When_ParseThousandths_Then_ExpectedThousandths", givenValue: "0.001", wantThousandths: 1},
+		{name: "Given_Integer_When_ParseThousandths_Then_ExpectedThousandths", givenValue: "-3", wantThousandths: -3000},
+		{name: "Given_TooManyDecimals_When_ParseThousandths_Then_ExpectedInvalidThousandthsError", givenValue: "0.0001", wantErr: domain.ErrInvalidThousandths},
+		{name: "Given_Exponent_When_ParseThousandths_Then_ExpectedInvalidThousandthsError", givenValue: "1e3", wantErr: domain.ErrInvalidThousandths},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thousandths, err := domain.ParseThousandths(tt.givenValue)
+
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.wantThousandths, thousandths)
+		})
+	}
+}
+
+func TestThousandths_String(t *testing.T) {
+	require.Equal(t, "1.5", domain.Thousandths(1500).String())
+	require.Equal(t, "0.001", domain.Thousandths(1).String())
+	require.Equal(t, "-2", domain.Thousandths(-2000).String())
+}
+
+func quantity(v domain.Thousandths) *domain.Thousandths {
+	return &v
+}
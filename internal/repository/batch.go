@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+)
+
+// BatchOperationType is the kind of change made by a BatchOperation
+type BatchOperationType string
+
+const (
+	BatchCreate BatchOperationType = "create"
+	BatchUpdate BatchOperationType = "update"
+	BatchDelete BatchOperationType = "delete"
+)
+
+// BatchOperation is one change of a batch. Create and Update use Item like their
+// single item counterparts, Delete only uses Item.ID. Item.Version is not checked.
+type BatchOperation struct {
+	Type BatchOperationType
+	Item Item
+}
+
+// BatchResult is the outcome of a BatchOperation: the created or updated item, or its error
+type BatchResult struct {
+	Item Item
+	Err  error
+}
+
+// RunBatch runs ops one after the other with the single item methods of r, for
+// the backends that have no cheaper way to apply several changes. When stopOnError
+// is true, the results end with the first failed operation.
+func RunBatch(ctx context.Context, r ItemRepository, ops []BatchOperation, stopOnError bool) ([]BatchResult, error) {
+	results := make([]BatchResult, 0, len(ops))
+	for _, op := range ops {
+		if err := ctx.Err(); err != nil {
+			return nil, HandleError(err)
+		}
+
+		item := op.Item
+		item.Version = 0
+
+		var result BatchResult
+		switch op.Type {
+		case BatchCreate:
+			result.Item, result.Err = r.Create(ctx, item)
+		case BatchUpdate:
+			result.Item, result.Err = r.Update(ctx, item)
+		case BatchDelete:
+			result.Err = r.Delete(ctx, item.ID, 0)
+		default:
+			result.Err = NewInvalidBatchOperationError(op.Type)
+		}
+
+		results = append(results, result)
+		if result.Err != nil && stopOnError {
+			break
+		}
+	}
+	return results, nil
+}
+
+func NewInvalidBatchOperationError(opType BatchOperationType) error {
+	return NewGenericRepositoryError(fmt.Errorf("unknown batch operation %q", opType))
+}
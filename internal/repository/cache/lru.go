@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"container/list"
+	"strings"
+	"time"
+)
+
+// lru is a fixed capacity least recently used cache whose entries expire after ttl.
+// It is not safe for concurrent use; CachedItemRepository guards it with its mutex.
+type lru struct {
+	capacity int
+	ttl      time.Duration
+	now      func() time.Time
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+type lruEntry struct {
+	key       string
+	value     any
+	expiresAt time.Time
+}
+
+func newLRU(capacity int, ttl time.Duration, now func() time.Time) *lru {
+	return &lru{
+		capacity: capacity,
+		ttl:      ttl,
+		now:      now,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// get returns the value stored under key, unless it is missing or expired.
+func (c *lru) get(key string) (any, bool) {
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*lruEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.removeElement(element)
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return entry.value, true
+}
+
+// add stores value under key, evicting the least recently used entry when full.
+func (c *lru) add(key string, value any) {
+	expiresAt := c.now().Add(c.ttl)
+
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	if c.order.Len() > c.capacity {
+		c.removeElement(c.order.Back())
+	}
+}
+
+func (c *lru) remove(key string) {
+	if element, ok := c.entries[key]; ok {
+		c.removeElement(element)
+	}
+}
+
+// removePrefix removes every entry whose key starts with prefix.
+func (c *lru) removePrefix(prefix string) {
+	for key, element := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.removeElement(element)
+		}
+	}
+}
+
+func (c *lru) purge() {
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+}
+
+func (c *lru) len() int {
+	return c.order.Len()
+}
+
+func (c *lru) removeElement(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*lruEntry).key)
+}
@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func TestLRU(t *testing.T) {
+	t.Run("Given_FullCache_When_Add_Then_LeastRecentlyUsedIsEvicted", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+		c := newLRU(2, time.Minute, clock.Now)
+
+		c.add("a", 1)
+		c.add("b", 2)
+		_, ok := c.get("a")
+		require.True(t, ok)
+
+		c.add("c", 3)
+
+		_, ok = c.get("b")
+		require.False(t, ok)
+		value, ok := c.get("a")
+		require.True(t, ok)
+		require.Equal(t, 1, value)
+		require.Equal(t, 2, c.len())
+	})
+
+	t.Run("Given_ExpiredEntry_When_Get_Then_MissAndEntryIsRemoved", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+		c := newLRU(2, time.Minute, clock.Now)
+
+		c.add("a", 1)
+		clock.now = clock.now.Add(time.Minute)
+
+		_, ok := c.get("a")
+		require.False(t, ok)
+		require.Equal(t, 0, c.len())
+	})
+
+	t.Run("Given_ExistingKey_When_Add_Then_ValueAndExpirationAreRefreshed", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+		c := newLRU(2, time.Minute, clock.Now)
+
+		c.add("a", 1)
+		clock.now = clock.now.Add(30 * time.Second)
+		c.add("a", 2)
+		clock.now = clock.now.Add(45 * time.Second)
+
+		value, ok := c.get("a")
+		require.True(t, ok)
+		require.Equal(t, 2, value)
+		require.Equal(t, 1, c.len())
+	})
+
+	t.Run("Given_Entries_When_RemovePrefixAndPurge_Then_MatchingEntriesAreRemoved", func(t *testing.T) {
+		c := newLRU(10, time.Minute, time.Now)
+
+		c.add("item:1", 1)
+		c.add("list:all", 2)
+		c.add("list:active", 3)
+
+		c.removePrefix("list:")
+		require.Equal(t, 1, c.len())
+		_, ok := c.get("item:1")
+		require.True(t, ok)
+
+		c.remove("item:1")
+		require.Equal(t, 0, c.len())
+
+		c.add("item:2", 2)
+		c.purge()
+		require.Equal(t, 0, c.len())
+	})
+}
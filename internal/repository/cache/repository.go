@@ -0,0 +1,312 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+const (
+	DefaultCapacity = 1024
+	DefaultTTL      = 30 * time.Second
+
+	itemKeyPrefix = "item:"
+	listKeyPrefix = "list:"
+)
+
+// Options configures CachedItemRepository. Zero values use the defaults.
+type Options struct {
+	// Capacity is the maximum number of cached results (items and lists).
+	Capacity int
+	// TTL is how long a cached result is served before reading it again.
+	TTL time.Duration
+	// Now returns the current time, time.Now by default.
+	Now func() time.Time
+}
+
+// Stats holds the cache counters.
+type Stats struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+	Size   int   `json:"size"`
+}
+
+// CachedItemRepository decorates a repository.ItemRepository with a read-through
+// in-process cache. GetByID and List results are cached, and every write
+// invalidates the entries it may have changed. Errors are never cached.
+type CachedItemRepository struct {
+	next repository.ItemRepository
+
+	mu    sync.Mutex
+	cache *lru
+	// generation is incremented on every invalidation, so a read that started
+	// before a write does not store its (stale) result.
+	generation uint64
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// NewCachedItemRepository wraps next with a cache configured by options
+func NewCachedItemRepository(next repository.ItemRepository, options Options) *CachedItemRepository {
+	if options.Capacity <= 0 {
+		options.Capacity = DefaultCapacity
+	}
+	if options.TTL <= 0 {
+		options.TTL = DefaultTTL
+	}
+	if options.Now == nil {
+		options.Now = time.Now
+	}
+
+	return &CachedItemRepository{
+		next:  next,
+		cache: newLRU(options.Capacity, options.TTL, options.Now),
+	}
+}
+
+// Create inserts a new item and invalidates the cached lists
+func (r *CachedItemRepository) Create(ctx context.Context, item repository.Item) (repository.Item, error) {
+	defer r.invalidate(ctx, listKeyPrefix)
+
+	return r.next.Create(ctx, item)
+}
+
+// Update modifies an existing item and invalidates it and the cached lists.
+// The item is invalidated even on failure, so the version conflicts caused by
+// a stale cached copy resolve on the next read.
+func (r *CachedItemRepository) Update(ctx context.Context, item repository.Item) (repository.Item, error) {
+	defer r.invalidate(ctx, itemKey(item.ID), listKeyPrefix)
+
+	return r.next.Update(ctx, item)
+}
+
+// Patch modifies some fields of an item and invalidates it and the cached lists
+func (r *CachedItemRepository) Patch(ctx context.Context, id string, patch repository.ItemPatch) (repository.Item, error) {
+	defer r.invalidate(ctx, itemKey(id), listKeyPrefix)
+
+	return r.next.Patch(ctx, id, patch)
+}
+
+// Delete removes an item and invalidates it and the cached lists
+func (r *CachedItemRepository) Delete(ctx context.Context, id string, version int64) error {
+	defer r.invalidate(ctx, itemKey(id), listKeyPrefix)
+
+	return r.next.Delete(ctx, id, version)
+}
+
+// GetByID returns the cached item, reading it from the repository on a miss
+func (r *CachedItemRepository) GetByID(ctx context.Context, id string) (repository.Item, error) {
+	value, err := r.readThrough(ctx, itemKey(id), func() (any, error) {
+		return r.next.GetByID(ctx, id)
+	})
+	if err != nil {
+		return repository.Item{}, err
+	}
+
+	return cloneItem(value.(repository.Item)), nil
+}
+
+// List returns the cached page, reading it from the repository on a miss
+func (r *CachedItemRepository) List(ctx context.Context, opts repository.ListOptions) (repository.ItemPage, error) {
+	value, err := r.readThrough(ctx, listKey(opts), func() (any, error) {
+		return r.next.List(ctx, opts)
+	})
+	if err != nil {
+		return repository.ItemPage{}, err
+	}
+
+	return clonePage(value.(repository.ItemPage)), nil
+}
+
+// Search returns the cached results, searching the repository on a miss. The
+// results are stored with the lists, so they are invalidated by the same writes.
+func (r *CachedItemRepository) Search(ctx context.Context, query, listID string, limit int) ([]repository.Item, error) {
+	value, err := r.readThrough(ctx, searchKey(query, listID, limit), func() (any, error) {
+		items, err := r.next.Search(ctx, query, listID, limit)
+		return repository.ItemPage{Items: items}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return clonePage(value.(repository.ItemPage)).Items, nil
+}
+
+// BulkUpdateActive updates the items with the given IDs, invalidating them and the
+// cached lists, or the items matching a filter, invalidating the whole cache
+func (r *CachedItemRepository) BulkUpdateActive(ctx context.Context, update repository.ActiveUpdate) (repository.ActiveUpdateResult, error) {
+	if len(update.IDs) == 0 {
+		defer r.invalidate(ctx, "")
+	} else {
+		keys := make([]string, 0, len(update.IDs)+1)
+		keys = append(keys, listKeyPrefix)
+		for _, id := range update.IDs {
+			keys = append(keys, itemKey(id))
+		}
+		defer r.invalidate(ctx, keys...)
+	}
+
+	return r.next.BulkUpdateActive(ctx, update)
+}
+
+// DeleteMany removes the items matching filter; which ones is not known, so the whole cache is invalidated
+func (r *CachedItemRepository) DeleteMany(ctx context.Context, filter repository.ItemFilter) (int64, error) {
+	defer r.invalidate(ctx, "")
+
+	return r.next.DeleteMany(ctx, filter)
+}
+
+// ClearCategory removes a category from its items; which ones is not known, so the whole cache is invalidated
+func (r *CachedItemRepository) ClearCategory(ctx context.Context, categoryID string) (int64, error) {
+	defer r.invalidate(ctx, "")
+
+	return r.next.ClearCategory(ctx, categoryID)
+}
+
+// SumPrices is not cached: the totals change with every write of a priced item
+func (r *CachedItemRepository) SumPrices(ctx context.Context, filter repository.ItemFilter) ([]domain.PriceTotal, error) {
+	return r.next.SumPrices(ctx, filter)
+}
+
+// Batch applies ops and invalidates the items they touch and the cached lists
+func (r *CachedItemRepository) Batch(ctx context.Context, ops []repository.BatchOperation, stopOnError bool) ([]repository.BatchResult, error) {
+	keys := make([]string, 0, len(ops)+1)
+	keys = append(keys, listKeyPrefix)
+	for _, op := range ops {
+		if op.Type != repository.BatchCreate {
+			keys = append(keys, itemKey(op.Item.ID))
+		}
+	}
+	defer r.invalidate(ctx, keys...)
+
+	return r.next.Batch(ctx, ops, stopOnError)
+}
+
+// Stats returns the hit and miss counters and the number of cached results
+func (r *CachedItemRepository) Stats() Stats {
+	r.mu.Lock()
+	size := r.cache.len()
+	r.mu.Unlock()
+
+	return Stats{
+		Hits:   r.hits.Load(),
+		Misses: r.misses.Load(),
+		Size:   size,
+	}
+}
+
+// readThrough returns the value cached under key or loads and caches it.
+// Inside a transaction the cache is bypassed, since the transaction may see
+// changes that are not committed yet.
+func (r *CachedItemRepository) readThrough(ctx context.Context, key string, load func() (any, error)) (any, error) {
+	if inTx(ctx) {
+		return load()
+	}
+
+	r.mu.Lock()
+	value, ok := r.cache.get(key)
+	generation := r.generation
+	r.mu.Unlock()
+
+	if ok {
+		r.hits.Add(1)
+		return value, nil
+	}
+	r.misses.Add(1)
+
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	if r.generation == generation {
+		r.cache.add(key, cloneValue(value))
+	}
+	r.mu.Unlock()
+
+	return value, nil
+}
+
+// invalidate removes the entries stored under the given keys; a key ending
+// with ":" removes every entry with that prefix and "" removes everything.
+// Inside a transaction the keys are invalidated again when it finishes.
+func (r *CachedItemRepository) invalidate(ctx context.Context, keys ...string) {
+	if state := txStateFrom(ctx); state != nil {
+		state.touch(keys...)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation++
+	for _, key := range keys {
+		switch {
+		case key == "":
+			r.cache.purge()
+		case strings.HasSuffix(key, ":"):
+			r.cache.removePrefix(key)
+		default:
+			r.cache.remove(key)
+		}
+	}
+}
+
+// itemKey normalizes id, as hexadecimal IDs are case insensitive
+func itemKey(id string) string {
+	return itemKeyPrefix + strings.ToLower(id)
+}
+
+// listKey identifies a page by every list option (limit, cursor and filter)
+func listKey(opts repository.ListOptions) string {
+	// ListOptions only holds JSON-friendly fields, marshalling cannot fail
+	b, _ := json.Marshal(opts)
+	return listKeyPrefix + string(b)
+}
+
+// searchKey identifies the results of a search by its query and limit
+func searchKey(query, listID string, limit int) string {
+	return fmt.Sprintf("%ssearch:%s:%d:%s", listKeyPrefix, listID, limit, query)
+}
+
+func cloneValue(value any) any {
+	switch v := value.(type) {
+	case repository.Item:
+		return cloneItem(v)
+	case repository.ItemPage:
+		return clonePage(v)
+	}
+	return value
+}
+
+func clonePage(page repository.ItemPage) repository.ItemPage {
+	items := make([]repository.Item, len(page.Items))
+	for i, item := range page.Items {
+		items[i] = cloneItem(item)
+	}
+	return repository.ItemPage{Items: items, NextCursor: page.NextCursor}
+}
+
+func cloneItem(item repository.Item) repository.Item {
+	if item.Observation != nil {
+		observation := *item.Observation
+		item.Observation = &observation
+	}
+	if item.Quantity != nil {
+		quantity := *item.Quantity
+		item.Quantity = &quantity
+	}
+	if item.UnitPrice != nil {
+		unitPrice := *item.UnitPrice
+		item.UnitPrice = &unitPrice
+	}
+	return item
+}
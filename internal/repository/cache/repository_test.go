@@ -0,0 +1,360 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository/cache"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository/local"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository/repositorytest"
+)
+
+var errDatabase = errors.New("database error")
+
+func mockItem() repository.Item {
+	observation := "whole grain"
+	return repository.Item{ID: primitive.NewObjectID().Hex(), Name: "Bread", Active: true, Observation: &observation}
+}
+
+func TestCachedItemRepository_GetByID(t *testing.T) {
+	ctx := context.Background()
+	item := mockItem()
+
+	repoMock := new(repository.RepositoryMock)
+	repoMock.On("GetByID", ctx, item.ID).Return(item, nil).Once()
+	repo := cache.NewCachedItemRepository(repoMock, cache.Options{})
+
+	got, err := repo.GetByID(ctx, item.ID)
+	require.NoError(t, err)
+	require.Equal(t, item, got)
+
+	// Mutating the returned item must not change the cached one
+	*got.Observation = "changed"
+
+	got, err = repo.GetByID(ctx, item.ID)
+	require.NoError(t, err)
+	require.Equal(t, item, got)
+
+	require.Equal(t, cache.Stats{Hits: 1, Misses: 1, Size: 1}, repo.Stats())
+	repoMock.AssertExpectations(t)
+}
+
+func TestCachedItemRepository_ErrorsAreNotCached(t *testing.T) {
+	ctx := context.Background()
+	item := mockItem()
+
+	repoMock := new(repository.RepositoryMock)
+	repoMock.On("GetByID", ctx, item.ID).Return(repository.Item{}, repository.NewItemNotFoundError()).Once()
+	repoMock.On("GetByID", ctx, item.ID).Return(item, nil).Once()
+	repoMock.On("List", ctx, repository.ListOptions{}).Return(repository.ItemPage{}, errDatabase).Once()
+	repo := cache.NewCachedItemRepository(repoMock, cache.Options{})
+
+	_, err := repo.GetByID(ctx, item.ID)
+	require.ErrorIs(t, err, repository.NewItemNotFoundError())
+	got, err := repo.GetByID(ctx, item.ID)
+	require.NoError(t, err)
+	require.Equal(t, item, got)
+
+	_, err = repo.List(ctx, repository.ListOptions{})
+	require.ErrorIs(t, err, errDatabase)
+
+	require.Equal(t, cache.Stats{Hits: 0, Misses: 3, Size: 1}, repo.Stats())
+	repoMock.AssertExpectations(t)
+}
+
+func TestCachedItemRepository_TTL(t *testing.T) {
+	ctx := context.Background()
+	item := mockItem()
+	now := time.Now()
+
+	repoMock := new(repository.RepositoryMock)
+	repoMock.On("List", ctx, repository.ListOptions{}).Return(repository.ItemPage{Items: []repository.Item{item}}, nil).Twice()
+	repo := cache.NewCachedItemRepository(repoMock, cache.Options{
+		TTL: time.Minute,
+		Now: func() time.Time { return now },
+	})
+
+	_, err := repo.List(ctx, repository.ListOptions{})
+	require.NoError(t, err)
+	_, err = repo.List(ctx, repository.ListOptions{})
+	require.NoError(t, err)
+
+	now = now.Add(time.Minute)
+	_, err = repo.List(ctx, repository.ListOptions{})
+	require.NoError(t, err)
+
+	require.Equal(t, int64(1), repo.Stats().Hits)
+	require.Equal(t, int64(2), repo.Stats().Misses)
+	repoMock.AssertExpectations(t)
+}
+
+func TestCachedItemRepository_ListPages(t *testing.T) {
+	ctx := context.Background()
+	first := repository.ItemPage{Items: []repository.Item{mockItem()}, NextCursor: "next"}
+	second := repository.ItemPage{Items: []repository.Item{mockItem()}}
+
+	repoMock := new(repository.RepositoryMock)
+	repoMock.On("List", ctx, repository.ListOptions{Limit: 1}).Return(first, nil).Once()
+	repoMock.On("List", ctx, repository.ListOptions{Limit: 1, Cursor: "next"}).Return(second, nil).Once()
+	repo := cache.NewCachedItemRepository(repoMock, cache.Options{})
+
+	for i := 0; i < 2; i++ {
+		got, err := repo.List(ctx, repository.ListOptions{Limit: 1})
+		require.NoError(t, err)
+		require.Equal(t, first, got)
+
+		got, err = repo.List(ctx, repository.ListOptions{Limit: 1, Cursor: "next"})
+		require.NoError(t, err)
+		require.Equal(t, second, got)
+	}
+
+	require.Equal(t, cache.Stats{Hits: 2, Misses: 2, Size: 2}, repo.Stats())
+	repoMock.AssertExpectations(t)
+}
+
+func TestCachedItemRepository_Search(t *testing.T) {
+	ctx := context.Background()
+	item := mockItem()
+
+	repoMock := new(repository.RepositoryMock)
+	repoMock.On("Search", ctx, "bread", "", 10).Return([]repository.Item{item}, nil).Twice()
+	repoMock.On("Search", ctx, "bread", "", 5).Return([]repository.Item{item}, nil).Once()
+	repoMock.On("Search", ctx, "bread", "000000000000000000000010", 10).Return([]repository.Item{}, nil).Once()
+	repoMock.On("Create", ctx, mock.Anything).Return(item, nil).Once()
+	repo := cache.NewCachedItemRepository(repoMock, cache.Options{})
+
+	for i := 0; i < 2; i++ {
+		got, err := repo.Search(ctx, "bread", "", 10)
+		require.NoError(t, err)
+		require.Equal(t, []repository.Item{item}, got)
+	}
+	_, err := repo.Search(ctx, "bread", "", 5)
+	require.NoError(t, err)
+	_, err = repo.Search(ctx, "bread", "000000000000000000000010", 10)
+	require.NoError(t, err)
+
+	// A new item may match the search, so the results are invalidated like the lists
+	_, err = repo.Create(ctx, mockItem())
+	require.NoError(t, err)
+	_, err = repo.Search(ctx, "bread", "", 10)
+	require.NoError(t, err)
+
+	require.Equal(t, int64(1), repo.Stats().Hits)
+	require.Equal(t, int64(4), repo.Stats().Misses)
+	repoMock.AssertExpectations(t)
+}
+
+func TestCachedItemRepository_Invalidation(t *testing.T) {
+	ctx := context.Background()
+	item := mockItem()
+	other := mockItem()
+
+	tests := []struct {
+		name                string
+		givenWrite          func(repo repository.ItemRepository) error
+		mockSetup           func(repoMock *repository.RepositoryMock)
+		wantItemInvalidated bool
+		wantOtherCached     bool
+	}{
+		{
+			name: "Given_CachedEntries_When_Create_Then_OnlyListIsInvalidated",
+			givenWrite: func(repo repository.ItemRepository) error {
+				_, err := repo.Create(ctx, mockItem())
+				return err
+			},
+			mockSetup: func(repoMock *repository.RepositoryMock) {
+				repoMock.On("Create", ctx, mock.Anything).Return(repository.Item{}, nil)
+			},
+			wantItemInvalidated: false,
+			wantOtherCached:     true,
+		},
+		{
+			name: "Given_CachedEntries_When_Update_Then_ItemAndListAreInvalidated",
+			givenWrite: func(repo repository.ItemRepository) error {
+				_, err := repo.Update(ctx, item)
+				return err
+			},
+			mockSetup: func(repoMock *repository.RepositoryMock) {
+				repoMock.On("Update", ctx, item).Return(item, nil)
+			},
+			wantItemInvalidated: true,
+			wantOtherCached:     true,
+		},
+		{
+			name: "Given_CachedEntries_When_UpdateFails_Then_ItemAndListAreStillInvalidated",
+			givenWrite: func(repo repository.ItemRepository) error {
+				_, err := repo.Update(ctx, item)
+				return err
+			},
+			mockSetup: func(repoMock *repository.RepositoryMock) {
+				repoMock.On("Update", ctx, item).Return(repository.Item{}, errDatabase)
+			},
+			wantItemInvalidated: true,
+			wantOtherCached:     true,
+		},
+		{
+			name: "Given_CachedEntries_When_Patch_Then_ItemAndListAreInvalidated",
+			givenWrite: func(repo repository.ItemRepository) error {
+				_, err := repo.Patch(ctx, item.ID, repository.ItemPatch{SetObservation: true})
+				return err
+			},
+			mockSetup: func(repoMock *repository.RepositoryMock) {
+				repoMock.On("Patch", ctx, item.ID, repository.ItemPatch{SetObservation: true}).Return(item, nil)
+			},
+			wantItemInvalidated: true,
+			wantOtherCached:     true,
+		},
+		{
+			name: "Given_CachedEntries_When_Delete_Then_ItemAndListAreInvalidated",
+			givenWrite: func(repo repository.ItemRepository) error {
+				return repo.Delete(ctx, item.ID, 0)
+			},
+			mockSetup: func(repoMock *repository.RepositoryMock) {
+				repoMock.On("Delete", ctx, item.ID, int64(0)).Return(nil)
+			},
+			wantItemInvalidated: true,
+			wantOtherCached:     true,
+		},
+		{
+			name: "Given_CachedEntries_When_BulkUpdateActive_Then_EverythingIsInvalidated",
+			givenWrite: func(repo repository.ItemRepository) error {
+				_, err := repo.BulkUpdateActive(ctx, repository.ActiveUpdate{Active: false})
+				return err
+			},
+			mockSetup: func(repoMock *repository.RepositoryMock) {
+				repoMock.On("BulkUpdateActive", ctx, repository.ActiveUpdate{Active: false}).
+					Return(repository.ActiveUpdateResult{MatchedCount: 2, ModifiedCount: 2}, nil)
+			},
+			wantItemInvalidated: true,
+			wantOtherCached:     false,
+		},
+		{
+			name: "Given_CachedEntries_When_BulkUpdateActiveByIDs_Then_TheseItemsAndListAreInvalidated",
+			givenWrite: func(repo repository.ItemRepository) error {
+				_, err := repo.BulkUpdateActive(ctx, repository.ActiveUpdate{Active: false, IDs: []string{item.ID}})
+				return err
+			},
+			mockSetup: func(repoMock *repository.RepositoryMock) {
+				repoMock.On("BulkUpdateActive", ctx, mock.Anything).
+					Return(repository.ActiveUpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil)
+			},
+			wantItemInvalidated: true,
+			wantOtherCached:     true,
+		},
+		{
+			name: "Given_CachedEntries_When_DeleteMany_Then_EverythingIsInvalidated",
+			givenWrite: func(repo repository.ItemRepository) error {
+				_, err := repo.DeleteMany(ctx, repository.ItemFilter{Active: &item.Active})
+				return err
+			},
+			mockSetup: func(repoMock *repository.RepositoryMock) {
+				repoMock.On("DeleteMany", ctx, mock.Anything).Return(int64(1), nil)
+			},
+			wantItemInvalidated: true,
+			wantOtherCached:     false,
+		},
+		{
+			name: "Given_CachedEntries_When_Batch_Then_TouchedItemsAndListAreInvalidated",
+			givenWrite: func(repo repository.ItemRepository) error {
+				_, err := repo.Batch(ctx, []repository.BatchOperation{
+					{Type: repository.BatchCreate, Item: mockItem()},
+					{Type: repository.BatchDelete, Item: repository.Item{ID: item.ID}},
+				}, false)
+				return err
+			},
+			mockSetup: func(repoMock *repository.RepositoryMock) {
+				repoMock.On("Batch", ctx, mock.Anything, false).Return([]repository.BatchResult{{}, {}}, nil)
+			},
+			wantItemInvalidated: true,
+			wantOtherCached:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMock := new(repository.RepositoryMock)
+			repoMock.On("GetByID", ctx, item.ID).Return(item, nil)
+			repoMock.On("GetByID", ctx, other.ID).Return(other, nil)
+			repoMock.On("List", ctx, repository.ListOptions{}).Return(repository.ItemPage{Items: []repository.Item{item, other}}, nil)
+			tt.mockSetup(repoMock)
+
+			repo := cache.NewCachedItemRepository(repoMock, cache.Options{})
+			for _, read := range []func() error{
+				func() error { _, err := repo.GetByID(ctx, item.ID); return err },
+				func() error { _, err := repo.GetByID(ctx, other.ID); return err },
+				func() error { _, err := repo.List(ctx, repository.ListOptions{}); return err },
+			} {
+				require.NoError(t, read())
+			}
+			require.Equal(t, 3, repo.Stats().Size)
+
+			_ = tt.givenWrite(repo)
+
+			wantSize := 0
+			if !tt.wantItemInvalidated {
+				wantSize++
+			}
+			if tt.wantOtherCached {
+				wantSize++
+			}
+			require.Equal(t, wantSize, repo.Stats().Size)
+
+			_, err := repo.List(ctx, repository.ListOptions{})
+			require.NoError(t, err)
+			repoMock.AssertNumberOfCalls(t, "List", 2)
+		})
+	}
+}
+
+func TestCachedItemRepository_TxManager(t *testing.T) {
+	ctx := context.Background()
+	localRepository := local.NewLocalItemRepository()
+	repo := cache.NewCachedItemRepository(localRepository, cache.Options{})
+	txManager := repo.TxManager(local.NewLocalTxManager(localRepository))
+
+	item := mockItem()
+	_, err := repo.Create(ctx, item)
+	require.NoError(t, err)
+	_, err = repo.GetByID(ctx, item.ID)
+	require.NoError(t, err)
+
+	err = txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := repo.Update(ctx, repository.Item{ID: item.ID, Name: "Rye bread"}); err != nil {
+			return err
+		}
+		// Reads inside the transaction bypass the cache and see its changes
+		updated, err := repo.GetByID(ctx, item.ID)
+		require.NoError(t, err)
+		require.Equal(t, "Rye bread", updated.Name)
+
+		return errDatabase
+	})
+	require.ErrorIs(t, err, errDatabase)
+
+	// The rolled back value was never cached
+	got, err := repo.GetByID(ctx, item.ID)
+	require.NoError(t, err)
+	require.Equal(t, item.Name, got.Name)
+	require.Equal(t, cache.Stats{Hits: 0, Misses: 2, Size: 1}, repo.Stats())
+}
+
+func TestConformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.ItemRepository {
+		return cache.NewCachedItemRepository(local.NewLocalItemRepository(), cache.Options{})
+	})
+}
+
+func TestTxConformance(t *testing.T) {
+	repositorytest.RunTx(t, func(t *testing.T) (repository.ItemRepository, repository.TxManager) {
+		localRepository := local.NewLocalItemRepository()
+		repo := cache.NewCachedItemRepository(localRepository, cache.Options{})
+		return repo, repo.TxManager(local.NewLocalTxManager(localRepository))
+	})
+}
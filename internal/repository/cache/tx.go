@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+type txKey struct{}
+
+// txState collects the cache keys written during a transaction.
+type txState struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+func (s *txState) touch(keys ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys = append(s.keys, keys...)
+}
+
+func txStateFrom(ctx context.Context) *txState {
+	state, _ := ctx.Value(txKey{}).(*txState)
+	return state
+}
+
+func inTx(ctx context.Context) bool {
+	return txStateFrom(ctx) != nil
+}
+
+type cachedTxManager struct {
+	next repository.TxManager
+	repo *CachedItemRepository
+}
+
+// TxManager wraps the transaction manager of the decorated repository so that
+// reads made inside a transaction bypass the cache and the entries it wrote are
+// invalidated again once it finishes, when its changes become visible.
+func (r *CachedItemRepository) TxManager(next repository.TxManager) repository.TxManager {
+	return &cachedTxManager{
+		next: next,
+		repo: r,
+	}
+}
+
+// WithinTransaction runs fn in a transaction of the wrapped manager
+func (m *cachedTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if inTx(ctx) {
+		return m.next.WithinTransaction(ctx, fn)
+	}
+
+	state := &txState{}
+	defer func() {
+		if len(state.keys) > 0 {
+			m.repo.invalidate(context.Background(), state.keys...)
+		}
+	}()
+
+	return m.next.WithinTransaction(ctx, func(ctx context.Context) error {
+		return fn(context.WithValue(ctx, txKey{}, state))
+	})
+}
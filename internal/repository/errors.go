@@ -6,6 +6,14 @@ import (
 	"net/http"
 )
 
+// ErrVersionConflict is the cause of the error returned when the stored version
+// of an item is not the one the change was based on
+var ErrVersionConflict = errors.New("item version does not match")
+
+// ErrPositionConflict is the cause of the error returned when another item of
+// the list already has the position an item is given
+var ErrPositionConflict = errors.New("item position is taken")
+
 type Error struct {
 	Cause   error
 	Message string
@@ -27,6 +35,36 @@ func NewItemNotFoundError() error {
 	}
 }
 
+func NewListNotFoundError() error {
+	return Error{
+		Message: "list not found",
+		HTTP:    http.StatusNotFound,
+	}
+}
+
+func NewCategoryNotFoundError() error {
+	return Error{
+		Message: "category not found",
+		HTTP:    http.StatusNotFound,
+	}
+}
+
+func NewVersionConflictError() error {
+	return Error{
+		Cause:   ErrVersionConflict,
+		Message: "item was changed by someone else",
+		HTTP:    http.StatusPreconditionFailed,
+	}
+}
+
+func NewPositionConflictError() error {
+	return Error{
+		Cause:   ErrPositionConflict,
+		Message: "another item of the list has this position",
+		HTTP:    http.StatusConflict,
+	}
+}
+
 func NewInvalidHexIDError() error {
 	return Error{
 		Message: "invalid hexadecimal representation of an ObjectID",
@@ -34,6 +72,22 @@ func NewInvalidHexIDError() error {
 	}
 }
 
+func NewInvalidCursorError(cause error) error {
+	return Error{
+		Cause:   cause,
+		Message: "invalid cursor",
+		HTTP:    http.StatusBadRequest,
+	}
+}
+
+func NewInvalidSortError(cause error) error {
+	return Error{
+		Cause:   cause,
+		Message: "invalid sort",
+		HTTP:    http.StatusBadRequest,
+	}
+}
+
 func NewGenericRepositoryError(cause error) error {
 	return Error{
 		Cause:   cause,
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// IdempotencyRecord is a request sent with an Idempotency-Key and, once it is
+// completed, the response replayed to the retries of the same request.
+type IdempotencyRecord struct {
+	Key string `bson:"_id"`
+	// RequestHash identifies the method, path and body of the request the key was first used with
+	RequestHash string `bson:"requestHash"`
+	// Completed is false while the first request is still being processed
+	Completed  bool        `bson:"completed"`
+	StatusCode int         `bson:"statusCode,omitempty"`
+	Header     http.Header `bson:"header,omitempty"`
+	Body       []byte      `bson:"body,omitempty"`
+	// ExpiresAt ends the short lease of a record still being processed, then
+	// how long the response of a completed record is replayed
+	ExpiresAt time.Time `bson:"expiresAt"`
+}
+
+// IdempotencyStore keeps the requests sent with an Idempotency-Key until they expire
+type IdempotencyStore interface {
+	// Reserve claims record.Key for a new request. When the key is already
+	// claimed and not expired, nothing is written and the stored record is
+	// returned with reserved false. A record whose lease expired before it was
+	// completed, e.g. after a crash, is taken over.
+	Reserve(ctx context.Context, record IdempotencyRecord) (stored IdempotencyRecord, reserved bool, err error)
+
+	// Complete stores the response of the request that reserved record.Key and
+	// extends its expiration to record.ExpiresAt
+	Complete(ctx context.Context, record IdempotencyRecord) error
+
+	// Release removes a key, so that the request can be retried with it
+	Release(ctx context.Context, key string) error
+}
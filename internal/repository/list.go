@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ListOptions selects the page of items returned by List.
+// Pages are keyset-based (they start after the sort keys of the previous page's
+// last item), so they stay stable while items are inserted.
+type ListOptions struct {
+	// Limit is the maximum number of items returned; 0 returns every remaining item.
+	Limit int
+	// Cursor is the NextCursor of the previous page; empty starts from the first item.
+	Cursor string
+	// Filter restricts the listed items; the zero value lists every item.
+	Filter ItemFilter
+	// Sort is the listing order, DefaultSort when empty. The ID is always the last key.
+	Sort []SortField
+}
+
+// ItemFilter restricts the items returned by List. Every set field must match.
+type ItemFilter struct {
+	// ListID matches the items of this list
+	ListID string `json:"listId,omitempty"`
+	// Active matches the items with this active value
+	Active *bool `json:"active,omitempty"`
+	// Name matches the items whose name contains it, ignoring case
+	Name string `json:"name,omitempty"`
+	// HasObservation matches the items with (true) or without (false) a non-empty observation
+	HasObservation *bool `json:"hasObservation,omitempty"`
+	// CreatedAfter matches the items created strictly after it
+	CreatedAfter *time.Time `json:"createdAfter,omitempty"`
+	// UpdatedBefore matches the items updated strictly before it
+	UpdatedBefore *time.Time `json:"updatedBefore,omitempty"`
+}
+
+// Match reports whether item matches every condition of the filter.
+// Backends that cannot push the filter down to the database use it.
+func (f ItemFilter) Match(item Item) bool {
+	if f.ListID != "" && item.ListID != f.ListID {
+		return false
+	}
+	if f.Active != nil && item.Active != *f.Active {
+		return false
+	}
+	if f.Name != "" && !strings.Contains(strings.ToLower(item.Name), strings.ToLower(f.Name)) {
+		return false
+	}
+	if f.HasObservation != nil && (item.Observation != nil && *item.Observation != "") != *f.HasObservation {
+		return false
+	}
+	if f.CreatedAfter != nil && !item.CreatedAt.After(*f.CreatedAfter) {
+		return false
+	}
+	if f.UpdatedBefore != nil && !item.UpdatedAt.Before(*f.UpdatedBefore) {
+		return false
+	}
+	return true
+}
+
+// ItemPage is a page of items returned by List.
+type ItemPage struct {
+	Items []Item
+	// NextCursor is the cursor of the following page, empty on the last one.
+	NextCursor string
+}
+
+// Cursor is the decoded position after which a page starts: the sort keys of the
+// last item of the previous page and the sort they were read with.
+type Cursor struct {
+	Sort      string    `json:"s"`
+	ID        string    `json:"i"`
+	Name      string    `json:"n,omitempty"`
+	Active    bool      `json:"a,omitempty"`
+	Position  string    `json:"p,omitempty"`
+	CreatedAt time.Time `json:"c"`
+	UpdatedAt time.Time `json:"u"`
+}
+
+// EncodeCursor returns the opaque cursor pointing after item in the given order.
+func EncodeCursor(item Item, sort []SortField) string {
+	// Marshalling strings, a bool and times cannot fail
+	b, _ := json.Marshal(Cursor{
+		Sort:      SortString(sort),
+		ID:        item.ID,
+		Name:      item.Name,
+		Active:    item.Active,
+		Position:  item.Position,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+	})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses a cursor returned by EncodeCursor for the same sort.
+// An empty cursor returns a nil Cursor (first page).
+func DecodeCursor(cursor string, sort []SortField) (*Cursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, NewInvalidCursorError(err)
+	}
+
+	var c Cursor
+	if err = json.Unmarshal(b, &c); err != nil || c.ID == "" {
+		return nil, NewInvalidCursorError(err)
+	}
+	if c.Sort != SortString(sort) {
+		return nil, NewInvalidCursorError(fmt.Errorf("cursor of sort %q used with sort %q", c.Sort, SortString(sort)))
+	}
+
+	return &c, nil
+}
+
+// Item returns the cursor position as an item holding its sort keys.
+func (c *Cursor) Item() Item {
+	return Item{
+		ID:        c.ID,
+		Name:      c.Name,
+		Active:    c.Active,
+		Position:  c.Position,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+// After reports whether item comes after the cursor position in the given order.
+func (c *Cursor) After(item Item, sort []SortField) bool {
+	return c == nil || CompareItems(item, c.Item(), sort) > 0
+}
+
+// NewItemPage builds the page from items read in the given order with a limit
+// of limit+1: the extra item only tells that there is a next page.
+func NewItemPage(items []Item, limit int, sort []SortField) ItemPage {
+	if items == nil {
+		items = make([]Item, 0)
+	}
+	if limit <= 0 || len(items) <= limit {
+		return ItemPage{Items: items}
+	}
+
+	items = items[:limit]
+	return ItemPage{
+		Items:      items,
+		NextCursor: EncodeCursor(items[limit-1], sort),
+	}
+}
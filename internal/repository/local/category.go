@@ -0,0 +1,172 @@
+package local
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+// LocalCategoryRepository implements repository.CategoryRepository in memory
+type LocalCategoryRepository struct {
+	// tx is shared with the other stores of the transactions (see LocalTxManager)
+	tx         *txLock
+	mu         sync.RWMutex
+	categories map[string]repository.Category
+}
+
+// NewLocalCategoryRepository creates a new instance of LocalCategoryRepository
+func NewLocalCategoryRepository() *LocalCategoryRepository {
+	return &LocalCategoryRepository{
+		tx:         &txLock{},
+		categories: make(map[string]repository.Category),
+	}
+}
+
+// Create inserts a new category in the in-memory repository
+func (r *LocalCategoryRepository) Create(ctx context.Context, category repository.Category) (repository.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return repository.Category{}, repository.HandleError(err)
+	}
+
+	defer r.tx.lockOutsideTx(ctx)()
+
+	id, err := normalizeID(category.ID)
+	if err != nil {
+		return repository.Category{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.categories[id]; ok {
+		return repository.Category{}, repository.HandleError(errDuplicateKey)
+	}
+
+	now := now()
+	stored := repository.Category{
+		ID:        id,
+		Name:      category.Name,
+		Position:  category.Position,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	r.categories[id] = stored
+
+	return stored, nil
+}
+
+// Update renames and moves a category of the in-memory repository
+func (r *LocalCategoryRepository) Update(ctx context.Context, category repository.Category) (repository.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return repository.Category{}, repository.HandleError(err)
+	}
+
+	defer r.tx.lockOutsideTx(ctx)()
+
+	id, err := normalizeID(category.ID)
+	if err != nil {
+		return repository.Category{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.categories[id]
+	if !ok {
+		return repository.Category{}, repository.NewCategoryNotFoundError()
+	}
+
+	stored.Name = category.Name
+	if category.Position != 0 {
+		stored.Position = category.Position
+	}
+	stored.UpdatedAt = now()
+	r.categories[id] = stored
+
+	return stored, nil
+}
+
+// Delete removes a category from the in-memory repository
+func (r *LocalCategoryRepository) Delete(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return repository.HandleError(err)
+	}
+
+	defer r.tx.lockOutsideTx(ctx)()
+
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.categories[id]; !ok {
+		return repository.NewCategoryNotFoundError()
+	}
+	delete(r.categories, id)
+
+	return nil
+}
+
+// Lock reports a missing category. The transactions of LocalTxManager already run
+// one at a time, so nothing else is needed to keep the category until they end.
+func (r *LocalCategoryRepository) Lock(ctx context.Context, id string) error {
+	_, err := r.GetByID(ctx, id)
+	return err
+}
+
+// GetByID retrieves a category by its ID from the in-memory repository
+func (r *LocalCategoryRepository) GetByID(ctx context.Context, id string) (repository.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return repository.Category{}, repository.HandleError(err)
+	}
+
+	defer r.tx.lockOutsideTx(ctx)()
+
+	id, err := normalizeID(id)
+	if err != nil {
+		return repository.Category{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	category, ok := r.categories[id]
+	if !ok {
+		return repository.Category{}, repository.NewCategoryNotFoundError()
+	}
+
+	return category, nil
+}
+
+// List retrieves every category from the in-memory repository, ordered by position, creation time and ID
+func (r *LocalCategoryRepository) List(ctx context.Context) ([]repository.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, repository.HandleError(err)
+	}
+
+	defer r.tx.lockOutsideTx(ctx)()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	categories := make([]repository.Category, 0, len(r.categories))
+	for _, category := range r.categories {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].Position != categories[j].Position {
+			return categories[i].Position < categories[j].Position
+		}
+		if !categories[i].CreatedAt.Equal(categories[j].CreatedAt) {
+			return categories[i].CreatedAt.Before(categories[j].CreatedAt)
+		}
+		return categories[i].ID < categories[j].ID
+	})
+
+	return categories, nil
+}
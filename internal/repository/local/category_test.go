@@ -0,0 +1,15 @@
+package local_test
+
+import (
+	"testing"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository/local"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository/repositorytest"
+)
+
+func TestCategoryConformance(t *testing.T) {
+	repositorytest.RunCategory(t, func(t *testing.T) repository.CategoryRepository {
+		return local.NewLocalCategoryRepository()
+	})
+}
@@ -0,0 +1,82 @@
+package local
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+// LocalIdempotencyStore implements repository.IdempotencyStore in memory.
+// Expired keys are removed when a new key is reserved.
+type LocalIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]repository.IdempotencyRecord
+}
+
+// NewLocalIdempotencyStore creates a new instance of LocalIdempotencyStore
+func NewLocalIdempotencyStore() *LocalIdempotencyStore {
+	return &LocalIdempotencyStore{
+		records: make(map[string]repository.IdempotencyRecord),
+	}
+}
+
+// Reserve claims record.Key unless it is already claimed and not expired
+func (s *LocalIdempotencyStore) Reserve(ctx context.Context, record repository.IdempotencyRecord) (repository.IdempotencyRecord, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return repository.IdempotencyRecord{}, false, repository.HandleError(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := now()
+	if stored, ok := s.records[record.Key]; ok && now.Before(stored.ExpiresAt) {
+		return cloneIdempotencyRecord(stored), false, nil
+	}
+
+	for key, stored := range s.records {
+		if !now.Before(stored.ExpiresAt) {
+			delete(s.records, key)
+		}
+	}
+	s.records[record.Key] = cloneIdempotencyRecord(record)
+
+	return repository.IdempotencyRecord{}, true, nil
+}
+
+// Complete stores the response of the request that reserved record.Key
+func (s *LocalIdempotencyStore) Complete(ctx context.Context, record repository.IdempotencyRecord) error {
+	if err := ctx.Err(); err != nil {
+		return repository.HandleError(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[record.Key] = cloneIdempotencyRecord(record)
+
+	return nil
+}
+
+// Release removes a key
+func (s *LocalIdempotencyStore) Release(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return repository.HandleError(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+
+	return nil
+}
+
+// cloneIdempotencyRecord copies the header and body, so callers never share them with the store
+func cloneIdempotencyRecord(record repository.IdempotencyRecord) repository.IdempotencyRecord {
+	record.Header = record.Header.Clone()
+	record.Body = bytes.Clone(record.Body)
+	return record
+}
@@ -0,0 +1,119 @@
+package local_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository/local"
+)
+
+func mockIdempotencyRecord(expiresAt time.Time) repository.IdempotencyRecord {
+	return repository.IdempotencyRecord{Key: "key-1", RequestHash: "hash-1", ExpiresAt: expiresAt}
+}
+
+func TestLocalIdempotencyStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Given_NewKey_When_Reserve_Then_KeyIsReserved", func(t *testing.T) {
+		store := local.NewLocalIdempotencyStore()
+
+		_, reserved, err := store.Reserve(ctx, mockIdempotencyRecord(time.Now().Add(time.Hour)))
+		require.NoError(t, err)
+		require.True(t, reserved)
+	})
+
+	t.Run("Given_ReservedKey_When_Reserve_Then_StoredRecordIsReturned", func(t *testing.T) {
+		store := local.NewLocalIdempotencyStore()
+		record := mockIdempotencyRecord(time.Now().Add(time.Hour))
+
+		_, _, err := store.Reserve(ctx, record)
+		require.NoError(t, err)
+
+		stored, reserved, err := store.Reserve(ctx, repository.IdempotencyRecord{Key: "key-1", RequestHash: "hash-2", ExpiresAt: time.Now().Add(time.Hour)})
+		require.NoError(t, err)
+		require.False(t, reserved)
+		require.Equal(t, record, stored)
+	})
+
+	t.Run("Given_CompletedKey_When_Reserve_Then_StoredResponseIsReturned", func(t *testing.T) {
+		store := local.NewLocalIdempotencyStore()
+		record := mockIdempotencyRecord(time.Now().Add(time.Hour))
+
+		_, _, err := store.Reserve(ctx, record)
+		require.NoError(t, err)
+
+		record.Completed = true
+		record.StatusCode = http.StatusCreated
+		record.Header = http.Header{"Content-Type": {"application/json"}}
+		record.Body = []byte(`{"id":"1"}`)
+		require.NoError(t, store.Complete(ctx, record))
+
+		// The store keeps its own copy of the body
+		record.Body[0] = 'x'
+
+		stored, reserved, err := store.Reserve(ctx, mockIdempotencyRecord(time.Now().Add(time.Hour)))
+		require.NoError(t, err)
+		require.False(t, reserved)
+		require.True(t, stored.Completed)
+		require.Equal(t, http.StatusCreated, stored.StatusCode)
+		require.Equal(t, "application/json", stored.Header.Get("Content-Type"))
+		require.Equal(t, `{"id":"1"}`, string(stored.Body))
+	})
+
+	t.Run("Given_ExpiredKey_When_Reserve_Then_KeyIsReservedAgain", func(t *testing.T) {
+		store := local.NewLocalIdempotencyStore()
+
+		_, _, err := store.Reserve(ctx, mockIdempotencyRecord(time.Now().Add(-time.Second)))
+		require.NoError(t, err)
+
+		_, reserved, err := store.Reserve(ctx, mockIdempotencyRecord(time.Now().Add(time.Hour)))
+		require.NoError(t, err)
+		require.True(t, reserved)
+	})
+
+	t.Run("Given_CompletedKeyPastItsLease_When_Reserve_Then_StoredResponseIsReturned", func(t *testing.T) {
+		store := local.NewLocalIdempotencyStore()
+		record := mockIdempotencyRecord(time.Now().Add(-time.Second))
+
+		_, _, err := store.Reserve(ctx, record)
+		require.NoError(t, err)
+
+		record.Completed = true
+		record.StatusCode = http.StatusCreated
+		record.ExpiresAt = time.Now().Add(time.Hour)
+		require.NoError(t, store.Complete(ctx, record))
+
+		stored, reserved, err := store.Reserve(ctx, mockIdempotencyRecord(time.Now().Add(time.Hour)))
+		require.NoError(t, err)
+		require.False(t, reserved)
+		require.True(t, stored.Completed)
+	})
+
+	t.Run("Given_ReleasedKey_When_Reserve_Then_KeyIsReservedAgain", func(t *testing.T) {
+		store := local.NewLocalIdempotencyStore()
+
+		_, _, err := store.Reserve(ctx, mockIdempotencyRecord(time.Now().Add(time.Hour)))
+		require.NoError(t, err)
+		require.NoError(t, store.Release(ctx, "key-1"))
+
+		_, reserved, err := store.Reserve(ctx, mockIdempotencyRecord(time.Now().Add(time.Hour)))
+		require.NoError(t, err)
+		require.True(t, reserved)
+	})
+
+	t.Run("Given_CanceledContext_When_Reserve_Then_ReturnsError", func(t *testing.T) {
+		store := local.NewLocalIdempotencyStore()
+
+		canceledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, reserved, err := store.Reserve(canceledCtx, mockIdempotencyRecord(time.Now().Add(time.Hour)))
+		require.Error(t, err)
+		require.False(t, reserved)
+	})
+}
@@ -0,0 +1,174 @@
+package local
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+// LocalListRepository implements repository.ListRepository in memory.
+// Like the database migrations, it starts with the default list.
+type LocalListRepository struct {
+	// tx is shared with the other stores of the transactions (see LocalTxManager)
+	tx    *txLock
+	mu    sync.RWMutex
+	lists map[string]repository.List
+}
+
+// NewLocalListRepository creates a new instance of LocalListRepository
+func NewLocalListRepository() *LocalListRepository {
+	now := now()
+	return &LocalListRepository{
+		tx: &txLock{},
+		lists: map[string]repository.List{
+			repository.DefaultListID: {
+				ID:        repository.DefaultListID,
+				Name:      repository.DefaultListName,
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+		},
+	}
+}
+
+// Create inserts a new list in the in-memory repository
+func (r *LocalListRepository) Create(ctx context.Context, list repository.List) (repository.List, error) {
+	if err := ctx.Err(); err != nil {
+		return repository.List{}, repository.HandleError(err)
+	}
+
+	defer r.tx.lockOutsideTx(ctx)()
+
+	id, err := normalizeID(list.ID)
+	if err != nil {
+		return repository.List{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.lists[id]; ok {
+		return repository.List{}, repository.HandleError(errDuplicateKey)
+	}
+
+	now := now()
+	stored := repository.List{
+		ID:        id,
+		Name:      list.Name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	r.lists[id] = stored
+
+	return stored, nil
+}
+
+// Update renames a list of the in-memory repository
+func (r *LocalListRepository) Update(ctx context.Context, list repository.List) (repository.List, error) {
+	if err := ctx.Err(); err != nil {
+		return repository.List{}, repository.HandleError(err)
+	}
+
+	defer r.tx.lockOutsideTx(ctx)()
+
+	id, err := normalizeID(list.ID)
+	if err != nil {
+		return repository.List{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.lists[id]
+	if !ok {
+		return repository.List{}, repository.NewListNotFoundError()
+	}
+
+	stored.Name = list.Name
+	stored.UpdatedAt = now()
+	r.lists[id] = stored
+
+	return stored, nil
+}
+
+// Delete removes a list from the in-memory repository
+func (r *LocalListRepository) Delete(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return repository.HandleError(err)
+	}
+
+	defer r.tx.lockOutsideTx(ctx)()
+
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.lists[id]; !ok {
+		return repository.NewListNotFoundError()
+	}
+	delete(r.lists, id)
+
+	return nil
+}
+
+// Lock reports a missing list. The transactions of LocalTxManager already run
+// one at a time, so nothing else is needed to keep the list until they end.
+func (r *LocalListRepository) Lock(ctx context.Context, id string) error {
+	_, err := r.GetByID(ctx, id)
+	return err
+}
+
+// GetByID retrieves a list by its ID from the in-memory repository
+func (r *LocalListRepository) GetByID(ctx context.Context, id string) (repository.List, error) {
+	if err := ctx.Err(); err != nil {
+		return repository.List{}, repository.HandleError(err)
+	}
+
+	defer r.tx.lockOutsideTx(ctx)()
+
+	id, err := normalizeID(id)
+	if err != nil {
+		return repository.List{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list, ok := r.lists[id]
+	if !ok {
+		return repository.List{}, repository.NewListNotFoundError()
+	}
+
+	return list, nil
+}
+
+// List retrieves every list from the in-memory repository, ordered by creation time and ID
+func (r *LocalListRepository) List(ctx context.Context) ([]repository.List, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, repository.HandleError(err)
+	}
+
+	defer r.tx.lockOutsideTx(ctx)()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	lists := make([]repository.List, 0, len(r.lists))
+	for _, list := range r.lists {
+		lists = append(lists, list)
+	}
+	sort.Slice(lists, func(i, j int) bool {
+		if !lists[i].CreatedAt.Equal(lists[j].CreatedAt) {
+			return lists[i].CreatedAt.Before(lists[j].CreatedAt)
+		}
+		return lists[i].ID < lists[j].ID
+	})
+
+	return lists, nil
+}
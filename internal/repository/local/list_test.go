@@ -0,0 +1,28 @@
+package local_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository/local"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository/repositorytest"
+)
+
+func TestNewLocalListRepository(t *testing.T) {
+	repo := local.NewLocalListRepository()
+
+	lists, err := repo.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, lists, 1)
+	require.Equal(t, repository.DefaultListID, lists[0].ID)
+	require.Equal(t, repository.DefaultListName, lists[0].Name)
+}
+
+func TestListConformance(t *testing.T) {
+	repositorytest.RunList(t, func(t *testing.T) repository.ListRepository {
+		return local.NewLocalListRepository()
+	})
+}
@@ -1,49 +1,511 @@
 package local
 
-//TODO this file is used only for save in memory database
-// import (
-// 	"context"
-
-// 	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
-// )
-
-// type localRepository struct {
-// 	items map[string]repository.Item
-// }
-
-// func NewLocalRepository() repository.ItemRepository {
-// 	return &localRepository{
-// 		items: make(map[string]repository.Item),
-// 	}
-// }
-
-// func (r *localRepository) Create(ctx context.Context, item repository.Item) (repository.Item, error) {
-// 	r.items[item.ID] = item
-// 	return item, nil
-// }
-
-// func (r *localRepository) Update(ctx context.Context, item repository.Item) (repository.Item, error) {
-// 	r.items[item.ID] = item
-// 	return item, nil
-// }
-
-// func (r *localRepository) Delete(ctx context.Context, id string) error {
-// 	delete(r.items, id)
-// 	return nil
-// }
-
-// func (r *localRepository) GetByID(ctx context.Context, id string) (repository.Item, error) {
-// 	item, ok := r.items[id]
-// 	if !ok {
-// 		return repository.Item{}, repository.NewRepositoryError(repository.ErrItemNotFound)
-// 	}
-// 	return item, nil
-// }
-
-// func (r *localRepository) List(ctx context.Context) ([]repository.Item, error) {
-// 	items := make([]repository.Item, 0, len(r.items))
-// 	for _, item := range r.items {
-// 		items = append(items, item)
-// 	}
-// 	return items, nil
-// }
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+var (
+	errDuplicateKey = errors.New("duplicate key: item already exists")
+)
+
+// LocalItemRepository implements repository.ItemRepository in memory.
+// It mirrors the behavior of the MongoDB repository so the API can run
+// without an external database (e.g. local frontend development).
+type LocalItemRepository struct {
+	// tx is held exclusively by a running transaction (see LocalTxManager)
+	// and shared by the operations made outside of it.
+	tx    *txLock
+	mu    sync.RWMutex
+	items map[string]repository.Item
+	order []string
+}
+
+// NewLocalItemRepository creates a new instance of LocalItemRepository
+func NewLocalItemRepository() *LocalItemRepository {
+	return &LocalItemRepository{
+		tx:    &txLock{},
+		items: make(map[string]repository.Item),
+	}
+}
+
+// Create inserts a new item in the in-memory repository
+func (r *LocalItemRepository) Create(ctx context.Context, item repository.Item) (repository.Item, error) {
+	if err := ctx.Err(); err != nil {
+		return repository.Item{}, repository.HandleError(err)
+	}
+
+	defer r.tx.lockOutsideTx(ctx)()
+
+	id, err := normalizeID(item.ID)
+	if err != nil {
+		return repository.Item{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.items[id]; ok {
+		return repository.Item{}, repository.HandleError(errDuplicateKey)
+	}
+	if r.positionTaken(item.ListID, item.Position, id) {
+		return repository.Item{}, repository.NewPositionConflictError()
+	}
+
+	now := now()
+	stored := repository.Item{
+		ID:          id,
+		ListID:      item.ListID,
+		Name:        item.Name,
+		Active:      item.Active,
+		Observation: copyString(item.Observation),
+		CategoryID:  item.CategoryID,
+		Position:    item.Position,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Version:     1,
+	}
+	if item.Quantity != nil {
+		stored.Quantity = copyInt(item.Quantity)
+		stored.Unit = item.Unit
+	}
+	if item.UnitPrice != nil {
+		stored.UnitPrice = copyInt(item.UnitPrice)
+		stored.Currency = item.Currency
+	}
+	r.items[id] = stored
+	r.order = append(r.order, id)
+
+	return cloneItem(stored), nil
+}
+
+// Update modifies an existing item in the in-memory repository and returns the stored item
+func (r *LocalItemRepository) Update(ctx context.Context, item repository.Item) (repository.Item, error) {
+	if err := ctx.Err(); err != nil {
+		return repository.Item{}, repository.HandleError(err)
+	}
+
+	defer r.tx.lockOutsideTx(ctx)()
+
+	id, err := normalizeID(item.ID)
+	if err != nil {
+		return repository.Item{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.items[id]
+	if !ok {
+		return repository.Item{}, repository.NewItemNotFoundError()
+	}
+	if item.Version != 0 && item.Version != stored.Version {
+		return repository.Item{}, repository.NewVersionConflictError()
+	}
+
+	stored.Name = item.Name
+	stored.Active = item.Active
+	stored.UpdatedAt = now()
+	stored.Version++
+	// Same as the MongoDB repository: a nil observation, quantity or unit price, or an empty category, keeps the stored one
+	if item.Observation != nil {
+		stored.Observation = copyString(item.Observation)
+	}
+	if item.Quantity != nil {
+		stored.Quantity = copyInt(item.Quantity)
+		stored.Unit = item.Unit
+	}
+	if item.UnitPrice != nil {
+		stored.UnitPrice = copyInt(item.UnitPrice)
+		stored.Currency = item.Currency
+	}
+	if item.CategoryID != "" {
+		stored.CategoryID = item.CategoryID
+	}
+	r.items[id] = stored
+
+	return cloneItem(stored), nil
+}
+
+// Patch changes only the fields set in patch of an item in the in-memory repository
+func (r *LocalItemRepository) Patch(ctx context.Context, id string, patch repository.ItemPatch) (repository.Item, error) {
+	if err := ctx.Err(); err != nil {
+		return repository.Item{}, repository.HandleError(err)
+	}
+
+	defer r.tx.lockOutsideTx(ctx)()
+
+	id, err := normalizeID(id)
+	if err != nil {
+		return repository.Item{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.items[id]
+	if !ok {
+		return repository.Item{}, repository.NewItemNotFoundError()
+	}
+	if patch.Version != 0 && patch.Version != stored.Version {
+		return repository.Item{}, repository.NewVersionConflictError()
+	}
+	if patch.Position != nil && r.positionTaken(stored.ListID, *patch.Position, id) {
+		return repository.Item{}, repository.NewPositionConflictError()
+	}
+
+	if patch.Name != nil {
+		stored.Name = *patch.Name
+	}
+	if patch.Active != nil {
+		stored.Active = *patch.Active
+	}
+	if patch.Position != nil {
+		stored.Position = *patch.Position
+	}
+	if patch.SetObservation {
+		stored.Observation = copyString(patch.Observation)
+	}
+	if patch.SetQuantity {
+		stored.Quantity = copyInt(patch.Quantity)
+		stored.Unit = ""
+		if patch.Quantity != nil {
+			stored.Unit = patch.Unit
+		}
+	}
+	if patch.SetPrice {
+		stored.UnitPrice = copyInt(patch.UnitPrice)
+		stored.Currency = ""
+		if patch.UnitPrice != nil {
+			stored.Currency = patch.Currency
+		}
+	}
+	if patch.SetCategory {
+		stored.CategoryID = ""
+		if patch.CategoryID != nil {
+			stored.CategoryID = *patch.CategoryID
+		}
+	}
+	stored.UpdatedAt = now()
+	stored.Version++
+	r.items[id] = stored
+
+	return cloneItem(stored), nil
+}
+
+// Delete removes an item from the in-memory repository
+func (r *LocalItemRepository) Delete(ctx context.Context, id string, version int64) error {
+	if err := ctx.Err(); err != nil {
+		return repository.HandleError(err)
+	}
+
+	defer r.tx.lockOutsideTx(ctx)()
+
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.items[id]
+	if !ok {
+		return repository.NewItemNotFoundError()
+	}
+	if version != 0 && version != stored.Version {
+		return repository.NewVersionConflictError()
+	}
+
+	delete(r.items, id)
+	for i, orderedID := range r.order {
+		if orderedID == id {
+			r.order = append(r.order[:i], r.order[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+// GetByID retrieves an item by its ID from the in-memory repository
+func (r *LocalItemRepository) GetByID(ctx context.Context, id string) (repository.Item, error) {
+	if err := ctx.Err(); err != nil {
+		return repository.Item{}, repository.HandleError(err)
+	}
+
+	defer r.tx.lockOutsideTx(ctx)()
+
+	id, err := normalizeID(id)
+	if err != nil {
+		return repository.Item{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	item, ok := r.items[id]
+	if !ok {
+		return repository.Item{}, repository.NewItemNotFoundError()
+	}
+
+	return cloneItem(item), nil
+}
+
+// List retrieves a page of the items matching opts.Filter from the in-memory repository, ordered by opts.Sort and ID
+func (r *LocalItemRepository) List(ctx context.Context, opts repository.ListOptions) (repository.ItemPage, error) {
+	if err := ctx.Err(); err != nil {
+		return repository.ItemPage{}, repository.HandleError(err)
+	}
+
+	sortFields, err := repository.NormalizeSort(opts.Sort)
+	if err != nil {
+		return repository.ItemPage{}, err
+	}
+	cursor, err := repository.DecodeCursor(opts.Cursor, sortFields)
+	if err != nil {
+		return repository.ItemPage{}, err
+	}
+
+	defer r.tx.lockOutsideTx(ctx)()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := make([]repository.Item, 0, len(r.order))
+	for _, id := range r.order {
+		if item := r.items[id]; cursor.After(item, sortFields) && opts.Filter.Match(item) {
+			items = append(items, cloneItem(item))
+		}
+	}
+
+	// Same order as the database backends, including the name collation
+	sort.Slice(items, func(i, j int) bool {
+		return repository.CompareItems(items[i], items[j], sortFields) < 0
+	})
+	if opts.Limit > 0 && len(items) > opts.Limit+1 {
+		items = items[:opts.Limit+1]
+	}
+
+	return repository.NewItemPage(items, opts.Limit, sortFields), nil
+}
+
+// Search retrieves the items matching query from the in-memory repository with the pure-Go search
+func (r *LocalItemRepository) Search(ctx context.Context, query, listID string, limit int) ([]repository.Item, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, repository.HandleError(err)
+	}
+
+	defer r.tx.lockOutsideTx(ctx)()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := make([]repository.Item, 0, len(r.order))
+	for _, id := range r.order {
+		if item := r.items[id]; listID == "" || item.ListID == listID {
+			items = append(items, item)
+		}
+	}
+
+	found := repository.SearchItems(items, query, limit)
+	for i, item := range found {
+		found[i] = cloneItem(item)
+	}
+	return found, nil
+}
+
+// BulkUpdateActive updates the active field of the selected items in the in-memory repository.
+// As in MongoDB, every matched item is reported as modified because updatedAt always changes.
+func (r *LocalItemRepository) BulkUpdateActive(ctx context.Context, update repository.ActiveUpdate) (repository.ActiveUpdateResult, error) {
+	if err := ctx.Err(); err != nil {
+		return repository.ActiveUpdateResult{}, repository.HandleError(err)
+	}
+
+	ids := make([]string, len(update.IDs))
+	for i, id := range update.IDs {
+		normalized, err := normalizeID(id)
+		if err != nil {
+			return repository.ActiveUpdateResult{}, err
+		}
+		ids[i] = normalized
+	}
+
+	defer r.tx.lockOutsideTx(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		result repository.ActiveUpdateResult
+		now    = now()
+	)
+	setActive := func(id string, item repository.Item) {
+		item.Active = update.Active
+		item.UpdatedAt = now
+		item.Version++
+		r.items[id] = item
+		result.MatchedCount++
+	}
+
+	if len(ids) > 0 {
+		for i, id := range ids {
+			if item, ok := r.items[id]; ok && (update.Filter.ListID == "" || item.ListID == update.Filter.ListID) {
+				setActive(id, item)
+			} else {
+				result.NotFoundIDs = append(result.NotFoundIDs, update.IDs[i])
+			}
+		}
+	} else {
+		for id, item := range r.items {
+			if update.Filter.Match(item) {
+				setActive(id, item)
+			}
+		}
+	}
+	result.ModifiedCount = result.MatchedCount
+
+	return result, nil
+}
+
+// DeleteMany removes the items of the in-memory repository matching filter
+func (r *LocalItemRepository) DeleteMany(ctx context.Context, filter repository.ItemFilter) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, repository.HandleError(err)
+	}
+
+	defer r.tx.lockOutsideTx(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.order[:0]
+	for _, id := range r.order {
+		if filter.Match(r.items[id]) {
+			delete(r.items, id)
+		} else {
+			kept = append(kept, id)
+		}
+	}
+	deletedCount := int64(len(r.order) - len(kept))
+	r.order = kept
+
+	return deletedCount, nil
+}
+
+// ClearCategory removes the category of the items of the in-memory repository in categoryID
+func (r *LocalItemRepository) ClearCategory(ctx context.Context, categoryID string) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, repository.HandleError(err)
+	}
+
+	defer r.tx.lockOutsideTx(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var changedCount int64
+	now := now()
+	for id, item := range r.items {
+		if item.CategoryID == categoryID {
+			item.CategoryID = ""
+			item.UpdatedAt = now
+			item.Version++
+			r.items[id] = item
+			changedCount++
+		}
+	}
+
+	return changedCount, nil
+}
+
+// SumPrices sums the prices of the items of the in-memory repository matching filter with the pure-Go SumPrices
+func (r *LocalItemRepository) SumPrices(ctx context.Context, filter repository.ItemFilter) ([]domain.PriceTotal, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, repository.HandleError(err)
+	}
+
+	defer r.tx.lockOutsideTx(ctx)()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var items []repository.Item
+	for _, id := range r.order {
+		if item := r.items[id]; filter.Match(item) {
+			items = append(items, item)
+		}
+	}
+
+	return repository.SumPrices(items), nil
+}
+
+// Batch applies ops one after the other, each one like its single item method
+func (r *LocalItemRepository) Batch(ctx context.Context, ops []repository.BatchOperation, stopOnError bool) ([]repository.BatchResult, error) {
+	return repository.RunBatch(ctx, r, ops, stopOnError)
+}
+
+// Ping always succeeds since there is no remote connection to verify.
+func (r *LocalItemRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// positionTaken reports whether an item of the list other than id has the
+// position, like the unique index of the MongoDB repository. r.mu must be held.
+func (r *LocalItemRepository) positionTaken(listID, position, id string) bool {
+	if position == "" {
+		return false
+	}
+	for _, item := range r.items {
+		if item.ListID == listID && item.Position == position && item.ID != id {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeID validates the ID the same way the MongoDB repository does and
+// returns its canonical (lowercase) hexadecimal form.
+func normalizeID(id string) (string, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return "", repository.NewInvalidHexIDError()
+	}
+	return objectID.Hex(), nil
+}
+
+// now returns the current time with the precision MongoDB stores (milliseconds).
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+func cloneItem(item repository.Item) repository.Item {
+	item.Observation = copyString(item.Observation)
+	item.Quantity = copyInt(item.Quantity)
+	item.UnitPrice = copyInt(item.UnitPrice)
+	return item
+}
+
+func copyString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	return &c
+}
+
+func copyInt(i *int64) *int64 {
+	if i == nil {
+		return nil
+	}
+	c := *i
+	return &c
+}
@@ -0,0 +1,506 @@
+package local_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository/local"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository/repositorytest"
+)
+
+var (
+	testObjectID = primitive.NewObjectID()
+)
+
+func mockItem() repository.Item {
+	obs := "mock observation"
+	return repository.Item{ID: testObjectID.Hex(), Name: "Test Item", Active: true, Observation: &obs}
+}
+
+func mockUpdateItemInput() repository.Item {
+	return repository.Item{ID: testObjectID.Hex(), Name: "Updated Item", Active: false}
+}
+
+func mockInvalidHexIDItem() repository.Item {
+	return repository.Item{ID: "invalid-hex-id", Name: "Test Item", Active: true}
+}
+
+func TestCreate(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		givenExisting   []repository.Item
+		givenItem       repository.Item
+		wantErr         error
+		wantCreatedItem repository.Item
+	}{
+		{
+			name:            "Given_ValidItem_When_Create_Then_ExpectedSuccess",
+			givenItem:       mockItem(),
+			wantCreatedItem: mockItem(),
+		},
+		{
+			name:      "Given_ItemWithInvalidHexID_When_Create_Then_ExpectedInvalidIDError",
+			givenItem: mockInvalidHexIDItem(),
+			wantErr:   repository.NewInvalidHexIDError(),
+		},
+		{
+			name:          "Given_ExistingID_When_Create_Then_ExpectedGenericError",
+			givenExisting: []repository.Item{mockItem()},
+			givenItem:     mockItem(),
+			wantErr:       repository.NewGenericRepositoryError(nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := local.NewLocalItemRepository()
+			for _, item := range tt.givenExisting {
+				_, err := repo.Create(ctx, item)
+				require.NoError(t, err)
+			}
+
+			createdItem, err := repo.Create(ctx, tt.givenItem)
+
+			if tt.wantErr != nil {
+				require.ErrorAs(t, err, new(repository.Error))
+				require.Equal(t, tt.wantErr.(repository.Error).HTTP, err.(repository.Error).HTTP)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantCreatedItem.Name, createdItem.Name)
+
+			storedItem, err := repo.GetByID(ctx, tt.givenItem.ID)
+			require.NoError(t, err)
+			require.Equal(t, storedItem, createdItem)
+			require.Equal(t, tt.givenItem.Name, storedItem.Name)
+			require.Equal(t, tt.givenItem.Observation, storedItem.Observation)
+			require.False(t, storedItem.CreatedAt.IsZero())
+			require.Equal(t, storedItem.CreatedAt, storedItem.UpdatedAt)
+		})
+	}
+}
+
+func TestGetByID(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		givenExisting []repository.Item
+		givenID       string
+		wantErr       error
+		wantName      string
+	}{
+		{
+			name:          "Given_ValidID_When_GetByID_Then_ExpectedSuccess",
+			givenExisting: []repository.Item{mockItem()},
+			givenID:       testObjectID.Hex(),
+			wantName:      mockItem().Name,
+		},
+		{
+			name:    "Given_ValidID_When_GetByID_And_ItemNotFound_Then_ExpectedNotFoundError",
+			givenID: testObjectID.Hex(),
+			wantErr: repository.NewItemNotFoundError(),
+		},
+		{
+			name:    "Given_InvalidID_When_GetByID_Then_ExpectedInvalidIDError",
+			givenID: "invalid-id",
+			wantErr: repository.NewInvalidHexIDError(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := local.NewLocalItemRepository()
+			for _, item := range tt.givenExisting {
+				_, err := repo.Create(ctx, item)
+				require.NoError(t, err)
+			}
+
+			item, err := repo.GetByID(ctx, tt.givenID)
+
+			if tt.wantErr != nil {
+				require.Equal(t, tt.wantErr, err)
+				require.Equal(t, repository.Item{}, item)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.givenID, item.ID)
+			require.Equal(t, tt.wantName, item.Name)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		givenExisting   []repository.Item
+		givenItem       repository.Item
+		wantErr         error
+		wantObservation *string
+	}{
+		{
+			name:            "Given_ValidItem_When_Update_Then_ExpectedSuccessKeepingObservation",
+			givenExisting:   []repository.Item{mockItem()},
+			givenItem:       mockUpdateItemInput(),
+			wantObservation: mockItem().Observation,
+		},
+		{
+			name:      "Given_ValidItem_When_Update_And_ItemNotFound_Then_ExpectedNotFoundError",
+			givenItem: mockUpdateItemInput(),
+			wantErr:   repository.NewItemNotFoundError(),
+		},
+		{
+			name:      "Given_InvalidID_When_Update_Then_ExpectedInvalidIDError",
+			givenItem: mockInvalidHexIDItem(),
+			wantErr:   repository.NewInvalidHexIDError(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := local.NewLocalItemRepository()
+			for _, item := range tt.givenExisting {
+				_, err := repo.Create(ctx, item)
+				require.NoError(t, err)
+			}
+
+			updatedItem, err := repo.Update(ctx, tt.givenItem)
+
+			if tt.wantErr != nil {
+				require.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+
+			storedItem, err := repo.GetByID(ctx, tt.givenItem.ID)
+			require.NoError(t, err)
+			require.Equal(t, storedItem, updatedItem)
+			require.Equal(t, tt.givenItem.Name, storedItem.Name)
+			require.Equal(t, tt.givenItem.Active, storedItem.Active)
+			require.Equal(t, tt.wantObservation, storedItem.Observation)
+			require.False(t, storedItem.UpdatedAt.Before(storedItem.CreatedAt))
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		givenExisting []repository.Item
+		givenID       string
+		wantErr       error
+	}{
+		{
+			name:          "Given_ValidID_When_Delete_Then_ExpectedSuccess",
+			givenExisting: []repository.Item{mockItem()},
+			givenID:       testObjectID.Hex(),
+		},
+		{
+			name:    "Given_ValidID_When_Delete_And_ItemNotFound_Then_ExpectedNotFoundError",
+			givenID: testObjectID.Hex(),
+			wantErr: repository.NewItemNotFoundError(),
+		},
+		{
+			name:    "Given_InvalidID_When_Delete_Then_ExpectedInvalidIDError",
+			givenID: "invalid-id",
+			wantErr: repository.NewInvalidHexIDError(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := local.NewLocalItemRepository()
+			for _, item := range tt.givenExisting {
+				_, err := repo.Create(ctx, item)
+				require.NoError(t, err)
+			}
+
+			err := repo.Delete(ctx, tt.givenID, 0)
+
+			if tt.wantErr != nil {
+				require.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+
+			_, err = repo.GetByID(ctx, tt.givenID)
+			require.Equal(t, repository.NewItemNotFoundError(), err)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	ctx := context.Background()
+
+	first := repository.Item{ID: primitive.NewObjectID().Hex(), Name: "First Item", Active: true}
+	second := repository.Item{ID: primitive.NewObjectID().Hex(), Name: "Second Item", Active: false}
+
+	tests := []struct {
+		name          string
+		givenExisting []repository.Item
+		wantNames     []string
+	}{
+		{
+			name:          "Given_ItemsExist_When_List_Then_ExpectedItemsInCreationOrder",
+			givenExisting: []repository.Item{first, second},
+			wantNames:     []string{"First Item", "Second Item"},
+		},
+		{
+			name:      "Given_NoItemsExist_When_List_Then_ExpectedEmptyList",
+			wantNames: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := local.NewLocalItemRepository()
+			for _, item := range tt.givenExisting {
+				_, err := repo.Create(ctx, item)
+				require.NoError(t, err)
+			}
+
+			page, err := repo.List(ctx, repository.ListOptions{})
+			items := page.Items
+			require.NoError(t, err)
+			require.NotNil(t, items)
+
+			names := make([]string, len(items))
+			for i, item := range items {
+				names[i] = item.Name
+			}
+			require.Equal(t, tt.wantNames, names)
+		})
+	}
+}
+
+func TestBulkUpdateActive(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name              string
+		givenExisting     []repository.Item
+		givenActive       bool
+		wantMatchedCount  int64
+		wantModifiedCount int64
+	}{
+		{
+			name: "Given_Items_When_BulkUpdateActive_Then_ReturnsCounts",
+			givenExisting: []repository.Item{
+				{ID: primitive.NewObjectID().Hex(), Name: "First Item", Active: true},
+				{ID: primitive.NewObjectID().Hex(), Name: "Second Item", Active: false},
+			},
+			givenActive:       true,
+			wantMatchedCount:  2,
+			wantModifiedCount: 2,
+		},
+		{
+			name:              "Given_EmptyRepository_When_BulkUpdateActive_Then_ReturnsZeroCounts",
+			givenActive:       false,
+			wantMatchedCount:  0,
+			wantModifiedCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := local.NewLocalItemRepository()
+			for _, item := range tt.givenExisting {
+				_, err := repo.Create(ctx, item)
+				require.NoError(t, err)
+			}
+
+			result, err := repo.BulkUpdateActive(ctx, repository.ActiveUpdate{Active: tt.givenActive})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantMatchedCount, result.MatchedCount)
+			require.Equal(t, tt.wantModifiedCount, result.ModifiedCount)
+
+			page, err := repo.List(ctx, repository.ListOptions{})
+			items := page.Items
+			require.NoError(t, err)
+			for _, item := range items {
+				require.Equal(t, tt.givenActive, item.Active)
+			}
+		})
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	ctx := context.Background()
+	repo := local.NewLocalItemRepository()
+
+	const workers = 50
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			item := repository.Item{ID: primitive.NewObjectID().Hex(), Name: "Concurrent Item", Active: true}
+			_, err := repo.Create(ctx, item)
+			require.NoError(t, err)
+			_, err = repo.Update(ctx, item)
+			require.NoError(t, err)
+			_, err = repo.BulkUpdateActive(ctx, repository.ActiveUpdate{Active: false})
+			require.NoError(t, err)
+			_, err = repo.List(ctx, repository.ListOptions{})
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	page, err := repo.List(ctx, repository.ListOptions{})
+	items := page.Items
+	require.NoError(t, err)
+	require.Len(t, items, workers)
+}
+
+func TestCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	repo := local.NewLocalItemRepository()
+
+	_, err := repo.Create(ctx, mockItem())
+	require.ErrorIs(t, err, context.Canceled)
+
+	err = repo.Ping(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestConformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.ItemRepository {
+		return local.NewLocalItemRepository()
+	})
+}
+
+func TestTxConformance(t *testing.T) {
+	repositorytest.RunTx(t, func(t *testing.T) (repository.ItemRepository, repository.TxManager) {
+		repo := local.NewLocalItemRepository()
+		return repo, local.NewLocalTxManager(repo)
+	})
+}
+
+func TestTransactionIsolation(t *testing.T) {
+	ctx := context.Background()
+	repo := local.NewLocalItemRepository()
+	txManager := local.NewLocalTxManager(repo)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error)
+
+	go func() {
+		done <- txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			_, err := repo.Create(ctx, mockItem())
+			close(started)
+			<-release
+			return err
+		})
+	}()
+	<-started
+
+	// An operation outside the transaction waits for it to finish
+	listed := make(chan []repository.Item)
+	go func() {
+		page, _ := repo.List(ctx, repository.ListOptions{})
+		listed <- page.Items
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	select {
+	case <-listed:
+		t.Fatal("List did not wait for the running transaction")
+	default:
+	}
+
+	close(release)
+	require.NoError(t, <-done)
+	require.Len(t, <-listed, 1)
+}
+
+func TestTransactionSpansStores(t *testing.T) {
+	ctx := context.Background()
+	items := local.NewLocalItemRepository()
+	lists := local.NewLocalListRepository()
+	categories := local.NewLocalCategoryRepository()
+	txManager := local.NewLocalTxManager(items, lists, categories)
+
+	list, err := lists.Create(ctx, repository.List{ID: primitive.NewObjectID().Hex(), Name: "Groceries"})
+	require.NoError(t, err)
+	category, err := categories.Create(ctx, repository.Category{ID: primitive.NewObjectID().Hex(), Name: "Dairy", Position: 1})
+	require.NoError(t, err)
+	item := mockItem()
+	item.ListID = list.ID
+	item.CategoryID = category.ID
+	_, err = items.Create(ctx, item)
+	require.NoError(t, err)
+
+	t.Run("Given_FailureAfterDeletingAListAndACategory_When_WithinTransaction_Then_EveryStoreIsRolledBack", func(t *testing.T) {
+		err := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := lists.Delete(ctx, list.ID); err != nil {
+				return err
+			}
+			if err := categories.Delete(ctx, category.ID); err != nil {
+				return err
+			}
+			if _, err := items.DeleteMany(ctx, repository.ItemFilter{ListID: list.ID}); err != nil {
+				return err
+			}
+			return context.DeadlineExceeded
+		})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		_, err = lists.GetByID(ctx, list.ID)
+		require.NoError(t, err)
+		_, err = categories.GetByID(ctx, category.ID)
+		require.NoError(t, err)
+		stored, err := items.GetByID(ctx, item.ID)
+		require.NoError(t, err)
+		require.Equal(t, list.ID, stored.ListID)
+	})
+
+	t.Run("Given_RunningTransaction_When_ListIsRead_Then_ItWaitsForTheTransaction", func(t *testing.T) {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		done := make(chan error)
+
+		go func() {
+			done <- txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+				err := lists.Delete(ctx, list.ID)
+				close(started)
+				<-release
+				return err
+			})
+		}()
+		<-started
+
+		read := make(chan error)
+		go func() {
+			_, err := lists.GetByID(ctx, list.ID)
+			read <- err
+		}()
+
+		time.Sleep(20 * time.Millisecond)
+		select {
+		case <-read:
+			t.Fatal("GetByID did not wait for the running transaction")
+		default:
+		}
+
+		close(release)
+		require.NoError(t, <-done)
+		require.Error(t, <-read)
+	})
+}
@@ -0,0 +1,160 @@
+package local
+
+import (
+	"context"
+	"sync"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+type txKey struct{}
+
+// txLock is held exclusively by a running transaction and shared by the
+// operations made outside of it. The stores of a LocalTxManager share one lock.
+type txLock struct {
+	mu sync.RWMutex
+}
+
+// txStore is an in-memory store that takes part in the transactions of LocalTxManager
+type txStore interface {
+	// joinTx makes the store share lock with the other stores of the transactions
+	joinTx(lock *txLock)
+	// snapshot copies the content of the store and returns the function restoring it
+	snapshot() (restore func())
+}
+
+// LocalTxManager implements repository.TxManager for the in-memory stores
+// (items, lists and categories). Transactions are serialized: while one runs,
+// operations made outside of it on any of the stores wait, and on error every
+// store is restored to the state it had before.
+type LocalTxManager struct {
+	lock   *txLock
+	stores []txStore
+}
+
+// NewLocalTxManager creates a new instance of LocalTxManager running transactions
+// over stores, e.g. a LocalItemRepository, a LocalListRepository and a
+// LocalCategoryRepository. It must be created before the stores are used.
+func NewLocalTxManager(stores ...txStore) repository.TxManager {
+	lock := &txLock{}
+	for _, store := range stores {
+		store.joinTx(lock)
+	}
+	return &LocalTxManager{
+		lock:   lock,
+		stores: stores,
+	}
+}
+
+// WithinTransaction runs fn with exclusive access to the stores and rolls back
+// its changes if it returns an error or panics. Store calls inside fn must use
+// the context it receives, otherwise they wait for the transaction.
+func (m *LocalTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if m.lock.inTx(ctx) {
+		return fn(ctx)
+	}
+
+	m.lock.mu.Lock()
+	defer m.lock.mu.Unlock()
+
+	restores := make([]func(), len(m.stores))
+	for i, store := range m.stores {
+		restores[i] = store.snapshot()
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			for _, restore := range restores {
+				restore()
+			}
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txKey{}, m.lock)); err != nil {
+		return err
+	}
+	committed = true
+
+	return nil
+}
+
+func (l *txLock) inTx(ctx context.Context) bool {
+	txLock, ok := ctx.Value(txKey{}).(*txLock)
+	return ok && txLock == l
+}
+
+// lockOutsideTx waits for a running transaction to finish, unless ctx belongs to it.
+// It returns the function that releases the lock.
+func (l *txLock) lockOutsideTx(ctx context.Context) func() {
+	if l.inTx(ctx) {
+		return func() {}
+	}
+	l.mu.RLock()
+	return l.mu.RUnlock
+}
+
+func (r *LocalItemRepository) joinTx(lock *txLock) {
+	r.tx = lock
+}
+
+func (r *LocalItemRepository) snapshot() func() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := make(map[string]repository.Item, len(r.items))
+	for id, item := range r.items {
+		items[id] = item
+	}
+	order := make([]string, len(r.order))
+	copy(order, r.order)
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.items = items
+		r.order = order
+	}
+}
+
+func (r *LocalListRepository) joinTx(lock *txLock) {
+	r.tx = lock
+}
+
+func (r *LocalListRepository) snapshot() func() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	lists := make(map[string]repository.List, len(r.lists))
+	for id, list := range r.lists {
+		lists[id] = list
+	}
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.lists = lists
+	}
+}
+
+func (r *LocalCategoryRepository) joinTx(lock *txLock) {
+	r.tx = lock
+}
+
+func (r *LocalCategoryRepository) snapshot() func() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	categories := make(map[string]repository.Category, len(r.categories))
+	for id, category := range r.categories {
+		categories[id] = category
+	}
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.categories = categories
+	}
+}
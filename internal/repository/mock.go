@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/stretchr/testify/mock"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
 )
 
 type RepositoryMock struct {
@@ -20,8 +22,13 @@ func (m *RepositoryMock) Update(ctx context.Context, item Item) (Item, error) {
 	return args.Get(0).(Item), args.Error(1)
 }
 
-func (m *RepositoryMock) Delete(ctx context.Context, id string) error {
-	args := m.Called(ctx, id)
+func (m *RepositoryMock) Patch(ctx context.Context, id string, patch ItemPatch) (Item, error) {
+	args := m.Called(ctx, id, patch)
+	return args.Get(0).(Item), args.Error(1)
+}
+
+func (m *RepositoryMock) Delete(ctx context.Context, id string, version int64) error {
+	args := m.Called(ctx, id, version)
 	return args.Error(0)
 }
 
@@ -30,12 +37,123 @@ func (m *RepositoryMock) GetByID(ctx context.Context, id string) (Item, error) {
 	return args.Get(0).(Item), args.Error(1)
 }
 
-func (m *RepositoryMock) List(ctx context.Context) ([]Item, error) {
-	args := m.Called(ctx)
+func (m *RepositoryMock) List(ctx context.Context, opts ListOptions) (ItemPage, error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).(ItemPage), args.Error(1)
+}
+
+func (m *RepositoryMock) Search(ctx context.Context, query, listID string, limit int) ([]Item, error) {
+	args := m.Called(ctx, query, listID, limit)
 	return args.Get(0).([]Item), args.Error(1)
 }
 
-func (m *RepositoryMock) BulkUpdateActive(ctx context.Context, active bool) (int64, int64, error) {
-	args := m.Called(ctx, active)
-	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+func (m *RepositoryMock) BulkUpdateActive(ctx context.Context, update ActiveUpdate) (ActiveUpdateResult, error) {
+	args := m.Called(ctx, update)
+	return args.Get(0).(ActiveUpdateResult), args.Error(1)
+}
+
+func (m *RepositoryMock) DeleteMany(ctx context.Context, filter ItemFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *RepositoryMock) Batch(ctx context.Context, ops []BatchOperation, stopOnError bool) ([]BatchResult, error) {
+	args := m.Called(ctx, ops, stopOnError)
+	results, _ := args.Get(0).([]BatchResult)
+	return results, args.Error(1)
+}
+
+func (m *RepositoryMock) ClearCategory(ctx context.Context, categoryID string) (int64, error) {
+	args := m.Called(ctx, categoryID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *RepositoryMock) SumPrices(ctx context.Context, filter ItemFilter) ([]domain.PriceTotal, error) {
+	args := m.Called(ctx, filter)
+	totals, _ := args.Get(0).([]domain.PriceTotal)
+	return totals, args.Error(1)
+}
+
+type ListRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *ListRepositoryMock) Create(ctx context.Context, list List) (List, error) {
+	args := m.Called(ctx, list)
+	return args.Get(0).(List), args.Error(1)
+}
+
+func (m *ListRepositoryMock) Update(ctx context.Context, list List) (List, error) {
+	args := m.Called(ctx, list)
+	return args.Get(0).(List), args.Error(1)
+}
+
+func (m *ListRepositoryMock) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *ListRepositoryMock) GetByID(ctx context.Context, id string) (List, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(List), args.Error(1)
+}
+
+func (m *ListRepositoryMock) Lock(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *ListRepositoryMock) List(ctx context.Context) ([]List, error) {
+	args := m.Called(ctx)
+	lists, _ := args.Get(0).([]List)
+	return lists, args.Error(1)
+}
+
+type CategoryRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *CategoryRepositoryMock) Create(ctx context.Context, category Category) (Category, error) {
+	args := m.Called(ctx, category)
+	return args.Get(0).(Category), args.Error(1)
+}
+
+func (m *CategoryRepositoryMock) Update(ctx context.Context, category Category) (Category, error) {
+	args := m.Called(ctx, category)
+	return args.Get(0).(Category), args.Error(1)
+}
+
+func (m *CategoryRepositoryMock) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *CategoryRepositoryMock) GetByID(ctx context.Context, id string) (Category, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(Category), args.Error(1)
+}
+
+func (m *CategoryRepositoryMock) Lock(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *CategoryRepositoryMock) List(ctx context.Context) ([]Category, error) {
+	args := m.Called(ctx)
+	categories, _ := args.Get(0).([]Category)
+	return categories, args.Error(1)
+}
+
+// TxManagerMock is a mock for TxManager. Unless told otherwise it runs fn
+// without a transaction and returns its error.
+type TxManagerMock struct {
+	mock.Mock
+}
+
+func (m *TxManagerMock) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if len(m.ExpectedCalls) == 0 {
+		return fn(ctx)
+	}
+	args := m.Called(ctx, fn)
+	return args.Error(0)
 }
@@ -3,12 +3,94 @@ package repository
 import "time"
 
 type Item struct {
-	ID          string    `json:"id" bson:"_id,omitempty"`
-	Name        string    `json:"name" bson:"name"`
-	Active      bool      `json:"active" bson:"active"`
-	Observation *string   `json:"observation,omitempty" bson:"observation,omitempty"`
-	CreatedAt   time.Time `json:"createdAt" bson:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt" bson:"updatedAt"`
+	ID          string  `json:"id" bson:"_id,omitempty"`
+	ListID      string  `json:"listId" bson:"listId"`
+	Name        string  `json:"name" bson:"name"`
+	Active      bool    `json:"active" bson:"active"`
+	Observation *string `json:"observation,omitempty" bson:"observation,omitempty"`
+	// Quantity, in thousandths, and Unit are stored together. On Update, a nil Quantity keeps the stored ones, like Observation.
+	Quantity *int64 `json:"quantity,omitempty" bson:"quantity,omitempty"`
+	Unit     string `json:"unit,omitempty" bson:"unit,omitempty"`
+	// CategoryID is empty for an uncategorized item. On Update, an empty CategoryID keeps the stored one.
+	CategoryID string `json:"categoryId,omitempty" bson:"categoryId,omitempty"`
+	// UnitPrice, in cents, and Currency are stored together. On Update, a nil UnitPrice keeps the stored ones.
+	UnitPrice *int64 `json:"unitPrice,omitempty" bson:"unitPrice,omitempty"`
+	Currency  string `json:"currency,omitempty" bson:"currency,omitempty"`
+	// Position orders the items of a list by hand and is unique in the list, unless empty.
+	// Update keeps the stored one; it is only changed by Patch.
+	Position  string    `json:"position,omitempty" bson:"position"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
+	// Version starts at 1 and is incremented by every change of the item.
+	// On Update, a non-zero Version must match the stored one.
+	Version int64 `json:"version" bson:"version"`
+}
+
+// ItemPatch holds the fields changed by Patch; nil fields are kept.
+// Observation replaces the stored one when SetObservation is true, and nil removes it.
+// Quantity and Unit replace the stored ones when SetQuantity is true, and a nil Quantity removes both.
+// CategoryID replaces the stored one when SetCategory is true, and nil removes it.
+// UnitPrice and Currency replace the stored ones when SetPrice is true, and a nil UnitPrice removes both.
+// Position, when set, must not be the position of another item of the list.
+type ItemPatch struct {
+	Name           *string `json:"name,omitempty"`
+	Active         *bool   `json:"active,omitempty"`
+	Observation    *string `json:"observation,omitempty"`
+	SetObservation bool    `json:"setObservation,omitempty"`
+	Quantity       *int64  `json:"quantity,omitempty"`
+	Unit           string  `json:"unit,omitempty"`
+	SetQuantity    bool    `json:"setQuantity,omitempty"`
+	CategoryID     *string `json:"categoryId,omitempty"`
+	SetCategory    bool    `json:"setCategory,omitempty"`
+	UnitPrice      *int64  `json:"unitPrice,omitempty"`
+	Currency       string  `json:"currency,omitempty"`
+	SetPrice       bool    `json:"setPrice,omitempty"`
+	Position       *string `json:"position,omitempty"`
+	// Version, when not zero, must match the stored version of the item
+	Version int64 `json:"version,omitempty"`
+}
+
+// ActiveUpdate selects the items changed by BulkUpdateActive: the ones with the
+// given IDs (without duplicates) when IDs is not empty, otherwise the ones matching Filter.
+// With IDs, only Filter.ListID is used: the items of other lists are not found.
+type ActiveUpdate struct {
+	Active bool
+	IDs    []string
+	Filter ItemFilter
+}
+
+// ActiveUpdateResult counts the items changed by BulkUpdateActive and lists the
+// requested IDs that don't exist
+type ActiveUpdateResult struct {
+	MatchedCount  int64
+	ModifiedCount int64
+	NotFoundIDs   []string
+}
+
+// The default list is created by the schema migrations (and by the in-memory
+// repository), with the same ID as domain.DefaultListID. The items stored before
+// there were lists are moved into it.
+const (
+	DefaultListID   = "000000000000000000000001"
+	DefaultListName = "Default"
+)
+
+// List is a named list of items, mapped to MongoDB collection
+type List struct {
+	ID        string    `json:"id" bson:"_id,omitempty"`
+	Name      string    `json:"name" bson:"name"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Category is a kind of item shown at Position, mapped to MongoDB collection.
+// The categories are ordered by position, creation time and ID.
+type Category struct {
+	ID        string    `json:"id" bson:"_id,omitempty"`
+	Name      string    `json:"name" bson:"name"`
+	Position  int       `json:"position" bson:"position"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
 }
 
 // User represents a user in the repository, mapped to MongoDB collection
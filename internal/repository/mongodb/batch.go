@@ -0,0 +1,155 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+// Batch applies ops with a single ordered BulkWrite. BulkWrite only reports
+// totals, so the items the operations refer to are read first: the updates and
+// deletes of missing items are reported as not found without being sent, and the
+// results are the items as they are after each operation.
+func (r *MongoDBItemRepository) Batch(ctx context.Context, ops []repository.BatchOperation, stopOnError bool) ([]repository.BatchResult, error) {
+	collection := r.client.GetCollection(CollectionItems)
+
+	results := make([]repository.BatchResult, len(ops))
+	ids := make([]primitive.ObjectID, len(ops))
+	referenced := make([]primitive.ObjectID, 0, len(ops))
+	for i, op := range ops {
+		id, err := primitive.ObjectIDFromHex(op.Item.ID)
+		if err != nil {
+			results[i].Err = repository.NewInvalidHexIDError()
+			continue
+		}
+		ids[i] = id
+		if op.Type != repository.BatchCreate {
+			referenced = append(referenced, id)
+		}
+	}
+
+	stored, err := r.itemsByID(ctx, referenced)
+	if err != nil {
+		return nil, err
+	}
+
+	now := now()
+	models := make([]mongo.WriteModel, 0, len(ops))
+	// opIndexes[m] is the index in ops of models[m]
+	opIndexes := make([]int, 0, len(ops))
+	for i, op := range ops {
+		if results[i].Err == nil {
+			id := ids[i]
+			item, exists := stored[id]
+
+			switch op.Type {
+			case repository.BatchCreate:
+				// A duplicated _id is reported by the BulkWrite
+				models = append(models, mongo.NewInsertOneModel().SetDocument(newItemDocument(id, op.Item, now)))
+				item = op.Item
+				item.ID = id.Hex()
+				item.CreatedAt = now
+				item.UpdatedAt = now
+				item.Version = 1
+			case repository.BatchUpdate:
+				if !exists {
+					results[i].Err = repository.NewItemNotFoundError()
+					break
+				}
+				models = append(models, mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": id}).SetUpdate(itemUpdate(op.Item, now)))
+				item.Name = op.Item.Name
+				item.Active = op.Item.Active
+				if op.Item.Observation != nil {
+					item.Observation = op.Item.Observation
+				}
+				item.UpdatedAt = now
+				item.Version++
+			case repository.BatchDelete:
+				if !exists {
+					results[i].Err = repository.NewItemNotFoundError()
+					break
+				}
+				models = append(models, mongo.NewDeleteOneModel().SetFilter(bson.M{"_id": id}))
+				delete(stored, id)
+			default:
+				results[i].Err = repository.NewInvalidBatchOperationError(op.Type)
+			}
+
+			if results[i].Err == nil {
+				opIndexes = append(opIndexes, i)
+				if op.Type != repository.BatchDelete {
+					stored[id] = item
+					results[i].Item = item
+				}
+			}
+		}
+
+		if results[i].Err != nil && stopOnError {
+			results = results[:i+1]
+			break
+		}
+	}
+
+	for start := 0; start < len(models); {
+		_, err := collection.BulkWrite(ctx, models[start:], options.BulkWrite().SetOrdered(true))
+		if err == nil {
+			break
+		}
+
+		var bulkErr mongo.BulkWriteException
+		if !errors.As(err, &bulkErr) || len(bulkErr.WriteErrors) == 0 {
+			return nil, repository.HandleError(err)
+		}
+
+		// An ordered BulkWrite stops at the first failed write, the rest are sent again
+		failed := start + bulkErr.WriteErrors[0].Index
+		opIndex := opIndexes[failed]
+		results[opIndex] = repository.BatchResult{Err: handleWriteError(bulkErr.WriteErrors[0])}
+		if stopOnError {
+			return results[:opIndex+1], nil
+		}
+		start = failed + 1
+	}
+
+	return results, nil
+}
+
+// itemsByID reads the items with the given IDs with a single Find
+func (r *MongoDBItemRepository) itemsByID(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]repository.Item, error) {
+	stored := make(map[primitive.ObjectID]repository.Item, len(ids))
+	if len(ids) == 0 {
+		return stored, nil
+	}
+
+	collection := r.client.GetCollection(CollectionItems)
+	cursor, err := collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, repository.HandleError(err)
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("Error closing MongoDB cursor: %v", err)
+		}
+	}()
+
+	var items []repository.Item
+	if err = cursor.All(ctx, &items); err != nil {
+		return nil, repository.HandleError(err)
+	}
+	for _, item := range items {
+		id, err := primitive.ObjectIDFromHex(item.ID)
+		if err != nil {
+			return nil, repository.HandleError(err)
+		}
+		stored[id] = item
+	}
+
+	return stored, nil
+}
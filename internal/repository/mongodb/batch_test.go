@@ -0,0 +1,216 @@
+package mongodb_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	dbmongo "github.com/lucaspereirasilva0/list-manager-api/internal/database/mongodb"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	mongorepo "github.com/lucaspereirasilva0/list-manager-api/internal/repository/mongodb"
+)
+
+func mockBulkWriteException(index int) error {
+	return mongo.BulkWriteException{
+		WriteErrors: []mongo.BulkWriteError{{WriteError: mongo.WriteError{Index: index, Code: 11000, Message: "duplicate key"}}},
+	}
+}
+
+func TestBatch(t *testing.T) {
+	ctx := context.Background()
+	obs := "stored observation"
+	storedItem := repository.Item{
+		ID:          testObjectID.Hex(),
+		Name:        "Stored Item",
+		Active:      true,
+		Observation: &obs,
+		CreatedAt:   time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		Version:     3,
+	}
+	newItem := repository.Item{ID: primitive.NewObjectID().Hex(), Name: "New Item", Active: true}
+	otherNewItem := repository.Item{ID: primitive.NewObjectID().Hex(), Name: "Other New Item", Active: false}
+	missingItem := repository.Item{ID: primitive.NewObjectID().Hex(), Name: "Missing Item"}
+
+	// bulkWrite is one BulkWrite call: the number of models sent and its error
+	type bulkWrite struct {
+		models int
+		err    error
+	}
+
+	tests := []struct {
+		name             string
+		givenOps         []repository.BatchOperation
+		givenStopOnError bool
+		givenStored      []repository.Item
+		givenFindError   error
+		givenBulkWrites  []bulkWrite
+		wantFind         bool
+		wantResults      []repository.BatchResult
+		wantHTTPs        []int
+		wantErr          error
+	}{
+		{
+			name: "Given_MixedOperations_When_Batch_Then_ExpectedSingleBulkWrite",
+			givenOps: []repository.BatchOperation{
+				{Type: repository.BatchCreate, Item: newItem},
+				{Type: repository.BatchUpdate, Item: repository.Item{ID: storedItem.ID, Name: "Updated Item", Active: false}},
+				{Type: repository.BatchDelete, Item: repository.Item{ID: storedItem.ID}},
+			},
+			givenStored:     []repository.Item{storedItem},
+			givenBulkWrites: []bulkWrite{{models: 3}},
+			wantFind:        true,
+			wantResults: []repository.BatchResult{
+				{Item: repository.Item{ID: newItem.ID, Name: "New Item", Active: true, Version: 1}},
+				{Item: repository.Item{ID: storedItem.ID, Name: "Updated Item", Active: false, Observation: &obs, CreatedAt: storedItem.CreatedAt, Version: 4}},
+				{},
+			},
+		},
+		{
+			name: "Given_MissingItem_When_Batch_Then_ExpectedNotFoundWithoutWritingIt",
+			givenOps: []repository.BatchOperation{
+				{Type: repository.BatchUpdate, Item: missingItem},
+				{Type: repository.BatchCreate, Item: newItem},
+			},
+			givenBulkWrites: []bulkWrite{{models: 1}},
+			wantFind:        true,
+			wantResults: []repository.BatchResult{
+				{},
+				{Item: repository.Item{ID: newItem.ID, Name: "New Item", Active: true, Version: 1}},
+			},
+			wantHTTPs: []int{http.StatusNotFound, 0},
+		},
+		{
+			name: "Given_InvalidHexID_When_Batch_Then_ExpectedInvalidHexIDError",
+			givenOps: []repository.BatchOperation{
+				{Type: repository.BatchCreate, Item: mockInvalidHexIDItemInput()},
+			},
+			wantResults: []repository.BatchResult{{}},
+			wantHTTPs:   []int{http.StatusUnprocessableEntity},
+		},
+		{
+			name: "Given_WriteError_When_Batch_Then_ExpectedFollowingWritesSentAgain",
+			givenOps: []repository.BatchOperation{
+				{Type: repository.BatchCreate, Item: newItem},
+				{Type: repository.BatchCreate, Item: otherNewItem},
+			},
+			givenBulkWrites: []bulkWrite{{models: 2, err: mockBulkWriteException(0)}, {models: 1}},
+			wantResults: []repository.BatchResult{
+				{},
+				{Item: repository.Item{ID: otherNewItem.ID, Name: "Other New Item", Active: false, Version: 1}},
+			},
+			wantHTTPs: []int{http.StatusInternalServerError, 0},
+		},
+		{
+			name: "Given_WriteErrorAndStopOnError_When_Batch_Then_ExpectedResultsEndWithIt",
+			givenOps: []repository.BatchOperation{
+				{Type: repository.BatchCreate, Item: newItem},
+				{Type: repository.BatchCreate, Item: otherNewItem},
+			},
+			givenStopOnError: true,
+			givenBulkWrites:  []bulkWrite{{models: 2, err: mockBulkWriteException(0)}},
+			wantResults:      []repository.BatchResult{{}},
+			wantHTTPs:        []int{http.StatusInternalServerError},
+		},
+		{
+			name: "Given_MissingItemAndStopOnError_When_Batch_Then_ExpectedOnlyPreviousOperationsWritten",
+			givenOps: []repository.BatchOperation{
+				{Type: repository.BatchCreate, Item: newItem},
+				{Type: repository.BatchDelete, Item: missingItem},
+				{Type: repository.BatchCreate, Item: otherNewItem},
+			},
+			givenStopOnError: true,
+			givenBulkWrites:  []bulkWrite{{models: 1}},
+			wantFind:         true,
+			wantResults: []repository.BatchResult{
+				{Item: repository.Item{ID: newItem.ID, Name: "New Item", Active: true, Version: 1}},
+				{},
+			},
+			wantHTTPs: []int{0, http.StatusNotFound},
+		},
+		{
+			name: "Given_FindError_When_Batch_Then_ExpectedInternalError",
+			givenOps: []repository.BatchOperation{
+				{Type: repository.BatchDelete, Item: repository.Item{ID: storedItem.ID}},
+			},
+			givenFindError: errDatabase,
+			wantFind:       true,
+			wantErr:        errDatabase,
+		},
+		{
+			name: "Given_BulkWriteError_When_Batch_Then_ExpectedInternalError",
+			givenOps: []repository.BatchOperation{
+				{Type: repository.BatchCreate, Item: newItem},
+			},
+			givenBulkWrites: []bulkWrite{{models: 1, err: errDatabase}},
+			wantErr:         errDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionMock := new(dbmongo.MockMongoCollectionOperations)
+			cursorMock := new(dbmongo.MockMongoCursorOperations)
+			clientMock := new(dbmongo.MockClientOperations)
+
+			if tt.givenFindError != nil {
+				collectionMock.On("Find", ctx, mock.Anything, mock.Anything).Return((*dbmongo.MockMongoCursorOperations)(nil), tt.givenFindError)
+			} else if tt.wantFind {
+				collectionMock.On("Find", ctx, mock.Anything, mock.Anything).Return(cursorMock, nil)
+				cursorMock.On("All", ctx, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					results := args.Get(1).(*[]repository.Item)
+					*results = tt.givenStored
+				})
+				cursorMock.On("Close", ctx).Return(nil)
+			}
+			for _, call := range tt.givenBulkWrites {
+				models := call.models
+				collectionMock.On("BulkWrite", ctx, mock.MatchedBy(func(m []mongo.WriteModel) bool { return len(m) == models })).
+					Return(&mongo.BulkWriteResult{}, call.err).Once()
+			}
+
+			clientMock.On("GetCollection", mongorepo.CollectionItems).Return(collectionMock)
+
+			repo := mongorepo.NewMongoDBItemRepository(clientMock)
+
+			results, err := repo.Batch(ctx, tt.givenOps, tt.givenStopOnError)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, results, len(tt.wantResults))
+			for i, result := range results {
+				wantHTTP := 0
+				if tt.wantHTTPs != nil {
+					wantHTTP = tt.wantHTTPs[i]
+				}
+				if wantHTTP != 0 {
+					var errRepository repository.Error
+					require.ErrorAs(t, result.Err, &errRepository)
+					require.Equal(t, wantHTTP, errRepository.HTTP)
+					continue
+				}
+
+				require.NoError(t, result.Err)
+				if tt.givenOps[i].Type == repository.BatchCreate {
+					require.Equal(t, result.Item.CreatedAt, result.Item.UpdatedAt)
+					result.Item.CreatedAt = time.Time{}
+				}
+				require.False(t, result.Item.UpdatedAt.IsZero() && tt.givenOps[i].Type != repository.BatchDelete)
+				result.Item.UpdatedAt = time.Time{}
+				require.Equal(t, tt.wantResults[i], result)
+			}
+
+			collectionMock.AssertExpectations(t)
+			cursorMock.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package mongodb
+
+import (
+	"context"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	dbmongo "github.com/lucaspereirasilva0/list-manager-api/internal/database/mongodb"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+// MongoDBCategoryRepository implements repository.CategoryRepository for MongoDB.
+// The items refer to their category by the hexadecimal form of its _id (categoryId).
+type MongoDBCategoryRepository struct {
+	client dbmongo.ClientOperations
+}
+
+// NewMongoDBCategoryRepository creates a new instance of MongoDBCategoryRepository
+func NewMongoDBCategoryRepository(client dbmongo.ClientOperations) repository.CategoryRepository {
+	return &MongoDBCategoryRepository{
+		client: client,
+	}
+}
+
+// Create inserts a new category in the MongoDB repository
+func (r *MongoDBCategoryRepository) Create(ctx context.Context, category repository.Category) (repository.Category, error) {
+	collection := r.client.GetCollection(CollectionCategories)
+
+	objectID, err := primitive.ObjectIDFromHex(category.ID)
+	if err != nil {
+		return repository.Category{}, repository.NewInvalidHexIDError()
+	}
+
+	now := now()
+	_, err = collection.InsertOne(ctx, bson.M{
+		"_id":       objectID,
+		"name":      category.Name,
+		"position":  category.Position,
+		"createdAt": now,
+		"updatedAt": now,
+	})
+	if err != nil {
+		return repository.Category{}, repository.HandleError(err)
+	}
+
+	category.ID = objectID.Hex()
+	category.CreatedAt = now
+	category.UpdatedAt = now
+
+	return category, nil
+}
+
+// Update renames and moves a category, keeping its position when category.Position
+// is zero, and returns it as stored after the update
+func (r *MongoDBCategoryRepository) Update(ctx context.Context, category repository.Category) (repository.Category, error) {
+	collection := r.client.GetCollection(CollectionCategories)
+
+	objectID, err := primitive.ObjectIDFromHex(category.ID)
+	if err != nil {
+		return repository.Category{}, repository.NewInvalidHexIDError()
+	}
+
+	setFields := bson.M{"name": category.Name, "updatedAt": now()}
+	if category.Position != 0 {
+		setFields["position"] = category.Position
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updatedCategory repository.Category
+	err = collection.FindOneAndUpdate(ctx, bson.M{"_id": objectID}, bson.M{"$set": setFields}, opts).Decode(&updatedCategory)
+	if err == mongo.ErrNoDocuments {
+		return repository.Category{}, repository.NewCategoryNotFoundError()
+	} else if err != nil {
+		return repository.Category{}, repository.HandleError(err)
+	}
+
+	return updatedCategory, nil
+}
+
+// Delete removes a category from the MongoDB repository
+func (r *MongoDBCategoryRepository) Delete(ctx context.Context, id string) error {
+	collection := r.client.GetCollection(CollectionCategories)
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return repository.NewInvalidHexIDError()
+	}
+
+	result, err := collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return repository.HandleError(err)
+	}
+	if result.DeletedCount == 0 {
+		return repository.NewCategoryNotFoundError()
+	}
+
+	return nil
+}
+
+// Lock reports a missing category. It increments the locks counter of the
+// category, like MongoDBListRepository.Lock, so that a transaction deleting it
+// at the same time conflicts with the one locking it.
+func (r *MongoDBCategoryRepository) Lock(ctx context.Context, id string) error {
+	collection := r.client.GetCollection(CollectionCategories)
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return repository.NewInvalidHexIDError()
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$inc": bson.M{"locks": 1}})
+	if err != nil {
+		return repository.HandleError(err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.NewCategoryNotFoundError()
+	}
+
+	return nil
+}
+
+// GetByID retrieves a category by its ID from the MongoDB repository
+func (r *MongoDBCategoryRepository) GetByID(ctx context.Context, id string) (repository.Category, error) {
+	collection := r.client.GetCollection(CollectionCategories)
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return repository.Category{}, repository.NewInvalidHexIDError()
+	}
+
+	var category repository.Category
+	err = collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&category)
+	if err == mongo.ErrNoDocuments {
+		return repository.Category{}, repository.NewCategoryNotFoundError()
+	} else if err != nil {
+		return repository.Category{}, repository.HandleError(err)
+	}
+
+	return category, nil
+}
+
+// List retrieves every category from the MongoDB repository, ordered by position, createdAt and _id
+func (r *MongoDBCategoryRepository) List(ctx context.Context) ([]repository.Category, error) {
+	collection := r.client.GetCollection(CollectionCategories)
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "position", Value: 1}, {Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return nil, repository.HandleError(err)
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("Error closing MongoDB cursor: %v", err)
+		}
+	}()
+
+	categories := []repository.Category{}
+	if err = cursor.All(ctx, &categories); err != nil {
+		return nil, repository.HandleError(err)
+	}
+
+	return categories, nil
+}
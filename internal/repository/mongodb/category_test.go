@@ -0,0 +1,218 @@
+package mongodb_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	dbmongo "github.com/lucaspereirasilva0/list-manager-api/internal/database/mongodb"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	mongorepo "github.com/lucaspereirasilva0/list-manager-api/internal/repository/mongodb"
+)
+
+func mockCategory() repository.Category {
+	return repository.Category{
+		ID:        testObjectID.Hex(),
+		Name:      "Dairy",
+		Position:  2,
+		CreatedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func mockCategorySingleResult(err error) *mongo.SingleResult {
+	bsonBytes, _ := bson.Marshal(mockCategory())
+	return mongo.NewSingleResultFromDocument(bsonBytes, err, nil)
+}
+
+func newCategoryRepository(collectionMock *dbmongo.MockMongoCollectionOperations) repository.CategoryRepository {
+	clientMock := new(dbmongo.MockClientOperations)
+	clientMock.On("GetCollection", mongorepo.CollectionCategories).Return(collectionMock)
+	return mongorepo.NewMongoDBCategoryRepository(clientMock)
+}
+
+func TestCategoryCreate(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		givenCategory repository.Category
+		givenErr      error
+		wantInsert    bool
+		wantHTTP      int
+		wantErr       error
+	}{
+		{
+			name:          "Given_Category_When_Create_Then_ExpectedStoredTimestamps",
+			givenCategory: repository.Category{ID: testObjectID.Hex(), Name: "Dairy", Position: 2},
+			wantInsert:    true,
+		},
+		{
+			name:          "Given_InvalidHexID_When_Create_Then_ExpectedInvalidHexIDError",
+			givenCategory: repository.Category{ID: "invalid-hex-id", Name: "Dairy"},
+			wantHTTP:      http.StatusUnprocessableEntity,
+		},
+		{
+			name:          "Given_DatabaseError_When_Create_Then_ExpectedInternalError",
+			givenCategory: repository.Category{ID: testObjectID.Hex(), Name: "Dairy", Position: 2},
+			givenErr:      errDatabase,
+			wantInsert:    true,
+			wantErr:       errDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionMock := new(dbmongo.MockMongoCollectionOperations)
+			if tt.wantInsert {
+				collectionMock.On("InsertOne", ctx, mock.MatchedBy(func(doc bson.M) bool {
+					return doc["_id"] == testObjectID && doc["name"] == tt.givenCategory.Name && doc["position"] == tt.givenCategory.Position
+				})).Return(&mongo.InsertOneResult{InsertedID: testObjectID}, tt.givenErr)
+			}
+
+			category, err := newCategoryRepository(collectionMock).Create(ctx, tt.givenCategory)
+
+			switch {
+			case tt.wantHTTP != 0:
+				var errRepository repository.Error
+				require.ErrorAs(t, err, &errRepository)
+				require.Equal(t, tt.wantHTTP, errRepository.HTTP)
+			case tt.wantErr != nil:
+				require.ErrorIs(t, err, tt.wantErr)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, testObjectID.Hex(), category.ID)
+				require.False(t, category.CreatedAt.IsZero())
+				require.Equal(t, category.CreatedAt, category.UpdatedAt)
+			}
+			collectionMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCategoryUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		givenCategory repository.Category
+		givenResult   *mongo.SingleResult
+		wantPosition  bool
+		wantCategory  repository.Category
+		wantHTTP      int
+	}{
+		{
+			name:          "Given_Position_When_Update_Then_CategoryIsMoved",
+			givenCategory: repository.Category{ID: testObjectID.Hex(), Name: "Dairy", Position: 2},
+			givenResult:   mockCategorySingleResult(nil),
+			wantPosition:  true,
+			wantCategory:  mockCategory(),
+		},
+		{
+			name:          "Given_ZeroPosition_When_Update_Then_PositionIsNotSet",
+			givenCategory: repository.Category{ID: testObjectID.Hex(), Name: "Dairy"},
+			givenResult:   mockCategorySingleResult(nil),
+			wantCategory:  mockCategory(),
+		},
+		{
+			name:          "Given_MissingCategory_When_Update_Then_ExpectedNotFoundError",
+			givenCategory: repository.Category{ID: testObjectID.Hex(), Name: "Dairy"},
+			givenResult:   mockCategorySingleResult(mongo.ErrNoDocuments),
+			wantHTTP:      http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionMock := new(dbmongo.MockMongoCollectionOperations)
+			collectionMock.On("FindOneAndUpdate", ctx, bson.M{"_id": testObjectID}, mock.MatchedBy(func(update bson.M) bool {
+				set := update["$set"].(bson.M)
+				_, hasPosition := set["position"]
+				return set["name"] == "Dairy" && hasPosition == tt.wantPosition
+			})).Return(tt.givenResult)
+
+			category, err := newCategoryRepository(collectionMock).Update(ctx, tt.givenCategory)
+
+			if tt.wantHTTP != 0 {
+				var errRepository repository.Error
+				require.ErrorAs(t, err, &errRepository)
+				require.Equal(t, tt.wantHTTP, errRepository.HTTP)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantCategory, category)
+			}
+			collectionMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCategoryLock(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		givenResult *mongo.UpdateResult
+		givenErr    error
+		wantHTTP    int
+	}{
+		{
+			name:        "Given_ExistingCategory_When_Lock_Then_ExpectedSuccess",
+			givenResult: &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1},
+		},
+		{
+			name:        "Given_MissingCategory_When_Lock_Then_ExpectedNotFoundError",
+			givenResult: &mongo.UpdateResult{},
+			wantHTTP:    http.StatusNotFound,
+		},
+		{
+			name:        "Given_DatabaseError_When_Lock_Then_ExpectedInternalError",
+			givenResult: (*mongo.UpdateResult)(nil),
+			givenErr:    errDatabase,
+			wantHTTP:    http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionMock := new(dbmongo.MockMongoCollectionOperations)
+			collectionMock.On("UpdateOne", ctx, bson.M{"_id": testObjectID}, bson.M{"$inc": bson.M{"locks": 1}}).Return(tt.givenResult, tt.givenErr)
+
+			err := newCategoryRepository(collectionMock).Lock(ctx, testObjectID.Hex())
+
+			if tt.wantHTTP != 0 {
+				var errRepository repository.Error
+				require.ErrorAs(t, err, &errRepository)
+				require.Equal(t, tt.wantHTTP, errRepository.HTTP)
+			} else {
+				require.NoError(t, err)
+			}
+			collectionMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCategoryList(t *testing.T) {
+	ctx := context.Background()
+
+	collectionMock := new(dbmongo.MockMongoCollectionOperations)
+	cursorMock := new(dbmongo.MockMongoCursorOperations)
+	collectionMock.On("Find", ctx, bson.M{}, mock.Anything).Return(cursorMock, nil)
+	cursorMock.On("All", ctx, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		results := args.Get(1).(*[]repository.Category)
+		*results = append((*results)[:0], mockCategory())
+	})
+	cursorMock.On("Close", ctx).Return(nil)
+
+	categories, err := newCategoryRepository(collectionMock).List(ctx)
+
+	require.NoError(t, err)
+	require.Equal(t, []repository.Category{mockCategory()}, categories)
+	collectionMock.AssertExpectations(t)
+	cursorMock.AssertExpectations(t)
+}
@@ -0,0 +1,105 @@
+package mongodb_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	dbmongo "github.com/lucaspereirasilva0/list-manager-api/internal/database/mongodb"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	mongorepo "github.com/lucaspereirasilva0/list-manager-api/internal/repository/mongodb"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository/repositorytest"
+)
+
+// TestTxConformance runs the shared transaction suite against MONGO_TEST_URI.
+// Transactions need a replica set, so it also requires MONGO_TEST_REPLICA_SET=true.
+func TestTxConformance(t *testing.T) {
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" || os.Getenv("MONGO_TEST_REPLICA_SET") != "true" {
+		t.Skip("MONGO_TEST_URI or MONGO_TEST_REPLICA_SET not set, skipping MongoDB transaction tests")
+	}
+
+	ctx := context.Background()
+	client, err := dbmongo.NewClient(ctx, uri, "listmanager_test")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Disconnect(context.Background()))
+	})
+
+	repositorytest.RunTx(t, func(t *testing.T) (repository.ItemRepository, repository.TxManager) {
+		_, err := client.GetCollection(mongorepo.CollectionItems).DeleteMany(ctx, bson.M{})
+		require.NoError(t, err)
+
+		return mongorepo.NewMongoDBItemRepository(client), mongorepo.NewMongoTxManager(client)
+	})
+}
+
+// TestConformance runs the shared repository suite against a real MongoDB
+// reachable at MONGO_TEST_URI. It is skipped when the variable is not set.
+func TestConformance(t *testing.T) {
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI not set, skipping MongoDB integration tests")
+	}
+
+	ctx := context.Background()
+	client, err := dbmongo.NewClient(ctx, uri, "listmanager_test")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Disconnect(context.Background()))
+	})
+
+	repositorytest.Run(t, func(t *testing.T) repository.ItemRepository {
+		_, err := client.GetCollection(mongorepo.CollectionItems).DeleteMany(ctx, bson.M{})
+		require.NoError(t, err)
+
+		return mongorepo.NewMongoDBItemRepository(client)
+	})
+}
+
+// TestListConformance runs the shared list suite against MONGO_TEST_URI.
+func TestListConformance(t *testing.T) {
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI not set, skipping MongoDB integration tests")
+	}
+
+	ctx := context.Background()
+	client, err := dbmongo.NewClient(ctx, uri, "listmanager_test")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Disconnect(context.Background()))
+	})
+
+	repositorytest.RunList(t, func(t *testing.T) repository.ListRepository {
+		_, err := client.GetCollection(mongorepo.CollectionLists).DeleteMany(ctx, bson.M{})
+		require.NoError(t, err)
+
+		return mongorepo.NewMongoDBListRepository(client)
+	})
+}
+
+// TestCategoryConformance runs the shared category suite against MONGO_TEST_URI.
+func TestCategoryConformance(t *testing.T) {
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI not set, skipping MongoDB integration tests")
+	}
+
+	ctx := context.Background()
+	client, err := dbmongo.NewClient(ctx, uri, "listmanager_test")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Disconnect(context.Background()))
+	})
+
+	repositorytest.RunCategory(t, func(t *testing.T) repository.CategoryRepository {
+		_, err := client.GetCollection(mongorepo.CollectionCategories).DeleteMany(ctx, bson.M{})
+		require.NoError(t, err)
+
+		return mongorepo.NewMongoDBCategoryRepository(client)
+	})
+}
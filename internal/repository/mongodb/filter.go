@@ -0,0 +1,146 @@
+package mongodb
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/collation"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+// listFilter translates the List options into the BSON filter of the items collection:
+// the conditions of opts.Filter and, on later pages, the position after the cursor.
+func listFilter(opts repository.ListOptions, sort []repository.SortField) (bson.M, error) {
+	filter := itemFilter(opts.Filter)
+
+	cursor, err := repository.DecodeCursor(opts.Cursor, sort)
+	if err != nil {
+		return nil, err
+	}
+	if cursor != nil {
+		objectID, err := primitive.ObjectIDFromHex(cursor.ID)
+		if err != nil {
+			return nil, repository.NewInvalidCursorError(err)
+		}
+		filter["$or"] = afterCursor(cursor.Item(), objectID, sort)
+	}
+
+	return filter, nil
+}
+
+// afterCursor matches the documents after the cursor position in the given order:
+// {k1 > v1} OR {k1 = v1, k2 > v2} OR ... with _id as the last key.
+func afterCursor(position repository.Item, objectID primitive.ObjectID, sort []repository.SortField) bson.A {
+	alternatives := make(bson.A, 0, len(sort)+1)
+	equal := bson.M{}
+	for _, s := range sort {
+		value := sortValue(position, s.Field)
+		operator := "$gt"
+		if s.Desc {
+			operator = "$lt"
+		}
+
+		alternative := bson.M{s.Field: bson.M{operator: value}}
+		for key, v := range equal {
+			alternative[key] = v
+		}
+		alternatives = append(alternatives, alternative)
+		equal[s.Field] = value
+	}
+
+	last := bson.M{"_id": bson.M{"$gt": objectID}}
+	for key, v := range equal {
+		last[key] = v
+	}
+	return append(alternatives, last)
+}
+
+// listSort returns the sort document of the order, ending with _id
+// (indexes listId_1_position_1_createdAt_1__id_1, createdAt_1__id_1, name_1__id_1
+// and updatedAt_-1__id_1).
+func listSort(sort []repository.SortField) bson.D {
+	doc := make(bson.D, 0, len(sort)+1)
+	for _, s := range sort {
+		direction := 1
+		if s.Desc {
+			direction = -1
+		}
+		doc = append(doc, bson.E{Key: s.Field, Value: direction})
+	}
+	return append(doc, bson.E{Key: "_id", Value: 1})
+}
+
+// sortsByName reports whether the order needs the name collation.
+func sortsByName(sort []repository.SortField) bool {
+	for _, s := range sort {
+		if s.Field == repository.SortFieldName {
+			return true
+		}
+	}
+	return false
+}
+
+// nameCollation compares names ignoring case and accents (strength 1), like
+// the collation package does for the other backends.
+func nameCollation() *options.Collation {
+	return &options.Collation{Locale: collation.Locale, Strength: collation.Strength}
+}
+
+func sortValue(item repository.Item, field string) any {
+	switch field {
+	case repository.SortFieldName:
+		return item.Name
+	case repository.SortFieldActive:
+		return item.Active
+	case repository.SortFieldCreatedAt:
+		return item.CreatedAt
+	case repository.SortFieldPosition:
+		return item.Position
+	}
+	return item.UpdatedAt
+}
+
+// itemFilter translates a repository.ItemFilter into a BSON filter (indexes
+// listId_1_createdAt_1__id_1, active_1_createdAt_1__id_1, createdAt_1__id_1 and updatedAt_-1).
+func itemFilter(f repository.ItemFilter) bson.M {
+	filter := bson.M{}
+
+	if f.ListID != "" {
+		filter["listId"] = f.ListID
+	}
+	if f.Active != nil {
+		filter["active"] = *f.Active
+	}
+	if f.Name != "" {
+		filter["name"] = primitive.Regex{Pattern: regexp.QuoteMeta(f.Name), Options: "i"}
+	}
+	if f.HasObservation != nil {
+		if *f.HasObservation {
+			filter["observation"] = bson.M{"$nin": bson.A{nil, ""}}
+		} else {
+			// null also matches the documents without the field
+			filter["observation"] = bson.M{"$in": bson.A{nil, ""}}
+		}
+	}
+	if f.CreatedAfter != nil {
+		filter["createdAt"] = bson.M{"$gt": *f.CreatedAfter}
+	}
+	if f.UpdatedBefore != nil {
+		filter["updatedAt"] = bson.M{"$lt": *f.UpdatedBefore}
+	}
+
+	return filter
+}
+
+// searchWords returns the words of a search query as typed (diacritics are kept
+// for the Portuguese stemmer), dropping the punctuation.
+func searchWords(query string) []string {
+	return strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
@@ -0,0 +1,86 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	dbmongo "github.com/lucaspereirasilva0/list-manager-api/internal/database/mongodb"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+// MongoIdempotencyStore implements repository.IdempotencyStore for MongoDB.
+// Keys are the _id of the documents, so a key is reserved by a single insert,
+// and expired documents are removed by the TTL index created by migration 7.
+type MongoIdempotencyStore struct {
+	client dbmongo.ClientOperations
+}
+
+// NewMongoIdempotencyStore creates a new instance of MongoIdempotencyStore
+func NewMongoIdempotencyStore(client dbmongo.ClientOperations) repository.IdempotencyStore {
+	return &MongoIdempotencyStore{
+		client: client,
+	}
+}
+
+// Reserve inserts record unless its key is already claimed and not expired
+func (s *MongoIdempotencyStore) Reserve(ctx context.Context, record repository.IdempotencyRecord) (repository.IdempotencyRecord, bool, error) {
+	collection := s.client.GetCollection(CollectionIdempotencyKeys)
+
+	// The TTL monitor only runs once a minute, an expired key may still be stored
+	_, err := collection.DeleteOne(ctx, bson.M{"_id": record.Key, "expiresAt": bson.M{"$lte": now()}})
+	if err != nil {
+		return repository.IdempotencyRecord{}, false, repository.HandleError(err)
+	}
+
+	_, err = collection.InsertOne(ctx, record)
+	if err == nil {
+		return repository.IdempotencyRecord{}, true, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return repository.IdempotencyRecord{}, false, repository.HandleError(err)
+	}
+
+	var stored repository.IdempotencyRecord
+	err = collection.FindOne(ctx, bson.M{"_id": record.Key}).Decode(&stored)
+	if err == mongo.ErrNoDocuments {
+		// Released in the meantime: report it as still in progress, the client retries
+		return repository.IdempotencyRecord{Key: record.Key, RequestHash: record.RequestHash}, false, nil
+	} else if err != nil {
+		return repository.IdempotencyRecord{}, false, repository.HandleError(err)
+	}
+
+	return stored, false, nil
+}
+
+// Complete stores the response of the request that reserved record.Key
+func (s *MongoIdempotencyStore) Complete(ctx context.Context, record repository.IdempotencyRecord) error {
+	collection := s.client.GetCollection(CollectionIdempotencyKeys)
+
+	update := bson.M{"$set": bson.M{
+		"completed":  true,
+		"statusCode": record.StatusCode,
+		"header":     record.Header,
+		"body":       record.Body,
+		"expiresAt":  record.ExpiresAt,
+	}}
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": record.Key}, update)
+	if err != nil {
+		return repository.HandleError(err)
+	}
+
+	return nil
+}
+
+// Release removes a key
+func (s *MongoIdempotencyStore) Release(ctx context.Context, key string) error {
+	collection := s.client.GetCollection(CollectionIdempotencyKeys)
+
+	_, err := collection.DeleteOne(ctx, bson.M{"_id": key})
+	if err != nil {
+		return repository.HandleError(err)
+	}
+
+	return nil
+}
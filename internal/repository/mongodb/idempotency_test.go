@@ -0,0 +1,141 @@
+package mongodb_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	dbmongo "github.com/lucaspereirasilva0/list-manager-api/internal/database/mongodb"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	mongorepo "github.com/lucaspereirasilva0/list-manager-api/internal/repository/mongodb"
+)
+
+func mockIdempotencyRecord() repository.IdempotencyRecord {
+	return repository.IdempotencyRecord{
+		Key:         "key-1",
+		RequestHash: "hash-1",
+		ExpiresAt:   time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func mockCompletedIdempotencyResult() *mongo.SingleResult {
+	record := mockIdempotencyRecord()
+	record.Completed = true
+	record.StatusCode = http.StatusCreated
+	record.Body = []byte(`{"id":"1"}`)
+	return mongo.NewSingleResultFromDocument(record, nil, nil)
+}
+
+func TestMongoIdempotencyStoreReserve(t *testing.T) {
+	ctx := context.Background()
+	duplicateKeyErr := mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000}}}
+
+	tests := []struct {
+		name           string
+		givenDeleteErr error
+		givenInsertErr error
+		givenFindOne   *mongo.SingleResult
+		wantReserved   bool
+		wantStored     repository.IdempotencyRecord
+		wantErr        error
+	}{
+		{
+			name:         "Given_NewKey_When_Reserve_Then_KeyIsReserved",
+			wantReserved: true,
+		},
+		{
+			name:           "Given_CompletedKey_When_Reserve_Then_StoredRecordIsReturned",
+			givenInsertErr: duplicateKeyErr,
+			givenFindOne:   mockCompletedIdempotencyResult(),
+			wantStored: repository.IdempotencyRecord{
+				Key:         "key-1",
+				RequestHash: "hash-1",
+				Completed:   true,
+				StatusCode:  http.StatusCreated,
+				Body:        []byte(`{"id":"1"}`),
+				ExpiresAt:   mockIdempotencyRecord().ExpiresAt,
+			},
+		},
+		{
+			name:           "Given_KeyReleasedAfterInsert_When_Reserve_Then_KeyIsInProgress",
+			givenInsertErr: duplicateKeyErr,
+			givenFindOne:   mongo.NewSingleResultFromDocument(bson.M{}, mongo.ErrNoDocuments, nil),
+			wantStored:     repository.IdempotencyRecord{Key: "key-1", RequestHash: "hash-1"},
+		},
+		{
+			name:           "Given_DatabaseError_When_Reserve_Then_ExpectedError",
+			givenInsertErr: errDatabase,
+			wantErr:        errDatabase,
+		},
+		{
+			name:           "Given_DeleteError_When_Reserve_Then_ExpectedError",
+			givenDeleteErr: errDatabase,
+			wantErr:        errDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionMock := new(dbmongo.MockMongoCollectionOperations)
+			clientMock := new(dbmongo.MockClientOperations)
+
+			collectionMock.On("DeleteOne", ctx, mock.MatchedBy(func(filter bson.M) bool {
+				return filter["_id"] == "key-1" && filter["expiresAt"] != nil
+			})).Return(mockNotFoundDeleteOneResult(), tt.givenDeleteErr)
+			if tt.givenDeleteErr == nil {
+				collectionMock.On("InsertOne", ctx, mockIdempotencyRecord()).Return(&mongo.InsertOneResult{InsertedID: "key-1"}, tt.givenInsertErr)
+			}
+			if tt.givenFindOne != nil {
+				collectionMock.On("FindOne", ctx, bson.M{"_id": "key-1"}).Return(tt.givenFindOne)
+			}
+			clientMock.On("GetCollection", mongorepo.CollectionIdempotencyKeys).Return(collectionMock)
+
+			store := mongorepo.NewMongoIdempotencyStore(clientMock)
+			stored, reserved, err := store.Reserve(ctx, mockIdempotencyRecord())
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantReserved, reserved)
+				require.Equal(t, tt.wantStored, stored)
+			}
+			collectionMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMongoIdempotencyStoreComplete(t *testing.T) {
+	ctx := context.Background()
+
+	collectionMock := new(dbmongo.MockMongoCollectionOperations)
+	clientMock := new(dbmongo.MockClientOperations)
+
+	record := mockIdempotencyRecord()
+	record.Completed = true
+	record.StatusCode = http.StatusCreated
+	record.Header = http.Header{"Content-Type": {"application/json"}}
+	record.Body = []byte(`{"id":"1"}`)
+
+	collectionMock.On("UpdateOne", ctx, bson.M{"_id": "key-1"}, bson.M{"$set": bson.M{
+		"completed":  true,
+		"statusCode": http.StatusCreated,
+		"header":     record.Header,
+		"body":       record.Body,
+		"expiresAt":  record.ExpiresAt,
+	}}).Return(&mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil)
+	collectionMock.On("DeleteOne", ctx, bson.M{"_id": "key-1"}).Return(mockSuccessfulDeleteOneResult(), nil)
+	clientMock.On("GetCollection", mongorepo.CollectionIdempotencyKeys).Return(collectionMock)
+
+	store := mongorepo.NewMongoIdempotencyStore(clientMock)
+	require.NoError(t, store.Complete(ctx, record))
+	require.NoError(t, store.Release(ctx, "key-1"))
+
+	collectionMock.AssertExpectations(t)
+}
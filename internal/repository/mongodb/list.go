@@ -0,0 +1,162 @@
+package mongodb
+
+import (
+	"context"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	dbmongo "github.com/lucaspereirasilva0/list-manager-api/internal/database/mongodb"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+// MongoDBListRepository implements repository.ListRepository for MongoDB.
+// The items refer to their list by the hexadecimal form of its _id (listId).
+type MongoDBListRepository struct {
+	client dbmongo.ClientOperations
+}
+
+// NewMongoDBListRepository creates a new instance of MongoDBListRepository
+func NewMongoDBListRepository(client dbmongo.ClientOperations) repository.ListRepository {
+	return &MongoDBListRepository{
+		client: client,
+	}
+}
+
+// Create inserts a new list in the MongoDB repository
+func (r *MongoDBListRepository) Create(ctx context.Context, list repository.List) (repository.List, error) {
+	collection := r.client.GetCollection(CollectionLists)
+
+	objectID, err := primitive.ObjectIDFromHex(list.ID)
+	if err != nil {
+		return repository.List{}, repository.NewInvalidHexIDError()
+	}
+
+	now := now()
+	_, err = collection.InsertOne(ctx, bson.M{
+		"_id":       objectID,
+		"name":      list.Name,
+		"createdAt": now,
+		"updatedAt": now,
+	})
+	if err != nil {
+		return repository.List{}, repository.HandleError(err)
+	}
+
+	list.ID = objectID.Hex()
+	list.CreatedAt = now
+	list.UpdatedAt = now
+
+	return list, nil
+}
+
+// Update renames a list and returns it as stored after the update
+func (r *MongoDBListRepository) Update(ctx context.Context, list repository.List) (repository.List, error) {
+	collection := r.client.GetCollection(CollectionLists)
+
+	objectID, err := primitive.ObjectIDFromHex(list.ID)
+	if err != nil {
+		return repository.List{}, repository.NewInvalidHexIDError()
+	}
+
+	update := bson.M{"$set": bson.M{"name": list.Name, "updatedAt": now()}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updatedList repository.List
+	err = collection.FindOneAndUpdate(ctx, bson.M{"_id": objectID}, update, opts).Decode(&updatedList)
+	if err == mongo.ErrNoDocuments {
+		return repository.List{}, repository.NewListNotFoundError()
+	} else if err != nil {
+		return repository.List{}, repository.HandleError(err)
+	}
+
+	return updatedList, nil
+}
+
+// Delete removes a list from the MongoDB repository
+func (r *MongoDBListRepository) Delete(ctx context.Context, id string) error {
+	collection := r.client.GetCollection(CollectionLists)
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return repository.NewInvalidHexIDError()
+	}
+
+	result, err := collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return repository.HandleError(err)
+	}
+	if result.DeletedCount == 0 {
+		return repository.NewListNotFoundError()
+	}
+
+	return nil
+}
+
+// Lock reports a missing list. It increments the locks counter of the list, as
+// MongoDB only detects the conflicts between writes: a transaction deleting the
+// list at the same time conflicts with the one locking it and is retried.
+func (r *MongoDBListRepository) Lock(ctx context.Context, id string) error {
+	collection := r.client.GetCollection(CollectionLists)
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return repository.NewInvalidHexIDError()
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$inc": bson.M{"locks": 1}})
+	if err != nil {
+		return repository.HandleError(err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.NewListNotFoundError()
+	}
+
+	return nil
+}
+
+// GetByID retrieves a list by its ID from the MongoDB repository
+func (r *MongoDBListRepository) GetByID(ctx context.Context, id string) (repository.List, error) {
+	collection := r.client.GetCollection(CollectionLists)
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return repository.List{}, repository.NewInvalidHexIDError()
+	}
+
+	var list repository.List
+	err = collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&list)
+	if err == mongo.ErrNoDocuments {
+		return repository.List{}, repository.NewListNotFoundError()
+	} else if err != nil {
+		return repository.List{}, repository.HandleError(err)
+	}
+
+	return list, nil
+}
+
+// List retrieves every list from the MongoDB repository, ordered by createdAt and _id
+func (r *MongoDBListRepository) List(ctx context.Context) ([]repository.List, error) {
+	collection := r.client.GetCollection(CollectionLists)
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return nil, repository.HandleError(err)
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("Error closing MongoDB cursor: %v", err)
+		}
+	}()
+
+	lists := []repository.List{}
+	if err = cursor.All(ctx, &lists); err != nil {
+		return nil, repository.HandleError(err)
+	}
+
+	return lists, nil
+}
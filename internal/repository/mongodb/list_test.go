@@ -0,0 +1,338 @@
+package mongodb_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	dbmongo "github.com/lucaspereirasilva0/list-manager-api/internal/database/mongodb"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	mongorepo "github.com/lucaspereirasilva0/list-manager-api/internal/repository/mongodb"
+)
+
+func mockList() repository.List {
+	return repository.List{
+		ID:        testObjectID.Hex(),
+		Name:      "Supermarket",
+		CreatedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func mockListSingleResult(err error) *mongo.SingleResult {
+	bsonBytes, _ := bson.Marshal(mockList())
+	return mongo.NewSingleResultFromDocument(bsonBytes, err, nil)
+}
+
+func newListRepository(collectionMock *dbmongo.MockMongoCollectionOperations) repository.ListRepository {
+	clientMock := new(dbmongo.MockClientOperations)
+	clientMock.On("GetCollection", mongorepo.CollectionLists).Return(collectionMock)
+	return mongorepo.NewMongoDBListRepository(clientMock)
+}
+
+func TestListCreate(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		givenList  repository.List
+		givenErr   error
+		wantInsert bool
+		wantHTTP   int
+		wantErr    error
+		wantListID string
+	}{
+		{
+			name:       "Given_List_When_Create_Then_ExpectedStoredTimestamps",
+			givenList:  repository.List{ID: testObjectID.Hex(), Name: "Supermarket"},
+			wantInsert: true,
+			wantListID: testObjectID.Hex(),
+		},
+		{
+			name:      "Given_InvalidHexID_When_Create_Then_ExpectedInvalidHexIDError",
+			givenList: repository.List{ID: "invalid-hex-id", Name: "Supermarket"},
+			wantHTTP:  http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Given_DatabaseError_When_Create_Then_ExpectedInternalError",
+			givenList:  repository.List{ID: testObjectID.Hex(), Name: "Supermarket"},
+			givenErr:   errDatabase,
+			wantInsert: true,
+			wantErr:    errDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionMock := new(dbmongo.MockMongoCollectionOperations)
+			if tt.wantInsert {
+				collectionMock.On("InsertOne", ctx, mock.MatchedBy(func(doc bson.M) bool {
+					return doc["_id"] == testObjectID && doc["name"] == tt.givenList.Name
+				})).Return(&mongo.InsertOneResult{InsertedID: testObjectID}, tt.givenErr)
+			}
+
+			list, err := newListRepository(collectionMock).Create(ctx, tt.givenList)
+
+			switch {
+			case tt.wantHTTP != 0:
+				var errRepository repository.Error
+				require.ErrorAs(t, err, &errRepository)
+				require.Equal(t, tt.wantHTTP, errRepository.HTTP)
+			case tt.wantErr != nil:
+				require.ErrorIs(t, err, tt.wantErr)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, tt.wantListID, list.ID)
+				require.False(t, list.CreatedAt.IsZero())
+				require.Equal(t, list.CreatedAt, list.UpdatedAt)
+			}
+			collectionMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListGetByID(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		givenID     string
+		givenResult *mongo.SingleResult
+		wantList    repository.List
+		wantHTTP    int
+	}{
+		{
+			name:        "Given_ExistingList_When_GetByID_Then_ExpectedList",
+			givenID:     testObjectID.Hex(),
+			givenResult: mockListSingleResult(nil),
+			wantList:    mockList(),
+		},
+		{
+			name:        "Given_MissingList_When_GetByID_Then_ExpectedNotFoundError",
+			givenID:     testObjectID.Hex(),
+			givenResult: mockListSingleResult(mongo.ErrNoDocuments),
+			wantHTTP:    http.StatusNotFound,
+		},
+		{
+			name:     "Given_InvalidHexID_When_GetByID_Then_ExpectedInvalidHexIDError",
+			givenID:  "invalid-hex-id",
+			wantHTTP: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionMock := new(dbmongo.MockMongoCollectionOperations)
+			if tt.givenResult != nil {
+				collectionMock.On("FindOne", ctx, bson.M{"_id": testObjectID}).Return(tt.givenResult)
+			}
+
+			list, err := newListRepository(collectionMock).GetByID(ctx, tt.givenID)
+
+			if tt.wantHTTP != 0 {
+				var errRepository repository.Error
+				require.ErrorAs(t, err, &errRepository)
+				require.Equal(t, tt.wantHTTP, errRepository.HTTP)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantList, list)
+			}
+			collectionMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		givenResult *mongo.SingleResult
+		wantList    repository.List
+		wantHTTP    int
+	}{
+		{
+			name:        "Given_ExistingList_When_Update_Then_ExpectedStoredList",
+			givenResult: mockListSingleResult(nil),
+			wantList:    mockList(),
+		},
+		{
+			name:        "Given_MissingList_When_Update_Then_ExpectedNotFoundError",
+			givenResult: mockListSingleResult(mongo.ErrNoDocuments),
+			wantHTTP:    http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionMock := new(dbmongo.MockMongoCollectionOperations)
+			collectionMock.On("FindOneAndUpdate", ctx, bson.M{"_id": testObjectID}, mock.MatchedBy(func(update bson.M) bool {
+				return update["$set"].(bson.M)["name"] == "Supermarket"
+			})).Return(tt.givenResult)
+
+			list, err := newListRepository(collectionMock).Update(ctx, repository.List{ID: testObjectID.Hex(), Name: "Supermarket"})
+
+			if tt.wantHTTP != 0 {
+				var errRepository repository.Error
+				require.ErrorAs(t, err, &errRepository)
+				require.Equal(t, tt.wantHTTP, errRepository.HTTP)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantList, list)
+			}
+			collectionMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListDelete(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		givenResult *mongo.DeleteResult
+		givenErr    error
+		wantHTTP    int
+	}{
+		{
+			name:        "Given_ExistingList_When_Delete_Then_ExpectedSuccess",
+			givenResult: mockSuccessfulDeleteOneResult(),
+		},
+		{
+			name:        "Given_MissingList_When_Delete_Then_ExpectedNotFoundError",
+			givenResult: mockNotFoundDeleteOneResult(),
+			wantHTTP:    http.StatusNotFound,
+		},
+		{
+			name:        "Given_DatabaseError_When_Delete_Then_ExpectedInternalError",
+			givenResult: (*mongo.DeleteResult)(nil),
+			givenErr:    errDatabase,
+			wantHTTP:    http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionMock := new(dbmongo.MockMongoCollectionOperations)
+			collectionMock.On("DeleteOne", ctx, bson.M{"_id": testObjectID}).Return(tt.givenResult, tt.givenErr)
+
+			err := newListRepository(collectionMock).Delete(ctx, testObjectID.Hex())
+
+			if tt.wantHTTP != 0 {
+				var errRepository repository.Error
+				require.ErrorAs(t, err, &errRepository)
+				require.Equal(t, tt.wantHTTP, errRepository.HTTP)
+			} else {
+				require.NoError(t, err)
+			}
+			collectionMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListLock(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		givenResult *mongo.UpdateResult
+		givenErr    error
+		wantHTTP    int
+	}{
+		{
+			name:        "Given_ExistingList_When_Lock_Then_ExpectedSuccess",
+			givenResult: &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1},
+		},
+		{
+			name:        "Given_MissingList_When_Lock_Then_ExpectedNotFoundError",
+			givenResult: &mongo.UpdateResult{},
+			wantHTTP:    http.StatusNotFound,
+		},
+		{
+			name:        "Given_DatabaseError_When_Lock_Then_ExpectedInternalError",
+			givenResult: (*mongo.UpdateResult)(nil),
+			givenErr:    errDatabase,
+			wantHTTP:    http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionMock := new(dbmongo.MockMongoCollectionOperations)
+			collectionMock.On("UpdateOne", ctx, bson.M{"_id": testObjectID}, bson.M{"$inc": bson.M{"locks": 1}}).Return(tt.givenResult, tt.givenErr)
+
+			err := newListRepository(collectionMock).Lock(ctx, testObjectID.Hex())
+
+			if tt.wantHTTP != 0 {
+				var errRepository repository.Error
+				require.ErrorAs(t, err, &errRepository)
+				require.Equal(t, tt.wantHTTP, errRepository.HTTP)
+			} else {
+				require.NoError(t, err)
+			}
+			collectionMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListList(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		givenStored  []repository.List
+		givenFindErr error
+		wantLists    []repository.List
+		wantErr      error
+	}{
+		{
+			name:        "Given_Lists_When_List_Then_ExpectedEveryList",
+			givenStored: []repository.List{mockList()},
+			wantLists:   []repository.List{mockList()},
+		},
+		{
+			name:      "Given_NoList_When_List_Then_ExpectedEmptySlice",
+			wantLists: []repository.List{},
+		},
+		{
+			name:         "Given_DatabaseError_When_List_Then_ExpectedInternalError",
+			givenFindErr: errDatabase,
+			wantErr:      errDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionMock := new(dbmongo.MockMongoCollectionOperations)
+			cursorMock := new(dbmongo.MockMongoCursorOperations)
+
+			if tt.givenFindErr != nil {
+				collectionMock.On("Find", ctx, bson.M{}, mock.Anything).Return((*dbmongo.MockMongoCursorOperations)(nil), tt.givenFindErr)
+			} else {
+				collectionMock.On("Find", ctx, bson.M{}, mock.Anything).Return(cursorMock, nil)
+				cursorMock.On("All", ctx, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					results := args.Get(1).(*[]repository.List)
+					*results = append((*results)[:0], tt.givenStored...)
+				})
+				cursorMock.On("Close", ctx).Return(nil)
+			}
+
+			lists, err := newListRepository(collectionMock).List(ctx)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantLists, lists)
+			collectionMock.AssertExpectations(t)
+			cursorMock.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,409 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	dbmongo "github.com/lucaspereirasilva0/list-manager-api/internal/database/mongodb"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+const (
+	indexItemsActive    = "active_1"
+	indexItemsCreatedAt = "createdAt_1__id_1"
+	indexItemsUpdatedAt = "updatedAt_-1"
+
+	indexItemsActiveCreatedAt = "active_1_createdAt_1__id_1"
+
+	indexItemsName          = "name_1__id_1"
+	indexItemsUpdatedAtSort = "updatedAt_-1__id_1"
+
+	indexItemsText = "items_text"
+
+	indexIdempotencyKeysExpiresAt = "expiresAt_1"
+
+	indexItemsListCreatedAt = "listId_1_createdAt_1__id_1"
+
+	indexItemsCategory = "categoryId_1"
+
+	indexItemsListPosition     = "listId_1_position_1"
+	indexItemsListPositionSort = "listId_1_position_1_createdAt_1__id_1"
+)
+
+// Migrations returns the schema migrations of the collections, in version order.
+func Migrations() []dbmongo.Migration {
+	return []dbmongo.Migration{
+		{
+			Version:     1,
+			Description: "create items indexes",
+			Up:          createItemsIndexes,
+			Down:        dropItemsIndexes,
+		},
+		{
+			Version:     2,
+			Description: "backfill missing item timestamps",
+			Up:          backfillItemsTimestamps,
+			// The backfilled timestamps are valid data, there is nothing to undo.
+			Down: func(ctx context.Context, db dbmongo.MongoDatabaseOperations) error { return nil },
+		},
+		{
+			Version:     3,
+			Description: "create items active listing index",
+			Up:          createItemsActiveListingIndex,
+			Down:        dropItemsActiveListingIndex,
+		},
+		{
+			Version:     4,
+			Description: "create items sort indexes",
+			Up:          createItemsSortIndexes,
+			Down:        dropItemsSortIndexes,
+		},
+		{
+			Version:     5,
+			Description: "create items text index",
+			Up:          createItemsTextIndex,
+			Down:        dropItemsTextIndex,
+		},
+		{
+			Version:     6,
+			Description: "backfill item versions",
+			Up:          backfillItemsVersion,
+			Down:        unsetItemsVersion,
+		},
+		{
+			Version:     7,
+			Description: "create idempotency keys TTL index",
+			Up:          createIdempotencyKeysTTLIndex,
+			Down:        dropIdempotencyKeysTTLIndex,
+		},
+		{
+			Version:     8,
+			Description: "create default list",
+			Up:          createDefaultList,
+			Down:        deleteDefaultList,
+		},
+		{
+			Version:     9,
+			Description: "move items to the default list",
+			Up:          moveItemsToDefaultList,
+			Down:        unsetItemsList,
+		},
+		{
+			Version:     10,
+			Description: "create items category index",
+			Up:          createItemsCategoryIndex,
+			Down:        dropItemsCategoryIndex,
+		},
+		{
+			Version:     11,
+			Description: "backfill item positions",
+			Up:          backfillItemsPosition,
+			Down:        unsetItemsPosition,
+		},
+	}
+}
+
+func createItemsIndexes(ctx context.Context, db dbmongo.MongoDatabaseOperations) error {
+	_, err := db.Collection(CollectionItems).CreateIndexes(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "active", Value: 1}}, Options: options.Index().SetName(indexItemsActive)},
+		{Keys: bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}, Options: options.Index().SetName(indexItemsCreatedAt)},
+		{Keys: bson.D{{Key: "updatedAt", Value: -1}}, Options: options.Index().SetName(indexItemsUpdatedAt)},
+	})
+	return err
+}
+
+func dropItemsIndexes(ctx context.Context, db dbmongo.MongoDatabaseOperations) error {
+	collection := db.Collection(CollectionItems)
+	for _, name := range []string{indexItemsActive, indexItemsCreatedAt, indexItemsUpdatedAt} {
+		if err := collection.DropIndex(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createItemsActiveListingIndex serves the pages of items filtered by active
+// (e.g. only the unchecked ones) in the listing order, without an in-memory sort.
+func createItemsActiveListingIndex(ctx context.Context, db dbmongo.MongoDatabaseOperations) error {
+	_, err := db.Collection(CollectionItems).CreateIndexes(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "active", Value: 1}, {Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName(indexItemsActiveCreatedAt),
+		},
+	})
+	return err
+}
+
+func dropItemsActiveListingIndex(ctx context.Context, db dbmongo.MongoDatabaseOperations) error {
+	return db.Collection(CollectionItems).DropIndex(ctx, indexItemsActiveCreatedAt)
+}
+
+// createItemsSortIndexes serves the listings sorted by name (with the same
+// collation as the queries, otherwise the index is not used) and by most
+// recently updated.
+func createItemsSortIndexes(ctx context.Context, db dbmongo.MongoDatabaseOperations) error {
+	_, err := db.Collection(CollectionItems).CreateIndexes(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName(indexItemsName).SetCollation(nameCollation()),
+		},
+		{
+			Keys:    bson.D{{Key: "updatedAt", Value: -1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName(indexItemsUpdatedAtSort),
+		},
+	})
+	return err
+}
+
+func dropItemsSortIndexes(ctx context.Context, db dbmongo.MongoDatabaseOperations) error {
+	collection := db.Collection(CollectionItems)
+	for _, name := range []string{indexItemsName, indexItemsUpdatedAtSort} {
+		if err := collection.DropIndex(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createItemsTextIndex serves the item search. Version 3 text indexes are case
+// and diacritic insensitive; the weights match repository.SearchWeightName and
+// repository.SearchWeightObservation.
+func createItemsTextIndex(ctx context.Context, db dbmongo.MongoDatabaseOperations) error {
+	_, err := db.Collection(CollectionItems).CreateIndexes(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "name", Value: "text"}, {Key: "observation", Value: "text"}},
+			Options: options.Index().
+				SetName(indexItemsText).
+				SetDefaultLanguage("portuguese").
+				SetTextVersion(3).
+				SetWeights(bson.D{
+					{Key: "name", Value: repository.SearchWeightName},
+					{Key: "observation", Value: repository.SearchWeightObservation},
+				}),
+		},
+	})
+	return err
+}
+
+func dropItemsTextIndex(ctx context.Context, db dbmongo.MongoDatabaseOperations) error {
+	return db.Collection(CollectionItems).DropIndex(ctx, indexItemsText)
+}
+
+// backfillItemsTimestamps fills createdAt/updatedAt on items written before the
+// timestamps existed. Item IDs are random, so the ObjectID creation time cannot
+// be used: createdAt falls back to updatedAt or to the migration time, and
+// updatedAt falls back to createdAt.
+func backfillItemsTimestamps(ctx context.Context, db dbmongo.MongoDatabaseOperations) error {
+	collection := db.Collection(CollectionItems)
+
+	_, err := collection.UpdateMany(ctx,
+		bson.M{"createdAt": bson.M{"$exists": false}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{"createdAt": bson.M{"$ifNull": bson.A{"$updatedAt", "$$NOW"}}}}}},
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = collection.UpdateMany(ctx,
+		bson.M{"updatedAt": bson.M{"$exists": false}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{"updatedAt": "$createdAt"}}}},
+	)
+	return err
+}
+
+// backfillItemsVersion gives the items created before optimistic concurrency
+// their first version, so an If-Match on them can match the filter of the writes.
+func backfillItemsVersion(ctx context.Context, db dbmongo.MongoDatabaseOperations) error {
+	_, err := db.Collection(CollectionItems).UpdateMany(ctx,
+		bson.M{"version": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"version": int64(1)}},
+	)
+	return err
+}
+
+func unsetItemsVersion(ctx context.Context, db dbmongo.MongoDatabaseOperations) error {
+	_, err := db.Collection(CollectionItems).UpdateMany(ctx,
+		bson.M{},
+		bson.M{"$unset": bson.M{"version": ""}},
+	)
+	return err
+}
+
+// createIdempotencyKeysTTLIndex removes the idempotency keys once they expire
+func createIdempotencyKeysTTLIndex(ctx context.Context, db dbmongo.MongoDatabaseOperations) error {
+	_, err := db.Collection(CollectionIdempotencyKeys).CreateIndexes(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+			Options: options.Index().SetName(indexIdempotencyKeysExpiresAt).SetExpireAfterSeconds(0),
+		},
+	})
+	return err
+}
+
+func dropIdempotencyKeysTTLIndex(ctx context.Context, db dbmongo.MongoDatabaseOperations) error {
+	return db.Collection(CollectionIdempotencyKeys).DropIndex(ctx, indexIdempotencyKeysExpiresAt)
+}
+
+// createDefaultList creates the list the existing items are moved into, unless it exists
+func createDefaultList(ctx context.Context, db dbmongo.MongoDatabaseOperations) error {
+	id, err := primitive.ObjectIDFromHex(repository.DefaultListID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	_, err = db.Collection(CollectionLists).UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$setOnInsert": bson.M{"name": repository.DefaultListName, "createdAt": now, "updatedAt": now}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+func deleteDefaultList(ctx context.Context, db dbmongo.MongoDatabaseOperations) error {
+	id, err := primitive.ObjectIDFromHex(repository.DefaultListID)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection(CollectionLists).DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+// moveItemsToDefaultList puts the items created before there were lists in the
+// default one, and creates the index of the listings of a list
+func moveItemsToDefaultList(ctx context.Context, db dbmongo.MongoDatabaseOperations) error {
+	collection := db.Collection(CollectionItems)
+
+	_, err := collection.UpdateMany(ctx,
+		bson.M{"listId": bson.M{"$in": bson.A{nil, ""}}},
+		bson.M{"$set": bson.M{"listId": repository.DefaultListID}},
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = collection.CreateIndexes(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "listId", Value: 1}, {Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName(indexItemsListCreatedAt),
+		},
+	})
+	return err
+}
+
+func unsetItemsList(ctx context.Context, db dbmongo.MongoDatabaseOperations) error {
+	collection := db.Collection(CollectionItems)
+
+	if err := collection.DropIndex(ctx, indexItemsListCreatedAt); err != nil {
+		return err
+	}
+	_, err := collection.UpdateMany(ctx,
+		bson.M{},
+		bson.M{"$unset": bson.M{"listId": ""}},
+	)
+	return err
+}
+
+// createItemsCategoryIndex serves the removal of a deleted category from its
+// items. The uncategorized items have no categoryId, so the index is sparse.
+func createItemsCategoryIndex(ctx context.Context, db dbmongo.MongoDatabaseOperations) error {
+	_, err := db.Collection(CollectionItems).CreateIndexes(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "categoryId", Value: 1}},
+			Options: options.Index().SetName(indexItemsCategory).SetSparse(true),
+		},
+	})
+	return err
+}
+
+func dropItemsCategoryIndex(ctx context.Context, db dbmongo.MongoDatabaseOperations) error {
+	return db.Collection(CollectionItems).DropIndex(ctx, indexItemsCategory)
+}
+
+// backfillItemsPosition gives the items stored before there were positions one
+// in the creation order of their list, and creates the indexes of the
+// positions: a partial unique one, so two items of a list never get the same
+// position, and the one of the listings by position.
+func backfillItemsPosition(ctx context.Context, db dbmongo.MongoDatabaseOperations) error {
+	collection := db.Collection(CollectionItems)
+
+	cursor, err := collection.Find(ctx,
+		bson.M{"position": bson.M{"$in": bson.A{nil, ""}}},
+		options.Find().
+			SetProjection(bson.M{"listId": 1}).
+			SetSort(bson.D{{Key: "listId", Value: 1}, {Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("Error closing MongoDB cursor: %v", err)
+		}
+	}()
+
+	var items []struct {
+		ID     primitive.ObjectID `bson:"_id"`
+		ListID string             `bson:"listId"`
+	}
+	if err = cursor.All(ctx, &items); err != nil {
+		return err
+	}
+
+	models := make([]mongo.WriteModel, 0, len(items))
+	counts := make(map[string]int)
+	for _, item := range items {
+		counts[item.ListID]++
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": item.ID}).
+			SetUpdate(bson.M{"$set": bson.M{"position": backfillPosition(counts[item.ListID])}}))
+	}
+	if len(models) > 0 {
+		if _, err = collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+			return err
+		}
+	}
+
+	_, err = collection.CreateIndexes(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "listId", Value: 1}, {Key: "position", Value: 1}},
+			Options: options.Index().SetName(indexItemsListPosition).SetUnique(true).
+				SetPartialFilterExpression(bson.M{"position": bson.M{"$gt": ""}}),
+		},
+		{
+			Keys:    bson.D{{Key: "listId", Value: 1}, {Key: "position", Value: 1}, {Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName(indexItemsListPositionSort),
+		},
+	})
+	return err
+}
+
+func unsetItemsPosition(ctx context.Context, db dbmongo.MongoDatabaseOperations) error {
+	collection := db.Collection(CollectionItems)
+
+	for _, name := range []string{indexItemsListPosition, indexItemsListPositionSort} {
+		if err := collection.DropIndex(ctx, name); err != nil {
+			return err
+		}
+	}
+	_, err := collection.UpdateMany(ctx,
+		bson.M{},
+		bson.M{"$unset": bson.M{"position": ""}},
+	)
+	return err
+}
+
+// backfillPosition is the position of the n-th item (from 1) backfilled in a
+// list, as in the SQL migrations (0009_add_items_position.sql). They all come
+// after "i0" and before "i1", the position of the item created after them.
+func backfillPosition(n int) string {
+	return fmt.Sprintf("i0%010d1", n)
+}
@@ -0,0 +1,306 @@
+package mongodb_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	dbmongo "github.com/lucaspereirasilva0/list-manager-api/internal/database/mongodb"
+	mongorepo "github.com/lucaspereirasilva0/list-manager-api/internal/repository/mongodb"
+)
+
+func TestMigrations(t *testing.T) {
+	ctx := context.Background()
+
+	migrations := mongorepo.Migrations()
+	require.Len(t, migrations, 11)
+
+	_, err := dbmongo.NewMigrator(new(dbmongo.MockMongoDatabaseOperations), migrations)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		givenStep func(ctx context.Context, db dbmongo.MongoDatabaseOperations) error
+		mockSetup func(collection *dbmongo.MockMongoCollectionOperations)
+		// wantCollection is the collection changed by the step, items when empty
+		wantCollection string
+		wantErr        error
+	}{
+		{
+			name:      "Given_ItemsCollection_When_CreateIndexesUp_Then_ExpectedSuccess",
+			givenStep: migrations[0].Up,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("CreateIndexes", ctx, mock.MatchedBy(func(models []mongo.IndexModel) bool {
+					return len(models) == 3
+				})).Return([]string{"active_1", "createdAt_1__id_1", "updatedAt_-1"}, nil)
+			},
+		},
+		{
+			name:      "Given_ItemsCollection_When_CreateIndexesDown_Then_DropsEveryIndex",
+			givenStep: migrations[0].Down,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("DropIndex", ctx, "active_1").Return(nil)
+				collection.On("DropIndex", ctx, "createdAt_1__id_1").Return(nil)
+				collection.On("DropIndex", ctx, "updatedAt_-1").Return(nil)
+			},
+		},
+		{
+			name:      "Given_DatabaseError_When_CreateIndexesDown_Then_ExpectedError",
+			givenStep: migrations[0].Down,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("DropIndex", ctx, "active_1").Return(errDatabase)
+			},
+			wantErr: errDatabase,
+		},
+		{
+			name:      "Given_ItemsWithoutTimestamps_When_BackfillUp_Then_UpdatesBothFields",
+			givenStep: migrations[1].Up,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("UpdateMany", ctx, mock.Anything, mock.AnythingOfType("mongo.Pipeline"), mock.Anything).
+					Return(mockSuccessfulUpdateManyResult(), nil).Twice()
+			},
+		},
+		{
+			name:      "Given_DatabaseError_When_BackfillUp_Then_ExpectedError",
+			givenStep: migrations[1].Up,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("UpdateMany", ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(mockEmptyUpdateManyResult(), errDatabase).Once()
+			},
+			wantErr: errDatabase,
+		},
+		{
+			name:      "Given_ItemsCollection_When_ActiveListingIndexUp_Then_ExpectedSuccess",
+			givenStep: migrations[2].Up,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("CreateIndexes", ctx, mock.MatchedBy(func(models []mongo.IndexModel) bool {
+					return len(models) == 1 && *models[0].Options.Name == "active_1_createdAt_1__id_1"
+				})).Return([]string{"active_1_createdAt_1__id_1"}, nil)
+			},
+		},
+		{
+			name:      "Given_ItemsCollection_When_ActiveListingIndexDown_Then_DropsIndex",
+			givenStep: migrations[2].Down,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("DropIndex", ctx, "active_1_createdAt_1__id_1").Return(nil)
+			},
+		},
+		{
+			name:      "Given_ItemsCollection_When_SortIndexesUp_Then_NameIndexUsesCollation",
+			givenStep: migrations[3].Up,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("CreateIndexes", ctx, mock.MatchedBy(func(models []mongo.IndexModel) bool {
+					return len(models) == 2 &&
+						*models[0].Options.Name == "name_1__id_1" &&
+						models[0].Options.Collation.Locale == "pt" && models[0].Options.Collation.Strength == 1 &&
+						*models[1].Options.Name == "updatedAt_-1__id_1"
+				})).Return([]string{"name_1__id_1", "updatedAt_-1__id_1"}, nil)
+			},
+		},
+		{
+			name:      "Given_ItemsCollection_When_SortIndexesDown_Then_DropsBothIndexes",
+			givenStep: migrations[3].Down,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("DropIndex", ctx, "name_1__id_1").Return(nil)
+				collection.On("DropIndex", ctx, "updatedAt_-1__id_1").Return(nil)
+			},
+		},
+		{
+			name:      "Given_ItemsCollection_When_TextIndexUp_Then_NameWeighsMoreThanObservation",
+			givenStep: migrations[4].Up,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("CreateIndexes", ctx, mock.MatchedBy(func(models []mongo.IndexModel) bool {
+					return len(models) == 1 &&
+						*models[0].Options.Name == "items_text" &&
+						*models[0].Options.DefaultLanguage == "portuguese" &&
+						reflect.DeepEqual(models[0].Options.Weights, bson.D{{Key: "name", Value: 3}, {Key: "observation", Value: 1}})
+				})).Return([]string{"items_text"}, nil)
+			},
+		},
+		{
+			name:      "Given_ItemsCollection_When_TextIndexDown_Then_DropsIndex",
+			givenStep: migrations[4].Down,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("DropIndex", ctx, "items_text").Return(nil)
+			},
+		},
+		{
+			name:      "Given_ItemsWithoutVersion_When_VersionBackfillUp_Then_SetsFirstVersion",
+			givenStep: migrations[5].Up,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("UpdateMany", ctx, bson.M{"version": bson.M{"$exists": false}}, bson.M{"$set": bson.M{"version": int64(1)}}, mock.Anything).
+					Return(mockSuccessfulUpdateManyResult(), nil).Once()
+			},
+		},
+		{
+			name:      "Given_DatabaseError_When_VersionBackfillDown_Then_ExpectedError",
+			givenStep: migrations[5].Down,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("UpdateMany", ctx, bson.M{}, bson.M{"$unset": bson.M{"version": ""}}, mock.Anything).
+					Return(mockEmptyUpdateManyResult(), errDatabase).Once()
+			},
+			wantErr: errDatabase,
+		},
+		{
+			name:      "Given_IdempotencyKeysCollection_When_TTLIndexUp_Then_ExpiresDocumentsAtExpiresAt",
+			givenStep: migrations[6].Up,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("CreateIndexes", ctx, mock.MatchedBy(func(models []mongo.IndexModel) bool {
+					return len(models) == 1 && *models[0].Options.ExpireAfterSeconds == 0
+				})).Return([]string{"expiresAt_1"}, nil)
+			},
+			wantCollection: mongorepo.CollectionIdempotencyKeys,
+		},
+		{
+			name:      "Given_IdempotencyKeysCollection_When_TTLIndexDown_Then_DropsIndex",
+			givenStep: migrations[6].Down,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("DropIndex", ctx, "expiresAt_1").Return(nil)
+			},
+			wantCollection: mongorepo.CollectionIdempotencyKeys,
+		},
+		{
+			name:      "Given_ListsCollection_When_DefaultListUp_Then_UpsertsItWithoutRenamingIt",
+			givenStep: migrations[7].Up,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("UpdateOne", ctx, bson.M{"_id": mockDefaultListObjectID()}, mock.MatchedBy(func(update bson.M) bool {
+					set, ok := update["$setOnInsert"].(bson.M)
+					return ok && set["name"] == "Default"
+				}), mock.Anything).Return(&mongo.UpdateResult{UpsertedCount: 1}, nil).Once()
+			},
+			wantCollection: mongorepo.CollectionLists,
+		},
+		{
+			name:      "Given_ListsCollection_When_DefaultListDown_Then_DeletesIt",
+			givenStep: migrations[7].Down,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("DeleteOne", ctx, bson.M{"_id": mockDefaultListObjectID()}).Return(&mongo.DeleteResult{DeletedCount: 1}, nil).Once()
+			},
+			wantCollection: mongorepo.CollectionLists,
+		},
+		{
+			name:      "Given_ItemsWithoutList_When_MoveToDefaultListUp_Then_SetsListAndCreatesIndex",
+			givenStep: migrations[8].Up,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("UpdateMany", ctx, bson.M{"listId": bson.M{"$in": bson.A{nil, ""}}}, bson.M{"$set": bson.M{"listId": "000000000000000000000001"}}, mock.Anything).
+					Return(mockSuccessfulUpdateManyResult(), nil).Once()
+				collection.On("CreateIndexes", ctx, mock.MatchedBy(func(models []mongo.IndexModel) bool {
+					return len(models) == 1 && *models[0].Options.Name == "listId_1_createdAt_1__id_1"
+				})).Return([]string{"listId_1_createdAt_1__id_1"}, nil)
+			},
+		},
+		{
+			name:      "Given_DatabaseError_When_MoveToDefaultListUp_Then_ExpectedErrorWithoutIndex",
+			givenStep: migrations[8].Up,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("UpdateMany", ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(mockEmptyUpdateManyResult(), errDatabase).Once()
+			},
+			wantErr: errDatabase,
+		},
+		{
+			name:      "Given_ItemsCollection_When_MoveToDefaultListDown_Then_DropsIndexAndUnsetsList",
+			givenStep: migrations[8].Down,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("DropIndex", ctx, "listId_1_createdAt_1__id_1").Return(nil)
+				collection.On("UpdateMany", ctx, bson.M{}, bson.M{"$unset": bson.M{"listId": ""}}, mock.Anything).
+					Return(mockSuccessfulUpdateManyResult(), nil).Once()
+			},
+		},
+		{
+			name:      "Given_ItemsCollection_When_CategoryIndexUp_Then_CreatesSparseIndex",
+			givenStep: migrations[9].Up,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("CreateIndexes", ctx, mock.MatchedBy(func(models []mongo.IndexModel) bool {
+					return len(models) == 1 && *models[0].Options.Name == "categoryId_1" && *models[0].Options.Sparse
+				})).Return([]string{"categoryId_1"}, nil)
+			},
+		},
+		{
+			name:      "Given_ItemsCollection_When_CategoryIndexDown_Then_DropsIndex",
+			givenStep: migrations[9].Down,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("DropIndex", ctx, "categoryId_1").Return(nil)
+			},
+		},
+		{
+			name:      "Given_ItemsWithoutPosition_When_BackfillPositionUp_Then_PositionsThemByListAndCreatesIndexes",
+			givenStep: migrations[10].Up,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				cursor := new(dbmongo.MockMongoCursorOperations)
+				collection.On("Find", ctx, bson.M{"position": bson.M{"$in": bson.A{nil, ""}}}, mock.Anything).Return(cursor, nil)
+				cursor.On("All", ctx, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					results := reflect.ValueOf(args.Get(1)).Elem()
+					for _, listID := range []string{"list-a", "list-a", "list-b"} {
+						item := reflect.New(results.Type().Elem()).Elem()
+						item.Field(0).Set(reflect.ValueOf(primitive.NewObjectID()))
+						item.Field(1).SetString(listID)
+						results.Set(reflect.Append(results, item))
+					}
+				})
+				cursor.On("Close", ctx).Return(nil)
+				collection.On("BulkWrite", ctx, mock.MatchedBy(func(models []mongo.WriteModel) bool {
+					positions := make([]any, len(models))
+					for i, model := range models {
+						positions[i] = model.(*mongo.UpdateOneModel).Update.(bson.M)["$set"].(bson.M)["position"]
+					}
+					return reflect.DeepEqual(positions, []any{"i000000000011", "i000000000021", "i000000000011"})
+				}), mock.Anything).Return(&mongo.BulkWriteResult{ModifiedCount: 3}, nil)
+				collection.On("CreateIndexes", ctx, mock.MatchedBy(func(models []mongo.IndexModel) bool {
+					return len(models) == 2 && *models[0].Options.Name == "listId_1_position_1" && *models[0].Options.Unique
+				})).Return([]string{"listId_1_position_1", "listId_1_position_1_createdAt_1__id_1"}, nil)
+			},
+		},
+		{
+			name:      "Given_DatabaseError_When_BackfillPositionUp_Then_ExpectedError",
+			givenStep: migrations[10].Up,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("Find", ctx, mock.Anything, mock.Anything).Return((*dbmongo.MockMongoCursorOperations)(nil), errDatabase)
+			},
+			wantErr: errDatabase,
+		},
+		{
+			name:      "Given_ItemsCollection_When_BackfillPositionDown_Then_DropsIndexesAndUnsetsPositions",
+			givenStep: migrations[10].Down,
+			mockSetup: func(collection *dbmongo.MockMongoCollectionOperations) {
+				collection.On("DropIndex", ctx, "listId_1_position_1").Return(nil)
+				collection.On("DropIndex", ctx, "listId_1_position_1_createdAt_1__id_1").Return(nil)
+				collection.On("UpdateMany", ctx, bson.M{}, bson.M{"$unset": bson.M{"position": ""}}, mock.Anything).
+					Return(mockSuccessfulUpdateManyResult(), nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := new(dbmongo.MockMongoDatabaseOperations)
+			collection := new(dbmongo.MockMongoCollectionOperations)
+			wantCollection := tt.wantCollection
+			if wantCollection == "" {
+				wantCollection = mongorepo.CollectionItems
+			}
+			db.On("Collection", wantCollection, mock.Anything).Return(collection)
+			tt.mockSetup(collection)
+
+			err := tt.givenStep(ctx, db)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			collection.AssertExpectations(t)
+		})
+	}
+}
+
+func mockDefaultListObjectID() primitive.ObjectID {
+	id, _ := primitive.ObjectIDFromHex("000000000000000000000001")
+	return id
+}
@@ -2,22 +2,32 @@ package mongodb
 
 import (
 	"context"
+	"errors"
 	"log"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	dbmongo "github.com/lucaspereirasilva0/list-manager-api/internal/database/mongodb"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
 	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
 )
 
 const (
-	CollectionItems = "items"
-	CollectionUsers = "users"
+	CollectionItems           = "items"
+	CollectionLists           = "lists"
+	CollectionCategories      = "categories"
+	CollectionUsers           = "users"
+	CollectionIdempotencyKeys = "idempotency_keys"
 )
 
+// duplicateKeyCode is the code of the write errors of a duplicate key
+const duplicateKeyCode = 11000
+
 // MongoDBItemRepository implements repository.ItemRepository for MongoDB
 type MongoDBItemRepository struct {
 	client dbmongo.ClientOperations
@@ -41,25 +51,23 @@ func (r *MongoDBItemRepository) Create(ctx context.Context, item repository.Item
 	}
 
 	// Use o ObjectID para a inserção no MongoDB
-	doc := bson.M{
-		"_id":       objectID,
-		"name":      item.Name,
-		"active":    item.Active,
-		"createdAt": time.Now(),
-		"updatedAt": time.Now(),
-	}
-	if item.Observation != nil {
-		doc["observation"] = *item.Observation
-	}
-	_, err = collection.InsertOne(ctx, doc)
+	now := now()
+	_, err = collection.InsertOne(ctx, newItemDocument(objectID, item, now))
 	if err != nil {
-		return repository.Item{}, repository.HandleError(err)
+		return repository.Item{}, handleWriteError(err)
 	}
 
+	// Return the timestamps actually stored instead of the caller's ones
+	item.ID = objectID.Hex()
+	item.CreatedAt = now
+	item.UpdatedAt = now
+	item.Version = 1
+
 	return item, nil
 }
 
-// Update modifies an existing item in the MongoDB repository
+// Update modifies an existing item in the MongoDB repository in a single
+// round-trip and returns the document as stored after the update
 func (r *MongoDBItemRepository) Update(ctx context.Context, item repository.Item) (repository.Item, error) {
 	collection := r.client.GetCollection(CollectionItems)
 
@@ -68,31 +76,166 @@ func (r *MongoDBItemRepository) Update(ctx context.Context, item repository.Item
 		return repository.Item{}, repository.NewInvalidHexIDError()
 	}
 
-	filter := bson.M{"_id": id}
+	filter := versionFilter(id, item.Version)
+	update := itemUpdate(item, now())
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updatedItem repository.Item
+	err = collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updatedItem)
+	if err == mongo.ErrNoDocuments {
+		return repository.Item{}, missingOrConflict(ctx, collection, id, item.Version)
+	} else if err != nil {
+		return repository.Item{}, repository.HandleError(err)
+	}
+
+	return updatedItem, nil
+}
+
+// newItemDocument is the document inserted for a new item, at its first version
+func newItemDocument(id primitive.ObjectID, item repository.Item, now time.Time) bson.M {
+	doc := bson.M{
+		"_id":       id,
+		"listId":    item.ListID,
+		"name":      item.Name,
+		"active":    item.Active,
+		"position":  item.Position,
+		"createdAt": now,
+		"updatedAt": now,
+		"version":   int64(1),
+	}
+	if item.Observation != nil {
+		doc["observation"] = *item.Observation
+	}
+	if item.Quantity != nil {
+		doc["quantity"] = *item.Quantity
+		doc["unit"] = item.Unit
+	}
+	if item.CategoryID != "" {
+		doc["categoryId"] = item.CategoryID
+	}
+	if item.UnitPrice != nil {
+		doc["unitPrice"] = *item.UnitPrice
+		doc["currency"] = item.Currency
+	}
+	return doc
+}
+
+// itemUpdate replaces the editable fields of an item, keeping the stored
+// observation, quantity, category and price when item has none, and increments its version
+func itemUpdate(item repository.Item, now time.Time) bson.M {
 	setFields := bson.M{
 		"name":      item.Name,
 		"active":    item.Active,
-		"updatedAt": time.Now(),
+		"updatedAt": now,
 	}
 	if item.Observation != nil {
 		setFields["observation"] = *item.Observation
 	}
-	update := bson.M{"$set": setFields}
+	if item.Quantity != nil {
+		setFields["quantity"] = *item.Quantity
+		setFields["unit"] = item.Unit
+	}
+	if item.CategoryID != "" {
+		setFields["categoryId"] = item.CategoryID
+	}
+	if item.UnitPrice != nil {
+		setFields["unitPrice"] = *item.UnitPrice
+		setFields["currency"] = item.Currency
+	}
+	return bson.M{"$set": setFields, "$inc": bson.M{"version": 1}}
+}
+
+// Patch changes only the fields set in patch with a single FindOneAndUpdate,
+// so concurrent patches of different fields do not overwrite each other
+func (r *MongoDBItemRepository) Patch(ctx context.Context, id string, patch repository.ItemPatch) (repository.Item, error) {
+	collection := r.client.GetCollection(CollectionItems)
 
-	result, err := collection.UpdateOne(ctx, filter, update)
+	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return repository.Item{}, repository.HandleError(err)
+		return repository.Item{}, repository.NewInvalidHexIDError()
 	}
 
-	if result.MatchedCount == 0 {
-		return repository.Item{}, repository.NewItemNotFoundError()
+	filter := versionFilter(objectID, patch.Version)
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var patchedItem repository.Item
+	err = collection.FindOneAndUpdate(ctx, filter, patchUpdate(patch), opts).Decode(&patchedItem)
+	if err == mongo.ErrNoDocuments {
+		return repository.Item{}, missingOrConflict(ctx, collection, objectID, patch.Version)
+	} else if err != nil {
+		return repository.Item{}, handleWriteError(err)
 	}
 
-	return item, nil
+	return patchedItem, nil
+}
+
+// patchUpdate translates a patch into a $set of the changed fields, and an
+// $unset of the observation, quantity, category or price when they are removed
+func patchUpdate(patch repository.ItemPatch) bson.M {
+	setFields := bson.M{"updatedAt": now()}
+	if patch.Name != nil {
+		setFields["name"] = *patch.Name
+	}
+	if patch.Active != nil {
+		setFields["active"] = *patch.Active
+	}
+	if patch.Position != nil {
+		setFields["position"] = *patch.Position
+	}
+
+	update := bson.M{"$set": setFields, "$inc": bson.M{"version": 1}}
+	unsetFields := bson.M{}
+	if patch.SetObservation {
+		if patch.Observation != nil {
+			setFields["observation"] = *patch.Observation
+		} else {
+			unsetFields["observation"] = ""
+		}
+	}
+	if patch.SetQuantity {
+		if patch.Quantity != nil {
+			setFields["quantity"] = *patch.Quantity
+			setFields["unit"] = patch.Unit
+		} else {
+			unsetFields["quantity"] = ""
+			unsetFields["unit"] = ""
+		}
+	}
+	if patch.SetCategory {
+		if patch.CategoryID != nil {
+			setFields["categoryId"] = *patch.CategoryID
+		} else {
+			unsetFields["categoryId"] = ""
+		}
+	}
+	if patch.SetPrice {
+		if patch.UnitPrice != nil {
+			setFields["unitPrice"] = *patch.UnitPrice
+			setFields["currency"] = patch.Currency
+		} else {
+			unsetFields["unitPrice"] = ""
+			unsetFields["currency"] = ""
+		}
+	}
+	if len(unsetFields) > 0 {
+		update["$unset"] = unsetFields
+	}
+	return update
+}
+
+// handleWriteError returns the position conflict error for a duplicate key in
+// the listId_1_position_1 index, and handles the other errors like repository.HandleError
+func handleWriteError(err error) error {
+	var writeErr mongo.BulkWriteError
+	isDuplicateKey := mongo.IsDuplicateKeyError(err) || (errors.As(err, &writeErr) && writeErr.Code == duplicateKeyCode)
+	if isDuplicateKey && strings.Contains(err.Error(), indexItemsListPosition) {
+		return repository.NewPositionConflictError()
+	}
+	return repository.HandleError(err)
 }
 
 // Delete removes an item from the MongoDB repository
-func (r *MongoDBItemRepository) Delete(ctx context.Context, id string) error {
+func (r *MongoDBItemRepository) Delete(ctx context.Context, id string, version int64) error {
 	collection := r.client.GetCollection(CollectionItems)
 
 	objID, err := primitive.ObjectIDFromHex(id)
@@ -100,19 +243,47 @@ func (r *MongoDBItemRepository) Delete(ctx context.Context, id string) error {
 		return repository.NewInvalidHexIDError()
 	}
 
-	filter := bson.M{"_id": objID}
+	filter := versionFilter(objID, version)
 	result, err := collection.DeleteOne(ctx, filter)
 	if err != nil {
 		return repository.HandleError(err)
 	}
 
 	if result.DeletedCount == 0 {
-		return repository.NewItemNotFoundError()
+		return missingOrConflict(ctx, collection, objID, version)
 	}
 
 	return nil
 }
 
+// versionFilter selects an item by _id and, when version is not zero, only if
+// it still has that version, so the check and the write are a single atomic operation
+func versionFilter(id primitive.ObjectID, version int64) bson.M {
+	filter := bson.M{"_id": id}
+	if version != 0 {
+		filter["version"] = version
+	}
+	return filter
+}
+
+// missingOrConflict tells why a write filtered by versionFilter matched no
+// document: the item does not exist, or it has another version
+func missingOrConflict(ctx context.Context, collection dbmongo.MongoCollectionOperations, id primitive.ObjectID, version int64) error {
+	if version == 0 {
+		return repository.NewItemNotFoundError()
+	}
+
+	opts := options.FindOne().SetProjection(bson.M{"_id": 1})
+	err := collection.FindOne(ctx, bson.M{"_id": id}, opts).Err()
+	if err == mongo.ErrNoDocuments {
+		return repository.NewItemNotFoundError()
+	} else if err != nil {
+		return repository.HandleError(err)
+	}
+
+	return repository.NewVersionConflictError()
+}
+
 // GetByID retrieves an item by its ID from the MongoDB repository
 func (r *MongoDBItemRepository) GetByID(ctx context.Context, id string) (repository.Item, error) {
 	collection := r.client.GetCollection(CollectionItems)
@@ -135,13 +306,33 @@ func (r *MongoDBItemRepository) GetByID(ctx context.Context, id string) (reposit
 	return item, nil
 }
 
-// List retrieves all items from the MongoDB repository
-func (r *MongoDBItemRepository) List(ctx context.Context) ([]repository.Item, error) {
+// List retrieves a page of the items matching opts.Filter from the MongoDB repository,
+// ordered by opts.Sort and _id and starting after opts.Cursor
+func (r *MongoDBItemRepository) List(ctx context.Context, opts repository.ListOptions) (repository.ItemPage, error) {
 	collection := r.client.GetCollection(CollectionItems)
 
-	cursor, err := collection.Find(ctx, bson.M{})
+	sortFields, err := repository.NormalizeSort(opts.Sort)
 	if err != nil {
-		return nil, repository.HandleError(err)
+		return repository.ItemPage{}, err
+	}
+	filter, err := listFilter(opts, sortFields)
+	if err != nil {
+		return repository.ItemPage{}, err
+	}
+
+	findOptions := options.Find().SetSort(listSort(sortFields))
+	if sortsByName(sortFields) {
+		// Case and accent insensitive order, matching the name_1__id_1 index
+		findOptions.SetCollation(nameCollation())
+	}
+	if opts.Limit > 0 {
+		// One more item tells whether there is a next page
+		findOptions.SetLimit(int64(opts.Limit) + 1)
+	}
+
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return repository.ItemPage{}, repository.HandleError(err)
 	}
 	defer func() {
 		if cursor != nil {
@@ -152,6 +343,52 @@ func (r *MongoDBItemRepository) List(ctx context.Context) ([]repository.Item, er
 	}()
 
 	var items []repository.Item
+	if err = cursor.All(ctx, &items); err != nil {
+		return repository.ItemPage{}, repository.HandleError(err)
+	}
+
+	return repository.NewItemPage(items, opts.Limit, sortFields), nil
+}
+
+// Search retrieves the items matching query from the MongoDB repository with the
+// text index items_text (case and diacritic insensitive, Portuguese stemming),
+// ordered by text score and _id
+func (r *MongoDBItemRepository) Search(ctx context.Context, query, listID string, limit int) ([]repository.Item, error) {
+	words := searchWords(query)
+	if len(words) == 0 {
+		return []repository.Item{}, nil
+	}
+
+	collection := r.client.GetCollection(CollectionItems)
+
+	score := bson.M{"$meta": "textScore"}
+	findOptions := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}})
+	if limit > 0 {
+		findOptions.SetLimit(int64(limit))
+	}
+
+	// Space-separated words match any of them; "-" and quotes are operators, so
+	// only the words of the query are kept
+	filter := bson.M{"$text": bson.M{"$search": strings.Join(words, " ")}}
+	if listID != "" {
+		filter["listId"] = listID
+	}
+
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, repository.HandleError(err)
+	}
+	defer func() {
+		if cursor != nil {
+			if err := cursor.Close(ctx); err != nil {
+				log.Printf("Error closing MongoDB cursor: %v", err)
+			}
+		}
+	}()
+
+	items := []repository.Item{}
 	if err = cursor.All(ctx, &items); err != nil {
 		return nil, repository.HandleError(err)
 	}
@@ -159,73 +396,158 @@ func (r *MongoDBItemRepository) List(ctx context.Context) ([]repository.Item, er
 	return items, nil
 }
 
-// BulkUpdateActive updates the active field for all items in the MongoDB repository
-func (r *MongoDBItemRepository) BulkUpdateActive(ctx context.Context, active bool) (int64, int64, error) {
+// BulkUpdateActive updates the active field of the selected items in the MongoDB
+// repository. When fewer items than IDs matched, they are read to tell which IDs don't exist.
+func (r *MongoDBItemRepository) BulkUpdateActive(ctx context.Context, activeUpdate repository.ActiveUpdate) (repository.ActiveUpdateResult, error) {
+	collection := r.client.GetCollection(CollectionItems)
+
+	ids := make([]primitive.ObjectID, len(activeUpdate.IDs))
+	for i, id := range activeUpdate.IDs {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return repository.ActiveUpdateResult{}, repository.NewInvalidHexIDError()
+		}
+		ids[i] = objectID
+	}
+
+	listID := activeUpdate.Filter.ListID
+	filter := itemFilter(activeUpdate.Filter)
+	if len(ids) > 0 {
+		filter = bson.M{"_id": bson.M{"$in": ids}}
+		if listID != "" {
+			filter["listId"] = listID
+		}
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"active":    activeUpdate.Active,
+			"updatedAt": now(),
+		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	updateResult, err := collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return repository.ActiveUpdateResult{}, repository.HandleError(err)
+	}
+
+	result := repository.ActiveUpdateResult{
+		MatchedCount:  updateResult.MatchedCount,
+		ModifiedCount: updateResult.ModifiedCount,
+	}
+	if len(ids) > 0 && updateResult.MatchedCount < int64(len(ids)) {
+		stored, err := r.itemsByID(ctx, ids)
+		if err != nil {
+			return repository.ActiveUpdateResult{}, err
+		}
+		for i, id := range ids {
+			if item, ok := stored[id]; !ok || (listID != "" && item.ListID != listID) {
+				result.NotFoundIDs = append(result.NotFoundIDs, activeUpdate.IDs[i])
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// DeleteMany removes the items matching filter with a single DeleteMany
+func (r *MongoDBItemRepository) DeleteMany(ctx context.Context, filter repository.ItemFilter) (int64, error) {
 	collection := r.client.GetCollection(CollectionItems)
 
-	filter := bson.M{}
-	update := bson.M{"$set": bson.M{
-		"active":    active,
-		"updatedAt": time.Now(),
+	result, err := collection.DeleteMany(ctx, itemFilter(filter))
+	if err != nil {
+		return 0, repository.HandleError(err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+// ClearCategory removes the category of the items of categoryID with a single UpdateMany
+func (r *MongoDBItemRepository) ClearCategory(ctx context.Context, categoryID string) (int64, error) {
+	collection := r.client.GetCollection(CollectionItems)
+
+	result, err := collection.UpdateMany(ctx,
+		bson.M{"categoryId": categoryID},
+		bson.M{
+			"$unset": bson.M{"categoryId": ""},
+			"$set":   bson.M{"updatedAt": now()},
+			"$inc":   bson.M{"version": 1},
+		},
+	)
+	if err != nil {
+		return 0, repository.HandleError(err)
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// priceTotalDocument is a group of the SumPrices aggregation
+type priceTotalDocument struct {
+	ID struct {
+		Currency   string `bson:"currency"`
+		CategoryID string `bson:"categoryId"`
+		Active     bool   `bson:"active"`
+	} `bson:"_id"`
+	Total primitive.Decimal128 `bson:"total"`
+}
+
+// SumPrices sums the line totals of the priced items matching filter with a
+// single aggregation. The quantities are stored in thousandths and the sums are
+// Decimal128, so they stay exact.
+func (r *MongoDBItemRepository) SumPrices(ctx context.Context, filter repository.ItemFilter) ([]domain.PriceTotal, error) {
+	collection := r.client.GetCollection(CollectionItems)
+
+	match := itemFilter(filter)
+	match["unitPrice"] = bson.M{"$ne": nil}
+	lineTotal := bson.M{"$multiply": bson.A{
+		bson.M{"$toDecimal": "$unitPrice"},
+		bson.M{"$toDecimal": bson.M{"$ifNull": bson.A{"$quantity", repository.QuantityScale}}},
 	}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"currency":   "$currency",
+				"categoryId": bson.M{"$ifNull": bson.A{"$categoryId", ""}},
+				"active":     "$active",
+			},
+			"total": bson.M{"$sum": lineTotal},
+		}}},
+	}
 
-	result, err := collection.UpdateMany(ctx, filter, update)
+	cursor, err := collection.Aggregate(ctx, pipeline)
 	if err != nil {
-		return 0, 0, repository.HandleError(err)
-	}
-
-	return result.MatchedCount, result.ModifiedCount, nil
-}
-
-//TODO adicionar quando implementar autenticacao de usuario
-// // CreateItemWithUser inserts a new item and an associated user in a single transaction
-// func (r *MongoDBItemRepository) CreateItemWithUser(ctx context.Context, item repository.Item, user repository.User) (repository.Item, repository.User, error) {
-// 	session, err := r.client.Client().StartSession()
-// 	if err != nil {
-// 		return repository.Item{}, repository.User{}, fmt.Errorf("failed to start session: %w", err)
-// 	}
-// 	defer session.EndSession(ctx)
-
-// 	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
-// 		itemsCollection := r.client.GetCollection(CollectionItems)
-// 		usersCollection := r.client.GetCollection(CollectionUsers)
-
-// 		// Use the ID provided from the domain layer (already in hex format)
-// 		objectID, err := primitive.ObjectIDFromHex(item.ID)
-// 		if err != nil {
-// 			return nil, fmt.Errorf("provided item ID is not a valid ObjectID: %w", err)
-// 		}
-
-// 		// Insert item
-// 		_, err = itemsCollection.InsertOne(sessCtx, bson.M{
-// 			"_id":    objectID,
-// 			"name":   item.Name,
-// 			"active": item.Active,
-// 		})
-// 		if err != nil {
-// 			return nil, fmt.Errorf("failed to insert item: %w", err)
-// 		}
-
-// 		// Generate a new ObjectID for the user if it's not set
-// 		if user.ID == "" {
-// 			user.ID = primitive.NewObjectID().Hex()
-// 		}
-
-// 		// Set CreatedBy to the new item's ID for association
-// 		user.CreatedBy = item.ID
-
-// 		// Insert user
-// 		_, err = usersCollection.InsertOne(sessCtx, user)
-// 		if err != nil {
-// 			return nil, fmt.Errorf("failed to insert user: %w", err)
-// 		}
-
-// 		return nil, nil
-// 	})
-
-// 	if err != nil {
-// 		return repository.Item{}, repository.User{}, fmt.Errorf("transaction failed: %w", err)
-// 	}
-
-// 	return item, user, nil
-// }
+		return nil, repository.HandleError(err)
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("Error closing MongoDB cursor: %v", err)
+		}
+	}()
+
+	var documents []priceTotalDocument
+	if err = cursor.All(ctx, &documents); err != nil {
+		return nil, repository.HandleError(err)
+	}
+
+	totals := make([]domain.PriceTotal, 0, len(documents))
+	for _, doc := range documents {
+		cents, err := repository.ParseScaledCents(doc.Total.String(), repository.QuantityScale)
+		if err != nil {
+			return nil, repository.HandleError(err)
+		}
+		totals = append(totals, domain.PriceTotal{
+			Currency:   domain.Currency(doc.ID.Currency),
+			CategoryID: doc.ID.CategoryID,
+			Active:     doc.ID.Active,
+			Cents:      cents,
+		})
+	}
+
+	return totals, nil
+}
+
+// now returns the current time with the precision MongoDB stores (milliseconds).
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
@@ -3,18 +3,23 @@ package mongodb_test
 import (
 	"context"
 	"errors"
+	"math/big"
+	"net/http"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
 
 	dbmongo "github.com/lucaspereirasilva0/list-manager-api/internal/database/mongodb"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
 	mongorepo "github.com/lucaspereirasilva0/list-manager-api/internal/repository/mongodb"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var (
@@ -37,7 +42,7 @@ func mockInvalidHexIDItemInput() repository.Item {
 }
 
 func mockCreateItemOutput() repository.Item {
-	return repository.Item{ID: testObjectID.Hex(), Name: "Test Item", Active: true, CreatedAt: time.Time{}, UpdatedAt: time.Time{}}
+	return repository.Item{ID: testObjectID.Hex(), Name: "Test Item", Active: true, CreatedAt: time.Time{}, UpdatedAt: time.Time{}, Version: 1}
 }
 
 func mockFoundItemOutput() repository.Item {
@@ -45,7 +50,15 @@ func mockFoundItemOutput() repository.Item {
 }
 
 func mockUpdateItemOutput() repository.Item {
-	return repository.Item{ID: testObjectID.Hex(), Name: "Updated Item", Active: false, UpdatedAt: time.Time{}}
+	obs := "stored observation"
+	return repository.Item{
+		ID:          testObjectID.Hex(),
+		Name:        "Updated Item",
+		Active:      false,
+		Observation: &obs,
+		CreatedAt:   time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC),
+	}
 }
 
 func mockItemListOutput() []repository.Item {
@@ -59,14 +72,6 @@ func mockInsertOneResult() *mongo.InsertOneResult {
 	return &mongo.InsertOneResult{InsertedID: testObjectID}
 }
 
-func mockSuccessfulUpdateOneResult() *mongo.UpdateResult {
-	return &mongo.UpdateResult{MatchedCount: 1}
-}
-
-func mockNotFoundUpdateOneResult() *mongo.UpdateResult {
-	return &mongo.UpdateResult{MatchedCount: 0}
-}
-
 func mockSuccessfulDeleteOneResult() *mongo.DeleteResult {
 	return &mongo.DeleteResult{DeletedCount: 1}
 }
@@ -98,6 +103,11 @@ func mockNotFoundFindOneResult() *mongo.SingleResult {
 	return mongo.NewSingleResultFromDocument(bsonBytes, mongo.ErrNoDocuments, nil)
 }
 
+func mockSuccessfulFindOneAndUpdateResult() *mongo.SingleResult {
+	bsonBytes, _ := bson.Marshal(mockUpdateItemOutput())
+	return mongo.NewSingleResultFromDocument(bsonBytes, nil, nil)
+}
+
 func mockDBErrorFindOneResult() *mongo.SingleResult {
 	bsonBytes, _ := bson.Marshal(repository.Item{})
 	return mongo.NewSingleResultFromDocument(bsonBytes, errDatabase, nil)
@@ -133,6 +143,24 @@ func TestCreate(t *testing.T) {
 			givenItem: mockInvalidHexIDItemInput(),
 			wantErr:   repository.NewInvalidHexIDError(),
 		},
+		{
+			name:      "Given_TakenPosition_When_Create_Then_ExpectedPositionConflictError",
+			givenItem: mockCreateItemInput(),
+			givenMockInsertOneError: mongo.WriteException{WriteErrors: mongo.WriteErrors{{
+				Code:    11000,
+				Message: "E11000 duplicate key error collection: listmanager.items index: listId_1_position_1 dup key",
+			}}},
+			wantErr: repository.NewPositionConflictError(),
+		},
+		{
+			name:      "Given_DuplicateID_When_Create_Then_ExpectedInternalError",
+			givenItem: mockCreateItemInput(),
+			givenMockInsertOneError: mongo.WriteException{WriteErrors: mongo.WriteErrors{{
+				Code:    11000,
+				Message: "E11000 duplicate key error collection: listmanager.items index: _id_ dup key",
+			}}},
+			wantErr: errors.New("generic repository error"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -140,8 +168,11 @@ func TestCreate(t *testing.T) {
 			collectionMock := new(dbmongo.MockMongoCollectionOperations)
 			clientMock := new(dbmongo.MockClientOperations)
 
+			var insertedDoc bson.M
 			if tt.givenMockInsertOneResult != nil || tt.givenMockInsertOneError != nil {
-				collectionMock.On("InsertOne", ctx, mock.Anything).Return(tt.givenMockInsertOneResult, tt.givenMockInsertOneError)
+				collectionMock.On("InsertOne", ctx, mock.Anything).Run(func(args mock.Arguments) {
+					insertedDoc = args.Get(1).(bson.M)
+				}).Return(tt.givenMockInsertOneResult, tt.givenMockInsertOneError)
 			}
 
 			clientMock.On("GetCollection", mongorepo.CollectionItems).Return(collectionMock)
@@ -154,6 +185,11 @@ func TestCreate(t *testing.T) {
 				require.ErrorContains(t, err, tt.wantErr.Error())
 			} else {
 				require.NoError(t, err)
+				require.Equal(t, insertedDoc["createdAt"], createdItem.CreatedAt)
+				require.Equal(t, insertedDoc["updatedAt"], createdItem.UpdatedAt)
+				require.False(t, createdItem.CreatedAt.IsZero())
+
+				createdItem.CreatedAt, createdItem.UpdatedAt = time.Time{}, time.Time{}
 				require.Equal(t, tt.wantCreatedItem, createdItem)
 			}
 
@@ -230,30 +266,29 @@ func TestUpdate(t *testing.T) {
 	ctx := context.Background()
 
 	tests := []struct {
-		name                     string
-		givenItem                repository.Item
-		givenMockUpdateOneResult *mongo.UpdateResult
-		givenMockUpdateOneError  error
-		wantErr                  error
-		wantUpdatedItem          repository.Item
+		name                            string
+		givenItem                       repository.Item
+		givenMockFindOneAndUpdateResult *mongo.SingleResult
+		wantErr                         error
+		wantUpdatedItem                 repository.Item
 	}{
 		{
-			name:                     "Given_ValidItem_When_Update_Then_ExpectedSuccess",
-			givenItem:                mockUpdateItemInput(),
-			givenMockUpdateOneResult: mockSuccessfulUpdateOneResult(),
-			wantUpdatedItem:          mockUpdateItemOutput(),
+			name:                            "Given_ValidItem_When_Update_Then_ExpectedStoredItem",
+			givenItem:                       mockUpdateItemInput(),
+			givenMockFindOneAndUpdateResult: mockSuccessfulFindOneAndUpdateResult(),
+			wantUpdatedItem:                 mockUpdateItemOutput(),
 		},
 		{
-			name:                     "Given_ValidItem_When_Update_And_ItemNotFound_Then_ExpectedNotFoundError",
-			givenItem:                mockUpdateItemInput(),
-			givenMockUpdateOneResult: mockNotFoundUpdateOneResult(),
-			wantErr:                  repository.NewItemNotFoundError(),
+			name:                            "Given_ValidItem_When_Update_And_ItemNotFound_Then_ExpectedNotFoundError",
+			givenItem:                       mockUpdateItemInput(),
+			givenMockFindOneAndUpdateResult: mockNotFoundFindOneResult(),
+			wantErr:                         repository.NewItemNotFoundError(),
 		},
 		{
-			name:                    "Given_ValidItem_When_Update_And_DatabaseError_Then_ExpectedInternalError",
-			givenItem:               mockUpdateItemInput(),
-			givenMockUpdateOneError: errDatabase,
-			wantErr:                 errDatabase,
+			name:                            "Given_ValidItem_When_Update_And_DatabaseError_Then_ExpectedInternalError",
+			givenItem:                       mockUpdateItemInput(),
+			givenMockFindOneAndUpdateResult: mockDBErrorFindOneResult(),
+			wantErr:                         errDatabase,
 		},
 		{
 			name:      "Given_InvalidID_When_Update_Then_ExpectedInvalidIDError",
@@ -267,8 +302,8 @@ func TestUpdate(t *testing.T) {
 			collectionMock := new(dbmongo.MockMongoCollectionOperations)
 			clientMock := new(dbmongo.MockClientOperations)
 
-			if tt.givenMockUpdateOneResult != nil || tt.givenMockUpdateOneError != nil {
-				collectionMock.On("UpdateOne", ctx, mock.Anything, mock.Anything).Return(tt.givenMockUpdateOneResult, tt.givenMockUpdateOneError)
+			if tt.givenMockFindOneAndUpdateResult != nil {
+				collectionMock.On("FindOneAndUpdate", ctx, mock.Anything, mock.Anything).Return(tt.givenMockFindOneAndUpdateResult)
 			}
 
 			clientMock.On("GetCollection", mongorepo.CollectionItems).Return(collectionMock)
@@ -290,31 +325,202 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestPatch(t *testing.T) {
+	ctx := context.Background()
+	name := "Updated Item"
+	observation := "new observation"
+	quantity := int64(1500)
+	unitPrice := int64(1290)
+
+	tests := []struct {
+		name                            string
+		givenID                         string
+		givenPatch                      repository.ItemPatch
+		givenMockFindOneAndUpdateResult *mongo.SingleResult
+		wantSet                         []string
+		wantUnset                       bool
+		wantErr                         error
+		wantPatchedItem                 repository.Item
+	}{
+		{
+			name:                            "Given_NameAndObservation_When_Patch_Then_OnlyThoseFieldsAreSet",
+			givenID:                         testObjectID.Hex(),
+			givenPatch:                      repository.ItemPatch{Name: &name, Observation: &observation, SetObservation: true},
+			givenMockFindOneAndUpdateResult: mockSuccessfulFindOneAndUpdateResult(),
+			wantSet:                         []string{"name", "observation", "updatedAt"},
+			wantPatchedItem:                 mockUpdateItemOutput(),
+		},
+		{
+			name:                            "Given_RemovedObservation_When_Patch_Then_ObservationIsUnset",
+			givenID:                         testObjectID.Hex(),
+			givenPatch:                      repository.ItemPatch{SetObservation: true},
+			givenMockFindOneAndUpdateResult: mockSuccessfulFindOneAndUpdateResult(),
+			wantSet:                         []string{"updatedAt"},
+			wantUnset:                       true,
+			wantPatchedItem:                 mockUpdateItemOutput(),
+		},
+		{
+			name:                            "Given_QuantityAndUnit_When_Patch_Then_BothAreSet",
+			givenID:                         testObjectID.Hex(),
+			givenPatch:                      repository.ItemPatch{Quantity: &quantity, Unit: "kg", SetQuantity: true},
+			givenMockFindOneAndUpdateResult: mockSuccessfulFindOneAndUpdateResult(),
+			wantSet:                         []string{"quantity", "unit", "updatedAt"},
+			wantPatchedItem:                 mockUpdateItemOutput(),
+		},
+		{
+			name:                            "Given_RemovedQuantity_When_Patch_Then_QuantityAndUnitAreUnset",
+			givenID:                         testObjectID.Hex(),
+			givenPatch:                      repository.ItemPatch{SetQuantity: true},
+			givenMockFindOneAndUpdateResult: mockSuccessfulFindOneAndUpdateResult(),
+			wantSet:                         []string{"updatedAt"},
+			wantUnset:                       true,
+			wantPatchedItem:                 mockUpdateItemOutput(),
+		},
+		{
+			name:                            "Given_UnitPriceAndCurrency_When_Patch_Then_BothAreSet",
+			givenID:                         testObjectID.Hex(),
+			givenPatch:                      repository.ItemPatch{UnitPrice: &unitPrice, Currency: "BRL", SetPrice: true},
+			givenMockFindOneAndUpdateResult: mockSuccessfulFindOneAndUpdateResult(),
+			wantSet:                         []string{"unitPrice", "currency", "updatedAt"},
+			wantPatchedItem:                 mockUpdateItemOutput(),
+		},
+		{
+			name:                            "Given_RemovedUnitPrice_When_Patch_Then_UnitPriceAndCurrencyAreUnset",
+			givenID:                         testObjectID.Hex(),
+			givenPatch:                      repository.ItemPatch{SetPrice: true},
+			givenMockFindOneAndUpdateResult: mockSuccessfulFindOneAndUpdateResult(),
+			wantSet:                         []string{"updatedAt"},
+			wantUnset:                       true,
+			wantPatchedItem:                 mockUpdateItemOutput(),
+		},
+		{
+			name:                            "Given_Position_When_Patch_Then_PositionIsSet",
+			givenID:                         testObjectID.Hex(),
+			givenPatch:                      repository.ItemPatch{Position: ptr("i0i")},
+			givenMockFindOneAndUpdateResult: mockSuccessfulFindOneAndUpdateResult(),
+			wantSet:                         []string{"position", "updatedAt"},
+			wantPatchedItem:                 mockUpdateItemOutput(),
+		},
+		{
+			name:                            "Given_RemovedCategory_When_Patch_Then_CategoryIsUnset",
+			givenID:                         testObjectID.Hex(),
+			givenPatch:                      repository.ItemPatch{SetCategory: true},
+			givenMockFindOneAndUpdateResult: mockSuccessfulFindOneAndUpdateResult(),
+			wantSet:                         []string{"updatedAt"},
+			wantUnset:                       true,
+			wantPatchedItem:                 mockUpdateItemOutput(),
+		},
+		{
+			name:                            "Given_ValidPatch_When_Patch_And_ItemNotFound_Then_ExpectedNotFoundError",
+			givenID:                         testObjectID.Hex(),
+			givenPatch:                      repository.ItemPatch{Name: &name},
+			givenMockFindOneAndUpdateResult: mockNotFoundFindOneResult(),
+			wantSet:                         []string{"name", "updatedAt"},
+			wantErr:                         repository.NewItemNotFoundError(),
+		},
+		{
+			name:       "Given_InvalidID_When_Patch_Then_ExpectedInvalidIDError",
+			givenID:    "invalid-hex-id",
+			givenPatch: repository.ItemPatch{Name: &name},
+			wantErr:    repository.NewInvalidHexIDError(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionMock := new(dbmongo.MockMongoCollectionOperations)
+			clientMock := new(dbmongo.MockClientOperations)
+
+			if tt.givenMockFindOneAndUpdateResult != nil {
+				collectionMock.On("FindOneAndUpdate", ctx, bson.M{"_id": testObjectID}, mock.MatchedBy(func(update bson.M) bool {
+					set := update["$set"].(bson.M)
+					if len(set) != len(tt.wantSet) {
+						return false
+					}
+					for _, field := range tt.wantSet {
+						if _, ok := set[field]; !ok {
+							return false
+						}
+					}
+					_, unset := update["$unset"]
+					return unset == tt.wantUnset
+				})).Return(tt.givenMockFindOneAndUpdateResult)
+			}
+
+			clientMock.On("GetCollection", mongorepo.CollectionItems).Return(collectionMock)
+
+			repo := mongorepo.NewMongoDBItemRepository(clientMock)
+
+			patchedItem, err := repo.Patch(ctx, tt.givenID, tt.givenPatch)
+
+			if tt.wantErr != nil {
+				require.ErrorContains(t, err, tt.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantPatchedItem, patchedItem)
+			}
+
+			collectionMock.AssertExpectations(t)
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	ctx := context.Background()
 
 	tests := []struct {
 		name                     string
 		givenID                  string
+		givenVersion             int64
 		givenMockDeleteOneResult *mongo.DeleteResult
 		givenMockDeleteOneError  error
+		givenMockFindOneResult   *mongo.SingleResult
+		wantFilter               bson.M
 		wantErr                  error
 	}{
 		{
 			name:                     "Given_ValidID_When_Delete_Then_ExpectedSuccess",
 			givenID:                  testObjectID.Hex(),
 			givenMockDeleteOneResult: mockSuccessfulDeleteOneResult(),
+			wantFilter:               bson.M{"_id": testObjectID},
+		},
+		{
+			name:                     "Given_CurrentVersion_When_Delete_Then_VersionIsPartOfTheFilter",
+			givenID:                  testObjectID.Hex(),
+			givenVersion:             3,
+			givenMockDeleteOneResult: mockSuccessfulDeleteOneResult(),
+			wantFilter:               bson.M{"_id": testObjectID, "version": int64(3)},
+		},
+		{
+			name:                     "Given_StaleVersion_When_Delete_Then_ExpectedVersionConflictError",
+			givenID:                  testObjectID.Hex(),
+			givenVersion:             2,
+			givenMockDeleteOneResult: mockNotFoundDeleteOneResult(),
+			givenMockFindOneResult:   mockSuccessfulFindOneResult(),
+			wantFilter:               bson.M{"_id": testObjectID, "version": int64(2)},
+			wantErr:                  repository.NewVersionConflictError(),
+		},
+		{
+			name:                     "Given_VersionOfMissingItem_When_Delete_Then_ExpectedNotFoundError",
+			givenID:                  testObjectID.Hex(),
+			givenVersion:             2,
+			givenMockDeleteOneResult: mockNotFoundDeleteOneResult(),
+			givenMockFindOneResult:   mockNotFoundFindOneResult(),
+			wantFilter:               bson.M{"_id": testObjectID, "version": int64(2)},
+			wantErr:                  repository.NewItemNotFoundError(),
 		},
 		{
 			name:                     "Given_ValidID_When_Delete_And_ItemNotFound_Then_ExpectedNotFoundError",
 			givenID:                  testObjectID.Hex(),
 			givenMockDeleteOneResult: mockNotFoundDeleteOneResult(),
+			wantFilter:               bson.M{"_id": testObjectID},
 			wantErr:                  repository.NewItemNotFoundError(),
 		},
 		{
 			name:                    "Given_ValidID_When_Delete_And_DatabaseError_Then_ExpectedInternalError",
 			givenID:                 testObjectID.Hex(),
 			givenMockDeleteOneError: errDatabase,
+			wantFilter:              bson.M{"_id": testObjectID},
 			wantErr:                 errDatabase,
 		},
 		{
@@ -330,14 +536,17 @@ func TestDelete(t *testing.T) {
 			clientMock := new(dbmongo.MockClientOperations)
 
 			if tt.givenMockDeleteOneResult != nil || tt.givenMockDeleteOneError != nil {
-				collectionMock.On("DeleteOne", ctx, mock.Anything).Return(tt.givenMockDeleteOneResult, tt.givenMockDeleteOneError)
+				collectionMock.On("DeleteOne", ctx, tt.wantFilter).Return(tt.givenMockDeleteOneResult, tt.givenMockDeleteOneError)
+			}
+			if tt.givenMockFindOneResult != nil {
+				collectionMock.On("FindOne", ctx, bson.M{"_id": testObjectID}).Return(tt.givenMockFindOneResult)
 			}
 
 			clientMock.On("GetCollection", mongorepo.CollectionItems).Return(collectionMock)
 
 			repo := mongorepo.NewMongoDBItemRepository(clientMock)
 
-			err := repo.Delete(ctx, tt.givenID)
+			err := repo.Delete(ctx, tt.givenID, tt.givenVersion)
 
 			if tt.wantErr != nil {
 				require.ErrorContains(t, err, tt.wantErr.Error())
@@ -353,48 +562,93 @@ func TestDelete(t *testing.T) {
 
 func TestBulkUpdateActive(t *testing.T) {
 	ctx := context.Background()
+	missingObjectID := primitive.NewObjectID()
 
 	tests := []struct {
 		name                      string
-		givenActive               bool
+		givenUpdate               repository.ActiveUpdate
 		givenMockUpdateManyResult *mongo.UpdateResult
 		givenMockUpdateManyError  error
+		givenStored               []repository.Item
+		wantFilter                bson.M
+		wantFind                  bool
 		wantErr                   error
-		wantMatchedCount          int64
-		wantModifiedCount         int64
+		wantHTTP                  int
+		wantResult                repository.ActiveUpdateResult
 	}{
 		{
 			name:                      "Given_TrueActive_When_BulkUpdateActive_Then_ReturnsSuccess",
-			givenActive:               true,
+			givenUpdate:               repository.ActiveUpdate{Active: true},
 			givenMockUpdateManyResult: mockSuccessfulUpdateManyResult(),
-			wantMatchedCount:          5,
-			wantModifiedCount:         5,
+			wantFilter:                bson.M{},
+			wantResult:                repository.ActiveUpdateResult{MatchedCount: 5, ModifiedCount: 5},
 		},
 		{
 			name:                      "Given_FalseActive_When_BulkUpdateActive_Then_ReturnsSuccess",
-			givenActive:               false,
+			givenUpdate:               repository.ActiveUpdate{Active: false},
 			givenMockUpdateManyResult: mockSuccessfulUpdateManyResult(),
-			wantMatchedCount:          5,
-			wantModifiedCount:         5,
+			wantFilter:                bson.M{},
+			wantResult:                repository.ActiveUpdateResult{MatchedCount: 5, ModifiedCount: 5},
 		},
 		{
 			name:                      "Given_EmptyCollection_When_BulkUpdateActive_Then_ReturnsZeroCounts",
-			givenActive:               true,
+			givenUpdate:               repository.ActiveUpdate{Active: true},
 			givenMockUpdateManyResult: mockEmptyUpdateManyResult(),
-			wantMatchedCount:          0,
-			wantModifiedCount:         0,
+			wantFilter:                bson.M{},
 		},
 		{
 			name:                      "Given_PartialUpdate_When_BulkUpdateActive_Then_ReturnsPartialCounts",
-			givenActive:               true,
+			givenUpdate:               repository.ActiveUpdate{Active: true},
 			givenMockUpdateManyResult: mockPartialUpdateManyResult(),
-			wantMatchedCount:          5,
-			wantModifiedCount:         3,
+			wantFilter:                bson.M{},
+			wantResult:                repository.ActiveUpdateResult{MatchedCount: 5, ModifiedCount: 3},
+		},
+		{
+			name:                      "Given_Filter_When_BulkUpdateActive_Then_ExpectedBSONFilter",
+			givenUpdate:               repository.ActiveUpdate{Active: true, Filter: repository.ItemFilter{Active: ptr(false)}},
+			givenMockUpdateManyResult: mockPartialUpdateManyResult(),
+			wantFilter:                bson.M{"active": false},
+			wantResult:                repository.ActiveUpdateResult{MatchedCount: 5, ModifiedCount: 3},
+		},
+		{
+			name:                      "Given_IDs_When_BulkUpdateActive_Then_ExpectedOnlyTheseItemsUpdated",
+			givenUpdate:               repository.ActiveUpdate{Active: false, IDs: []string{testObjectID.Hex()}},
+			givenMockUpdateManyResult: &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1},
+			wantFilter:                bson.M{"_id": bson.M{"$in": []primitive.ObjectID{testObjectID}}},
+			wantResult:                repository.ActiveUpdateResult{MatchedCount: 1, ModifiedCount: 1},
+		},
+		{
+			name:                      "Given_MissingID_When_BulkUpdateActive_Then_ExpectedNotFoundIDs",
+			givenUpdate:               repository.ActiveUpdate{Active: false, IDs: []string{testObjectID.Hex(), missingObjectID.Hex()}},
+			givenMockUpdateManyResult: &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1},
+			givenStored:               []repository.Item{mockFoundItemOutput()},
+			wantFilter:                bson.M{"_id": bson.M{"$in": []primitive.ObjectID{testObjectID, missingObjectID}}},
+			wantFind:                  true,
+			wantResult:                repository.ActiveUpdateResult{MatchedCount: 1, ModifiedCount: 1, NotFoundIDs: []string{missingObjectID.Hex()}},
+		},
+		{
+			name: "Given_IDOfAnotherList_When_BulkUpdateActiveByListID_Then_ExpectedNotFoundIDs",
+			givenUpdate: repository.ActiveUpdate{
+				Active: false,
+				IDs:    []string{testObjectID.Hex()},
+				Filter: repository.ItemFilter{ListID: "000000000000000000000010"},
+			},
+			givenMockUpdateManyResult: mockEmptyUpdateManyResult(),
+			givenStored:               []repository.Item{mockFoundItemOutput()},
+			wantFilter:                bson.M{"_id": bson.M{"$in": []primitive.ObjectID{testObjectID}}, "listId": "000000000000000000000010"},
+			wantFind:                  true,
+			wantResult:                repository.ActiveUpdateResult{NotFoundIDs: []string{testObjectID.Hex()}},
+		},
+		{
+			name:        "Given_InvalidHexID_When_BulkUpdateActive_Then_ExpectedInvalidHexIDErrorWithoutUpdate",
+			givenUpdate: repository.ActiveUpdate{Active: false, IDs: []string{testObjectID.Hex(), "invalid-hex-id"}},
+			wantHTTP:    http.StatusUnprocessableEntity,
 		},
 		{
 			name:                     "Given_DatabaseError_When_BulkUpdateActive_Then_ReturnsError",
-			givenActive:              true,
+			givenUpdate:              repository.ActiveUpdate{Active: true},
 			givenMockUpdateManyError: errDatabase,
+			wantFilter:               bson.M{},
 			wantErr:                  errDatabase,
 		},
 	}
@@ -402,58 +656,390 @@ func TestBulkUpdateActive(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			collectionMock := new(dbmongo.MockMongoCollectionOperations)
+			cursorMock := new(dbmongo.MockMongoCursorOperations)
 			clientMock := new(dbmongo.MockClientOperations)
 
-			if tt.givenMockUpdateManyResult != nil || tt.givenMockUpdateManyError != nil {
-				collectionMock.On("UpdateMany", ctx, mock.Anything, mock.Anything, mock.Anything).Return(tt.givenMockUpdateManyResult, tt.givenMockUpdateManyError)
+			if tt.wantFilter != nil {
+				collectionMock.On("UpdateMany", ctx, tt.wantFilter, mock.Anything, mock.Anything).Return(tt.givenMockUpdateManyResult, tt.givenMockUpdateManyError)
+			}
+			if tt.wantFind {
+				collectionMock.On("Find", ctx, bson.M{"_id": tt.wantFilter["_id"]}, mock.Anything).Return(cursorMock, nil)
+				cursorMock.On("All", ctx, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					results := args.Get(1).(*[]repository.Item)
+					*results = tt.givenStored
+				})
+				cursorMock.On("Close", ctx).Return(nil)
 			}
 
 			clientMock.On("GetCollection", mongorepo.CollectionItems).Return(collectionMock)
 
 			repo := mongorepo.NewMongoDBItemRepository(clientMock)
 
-			matchedCount, modifiedCount, err := repo.BulkUpdateActive(ctx, tt.givenActive)
+			result, err := repo.BulkUpdateActive(ctx, tt.givenUpdate)
 
-			if tt.wantErr != nil {
+			switch {
+			case tt.wantHTTP != 0:
+				var errRepository repository.Error
+				require.ErrorAs(t, err, &errRepository)
+				require.Equal(t, tt.wantHTTP, errRepository.HTTP)
+			case tt.wantErr != nil:
 				require.ErrorContains(t, err, tt.wantErr.Error())
+			default:
+				require.NoError(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+
+			collectionMock.AssertExpectations(t)
+			cursorMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteMany(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name             string
+		givenFilter      repository.ItemFilter
+		givenResult      *mongo.DeleteResult
+		givenError       error
+		wantFilter       bson.M
+		wantDeletedCount int64
+		wantErr          error
+	}{
+		{
+			name:             "Given_InactiveFilter_When_DeleteMany_Then_ExpectedDeletedCount",
+			givenFilter:      repository.ItemFilter{Active: ptr(false)},
+			givenResult:      &mongo.DeleteResult{DeletedCount: 3},
+			wantFilter:       bson.M{"active": false},
+			wantDeletedCount: 3,
+		},
+		{
+			name:        "Given_NameAndObservationFilter_When_DeleteMany_Then_ExpectedBSONFilter",
+			givenFilter: repository.ItemFilter{Name: "p.o", HasObservation: ptr(false)},
+			givenResult: mockNotFoundDeleteOneResult(),
+			wantFilter: bson.M{
+				"name":        primitive.Regex{Pattern: `p\.o`, Options: "i"},
+				"observation": bson.M{"$in": bson.A{nil, ""}},
+			},
+		},
+		{
+			name:             "Given_ListFilter_When_DeleteMany_Then_ExpectedOnlyItemsOfTheList",
+			givenFilter:      repository.ItemFilter{ListID: "000000000000000000000010", Active: ptr(false)},
+			givenResult:      &mongo.DeleteResult{DeletedCount: 2},
+			wantFilter:       bson.M{"listId": "000000000000000000000010", "active": false},
+			wantDeletedCount: 2,
+		},
+		{
+			name:        "Given_DatabaseError_When_DeleteMany_Then_ExpectedInternalError",
+			givenFilter: repository.ItemFilter{Active: ptr(false)},
+			givenResult: (*mongo.DeleteResult)(nil),
+			givenError:  errDatabase,
+			wantFilter:  bson.M{"active": false},
+			wantErr:     errDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionMock := new(dbmongo.MockMongoCollectionOperations)
+			clientMock := new(dbmongo.MockClientOperations)
+
+			collectionMock.On("DeleteMany", ctx, tt.wantFilter, mock.Anything).Return(tt.givenResult, tt.givenError)
+			clientMock.On("GetCollection", mongorepo.CollectionItems).Return(collectionMock)
+
+			repo := mongorepo.NewMongoDBItemRepository(clientMock)
+
+			deletedCount, err := repo.DeleteMany(ctx, tt.givenFilter)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
 			} else {
 				require.NoError(t, err)
-				require.Equal(t, tt.wantMatchedCount, matchedCount)
-				require.Equal(t, tt.wantModifiedCount, modifiedCount)
+				require.Equal(t, tt.wantDeletedCount, deletedCount)
+			}
+
+			collectionMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestClearCategory(t *testing.T) {
+	ctx := context.Background()
+	categoryID := "000000000000000000000020"
+
+	tests := []struct {
+		name             string
+		givenResult      *mongo.UpdateResult
+		givenError       error
+		wantChangedCount int64
+		wantErr          error
+	}{
+		{
+			name:             "Given_ItemsOfTheCategory_When_ClearCategory_Then_ExpectedChangedCount",
+			givenResult:      mockSuccessfulUpdateManyResult(),
+			wantChangedCount: 5,
+		},
+		{
+			name:        "Given_DatabaseError_When_ClearCategory_Then_ExpectedInternalError",
+			givenResult: (*mongo.UpdateResult)(nil),
+			givenError:  errDatabase,
+			wantErr:     errDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionMock := new(dbmongo.MockMongoCollectionOperations)
+			clientMock := new(dbmongo.MockClientOperations)
+
+			collectionMock.On("UpdateMany", ctx, bson.M{"categoryId": categoryID}, mock.MatchedBy(func(update bson.M) bool {
+				unset, ok := update["$unset"].(bson.M)
+				return ok && unset["categoryId"] == "" && update["$inc"] != nil
+			}), mock.Anything).Return(tt.givenResult, tt.givenError)
+			clientMock.On("GetCollection", mongorepo.CollectionItems).Return(collectionMock)
+
+			repo := mongorepo.NewMongoDBItemRepository(clientMock)
+
+			changedCount, err := repo.ClearCategory(ctx, categoryID)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantChangedCount, changedCount)
+			}
+
+			collectionMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSumPrices(t *testing.T) {
+	ctx := context.Background()
+	decimal := func(s string) primitive.Decimal128 {
+		d, _ := primitive.ParseDecimal128(s)
+		return d
+	}
+
+	tests := []struct {
+		name           string
+		givenFilter    repository.ItemFilter
+		givenDocuments []bson.M
+		givenError     error
+		wantMatch      bson.M
+		wantTotals     []domain.PriceTotal
+		wantErr        error
+	}{
+		{
+			name:        "Given_PricedItems_When_SumPrices_Then_ExpectedExactTotals",
+			givenFilter: repository.ItemFilter{ListID: "000000000000000000000001"},
+			givenDocuments: []bson.M{
+				{"_id": bson.M{"currency": "BRL", "categoryId": "000000000000000000000020", "active": true}, "total": decimal("1935000")},
+				{"_id": bson.M{"currency": "BRL", "categoryId": "", "active": false}, "total": decimal("333333")},
+			},
+			wantMatch: bson.M{"listId": "000000000000000000000001", "unitPrice": bson.M{"$ne": nil}},
+			wantTotals: []domain.PriceTotal{
+				{Currency: "BRL", CategoryID: "000000000000000000000020", Active: true, Cents: big.NewRat(1935, 1)},
+				{Currency: "BRL", Active: false, Cents: big.NewRat(333333, 1000)},
+			},
+		},
+		{
+			name:       "Given_NoPricedItem_When_SumPrices_Then_ExpectedEmptySlice",
+			wantMatch:  bson.M{"unitPrice": bson.M{"$ne": nil}},
+			wantTotals: []domain.PriceTotal{},
+		},
+		{
+			name:       "Given_DatabaseError_When_SumPrices_Then_ExpectedInternalError",
+			givenError: errDatabase,
+			wantMatch:  bson.M{"unitPrice": bson.M{"$ne": nil}},
+			wantErr:    errDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionMock := new(dbmongo.MockMongoCollectionOperations)
+			cursorMock := new(dbmongo.MockMongoCursorOperations)
+			clientMock := new(dbmongo.MockClientOperations)
+
+			matchesFilter := mock.MatchedBy(func(pipeline mongo.Pipeline) bool {
+				return reflect.DeepEqual(pipeline[0][0].Value, tt.wantMatch)
+			})
+			if tt.givenError != nil {
+				collectionMock.On("Aggregate", ctx, matchesFilter).Return((*dbmongo.MockMongoCursorOperations)(nil), tt.givenError)
+			} else {
+				collectionMock.On("Aggregate", ctx, matchesFilter).Return(cursorMock, nil)
+				cursorMock.On("All", ctx, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					raw, _ := bson.Marshal(bson.M{"documents": bson.A{}})
+					if len(tt.givenDocuments) > 0 {
+						raw, _ = bson.Marshal(bson.M{"documents": tt.givenDocuments})
+					}
+					require.NoError(t, bson.Raw(raw).Lookup("documents").Unmarshal(args.Get(1)))
+				})
+				cursorMock.On("Close", ctx).Return(nil)
+			}
+			clientMock.On("GetCollection", mongorepo.CollectionItems).Return(collectionMock)
+
+			repo := mongorepo.NewMongoDBItemRepository(clientMock)
+
+			totals, err := repo.SumPrices(ctx, tt.givenFilter)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, totals, len(tt.wantTotals))
+				for i, want := range tt.wantTotals {
+					require.Equal(t, want.Currency, totals[i].Currency)
+					require.Equal(t, want.CategoryID, totals[i].CategoryID)
+					require.Equal(t, want.Active, totals[i].Active)
+					require.Zero(t, want.Cents.Cmp(totals[i].Cents), "got %s", totals[i].Cents.RatString())
+				}
+				cursorMock.AssertExpectations(t)
 			}
 
 			collectionMock.AssertExpectations(t)
-			clientMock.AssertExpectations(t)
 		})
 	}
 }
 
 func TestList(t *testing.T) {
 	ctx := context.Background()
+	items := mockItemListOutput()
+	cursorItem := repository.Item{ID: testObjectID.Hex(), Position: "i1", CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+	sortedCursorItem := repository.Item{ID: testObjectID.Hex(), Name: "Pão", UpdatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+	updatedThenName := []repository.SortField{
+		{Field: repository.SortFieldUpdatedAt, Desc: true},
+		{Field: repository.SortFieldName},
+	}
 
 	tests := []struct {
 		name           string
+		givenOptions   repository.ListOptions
 		givenFindError error
 		givenAllError  error
-		wantItems      []repository.Item
+		givenItems     []repository.Item
+		wantFilter     bson.M
+		wantSort       bson.D
+		wantCollation  *options.Collation
+		wantLimit      *int64
+		wantPage       repository.ItemPage
 		wantErr        error
+		wantHTTP       int
 	}{
 		{
-			name:      "Given_ItemsExist_When_List_Then_ExpectedSuccess",
-			wantItems: mockItemListOutput(),
+			name:       "Given_ItemsExist_When_List_Then_ExpectedSuccess",
+			givenItems: items,
+			wantFilter: bson.M{},
+			wantPage:   repository.ItemPage{Items: items},
+		},
+		{
+			name:       "Given_NoItemsExist_When_List_Then_ExpectedEmptyList",
+			givenItems: []repository.Item{},
+			wantFilter: bson.M{},
+			wantPage:   repository.ItemPage{Items: []repository.Item{}},
+		},
+		{
+			name:         "Given_MoreItemsThanLimit_When_List_Then_ExpectedPageWithNextCursor",
+			givenOptions: repository.ListOptions{Limit: 1},
+			givenItems:   items,
+			wantFilter:   bson.M{},
+			wantLimit:    ptr(int64(2)),
+			wantPage:     repository.ItemPage{Items: items[:1], NextCursor: repository.EncodeCursor(items[0], repository.DefaultSort)},
+		},
+		{
+			name:         "Given_Cursor_When_List_Then_ExpectedItemsAfterCursor",
+			givenOptions: repository.ListOptions{Limit: 2, Cursor: repository.EncodeCursor(cursorItem, repository.DefaultSort)},
+			givenItems:   items,
+			wantFilter: bson.M{"$or": bson.A{
+				bson.M{"position": bson.M{"$gt": cursorItem.Position}},
+				bson.M{"position": cursorItem.Position, "createdAt": bson.M{"$gt": cursorItem.CreatedAt}},
+				bson.M{"position": cursorItem.Position, "createdAt": cursorItem.CreatedAt, "_id": bson.M{"$gt": testObjectID}},
+			}},
+			wantLimit: ptr(int64(3)),
+			wantPage:  repository.ItemPage{Items: items},
+		},
+		{
+			name: "Given_Filter_When_List_Then_ExpectedBSONFilter",
+			givenOptions: repository.ListOptions{
+				Cursor: repository.EncodeCursor(cursorItem, repository.DefaultSort),
+				Filter: repository.ItemFilter{
+					Active:         ptr(false),
+					Name:           "p.o",
+					HasObservation: ptr(true),
+					CreatedAfter:   ptr(cursorItem.CreatedAt),
+					UpdatedBefore:  ptr(cursorItem.CreatedAt.Add(time.Hour)),
+				},
+			},
+			givenItems: items,
+			wantFilter: bson.M{
+				"active":      false,
+				"name":        primitive.Regex{Pattern: `p\.o`, Options: "i"},
+				"observation": bson.M{"$nin": bson.A{nil, ""}},
+				"createdAt":   bson.M{"$gt": cursorItem.CreatedAt},
+				"updatedAt":   bson.M{"$lt": cursorItem.CreatedAt.Add(time.Hour)},
+				"$or": bson.A{
+					bson.M{"position": bson.M{"$gt": cursorItem.Position}},
+					bson.M{"position": cursorItem.Position, "createdAt": bson.M{"$gt": cursorItem.CreatedAt}},
+					bson.M{"position": cursorItem.Position, "createdAt": cursorItem.CreatedAt, "_id": bson.M{"$gt": testObjectID}},
+				},
+			},
+			wantPage: repository.ItemPage{Items: items},
+		},
+		{
+			name:         "Given_WithoutObservationFilter_When_List_Then_ExpectedNullOrEmptyObservation",
+			givenOptions: repository.ListOptions{Filter: repository.ItemFilter{HasObservation: ptr(false)}},
+			givenItems:   items,
+			wantFilter:   bson.M{"observation": bson.M{"$in": bson.A{nil, ""}}},
+			wantPage:     repository.ItemPage{Items: items},
+		},
+		{
+			name: "Given_SortAndCursor_When_List_Then_ExpectedSortWithCollationAndKeysetFilter",
+			givenOptions: repository.ListOptions{
+				Limit:  2,
+				Sort:   updatedThenName,
+				Cursor: repository.EncodeCursor(sortedCursorItem, updatedThenName),
+			},
+			givenItems: items,
+			wantFilter: bson.M{"$or": bson.A{
+				bson.M{"updatedAt": bson.M{"$lt": sortedCursorItem.UpdatedAt}},
+				bson.M{"updatedAt": sortedCursorItem.UpdatedAt, "name": bson.M{"$gt": "Pão"}},
+				bson.M{"updatedAt": sortedCursorItem.UpdatedAt, "name": "Pão", "_id": bson.M{"$gt": testObjectID}},
+			}},
+			wantSort:      bson.D{{Key: "updatedAt", Value: -1}, {Key: "name", Value: 1}, {Key: "_id", Value: 1}},
+			wantCollation: &options.Collation{Locale: "pt", Strength: 1},
+			wantLimit:     ptr(int64(3)),
+			wantPage:      repository.ItemPage{Items: items},
+		},
+		{
+			name:         "Given_UnknownSortField_When_List_Then_ExpectedBadRequestError",
+			givenOptions: repository.ListOptions{Sort: []repository.SortField{{Field: "observation"}}},
+			wantHTTP:     http.StatusBadRequest,
+		},
+		{
+			name: "Given_CursorOfAnotherSort_When_List_Then_ExpectedBadRequestError",
+			givenOptions: repository.ListOptions{
+				Sort:   updatedThenName,
+				Cursor: repository.EncodeCursor(cursorItem, repository.DefaultSort),
+			},
+			wantHTTP: http.StatusBadRequest,
 		},
 		{
-			name:      "Given_NoItemsExist_When_List_Then_ExpectedEmptyList",
-			wantItems: []repository.Item{},
+			name:         "Given_InvalidCursor_When_List_Then_ExpectedBadRequestError",
+			givenOptions: repository.ListOptions{Cursor: "invalid"},
+			wantHTTP:     http.StatusBadRequest,
 		},
 		{
 			name:           "Given_FindError_When_List_Then_ExpectedInternalError",
 			givenFindError: errDatabase,
+			wantFilter:     bson.M{},
 			wantErr:        errDatabase,
 		},
 		{
 			name:          "Given_CursorAllError_When_List_Then_ExpectedInternalError",
 			givenAllError: errDatabase,
+			wantFilter:    bson.M{},
 			wantErr:       errDatabase,
 		},
 	}
@@ -464,14 +1050,24 @@ func TestList(t *testing.T) {
 			cursorMock := new(dbmongo.MockMongoCursorOperations)
 			clientMock := new(dbmongo.MockClientOperations)
 
+			wantSort := tt.wantSort
+			if wantSort == nil {
+				wantSort = bson.D{{Key: "position", Value: 1}, {Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}
+			}
+			matchOptions := mock.MatchedBy(func(opts []*options.FindOptions) bool {
+				return len(opts) == 1 &&
+					reflect.DeepEqual(opts[0].Sort, wantSort) &&
+					reflect.DeepEqual(opts[0].Collation, tt.wantCollation) &&
+					reflect.DeepEqual(opts[0].Limit, tt.wantLimit)
+			})
 			if tt.givenFindError != nil {
-				collectionMock.On("Find", ctx, mock.Anything).Return((*dbmongo.MockMongoCursorOperations)(nil), tt.givenFindError)
-			} else {
-				collectionMock.On("Find", ctx, mock.Anything).Return(cursorMock, nil)
+				collectionMock.On("Find", ctx, tt.wantFilter, matchOptions).Return((*dbmongo.MockMongoCursorOperations)(nil), tt.givenFindError)
+			} else if tt.wantFilter != nil {
+				collectionMock.On("Find", ctx, tt.wantFilter, matchOptions).Return(cursorMock, nil)
 				cursorMock.On("All", ctx, mock.Anything).Return(tt.givenAllError).Run(func(args mock.Arguments) {
 					if tt.givenAllError == nil {
 						results := args.Get(1).(*[]repository.Item)
-						*results = tt.wantItems
+						*results = tt.givenItems
 					}
 				})
 				cursorMock.On("Close", ctx).Return(nil)
@@ -481,17 +1077,125 @@ func TestList(t *testing.T) {
 
 			repo := mongorepo.NewMongoDBItemRepository(clientMock)
 
-			items, err := repo.List(ctx)
+			page, err := repo.List(ctx, tt.givenOptions)
+
+			switch {
+			case tt.wantHTTP != 0:
+				var errRepository repository.Error
+				require.ErrorAs(t, err, &errRepository)
+				require.Equal(t, tt.wantHTTP, errRepository.HTTP)
+			case tt.wantErr != nil:
+				require.ErrorIs(t, err, tt.wantErr)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, tt.wantPage, page)
+			}
+
+			collectionMock.AssertExpectations(t)
+			cursorMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+	items := mockItemListOutput()
+
+	tests := []struct {
+		name           string
+		givenQuery     string
+		givenListID    string
+		givenLimit     int
+		givenFindError error
+		wantSearch     string
+		wantListID     string
+		wantLimit      *int64
+		wantItems      []repository.Item
+		wantErr        error
+	}{
+		{
+			name:       "Given_Query_When_Search_Then_ExpectedTextSearchSortedByScore",
+			givenQuery: "Feijão preto",
+			givenLimit: 10,
+			wantSearch: "Feijão preto",
+			wantLimit:  ptr(int64(10)),
+			wantItems:  items,
+		},
+		{
+			name:       "Given_QueryWithOperators_When_Search_Then_OnlyWordsAreSearched",
+			givenQuery: `-arroz "integral"`,
+			wantSearch: "arroz integral",
+			wantItems:  items,
+		},
+		{
+			name:        "Given_List_When_Search_Then_ExpectedTextSearchInTheList",
+			givenQuery:  "arroz",
+			givenListID: "000000000000000000000010",
+			wantSearch:  "arroz",
+			wantListID:  "000000000000000000000010",
+			wantItems:   items,
+		},
+		{
+			name:       "Given_QueryWithoutWords_When_Search_Then_ExpectedEmptyResultWithoutQuery",
+			givenQuery: "?!",
+			wantItems:  []repository.Item{},
+		},
+		{
+			name:           "Given_FindError_When_Search_Then_ExpectedInternalError",
+			givenQuery:     "milk",
+			givenFindError: errDatabase,
+			wantSearch:     "milk",
+			wantErr:        errDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionMock := new(dbmongo.MockMongoCollectionOperations)
+			cursorMock := new(dbmongo.MockMongoCursorOperations)
+			clientMock := new(dbmongo.MockClientOperations)
+
+			score := bson.M{"$meta": "textScore"}
+			wantFilter := bson.M{"$text": bson.M{"$search": tt.wantSearch}}
+			if tt.wantListID != "" {
+				wantFilter["listId"] = tt.wantListID
+			}
+			matchOptions := mock.MatchedBy(func(opts []*options.FindOptions) bool {
+				return len(opts) == 1 &&
+					reflect.DeepEqual(opts[0].Projection, bson.M{"score": score}) &&
+					reflect.DeepEqual(opts[0].Sort, bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}}) &&
+					reflect.DeepEqual(opts[0].Limit, tt.wantLimit)
+			})
+			if tt.givenFindError != nil {
+				collectionMock.On("Find", ctx, wantFilter, matchOptions).Return((*dbmongo.MockMongoCursorOperations)(nil), tt.givenFindError)
+			} else if tt.wantSearch != "" {
+				collectionMock.On("Find", ctx, wantFilter, matchOptions).Return(cursorMock, nil)
+				cursorMock.On("All", ctx, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					results := args.Get(1).(*[]repository.Item)
+					*results = tt.wantItems
+				})
+				cursorMock.On("Close", ctx).Return(nil)
+			}
+
+			clientMock.On("GetCollection", mongorepo.CollectionItems).Return(collectionMock).Maybe()
+
+			repo := mongorepo.NewMongoDBItemRepository(clientMock)
+
+			found, err := repo.Search(ctx, tt.givenQuery, tt.givenListID, tt.givenLimit)
 
 			if tt.wantErr != nil {
 				require.ErrorIs(t, err, tt.wantErr)
 			} else {
 				require.NoError(t, err)
+				require.Equal(t, tt.wantItems, found)
 			}
-			require.Equal(t, tt.wantItems, items)
 
 			collectionMock.AssertExpectations(t)
 			cursorMock.AssertExpectations(t)
 		})
 	}
 }
+
+func ptr[T any](v T) *T {
+	return &v
+}
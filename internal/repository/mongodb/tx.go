@@ -0,0 +1,50 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	dbmongo "github.com/lucaspereirasilva0/list-manager-api/internal/database/mongodb"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+// MongoTxManager implements repository.TxManager with MongoDB multi-document
+// transactions (requires a replica set or sharded cluster).
+type MongoTxManager struct {
+	client dbmongo.ClientOperations
+}
+
+// NewMongoTxManager creates a new instance of MongoTxManager
+func NewMongoTxManager(client dbmongo.ClientOperations) repository.TxManager {
+	return &MongoTxManager{
+		client: client,
+	}
+}
+
+// WithinTransaction runs fn in a session transaction. The session context given
+// to fn makes the repository operations part of the transaction. The driver
+// retries the whole transaction on TransientTransactionError and the commit on
+// UnknownTransactionCommitResult.
+func (m *MongoTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if session := mongo.SessionFromContext(ctx); session != nil {
+		// Already inside a transaction: join it
+		return fn(ctx)
+	}
+
+	session, err := m.client.Client().StartSession()
+	if err != nil {
+		return repository.HandleError(fmt.Errorf("failed to start session: %w", err))
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+	if err != nil {
+		return repository.HandleError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package mongodb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	dbmongo "github.com/lucaspereirasilva0/list-manager-api/internal/database/mongodb"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	mongorepo "github.com/lucaspereirasilva0/list-manager-api/internal/repository/mongodb"
+)
+
+func TestWithinTransaction(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name                 string
+		givenStartSessionErr error
+		givenFnErr           error
+		givenWithTxErr       error
+		wantErr              error
+		wantFnCalled         bool
+		wantSessionInFnCtx   bool
+		wantSessionEnded     bool
+	}{
+		{
+			name:               "Given_SuccessfulFn_When_WithinTransaction_Then_FnRunsInSession",
+			wantFnCalled:       true,
+			wantSessionInFnCtx: true,
+			wantSessionEnded:   true,
+		},
+		{
+			name:               "Given_FailingFn_When_WithinTransaction_Then_ExpectedFnError",
+			givenFnErr:         repository.NewItemNotFoundError(),
+			wantErr:            repository.NewItemNotFoundError(),
+			wantFnCalled:       true,
+			wantSessionInFnCtx: true,
+			wantSessionEnded:   true,
+		},
+		{
+			name:               "Given_CommitError_When_WithinTransaction_Then_ExpectedGenericError",
+			givenWithTxErr:     errDatabase,
+			wantErr:            repository.NewGenericRepositoryError(errDatabase),
+			wantFnCalled:       true,
+			wantSessionInFnCtx: true,
+			wantSessionEnded:   true,
+		},
+		{
+			name:                 "Given_StartSessionError_When_WithinTransaction_Then_ExpectedGenericErrorAndFnNotCalled",
+			givenStartSessionErr: errDatabase,
+			wantErr:              repository.NewGenericRepositoryError(errDatabase),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessionMock := new(dbmongo.MockSession)
+			mongoClientMock := new(dbmongo.MockMongoClientOperations)
+			clientMock := new(dbmongo.MockClientOperations)
+
+			clientMock.On("Client").Return(mongoClientMock)
+			if tt.givenStartSessionErr != nil {
+				mongoClientMock.On("StartSession").Return(nil, tt.givenStartSessionErr)
+			} else {
+				mongoClientMock.On("StartSession").Return(sessionMock, nil)
+				sessionMock.On("WithTransaction", ctx).Return(tt.givenWithTxErr)
+				sessionMock.On("EndSession", ctx).Return()
+			}
+
+			txManager := mongorepo.NewMongoTxManager(clientMock)
+
+			fnCalled, sessionInFnCtx := false, false
+			err := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+				fnCalled = true
+				sessionInFnCtx = mongo.SessionFromContext(ctx) != nil
+				return tt.givenFnErr
+			})
+
+			if tt.wantErr != nil {
+				require.ErrorAs(t, err, new(repository.Error))
+				require.Equal(t, tt.wantErr.(repository.Error).HTTP, err.(repository.Error).HTTP)
+				require.ErrorContains(t, err, tt.wantErr.(repository.Error).Message)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantFnCalled, fnCalled)
+			require.Equal(t, tt.wantSessionInFnCtx, sessionInFnCtx)
+			if tt.wantSessionEnded {
+				sessionMock.AssertCalled(t, "EndSession", ctx)
+			}
+		})
+	}
+}
+
+func TestWithinTransaction_NestedCallJoinsOuterSession(t *testing.T) {
+	ctx := context.Background()
+
+	sessionMock := new(dbmongo.MockSession)
+	mongoClientMock := new(dbmongo.MockMongoClientOperations)
+	clientMock := new(dbmongo.MockClientOperations)
+
+	clientMock.On("Client").Return(mongoClientMock)
+	mongoClientMock.On("StartSession").Return(sessionMock, nil).Once()
+	sessionMock.On("WithTransaction", ctx).Return(nil).Once()
+	sessionMock.On("EndSession", ctx).Return().Once()
+
+	txManager := mongorepo.NewMongoTxManager(clientMock)
+
+	err := txManager.WithinTransaction(ctx, func(outerCtx context.Context) error {
+		return txManager.WithinTransaction(outerCtx, func(innerCtx context.Context) error {
+			require.Same(t, mongo.SessionFromContext(outerCtx), mongo.SessionFromContext(innerCtx))
+			return nil
+		})
+	})
+
+	require.NoError(t, err)
+	mongoClientMock.AssertNumberOfCalls(t, "StartSession", 1)
+	mongoClientMock.AssertExpectations(t)
+}
@@ -0,0 +1,22 @@
+package postgres
+
+import (
+	"github.com/lucaspereirasilva0/list-manager-api/internal/database/sqldb"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository/sqlstore"
+)
+
+// NewPostgresItemRepository creates a repository.ItemRepository backed by PostgreSQL
+func NewPostgresItemRepository(db sqldb.DBOperations) repository.ItemRepository {
+	return sqlstore.NewSQLItemRepository(db)
+}
+
+// NewPostgresListRepository creates a repository.ListRepository backed by PostgreSQL
+func NewPostgresListRepository(db sqldb.DBOperations) repository.ListRepository {
+	return sqlstore.NewSQLListRepository(db)
+}
+
+// NewPostgresCategoryRepository creates a repository.CategoryRepository backed by PostgreSQL
+func NewPostgresCategoryRepository(db sqldb.DBOperations) repository.CategoryRepository {
+	return sqlstore.NewSQLCategoryRepository(db)
+}
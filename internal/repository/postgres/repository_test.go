@@ -0,0 +1,205 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	dbpostgres "github.com/lucaspereirasilva0/list-manager-api/internal/database/postgres"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	postgresrepo "github.com/lucaspereirasilva0/list-manager-api/internal/repository/postgres"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository/repositorytest"
+)
+
+// newTestRepository connects to the database in POSTGRES_TEST_DSN and starts from an empty items table.
+// The tests are skipped when the variable is not set.
+func newTestRepository(t *testing.T) repository.ItemRepository {
+	t.Helper()
+
+	repo, _ := newTestTxRepository(t)
+	return repo
+}
+
+func newTestTxRepository(t *testing.T) (repository.ItemRepository, repository.TxManager) {
+	t.Helper()
+
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN not set, skipping PostgreSQL integration tests")
+	}
+
+	ctx := context.Background()
+	client, err := dbpostgres.NewClient(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Disconnect(context.Background()))
+	})
+
+	_, err = client.DB().ExecContext(ctx, "TRUNCATE items")
+	require.NoError(t, err)
+
+	return postgresrepo.NewPostgresItemRepository(client.DB()), client.TxManager()
+}
+
+// newTestListRepository starts from the lists table as migrated: only the default list.
+func newTestListRepository(t *testing.T) repository.ListRepository {
+	t.Helper()
+
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN not set, skipping PostgreSQL integration tests")
+	}
+
+	ctx := context.Background()
+	client, err := dbpostgres.NewClient(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Disconnect(context.Background()))
+	})
+
+	_, err = client.DB().ExecContext(ctx, "DELETE FROM lists WHERE id <> $1", repository.DefaultListID)
+	require.NoError(t, err)
+
+	return postgresrepo.NewPostgresListRepository(client.DB())
+}
+
+// newTestCategoryRepository starts from an empty categories table.
+func newTestCategoryRepository(t *testing.T) repository.CategoryRepository {
+	t.Helper()
+
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN not set, skipping PostgreSQL integration tests")
+	}
+
+	ctx := context.Background()
+	client, err := dbpostgres.NewClient(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Disconnect(context.Background()))
+	})
+
+	_, err = client.DB().ExecContext(ctx, "TRUNCATE categories")
+	require.NoError(t, err)
+
+	return postgresrepo.NewPostgresCategoryRepository(client.DB())
+}
+
+func mockItem() repository.Item {
+	obs := "mock observation"
+	return repository.Item{ID: primitive.NewObjectID().Hex(), Name: "Test Item", Active: true, Observation: &obs}
+}
+
+func TestCreateAndGetByID(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	item := mockItem()
+
+	createdItem, err := repo.Create(ctx, item)
+	require.NoError(t, err)
+	require.Equal(t, item, createdItem)
+
+	storedItem, err := repo.GetByID(ctx, item.ID)
+	require.NoError(t, err)
+	require.Equal(t, item.Name, storedItem.Name)
+	require.Equal(t, item.Observation, storedItem.Observation)
+	require.False(t, storedItem.CreatedAt.IsZero())
+
+	_, err = repo.Create(ctx, item)
+	require.ErrorContains(t, err, "generic repository error")
+}
+
+func TestInvalidID(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	_, err := repo.GetByID(ctx, "invalid-id")
+	require.Equal(t, repository.NewInvalidHexIDError(), err)
+
+	_, err = repo.Update(ctx, repository.Item{ID: "invalid-id"})
+	require.Equal(t, repository.NewInvalidHexIDError(), err)
+
+	err = repo.Delete(ctx, "invalid-id", 0)
+	require.Equal(t, repository.NewInvalidHexIDError(), err)
+}
+
+func TestUpdate(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	item := mockItem()
+	_, err := repo.Update(ctx, item)
+	require.Equal(t, repository.NewItemNotFoundError(), err)
+
+	_, err = repo.Create(ctx, item)
+	require.NoError(t, err)
+
+	_, err = repo.Update(ctx, repository.Item{ID: item.ID, Name: "Updated Item", Active: false})
+	require.NoError(t, err)
+
+	storedItem, err := repo.GetByID(ctx, item.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Updated Item", storedItem.Name)
+	require.False(t, storedItem.Active)
+	require.Equal(t, item.Observation, storedItem.Observation)
+}
+
+func TestDelete(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	item := mockItem()
+	_, err := repo.Create(ctx, item)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Delete(ctx, item.ID, 0))
+	require.Equal(t, repository.NewItemNotFoundError(), repo.Delete(ctx, item.ID, 0))
+}
+
+func TestListAndBulkUpdateActive(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	page, err := repo.List(ctx, repository.ListOptions{})
+	items := page.Items
+	require.NoError(t, err)
+	require.Empty(t, items)
+
+	for i := 0; i < 3; i++ {
+		_, err = repo.Create(ctx, mockItem())
+		require.NoError(t, err)
+	}
+
+	result, err := repo.BulkUpdateActive(ctx, repository.ActiveUpdate{Active: false})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), result.MatchedCount)
+	require.Equal(t, int64(3), result.ModifiedCount)
+
+	page, err = repo.List(ctx, repository.ListOptions{})
+	items = page.Items
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	for _, item := range items {
+		require.False(t, item.Active)
+	}
+}
+
+func TestConformance(t *testing.T) {
+	repositorytest.Run(t, newTestRepository)
+}
+
+func TestTxConformance(t *testing.T) {
+	repositorytest.RunTx(t, newTestTxRepository)
+}
+
+func TestListConformance(t *testing.T) {
+	repositorytest.RunList(t, newTestListRepository)
+}
+
+func TestCategoryConformance(t *testing.T) {
+	repositorytest.RunCategory(t, newTestCategoryRepository)
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+)
+
+// QuantityScale is the number of thousandths a quantity is stored in per unit:
+// a quantity of 1.5 is stored as 1500
+const QuantityScale = 1000
+
+// LineTotal returns the price of item in cents, its unit price times its
+// quantity (1 without quantity), and false when it has no unit price
+func LineTotal(item Item) (*big.Rat, bool) {
+	if item.UnitPrice == nil {
+		return nil, false
+	}
+
+	thousandths := int64(QuantityScale)
+	if item.Quantity != nil {
+		thousandths = *item.Quantity
+	}
+	total := new(big.Int).Mul(big.NewInt(*item.UnitPrice), big.NewInt(thousandths))
+	return new(big.Rat).SetFrac(total, big.NewInt(QuantityScale)), true
+}
+
+// SumPrices is the pure-Go SumPrices used by the backends that hold the items
+// in memory. The totals are ordered by currency, category and active value.
+func SumPrices(items []Item) []domain.PriceTotal {
+	type key struct {
+		currency, categoryID string
+		active               bool
+	}
+
+	sums := make(map[key]*big.Rat)
+	for _, item := range items {
+		lineTotal, ok := LineTotal(item)
+		if !ok {
+			continue
+		}
+		k := key{currency: item.Currency, categoryID: item.CategoryID, active: item.Active}
+		if sums[k] == nil {
+			sums[k] = new(big.Rat)
+		}
+		sums[k].Add(sums[k], lineTotal)
+	}
+
+	totals := make([]domain.PriceTotal, 0, len(sums))
+	for k, cents := range sums {
+		totals = append(totals, domain.PriceTotal{Currency: domain.Currency(k.currency), CategoryID: k.categoryID, Active: k.active, Cents: cents})
+	}
+	SortPriceTotals(totals)
+	return totals
+}
+
+// SortPriceTotals orders totals by currency, category and active value
+func SortPriceTotals(totals []domain.PriceTotal) {
+	sort.Slice(totals, func(i, j int) bool {
+		a, b := totals[i], totals[j]
+		if a.Currency != b.Currency {
+			return a.Currency < b.Currency
+		}
+		if a.CategoryID != b.CategoryID {
+			return a.CategoryID < b.CategoryID
+		}
+		return !a.Active && b.Active
+	})
+}
+
+// ParseScaledCents reads a decimal sum of cents computed by a database, like
+// "1234.5" or "1.2345E+3", divided by scale, exactly
+func ParseScaledCents(sum string, scale int64) (*big.Rat, error) {
+	cents, ok := new(big.Rat).SetString(sum)
+	if !ok {
+		return nil, fmt.Errorf("invalid sum of prices %q", sum)
+	}
+	return cents.Quo(cents, big.NewRat(scale, 1)), nil
+}
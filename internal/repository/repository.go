@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
 )
 
 // ItemRepository defines the interface for item persistence operations
@@ -9,18 +11,97 @@ type ItemRepository interface {
 	// Create inserts a new item in the repository
 	Create(ctx context.Context, item Item) (Item, error)
 
-	// Update modifies an existing item in the repository
+	// Update modifies an existing item in the repository and increments its version.
+	// When item.Version is not zero and differs from the stored one, nothing is
+	// changed and the error is NewVersionConflictError
 	Update(ctx context.Context, item Item) (Item, error)
 
-	// Delete removes an item from the repository
-	Delete(ctx context.Context, id string) error
+	// Patch atomically changes only the fields set in patch and returns the updated item,
+	// checking patch.Version like Update
+	Patch(ctx context.Context, id string, patch ItemPatch) (Item, error)
+
+	// Delete removes an item from the repository; a non-zero version must match the stored one
+	Delete(ctx context.Context, id string, version int64) error
 
 	// GetByID retrieves an item by its ID
 	GetByID(ctx context.Context, id string) (Item, error)
 
-	// List retrieves all items from the repository
-	List(ctx context.Context) ([]Item, error)
+	// List retrieves a page of items from the repository, ordered by opts.Sort
+	List(ctx context.Context, opts ListOptions) (ItemPage, error)
+
+	// Search retrieves up to limit items of listID (of every list when empty)
+	// whose name or observation contain the words of query, ignoring case and
+	// diacritics, most relevant first
+	Search(ctx context.Context, query, listID string, limit int) ([]Item, error)
+
+	// BulkUpdateActive sets the active field of the items selected by update,
+	// incrementing their version. Every ID is validated before anything is changed.
+	BulkUpdateActive(ctx context.Context, update ActiveUpdate) (ActiveUpdateResult, error)
+
+	// DeleteMany removes every item matching filter, all of them for the zero value
+	DeleteMany(ctx context.Context, filter ItemFilter) (deletedCount int64, err error)
+
+	// Batch applies ops in order and returns one result per operation. A failed
+	// operation does not prevent the following ones unless stopOnError is true,
+	// then the results end with the first failure. The error is only returned
+	// when the batch as a whole could not be run.
+	Batch(ctx context.Context, ops []BatchOperation, stopOnError bool) ([]BatchResult, error)
+
+	// ClearCategory removes the category of the items of categoryID, incrementing
+	// their version, and returns how many were changed
+	ClearCategory(ctx context.Context, categoryID string) (int64, error)
+
+	// SumPrices returns the exact sum of the line totals of the priced items
+	// matching filter, one domain.PriceTotal per currency, category and active value
+	// (see SumPrices for the line totals), in no particular order
+	SumPrices(ctx context.Context, filter ItemFilter) ([]domain.PriceTotal, error)
+}
+
+// ListRepository defines the interface for list persistence operations.
+// Removing the items of a deleted list is up to the caller.
+type ListRepository interface {
+	// Create inserts a new list in the repository
+	Create(ctx context.Context, list List) (List, error)
+
+	// Update renames an existing list
+	Update(ctx context.Context, list List) (List, error)
+
+	// Delete removes a list from the repository
+	Delete(ctx context.Context, id string) error
+
+	// GetByID retrieves a list by its ID
+	GetByID(ctx context.Context, id string) (List, error)
+
+	// Lock reports a missing list like GetByID. Within a transaction it also keeps
+	// the list from being deleted by another one until it ends, so that the items
+	// written in the meantime are never left in a deleted list.
+	Lock(ctx context.Context, id string) error
+
+	// List retrieves every list, in creation order
+	List(ctx context.Context) ([]List, error)
+}
+
+// CategoryRepository defines the interface for category persistence operations.
+// Clearing the category of the items of a deleted category is up to the caller.
+type CategoryRepository interface {
+	// Create inserts a new category in the repository
+	Create(ctx context.Context, category Category) (Category, error)
+
+	// Update renames an existing category and moves it to category.Position,
+	// unless it is zero
+	Update(ctx context.Context, category Category) (Category, error)
+
+	// Delete removes a category from the repository
+	Delete(ctx context.Context, id string) error
+
+	// GetByID retrieves a category by its ID
+	GetByID(ctx context.Context, id string) (Category, error)
+
+	// Lock reports a missing category like GetByID. Within a transaction it also
+	// keeps the category from being deleted by another one until it ends, so that
+	// the items put in it in the meantime never keep a deleted category.
+	Lock(ctx context.Context, id string) error
 
-	// BulkUpdateActive updates the active field for all items in the repository
-	BulkUpdateActive(ctx context.Context, active bool) (matchedCount int64, modifiedCount int64, err error)
+	// List retrieves every category, ordered by position, creation time and ID
+	List(ctx context.Context) ([]Category, error)
 }
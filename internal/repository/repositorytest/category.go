@@ -0,0 +1,170 @@
+package repositorytest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+// CategoryFactory returns a new, empty category repository.
+type CategoryFactory func(t *testing.T) repository.CategoryRepository
+
+// RunCategory executes the category conformance suite against the repositories built by factory.
+func RunCategory(t *testing.T, factory CategoryFactory) {
+	t.Helper()
+
+	t.Run("Given_NewCategory_When_Create_Then_ItCanBeRead", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		category := NewCategory("Dairy", 2)
+		created, err := repo.Create(ctx, category)
+		require.NoError(t, err)
+		require.Equal(t, category.ID, created.ID)
+		require.Equal(t, "Dairy", created.Name)
+		require.Equal(t, 2, created.Position)
+		require.False(t, created.CreatedAt.IsZero())
+		require.True(t, created.CreatedAt.Equal(created.UpdatedAt))
+
+		stored, err := repo.GetByID(ctx, category.ID)
+		require.NoError(t, err)
+		require.Equal(t, created.Name, stored.Name)
+		require.Equal(t, created.Position, stored.Position)
+		require.True(t, created.CreatedAt.Equal(stored.CreatedAt))
+
+		_, err = repo.Create(ctx, category)
+		RequireRepositoryError(t, err, http.StatusInternalServerError)
+	})
+
+	t.Run("Given_ExistingCategory_When_Update_Then_ItIsRenamedAndMoved", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		category := NewCategory("Dairy", 2)
+		created, err := repo.Create(ctx, category)
+		require.NoError(t, err)
+
+		updated, err := repo.Update(ctx, repository.Category{ID: category.ID, Name: "Dairy and eggs", Position: 5})
+		require.NoError(t, err)
+		require.Equal(t, "Dairy and eggs", updated.Name)
+		require.Equal(t, 5, updated.Position)
+		require.True(t, created.CreatedAt.Equal(updated.CreatedAt))
+		require.False(t, updated.UpdatedAt.Before(created.UpdatedAt))
+
+		stored, err := repo.GetByID(ctx, category.ID)
+		require.NoError(t, err)
+		require.Equal(t, "Dairy and eggs", stored.Name)
+		require.Equal(t, 5, stored.Position)
+	})
+
+	t.Run("Given_ZeroPosition_When_Update_Then_PositionIsKept", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		category := NewCategory("Dairy", 2)
+		_, err := repo.Create(ctx, category)
+		require.NoError(t, err)
+
+		updated, err := repo.Update(ctx, repository.Category{ID: category.ID, Name: "Milk"})
+		require.NoError(t, err)
+		require.Equal(t, "Milk", updated.Name)
+		require.Equal(t, 2, updated.Position)
+	})
+
+	t.Run("Given_ExistingCategory_When_Delete_Then_ItIsNotFound", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		category := NewCategory("Cleaning", 3)
+		_, err := repo.Create(ctx, category)
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Delete(ctx, category.ID))
+
+		_, err = repo.GetByID(ctx, category.ID)
+		RequireRepositoryError(t, err, http.StatusNotFound)
+	})
+
+	t.Run("Given_ExistingCategory_When_Lock_Then_ItIsKept", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		category := NewCategory("Dairy", 2)
+		created, err := repo.Create(ctx, category)
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Lock(ctx, category.ID))
+
+		stored, err := repo.GetByID(ctx, category.ID)
+		require.NoError(t, err)
+		require.Equal(t, created.Name, stored.Name)
+		require.Equal(t, created.Position, stored.Position)
+		require.True(t, created.UpdatedAt.Equal(stored.UpdatedAt))
+	})
+
+	t.Run("Given_MissingCategory_When_Changed_Then_ExpectedNotFoundError", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+		missing := NewCategory("Missing", 1)
+
+		_, err := repo.GetByID(ctx, missing.ID)
+		RequireRepositoryError(t, err, http.StatusNotFound)
+		err = repo.Lock(ctx, missing.ID)
+		RequireRepositoryError(t, err, http.StatusNotFound)
+		_, err = repo.Update(ctx, missing)
+		RequireRepositoryError(t, err, http.StatusNotFound)
+		err = repo.Delete(ctx, missing.ID)
+		RequireRepositoryError(t, err, http.StatusNotFound)
+	})
+
+	t.Run("Given_InvalidID_When_GetByID_Then_ExpectedInvalidIDError", func(t *testing.T) {
+		_, err := factory(t).GetByID(context.Background(), "invalid-id")
+		RequireRepositoryError(t, err, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("Given_SeveralCategories_When_List_Then_TheyAreInPositionOrder", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		// Equal positions keep the creation order; ObjectIDs increase within a millisecond
+		for _, category := range []repository.Category{
+			NewCategory("Cleaning", 3),
+			NewCategory("Produce", 1),
+			NewCategory("Dairy", 2),
+			NewCategory("Bakery", 2),
+		} {
+			_, err := repo.Create(ctx, category)
+			require.NoError(t, err)
+		}
+
+		categories, err := repo.List(ctx)
+		require.NoError(t, err)
+
+		names := make([]string, len(categories))
+		for i, category := range categories {
+			names[i] = category.Name
+		}
+		require.Equal(t, []string{"Produce", "Dairy", "Bakery", "Cleaning"}, names)
+	})
+
+	t.Run("Given_NoCategory_When_List_Then_ExpectedEmptySlice", func(t *testing.T) {
+		categories, err := factory(t).List(context.Background())
+		require.NoError(t, err)
+		require.NotNil(t, categories)
+		require.Empty(t, categories)
+	})
+}
+
+// NewCategory returns a repository category with a fresh, valid hexadecimal ID.
+func NewCategory(name string, position int) repository.Category {
+	return repository.Category{
+		ID:       primitive.NewObjectID().Hex(),
+		Name:     name,
+		Position: position,
+	}
+}
@@ -0,0 +1,140 @@
+package repositorytest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+// ListFactory returns a new list repository without lists other than the default one.
+type ListFactory func(t *testing.T) repository.ListRepository
+
+// RunList executes the list conformance suite against the repositories built by factory.
+func RunList(t *testing.T, factory ListFactory) {
+	t.Helper()
+
+	t.Run("Given_NewList_When_Create_Then_ItCanBeRead", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		list := NewList("Supermarket")
+		created, err := repo.Create(ctx, list)
+		require.NoError(t, err)
+		require.Equal(t, list.ID, created.ID)
+		require.Equal(t, "Supermarket", created.Name)
+		require.False(t, created.CreatedAt.IsZero())
+		require.True(t, created.CreatedAt.Equal(created.UpdatedAt))
+
+		stored, err := repo.GetByID(ctx, list.ID)
+		require.NoError(t, err)
+		require.Equal(t, created.Name, stored.Name)
+		require.True(t, created.CreatedAt.Equal(stored.CreatedAt))
+
+		_, err = repo.Create(ctx, list)
+		RequireRepositoryError(t, err, http.StatusInternalServerError)
+	})
+
+	t.Run("Given_ExistingList_When_Update_Then_ItIsRenamed", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		list := NewList("Supermarket")
+		created, err := repo.Create(ctx, list)
+		require.NoError(t, err)
+
+		updated, err := repo.Update(ctx, repository.List{ID: list.ID, Name: "Grocery"})
+		require.NoError(t, err)
+		require.Equal(t, "Grocery", updated.Name)
+		require.True(t, created.CreatedAt.Equal(updated.CreatedAt))
+		require.False(t, updated.UpdatedAt.Before(created.UpdatedAt))
+
+		stored, err := repo.GetByID(ctx, list.ID)
+		require.NoError(t, err)
+		require.Equal(t, "Grocery", stored.Name)
+	})
+
+	t.Run("Given_ExistingList_When_Delete_Then_ItIsNotFound", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		list := NewList("Pharmacy")
+		_, err := repo.Create(ctx, list)
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Delete(ctx, list.ID))
+
+		_, err = repo.GetByID(ctx, list.ID)
+		RequireRepositoryError(t, err, http.StatusNotFound)
+	})
+
+	t.Run("Given_ExistingList_When_Lock_Then_ItIsKept", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		list := NewList("Pharmacy")
+		created, err := repo.Create(ctx, list)
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Lock(ctx, list.ID))
+
+		stored, err := repo.GetByID(ctx, list.ID)
+		require.NoError(t, err)
+		require.Equal(t, created.Name, stored.Name)
+		require.True(t, created.UpdatedAt.Equal(stored.UpdatedAt))
+	})
+
+	t.Run("Given_MissingList_When_Changed_Then_ExpectedNotFoundError", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+		missing := NewList("Missing")
+
+		_, err := repo.GetByID(ctx, missing.ID)
+		RequireRepositoryError(t, err, http.StatusNotFound)
+		err = repo.Lock(ctx, missing.ID)
+		RequireRepositoryError(t, err, http.StatusNotFound)
+		_, err = repo.Update(ctx, missing)
+		RequireRepositoryError(t, err, http.StatusNotFound)
+		err = repo.Delete(ctx, missing.ID)
+		RequireRepositoryError(t, err, http.StatusNotFound)
+	})
+
+	t.Run("Given_InvalidID_When_GetByID_Then_ExpectedInvalidIDError", func(t *testing.T) {
+		_, err := factory(t).GetByID(context.Background(), "invalid-id")
+		RequireRepositoryError(t, err, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("Given_SeveralLists_When_List_Then_TheyAreInCreationOrder", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		// ObjectIDs increase, so the lists created in the same millisecond keep their order
+		for _, name := range []string{"Supermarket", "Pharmacy", "Hardware store"} {
+			_, err := repo.Create(ctx, NewList(name))
+			require.NoError(t, err)
+		}
+
+		lists, err := repo.List(ctx)
+		require.NoError(t, err)
+
+		names := []string{}
+		for _, list := range lists {
+			if list.ID != repository.DefaultListID {
+				names = append(names, list.Name)
+			}
+		}
+		require.Equal(t, []string{"Supermarket", "Pharmacy", "Hardware store"}, names)
+	})
+}
+
+// NewList returns a repository list with a fresh, valid hexadecimal ID.
+func NewList(name string) repository.List {
+	return repository.List{
+		ID:   primitive.NewObjectID().Hex(),
+		Name: name,
+	}
+}
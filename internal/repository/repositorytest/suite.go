@@ -0,0 +1,1967 @@
+// Package repositorytest provides a conformance suite that pins down the
+// behavior every repository.ItemRepository implementation must have.
+//
+// Backends run it from their own tests:
+//
+//	func TestConformance(t *testing.T) {
+//		repositorytest.Run(t, func(t *testing.T) repository.ItemRepository {
+//			return newEmptyRepository(t)
+//		})
+//	}
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+// Factory returns a new, empty repository. It is called once per test case;
+// any cleanup must be registered with t.Cleanup.
+type Factory func(t *testing.T) repository.ItemRepository
+
+// timestampTolerance absorbs the precision loss of the backends (MongoDB stores milliseconds).
+const timestampTolerance = time.Second
+
+// Run executes the whole conformance suite against the repositories built by factory.
+func Run(t *testing.T, factory Factory) {
+	t.Helper()
+
+	t.Run("Create", func(t *testing.T) { testCreate(t, factory) })
+	t.Run("GetByID", func(t *testing.T) { testGetByID(t, factory) })
+	t.Run("Update", func(t *testing.T) { testUpdate(t, factory) })
+	t.Run("Patch", func(t *testing.T) { testPatch(t, factory) })
+	t.Run("Delete", func(t *testing.T) { testDelete(t, factory) })
+	t.Run("List", func(t *testing.T) { testList(t, factory) })
+	t.Run("ListFilter", func(t *testing.T) { testListFilter(t, factory) })
+	t.Run("ListSort", func(t *testing.T) { testListSort(t, factory) })
+	t.Run("Search", func(t *testing.T) { testSearch(t, factory) })
+	t.Run("BulkUpdateActive", func(t *testing.T) { testBulkUpdateActive(t, factory) })
+	t.Run("DeleteMany", func(t *testing.T) { testDeleteMany(t, factory) })
+	t.Run("Batch", func(t *testing.T) { testBatch(t, factory) })
+	t.Run("ListScope", func(t *testing.T) { testListScope(t, factory) })
+	t.Run("Quantity", func(t *testing.T) { testQuantity(t, factory) })
+	t.Run("Category", func(t *testing.T) { testCategory(t, factory) })
+	t.Run("Price", func(t *testing.T) { testPrice(t, factory) })
+	t.Run("Position", func(t *testing.T) { testPosition(t, factory) })
+	t.Run("Timestamps", func(t *testing.T) { testTimestamps(t, factory) })
+	t.Run("Version", func(t *testing.T) { testVersion(t, factory) })
+	t.Run("Concurrency", func(t *testing.T) { testConcurrency(t, factory) })
+	t.Run("CanceledContext", func(t *testing.T) { testCanceledContext(t, factory) })
+}
+
+func testCreate(t *testing.T, factory Factory) {
+	tests := []struct {
+		name      string
+		givenItem repository.Item
+		givenSeed bool
+		wantHTTP  int
+	}{
+		{
+			name:      "Given_ValidItem_When_Create_Then_ItemIsStored",
+			givenItem: NewItem("Rice", true, ptr("5kg")),
+		},
+		{
+			name:      "Given_ItemWithoutObservation_When_Create_Then_ItemIsStoredWithoutObservation",
+			givenItem: NewItem("Beans", false, nil),
+		},
+		{
+			name:      "Given_InvalidHexID_When_Create_Then_ReturnsInvalidHexIDError",
+			givenItem: repository.Item{ID: "invalid-hex-id", Name: "Rice", Active: true},
+			wantHTTP:  http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "Given_ExistingID_When_Create_Then_ReturnsGenericError",
+			givenItem: NewItem("Rice", true, nil),
+			givenSeed: true,
+			wantHTTP:  http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := factory(t)
+
+			if tt.givenSeed {
+				_, err := repo.Create(ctx, tt.givenItem)
+				require.NoError(t, err)
+			}
+
+			createdItem, err := repo.Create(ctx, tt.givenItem)
+
+			if tt.wantHTTP != 0 {
+				RequireRepositoryError(t, err, tt.wantHTTP)
+				return
+			}
+			require.NoError(t, err)
+			requireSameContent(t, tt.givenItem, createdItem)
+
+			storedItem, err := repo.GetByID(ctx, tt.givenItem.ID)
+			require.NoError(t, err)
+			requireSameContent(t, tt.givenItem, storedItem)
+			// Create returns the timestamps it actually wrote
+			requireSameTimestamps(t, storedItem, createdItem)
+		})
+	}
+}
+
+func testGetByID(t *testing.T, factory Factory) {
+	existing := NewItem("Coffee", true, ptr("ground"))
+
+	tests := []struct {
+		name     string
+		givenID  string
+		wantItem *repository.Item
+		wantHTTP int
+	}{
+		{
+			name:     "Given_ExistingID_When_GetByID_Then_ReturnsItem",
+			givenID:  existing.ID,
+			wantItem: &existing,
+		},
+		{
+			name:     "Given_UnknownID_When_GetByID_Then_ReturnsNotFoundError",
+			givenID:  primitive.NewObjectID().Hex(),
+			wantHTTP: http.StatusNotFound,
+		},
+		{
+			name:     "Given_InvalidHexID_When_GetByID_Then_ReturnsInvalidHexIDError",
+			givenID:  "invalid-hex-id",
+			wantHTTP: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := factory(t)
+
+			_, err := repo.Create(ctx, existing)
+			require.NoError(t, err)
+
+			item, err := repo.GetByID(ctx, tt.givenID)
+
+			if tt.wantHTTP != 0 {
+				RequireRepositoryError(t, err, tt.wantHTTP)
+				require.Equal(t, repository.Item{}, item)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.givenID, item.ID)
+			requireSameContent(t, *tt.wantItem, item)
+		})
+	}
+}
+
+func testUpdate(t *testing.T, factory Factory) {
+	existing := NewItem("Milk", true, ptr("skimmed"))
+
+	tests := []struct {
+		name            string
+		givenItem       repository.Item
+		wantStored      repository.Item
+		wantHTTP        int
+		wantObservation *string
+	}{
+		{
+			name:       "Given_ExistingItem_When_Update_Then_FieldsAreReplaced",
+			givenItem:  repository.Item{ID: existing.ID, Name: "Whole milk", Active: false, Observation: ptr("2L")},
+			wantStored: repository.Item{ID: existing.ID, Name: "Whole milk", Active: false, Observation: ptr("2L")},
+		},
+		{
+			name:       "Given_NilObservation_When_Update_Then_StoredObservationIsKept",
+			givenItem:  repository.Item{ID: existing.ID, Name: "Whole milk", Active: false},
+			wantStored: repository.Item{ID: existing.ID, Name: "Whole milk", Active: false, Observation: ptr("skimmed")},
+		},
+		{
+			name:      "Given_UnknownID_When_Update_Then_ReturnsNotFoundError",
+			givenItem: NewItem("Milk", true, nil),
+			wantHTTP:  http.StatusNotFound,
+		},
+		{
+			name:      "Given_InvalidHexID_When_Update_Then_ReturnsInvalidHexIDError",
+			givenItem: repository.Item{ID: "invalid-hex-id", Name: "Milk"},
+			wantHTTP:  http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := factory(t)
+
+			_, err := repo.Create(ctx, existing)
+			require.NoError(t, err)
+
+			updatedItem, err := repo.Update(ctx, tt.givenItem)
+
+			if tt.wantHTTP != 0 {
+				RequireRepositoryError(t, err, tt.wantHTTP)
+
+				storedItem, err := repo.GetByID(ctx, existing.ID)
+				require.NoError(t, err)
+				requireSameContent(t, existing, storedItem)
+				return
+			}
+			require.NoError(t, err)
+
+			storedItem, err := repo.GetByID(ctx, existing.ID)
+			require.NoError(t, err)
+			requireSameContent(t, tt.wantStored, storedItem)
+			// Update returns the stored document, not the caller's input
+			requireSameContent(t, tt.wantStored, updatedItem)
+			requireSameTimestamps(t, storedItem, updatedItem)
+		})
+	}
+}
+
+func testPatch(t *testing.T, factory Factory) {
+	existing := NewItem("Milk", true, ptr("skimmed"))
+
+	tests := []struct {
+		name       string
+		givenID    string
+		givenPatch repository.ItemPatch
+		wantStored repository.Item
+		wantHTTP   int
+	}{
+		{
+			name:       "Given_Name_When_Patch_Then_OnlyNameChanges",
+			givenID:    existing.ID,
+			givenPatch: repository.ItemPatch{Name: ptr("Whole milk")},
+			wantStored: repository.Item{ID: existing.ID, Name: "Whole milk", Active: true, Observation: ptr("skimmed")},
+		},
+		{
+			name:       "Given_ActiveAndObservation_When_Patch_Then_BothChange",
+			givenID:    existing.ID,
+			givenPatch: repository.ItemPatch{Active: ptrTo(false), Observation: ptr("2L"), SetObservation: true},
+			wantStored: repository.Item{ID: existing.ID, Name: "Milk", Active: false, Observation: ptr("2L")},
+		},
+		{
+			name:       "Given_ObservationRemoval_When_Patch_Then_ObservationIsCleared",
+			givenID:    existing.ID,
+			givenPatch: repository.ItemPatch{SetObservation: true},
+			wantStored: repository.Item{ID: existing.ID, Name: "Milk", Active: true},
+		},
+		{
+			name:       "Given_EmptyPatch_When_Patch_Then_OnlyUpdatedAtChanges",
+			givenID:    existing.ID,
+			wantStored: existing,
+		},
+		{
+			name:       "Given_UnknownID_When_Patch_Then_ReturnsNotFoundError",
+			givenID:    NewItem("Milk", true, nil).ID,
+			givenPatch: repository.ItemPatch{Name: ptr("Bread")},
+			wantHTTP:   http.StatusNotFound,
+		},
+		{
+			name:       "Given_InvalidHexID_When_Patch_Then_ReturnsInvalidHexIDError",
+			givenID:    "invalid-hex-id",
+			givenPatch: repository.ItemPatch{Name: ptr("Bread")},
+			wantHTTP:   http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := factory(t)
+
+			created, err := repo.Create(ctx, existing)
+			require.NoError(t, err)
+			time.Sleep(2 * time.Millisecond)
+
+			patchedItem, err := repo.Patch(ctx, tt.givenID, tt.givenPatch)
+
+			if tt.wantHTTP != 0 {
+				RequireRepositoryError(t, err, tt.wantHTTP)
+
+				storedItem, err := repo.GetByID(ctx, existing.ID)
+				require.NoError(t, err)
+				requireSameContent(t, existing, storedItem)
+				return
+			}
+			require.NoError(t, err)
+
+			storedItem, err := repo.GetByID(ctx, existing.ID)
+			require.NoError(t, err)
+			requireSameContent(t, tt.wantStored, storedItem)
+			requireSameContent(t, tt.wantStored, patchedItem)
+			requireSameTimestamps(t, storedItem, patchedItem)
+			require.True(t, created.CreatedAt.Equal(storedItem.CreatedAt))
+			require.True(t, storedItem.UpdatedAt.After(created.UpdatedAt))
+		})
+	}
+
+	t.Run("Given_ConcurrentPatchesOfDifferentFields_When_Patch_Then_NoChangeIsLost", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		_, err := repo.Create(ctx, existing)
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 2)
+		for _, patch := range []repository.ItemPatch{
+			{Name: ptr("Oat milk")},
+			{Active: ptrTo(false)},
+		} {
+			wg.Add(1)
+			go func(patch repository.ItemPatch) {
+				defer wg.Done()
+				_, err := repo.Patch(ctx, existing.ID, patch)
+				errs <- err
+			}(patch)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		storedItem, err := repo.GetByID(ctx, existing.ID)
+		require.NoError(t, err)
+		requireSameContent(t, repository.Item{ID: existing.ID, Name: "Oat milk", Active: false, Observation: ptr("skimmed")}, storedItem)
+	})
+}
+
+func testDelete(t *testing.T, factory Factory) {
+	existing := NewItem("Bread", true, nil)
+
+	tests := []struct {
+		name     string
+		givenID  string
+		wantHTTP int
+	}{
+		{
+			name:    "Given_ExistingID_When_Delete_Then_ItemIsRemoved",
+			givenID: existing.ID,
+		},
+		{
+			name:     "Given_UnknownID_When_Delete_Then_ReturnsNotFoundError",
+			givenID:  primitive.NewObjectID().Hex(),
+			wantHTTP: http.StatusNotFound,
+		},
+		{
+			name:     "Given_InvalidHexID_When_Delete_Then_ReturnsInvalidHexIDError",
+			givenID:  "invalid-hex-id",
+			wantHTTP: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := factory(t)
+
+			_, err := repo.Create(ctx, existing)
+			require.NoError(t, err)
+
+			err = repo.Delete(ctx, tt.givenID, 0)
+
+			if tt.wantHTTP != 0 {
+				RequireRepositoryError(t, err, tt.wantHTTP)
+
+				require.Len(t, listAll(t, repo), 1)
+				return
+			}
+			require.NoError(t, err)
+
+			_, err = repo.GetByID(ctx, tt.givenID)
+			RequireRepositoryError(t, err, http.StatusNotFound)
+
+			err = repo.Delete(ctx, tt.givenID, 0)
+			RequireRepositoryError(t, err, http.StatusNotFound)
+		})
+	}
+}
+
+func testList(t *testing.T, factory Factory) {
+	tests := []struct {
+		name       string
+		givenItems []repository.Item
+	}{
+		{
+			name: "Given_Items_When_List_Then_ReturnsAllItems",
+			givenItems: []repository.Item{
+				NewItem("Apple", true, nil),
+				NewItem("Banana", false, ptr("ripe")),
+				NewItem("Cherry", true, nil),
+			},
+		},
+		{
+			name:       "Given_NoItems_When_List_Then_ReturnsEmptyList",
+			givenItems: []repository.Item{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := factory(t)
+
+			for _, item := range tt.givenItems {
+				_, err := repo.Create(ctx, item)
+				require.NoError(t, err)
+			}
+
+			page, err := repo.List(ctx, repository.ListOptions{})
+			require.NoError(t, err)
+			require.NotNil(t, page.Items)
+			require.Len(t, page.Items, len(tt.givenItems))
+			require.Empty(t, page.NextCursor)
+
+			byID := make(map[string]repository.Item, len(page.Items))
+			for _, item := range page.Items {
+				byID[item.ID] = item
+			}
+			for _, want := range tt.givenItems {
+				got, ok := byID[want.ID]
+				require.True(t, ok, "item %s missing from list", want.ID)
+				requireSameContent(t, want, got)
+			}
+		})
+	}
+
+	t.Run("Given_Limit_When_ListPages_Then_ItemsAreReturnedOnceInCreationOrder", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		var created []repository.Item
+		for _, name := range []string{"Apple", "Banana", "Cherry", "Grape", "Lemon"} {
+			item, err := repo.Create(ctx, NewItem(name, true, nil))
+			require.NoError(t, err)
+			created = append(created, item)
+		}
+
+		first, err := repo.List(ctx, repository.ListOptions{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, first.Items, 2)
+		require.NotEmpty(t, first.NextCursor)
+
+		// Items inserted between pages come after the existing ones and do not shift the pages
+		inserted, err := repo.Create(ctx, NewItem("Mango", true, nil))
+		require.NoError(t, err)
+
+		var got []repository.Item
+		got = append(got, first.Items...)
+		cursor := first.NextCursor
+		for cursor != "" {
+			page, err := repo.List(ctx, repository.ListOptions{Limit: 2, Cursor: cursor})
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(page.Items), 2)
+			got = append(got, page.Items...)
+			cursor = page.NextCursor
+		}
+
+		want := sortedByCreation(append(created, inserted))
+		require.Len(t, got, len(want))
+		for i := range want {
+			require.Equal(t, want[i].ID, got[i].ID)
+		}
+	})
+
+	t.Run("Given_LimitEqualToItemCount_When_List_Then_NoNextCursor", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		for _, name := range []string{"Apple", "Banana"} {
+			_, err := repo.Create(ctx, NewItem(name, true, nil))
+			require.NoError(t, err)
+		}
+
+		page, err := repo.List(ctx, repository.ListOptions{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, page.Items, 2)
+		require.Empty(t, page.NextCursor)
+	})
+
+	t.Run("Given_InvalidCursor_When_List_Then_ExpectedBadRequestError", func(t *testing.T) {
+		repo := factory(t)
+
+		_, err := repo.List(context.Background(), repository.ListOptions{Limit: 2, Cursor: "not-a-cursor"})
+		RequireRepositoryError(t, err, http.StatusBadRequest)
+	})
+}
+
+func testListFilter(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	repo := factory(t)
+
+	// Items are created in distinct milliseconds so the time filters are deterministic
+	var created []repository.Item
+	for _, item := range []repository.Item{
+		NewItem("Apple", true, ptr("green")),
+		NewItem("Banana", false, nil),
+		NewItem("Pineapple", true, ptr("")),
+		NewItem("Cherry", false, ptr("sour")),
+	} {
+		stored, err := repo.Create(ctx, item)
+		require.NoError(t, err)
+		created = append(created, stored)
+		time.Sleep(2 * time.Millisecond)
+	}
+	apple, banana, pineapple, cherry := created[0], created[1], created[2], created[3]
+
+	tests := []struct {
+		name        string
+		givenFilter repository.ItemFilter
+		wantItems   []repository.Item
+	}{
+		{
+			name:        "Given_ActiveFilter_When_List_Then_ReturnsActiveItems",
+			givenFilter: repository.ItemFilter{Active: ptrTo(true)},
+			wantItems:   []repository.Item{apple, pineapple},
+		},
+		{
+			name:        "Given_InactiveFilter_When_List_Then_ReturnsInactiveItems",
+			givenFilter: repository.ItemFilter{Active: ptrTo(false)},
+			wantItems:   []repository.Item{banana, cherry},
+		},
+		{
+			name:        "Given_NameFilter_When_List_Then_ReturnsItemsContainingNameIgnoringCase",
+			givenFilter: repository.ItemFilter{Name: "APPLE"},
+			wantItems:   []repository.Item{apple, pineapple},
+		},
+		{
+			name:        "Given_NameFilterWithWildcards_When_List_Then_WildcardsAreMatchedLiterally",
+			givenFilter: repository.ItemFilter{Name: "a%"},
+			wantItems:   []repository.Item{},
+		},
+		{
+			name:        "Given_HasObservationFilter_When_List_Then_ReturnsItemsWithNonEmptyObservation",
+			givenFilter: repository.ItemFilter{HasObservation: ptrTo(true)},
+			wantItems:   []repository.Item{apple, cherry},
+		},
+		{
+			name:        "Given_HasNoObservationFilter_When_List_Then_ReturnsItemsWithoutObservation",
+			givenFilter: repository.ItemFilter{HasObservation: ptrTo(false)},
+			wantItems:   []repository.Item{banana, pineapple},
+		},
+		{
+			name:        "Given_CreatedAfterFilter_When_List_Then_ReturnsItemsCreatedLater",
+			givenFilter: repository.ItemFilter{CreatedAfter: &banana.CreatedAt},
+			wantItems:   []repository.Item{pineapple, cherry},
+		},
+		{
+			name:        "Given_UpdatedBeforeFilter_When_List_Then_ReturnsItemsUpdatedEarlier",
+			givenFilter: repository.ItemFilter{UpdatedBefore: &pineapple.UpdatedAt},
+			wantItems:   []repository.Item{apple, banana},
+		},
+		{
+			name: "Given_CombinedFilters_When_List_Then_ReturnsItemsMatchingAll",
+			givenFilter: repository.ItemFilter{
+				Active:         ptrTo(false),
+				HasObservation: ptrTo(true),
+				CreatedAfter:   &apple.CreatedAt,
+			},
+			wantItems: []repository.Item{cherry},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := repo.List(ctx, repository.ListOptions{Filter: tt.givenFilter})
+			require.NoError(t, err)
+			requireSameIDs(t, tt.wantItems, page.Items)
+		})
+	}
+
+	t.Run("Given_FilterAndLimit_When_ListPages_Then_PagesOnlyContainMatchingItems", func(t *testing.T) {
+		opts := repository.ListOptions{Limit: 1, Filter: repository.ItemFilter{Active: ptrTo(true)}}
+
+		first, err := repo.List(ctx, opts)
+		require.NoError(t, err)
+		require.NotEmpty(t, first.NextCursor)
+
+		opts.Cursor = first.NextCursor
+		second, err := repo.List(ctx, opts)
+		require.NoError(t, err)
+		require.Empty(t, second.NextCursor)
+
+		requireSameIDs(t, []repository.Item{apple, pineapple}, append(first.Items, second.Items...))
+	})
+}
+
+func testListSort(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	repo := factory(t)
+
+	// Items are created in distinct milliseconds; "Maçã" and "maca" are equal
+	// for the name collation, so their order comes from the ID tiebreaker
+	var created []repository.Item
+	for _, item := range []repository.Item{
+		NewItem("Pão", true, nil),
+		NewItem("pera", false, nil),
+		NewItem("açúcar", true, nil),
+		NewItem("Maçã", false, nil),
+		NewItem("Abacate", true, nil),
+		NewItem("maca", true, nil),
+		NewItem("Açaí", false, nil),
+	} {
+		stored, err := repo.Create(ctx, item)
+		require.NoError(t, err)
+		created = append(created, stored)
+		time.Sleep(2 * time.Millisecond)
+	}
+	pao, pera, acucar, macaAccented, abacate, maca, acai := created[0], created[1], created[2], created[3], created[4], created[5], created[6]
+
+	// The bread is the most recently updated item, then the avocado
+	updated, err := repo.Update(ctx, repository.Item{ID: abacate.ID, Name: abacate.Name, Active: abacate.Active})
+	require.NoError(t, err)
+	abacate = updated
+	time.Sleep(2 * time.Millisecond)
+	updated, err = repo.Update(ctx, repository.Item{ID: pao.ID, Name: pao.Name, Active: pao.Active})
+	require.NoError(t, err)
+	pao = updated
+
+	byName := []repository.SortField{{Field: repository.SortFieldName}}
+
+	tests := []struct {
+		name      string
+		givenSort []repository.SortField
+		wantItems []repository.Item
+	}{
+		{
+			name:      "Given_NoSort_When_List_Then_ItemsAreInCreationOrder",
+			wantItems: []repository.Item{pao, pera, acucar, macaAccented, abacate, maca, acai},
+		},
+		{
+			name:      "Given_NameSort_When_List_Then_NamesAreOrderedIgnoringCaseAndAccents",
+			givenSort: byName,
+			wantItems: []repository.Item{abacate, acai, acucar, macaAccented, maca, pao, pera},
+		},
+		{
+			name:      "Given_DescendingNameSort_When_List_Then_EqualNamesKeepAscendingIDs",
+			givenSort: []repository.SortField{{Field: repository.SortFieldName, Desc: true}},
+			wantItems: []repository.Item{pera, pao, macaAccented, maca, acucar, acai, abacate},
+		},
+		{
+			name: "Given_UpdatedAtDescendingThenName_When_List_Then_RecentlyUpdatedItemsComeFirst",
+			givenSort: []repository.SortField{
+				{Field: repository.SortFieldUpdatedAt, Desc: true},
+				{Field: repository.SortFieldName},
+			},
+			wantItems: []repository.Item{pao, abacate, acai, maca, macaAccented, acucar, pera},
+		},
+		{
+			name: "Given_ActiveThenName_When_List_Then_InactiveItemsComeFirst",
+			givenSort: []repository.SortField{
+				{Field: repository.SortFieldActive},
+				{Field: repository.SortFieldName},
+			},
+			wantItems: []repository.Item{acai, macaAccented, pera, abacate, acucar, maca, pao},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := repo.List(ctx, repository.ListOptions{Sort: tt.givenSort})
+			require.NoError(t, err)
+			requireSameIDs(t, tt.wantItems, page.Items)
+		})
+	}
+
+	t.Run("Given_SortFilterAndLimit_When_ListPages_Then_PagesFollowTheSort", func(t *testing.T) {
+		opts := repository.ListOptions{Limit: 2, Sort: byName, Filter: repository.ItemFilter{Active: ptrTo(true)}}
+
+		var got []repository.Item
+		for {
+			page, err := repo.List(ctx, opts)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(page.Items), opts.Limit)
+			got = append(got, page.Items...)
+			if page.NextCursor == "" {
+				break
+			}
+			opts.Cursor = page.NextCursor
+		}
+
+		requireSameIDs(t, []repository.Item{abacate, acucar, maca, pao}, got)
+	})
+
+	t.Run("Given_CursorOfAnotherSort_When_List_Then_ExpectedBadRequestError", func(t *testing.T) {
+		page, err := repo.List(ctx, repository.ListOptions{Limit: 1, Sort: byName})
+		require.NoError(t, err)
+
+		_, err = repo.List(ctx, repository.ListOptions{Limit: 1, Cursor: page.NextCursor})
+		RequireRepositoryError(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("Given_UnknownOrDuplicatedSortField_When_List_Then_ExpectedBadRequestError", func(t *testing.T) {
+		_, err := repo.List(ctx, repository.ListOptions{Sort: []repository.SortField{{Field: "observation"}}})
+		RequireRepositoryError(t, err, http.StatusBadRequest)
+
+		_, err = repo.List(ctx, repository.ListOptions{Sort: append(byName, repository.SortField{Field: repository.SortFieldName, Desc: true})})
+		RequireRepositoryError(t, err, http.StatusBadRequest)
+	})
+}
+
+func testSearch(t *testing.T, factory Factory) {
+	const otherList = "000000000000000000000010"
+	ctx := context.Background()
+	repo := factory(t)
+
+	sopa := NewItem("Sopa", true, ptr("de arroz"))
+	sopa.ListID = otherList
+	var created []repository.Item
+	for _, item := range []repository.Item{
+		NewItem("Feijão preto", true, ptr("1 kg")),
+		NewItem("Arroz", true, ptr("para o feijão")),
+		NewItem("Açúcar mascavo", false, nil),
+		NewItem("Café", true, ptr("")),
+		sopa,
+	} {
+		stored, err := repo.Create(ctx, item)
+		require.NoError(t, err)
+		created = append(created, stored)
+	}
+	feijao, arroz, acucar, sopa := created[0], created[1], created[2], created[4]
+
+	tests := []struct {
+		name        string
+		givenQuery  string
+		givenListID string
+		givenLimit  int
+		wantItems   []repository.Item
+	}{
+		{
+			name:       "Given_QueryWithoutAccents_When_Search_Then_AccentedNameIsFoundFirst",
+			givenQuery: "feijao",
+			wantItems:  []repository.Item{feijao, arroz},
+		},
+		{
+			name:       "Given_UppercaseQuery_When_Search_Then_CaseIsIgnored",
+			givenQuery: "ACUCAR",
+			wantItems:  []repository.Item{acucar},
+		},
+		{
+			name:       "Given_Limit_When_Search_Then_OnlyTheMostRelevantItemsAreReturned",
+			givenQuery: "feijão",
+			givenLimit: 1,
+			wantItems:  []repository.Item{feijao},
+		},
+		{
+			name:       "Given_QueryWithoutMatches_When_Search_Then_ExpectedEmptyResult",
+			givenQuery: "leite",
+			wantItems:  []repository.Item{},
+		},
+		{
+			name:       "Given_QueryWithoutWords_When_Search_Then_ExpectedEmptyResult",
+			givenQuery: " ?! ",
+			wantItems:  []repository.Item{},
+		},
+		{
+			name:       "Given_NoList_When_Search_Then_EveryListIsSearched",
+			givenQuery: "arroz",
+			wantItems:  []repository.Item{arroz, sopa},
+		},
+		{
+			name:        "Given_List_When_Search_Then_OnlyItsItemsAreFound",
+			givenQuery:  "arroz",
+			givenListID: otherList,
+			wantItems:   []repository.Item{sopa},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := repo.Search(ctx, tt.givenQuery, tt.givenListID, tt.givenLimit)
+			require.NoError(t, err)
+			requireSameIDs(t, tt.wantItems, found)
+			for _, item := range found {
+				stored, err := repo.GetByID(ctx, item.ID)
+				require.NoError(t, err)
+				requireSameContent(t, stored, item)
+			}
+		})
+	}
+}
+
+// requireSameIDs asserts that got holds the items of want, in the same order.
+func requireSameIDs(t *testing.T, want, got []repository.Item) {
+	t.Helper()
+	wantIDs := make([]string, len(want))
+	for i, item := range want {
+		wantIDs[i] = item.ID
+	}
+	gotIDs := make([]string, len(got))
+	for i, item := range got {
+		gotIDs[i] = item.ID
+	}
+	require.Equal(t, wantIDs, gotIDs)
+}
+
+// listAll returns every item of the repository.
+func listAll(t *testing.T, repo repository.ItemRepository) []repository.Item {
+	t.Helper()
+	page, err := repo.List(context.Background(), repository.ListOptions{})
+	require.NoError(t, err)
+	return page.Items
+}
+
+func sortedByCreation(items []repository.Item) []repository.Item {
+	sorted := append([]repository.Item(nil), items...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].CreatedAt.Equal(sorted[j].CreatedAt) {
+			return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted
+}
+
+func testBulkUpdateActive(t *testing.T, factory Factory) {
+	missingID := primitive.NewObjectID().Hex()
+	fruits := func() []repository.Item {
+		return []repository.Item{
+			NewItem("Apple", true, nil),
+			NewItem("Banana", false, nil),
+			NewItem("Cherry", false, nil),
+		}
+	}
+
+	tests := []struct {
+		name              string
+		givenItems        []repository.Item
+		givenUpdate       func(items []repository.Item) repository.ActiveUpdate
+		wantMatchedCount  int64
+		wantModifiedCount int64
+		wantNotFoundIDs   []string
+		wantActive        []bool
+		wantHTTP          int
+	}{
+		{
+			name:       "Given_MixedItems_When_BulkUpdateActiveFalse_Then_AllItemsAreInactive",
+			givenItems: fruits()[:2],
+			givenUpdate: func([]repository.Item) repository.ActiveUpdate {
+				return repository.ActiveUpdate{Active: false}
+			},
+			wantMatchedCount:  2,
+			wantModifiedCount: 2,
+			wantActive:        []bool{false, false},
+		},
+		{
+			name:       "Given_MixedItems_When_BulkUpdateActiveTrue_Then_AllItemsAreActive",
+			givenItems: fruits(),
+			givenUpdate: func([]repository.Item) repository.ActiveUpdate {
+				return repository.ActiveUpdate{Active: true}
+			},
+			wantMatchedCount:  3,
+			wantModifiedCount: 3,
+			wantActive:        []bool{true, true, true},
+		},
+		{
+			name: "Given_NoItems_When_BulkUpdateActive_Then_ReturnsZeroCounts",
+			givenUpdate: func([]repository.Item) repository.ActiveUpdate {
+				return repository.ActiveUpdate{Active: true}
+			},
+			wantMatchedCount:  0,
+			wantModifiedCount: 0,
+		},
+		{
+			name:       "Given_IDs_When_BulkUpdateActive_Then_OnlyTheseItemsAreUpdated",
+			givenItems: fruits(),
+			givenUpdate: func(items []repository.Item) repository.ActiveUpdate {
+				return repository.ActiveUpdate{Active: true, IDs: []string{items[1].ID}}
+			},
+			wantMatchedCount:  1,
+			wantModifiedCount: 1,
+			wantActive:        []bool{true, true, false},
+		},
+		{
+			name:       "Given_MissingID_When_BulkUpdateActive_Then_ItIsReportedAsNotFound",
+			givenItems: fruits(),
+			givenUpdate: func(items []repository.Item) repository.ActiveUpdate {
+				return repository.ActiveUpdate{Active: false, IDs: []string{items[0].ID, missingID}}
+			},
+			wantMatchedCount:  1,
+			wantModifiedCount: 1,
+			wantNotFoundIDs:   []string{missingID},
+			wantActive:        []bool{false, false, false},
+		},
+		{
+			name:       "Given_Filter_When_BulkUpdateActive_Then_OnlyMatchingItemsAreUpdated",
+			givenItems: fruits(),
+			givenUpdate: func([]repository.Item) repository.ActiveUpdate {
+				return repository.ActiveUpdate{Active: true, Filter: repository.ItemFilter{Name: "an"}}
+			},
+			wantMatchedCount:  1,
+			wantModifiedCount: 1,
+			wantActive:        []bool{true, true, false},
+		},
+		{
+			name:       "Given_InvalidID_When_BulkUpdateActive_Then_ReturnsInvalidHexIDErrorAndNothingIsUpdated",
+			givenItems: fruits(),
+			givenUpdate: func(items []repository.Item) repository.ActiveUpdate {
+				return repository.ActiveUpdate{Active: true, IDs: []string{items[1].ID, "invalid-hex-id"}}
+			},
+			wantHTTP:   http.StatusUnprocessableEntity,
+			wantActive: []bool{true, false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := factory(t)
+
+			for _, item := range tt.givenItems {
+				_, err := repo.Create(ctx, item)
+				require.NoError(t, err)
+			}
+
+			result, err := repo.BulkUpdateActive(ctx, tt.givenUpdate(tt.givenItems))
+			if tt.wantHTTP != 0 {
+				RequireRepositoryError(t, err, tt.wantHTTP)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantMatchedCount, result.MatchedCount)
+				require.Equal(t, tt.wantModifiedCount, result.ModifiedCount)
+				require.Equal(t, tt.wantNotFoundIDs, result.NotFoundIDs)
+			}
+
+			for i, item := range tt.givenItems {
+				storedItem, err := repo.GetByID(ctx, item.ID)
+				require.NoError(t, err)
+				require.Equal(t, tt.wantActive[i], storedItem.Active, item.Name)
+			}
+		})
+	}
+}
+
+func testDeleteMany(t *testing.T, factory Factory) {
+	tests := []struct {
+		name             string
+		givenFilter      repository.ItemFilter
+		wantDeletedCount int64
+		wantRemaining    []string
+	}{
+		{
+			name:             "Given_CheckedItems_When_DeleteManyInactive_Then_OnlyTheyAreDeleted",
+			givenFilter:      repository.ItemFilter{Active: ptrTo(false)},
+			wantDeletedCount: 2,
+			wantRemaining:    []string{"Bread"},
+		},
+		{
+			name:             "Given_SeveralConditions_When_DeleteMany_Then_ItemsMatchingAllOfThemAreDeleted",
+			givenFilter:      repository.ItemFilter{Active: ptrTo(false), Name: "MILK"},
+			wantDeletedCount: 1,
+			wantRemaining:    []string{"Bread", "Eggs"},
+		},
+		{
+			name:             "Given_NoMatchingItem_When_DeleteMany_Then_NothingIsDeleted",
+			givenFilter:      repository.ItemFilter{Name: "butter"},
+			wantDeletedCount: 0,
+			wantRemaining:    []string{"Milk", "Bread", "Eggs"},
+		},
+		{
+			name:             "Given_EmptyFilter_When_DeleteMany_Then_EveryItemIsDeleted",
+			wantDeletedCount: 3,
+			wantRemaining:    []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := factory(t)
+
+			for _, item := range []repository.Item{
+				NewItem("Milk", false, nil),
+				NewItem("Bread", true, nil),
+				NewItem("Eggs", false, nil),
+			} {
+				_, err := repo.Create(ctx, item)
+				require.NoError(t, err)
+			}
+
+			deletedCount, err := repo.DeleteMany(ctx, tt.givenFilter)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantDeletedCount, deletedCount)
+
+			remaining := []string{}
+			for _, item := range listAll(t, repo) {
+				remaining = append(remaining, item.Name)
+			}
+			require.ElementsMatch(t, tt.wantRemaining, remaining)
+		})
+	}
+}
+
+func testBatch(t *testing.T, factory Factory) {
+	t.Run("Given_MixedOperations_When_Batch_Then_EachOneIsAppliedInOrder", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		updated := NewItem("Milk", true, ptr("whole"))
+		_, err := repo.Create(ctx, updated)
+		require.NoError(t, err)
+		deleted := NewItem("Eggs", true, nil)
+		_, err = repo.Create(ctx, deleted)
+		require.NoError(t, err)
+
+		created := NewItem("Bread", true, nil)
+		results, err := repo.Batch(ctx, []repository.BatchOperation{
+			{Type: repository.BatchCreate, Item: created},
+			{Type: repository.BatchUpdate, Item: repository.Item{ID: updated.ID, Name: "Skimmed milk", Active: false}},
+			{Type: repository.BatchDelete, Item: repository.Item{ID: deleted.ID}},
+			{Type: repository.BatchUpdate, Item: repository.Item{ID: created.ID, Name: "Rye bread", Active: true}},
+		}, false)
+		require.NoError(t, err)
+		require.Len(t, results, 4)
+		for _, result := range results {
+			require.NoError(t, result.Err)
+		}
+
+		requireSameContent(t, created, results[0].Item)
+		require.Equal(t, int64(1), results[0].Item.Version)
+		require.Equal(t, "Skimmed milk", results[1].Item.Name)
+		require.Equal(t, ptr("whole"), results[1].Item.Observation)
+		require.Equal(t, int64(2), results[1].Item.Version)
+		require.Equal(t, "Rye bread", results[3].Item.Name)
+		require.Equal(t, int64(2), results[3].Item.Version)
+
+		storedItem, err := repo.GetByID(ctx, updated.ID)
+		require.NoError(t, err)
+		requireSameContent(t, results[1].Item, storedItem)
+		storedItem, err = repo.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		requireSameContent(t, results[3].Item, storedItem)
+		_, err = repo.GetByID(ctx, deleted.ID)
+		RequireRepositoryError(t, err, http.StatusNotFound)
+	})
+
+	t.Run("Given_FailedOperation_When_Batch_Then_TheFollowingOnesAreStillApplied", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		created := NewItem("Bread", true, nil)
+		results, err := repo.Batch(ctx, []repository.BatchOperation{
+			{Type: repository.BatchUpdate, Item: NewItem("Missing", true, nil)},
+			{Type: repository.BatchDelete, Item: repository.Item{ID: "invalid-hex-id"}},
+			{Type: repository.BatchCreate, Item: created},
+		}, false)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		RequireRepositoryError(t, results[0].Err, http.StatusNotFound)
+		RequireRepositoryError(t, results[1].Err, http.StatusUnprocessableEntity)
+		require.NoError(t, results[2].Err)
+
+		_, err = repo.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("Given_StopOnError_When_Batch_Then_ResultsEndWithTheFirstFailure", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		created := NewItem("Bread", true, nil)
+		notCreated := NewItem("Butter", true, nil)
+		results, err := repo.Batch(ctx, []repository.BatchOperation{
+			{Type: repository.BatchCreate, Item: created},
+			{Type: repository.BatchDelete, Item: NewItem("Missing", true, nil)},
+			{Type: repository.BatchCreate, Item: notCreated},
+		}, true)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		require.NoError(t, results[0].Err)
+		RequireRepositoryError(t, results[1].Err, http.StatusNotFound)
+
+		_, err = repo.GetByID(ctx, notCreated.ID)
+		RequireRepositoryError(t, err, http.StatusNotFound)
+	})
+}
+
+func testListScope(t *testing.T, factory Factory) {
+	const (
+		supermarket = "000000000000000000000010"
+		pharmacy    = "000000000000000000000020"
+	)
+	newItems := func() []repository.Item {
+		items := []repository.Item{
+			NewItem("Milk", false, nil),
+			NewItem("Bread", true, nil),
+			NewItem("Aspirin", false, nil),
+		}
+		items[0].ListID = supermarket
+		items[1].ListID = supermarket
+		items[2].ListID = pharmacy
+		return items
+	}
+	create := func(t *testing.T, repo repository.ItemRepository, items []repository.Item) {
+		t.Helper()
+		for _, item := range items {
+			created, err := repo.Create(context.Background(), item)
+			require.NoError(t, err)
+			require.Equal(t, item.ListID, created.ListID)
+		}
+	}
+	names := func(items []repository.Item) []string {
+		found := []string{}
+		for _, item := range items {
+			found = append(found, item.Name)
+		}
+		return found
+	}
+
+	t.Run("Given_ItemsOfTwoLists_When_GetByID_Then_ListIsKept", func(t *testing.T) {
+		repo := factory(t)
+		items := newItems()
+		create(t, repo, items)
+
+		stored, err := repo.GetByID(context.Background(), items[2].ID)
+		require.NoError(t, err)
+		require.Equal(t, pharmacy, stored.ListID)
+	})
+
+	t.Run("Given_ItemsOfTwoLists_When_ListByListID_Then_OnlyItsItemsAreListed", func(t *testing.T) {
+		repo := factory(t)
+		create(t, repo, newItems())
+
+		page, err := repo.List(context.Background(), repository.ListOptions{Filter: repository.ItemFilter{ListID: supermarket}})
+		require.NoError(t, err)
+		require.Equal(t, []string{"Milk", "Bread"}, names(page.Items))
+
+		page, err = repo.List(context.Background(), repository.ListOptions{Filter: repository.ItemFilter{ListID: pharmacy, Active: ptrTo(false)}})
+		require.NoError(t, err)
+		require.Equal(t, []string{"Aspirin"}, names(page.Items))
+	})
+
+	t.Run("Given_ItemsOfTwoLists_When_DeleteManyByListID_Then_OtherListIsKept", func(t *testing.T) {
+		repo := factory(t)
+		create(t, repo, newItems())
+
+		deletedCount, err := repo.DeleteMany(context.Background(), repository.ItemFilter{ListID: supermarket, Active: ptrTo(false)})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), deletedCount)
+		require.ElementsMatch(t, []string{"Bread", "Aspirin"}, names(listAll(t, repo)))
+	})
+
+	t.Run("Given_IDsOfAnotherList_When_BulkUpdateActiveByListID_Then_TheyAreNotFound", func(t *testing.T) {
+		repo := factory(t)
+		items := newItems()
+		create(t, repo, items)
+
+		result, err := repo.BulkUpdateActive(context.Background(), repository.ActiveUpdate{
+			Active: true,
+			IDs:    []string{items[0].ID, items[2].ID},
+			Filter: repository.ItemFilter{ListID: supermarket},
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), result.MatchedCount)
+		require.Equal(t, []string{items[2].ID}, result.NotFoundIDs)
+
+		stored, err := repo.GetByID(context.Background(), items[2].ID)
+		require.NoError(t, err)
+		require.False(t, stored.Active)
+	})
+
+	t.Run("Given_ItemsOfTwoLists_When_BulkUpdateActiveByFilterListID_Then_OnlyItsItemsChange", func(t *testing.T) {
+		repo := factory(t)
+		create(t, repo, newItems())
+
+		result, err := repo.BulkUpdateActive(context.Background(), repository.ActiveUpdate{
+			Active: true,
+			Filter: repository.ItemFilter{ListID: pharmacy},
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), result.MatchedCount)
+
+		for _, item := range listAll(t, repo) {
+			require.Equal(t, item.ListID == pharmacy || item.Name == "Bread", item.Active, item.Name)
+		}
+	})
+}
+
+func testQuantity(t *testing.T, factory Factory) {
+	existing := NewItem("Rice", true, nil)
+	existing.Quantity = ptrTo(int64(1500))
+	existing.Unit = "kg"
+
+	tests := []struct {
+		name       string
+		change     func(ctx context.Context, repo repository.ItemRepository) (repository.Item, error)
+		wantStored repository.Item
+	}{
+		{
+			name: "Given_NilQuantity_When_Update_Then_StoredQuantityAndUnitAreKept",
+			change: func(ctx context.Context, repo repository.ItemRepository) (repository.Item, error) {
+				return repo.Update(ctx, repository.Item{ID: existing.ID, Name: "Brown rice", Active: true})
+			},
+			wantStored: repository.Item{ID: existing.ID, Name: "Brown rice", Active: true, Quantity: ptrTo(int64(1500)), Unit: "kg"},
+		},
+		{
+			name: "Given_Quantity_When_Update_Then_QuantityAndUnitAreReplaced",
+			change: func(ctx context.Context, repo repository.ItemRepository) (repository.Item, error) {
+				return repo.Update(ctx, repository.Item{ID: existing.ID, Name: "Rice", Active: true, Quantity: ptrTo(int64(500_000)), Unit: "g"})
+			},
+			wantStored: repository.Item{ID: existing.ID, Name: "Rice", Active: true, Quantity: ptrTo(int64(500_000)), Unit: "g"},
+		},
+		{
+			name: "Given_Quantity_When_Patch_Then_QuantityAndUnitAreReplaced",
+			change: func(ctx context.Context, repo repository.ItemRepository) (repository.Item, error) {
+				return repo.Patch(ctx, existing.ID, repository.ItemPatch{Quantity: ptrTo(int64(125)), Unit: "kg", SetQuantity: true})
+			},
+			wantStored: repository.Item{ID: existing.ID, Name: "Rice", Active: true, Quantity: ptrTo(int64(125)), Unit: "kg"},
+		},
+		{
+			name: "Given_QuantityRemoval_When_Patch_Then_QuantityAndUnitAreCleared",
+			change: func(ctx context.Context, repo repository.ItemRepository) (repository.Item, error) {
+				return repo.Patch(ctx, existing.ID, repository.ItemPatch{SetQuantity: true})
+			},
+			wantStored: repository.Item{ID: existing.ID, Name: "Rice", Active: true},
+		},
+		{
+			name: "Given_OtherFields_When_Patch_Then_QuantityAndUnitAreKept",
+			change: func(ctx context.Context, repo repository.ItemRepository) (repository.Item, error) {
+				return repo.Patch(ctx, existing.ID, repository.ItemPatch{Active: ptrTo(false)})
+			},
+			wantStored: repository.Item{ID: existing.ID, Name: "Rice", Active: false, Quantity: ptrTo(int64(1500)), Unit: "kg"},
+		},
+	}
+
+	t.Run("Given_Quantity_When_Create_Then_QuantityAndUnitAreStored", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		created, err := repo.Create(ctx, existing)
+		require.NoError(t, err)
+		requireSameContent(t, existing, created)
+
+		storedItem, err := repo.GetByID(ctx, existing.ID)
+		require.NoError(t, err)
+		requireSameContent(t, existing, storedItem)
+
+		items := listAll(t, repo)
+		require.Len(t, items, 1)
+		requireSameContent(t, existing, items[0])
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := factory(t)
+
+			_, err := repo.Create(ctx, existing)
+			require.NoError(t, err)
+
+			changedItem, err := tt.change(ctx, repo)
+			require.NoError(t, err)
+
+			storedItem, err := repo.GetByID(ctx, existing.ID)
+			require.NoError(t, err)
+			requireSameContent(t, tt.wantStored, storedItem)
+			requireSameContent(t, tt.wantStored, changedItem)
+		})
+	}
+}
+
+func testCategory(t *testing.T, factory Factory) {
+	dairy := primitive.NewObjectID().Hex()
+	produce := primitive.NewObjectID().Hex()
+	existing := NewItem("Milk", true, nil)
+	existing.CategoryID = dairy
+
+	tests := []struct {
+		name       string
+		change     func(ctx context.Context, repo repository.ItemRepository) (repository.Item, error)
+		wantStored repository.Item
+	}{
+		{
+			name: "Given_EmptyCategory_When_Update_Then_StoredCategoryIsKept",
+			change: func(ctx context.Context, repo repository.ItemRepository) (repository.Item, error) {
+				return repo.Update(ctx, repository.Item{ID: existing.ID, Name: "Whole milk", Active: true})
+			},
+			wantStored: repository.Item{ID: existing.ID, Name: "Whole milk", Active: true, CategoryID: dairy},
+		},
+		{
+			name: "Given_Category_When_Update_Then_CategoryIsReplaced",
+			change: func(ctx context.Context, repo repository.ItemRepository) (repository.Item, error) {
+				return repo.Update(ctx, repository.Item{ID: existing.ID, Name: "Milk", Active: true, CategoryID: produce})
+			},
+			wantStored: repository.Item{ID: existing.ID, Name: "Milk", Active: true, CategoryID: produce},
+		},
+		{
+			name: "Given_Category_When_Patch_Then_CategoryIsReplaced",
+			change: func(ctx context.Context, repo repository.ItemRepository) (repository.Item, error) {
+				return repo.Patch(ctx, existing.ID, repository.ItemPatch{CategoryID: &produce, SetCategory: true})
+			},
+			wantStored: repository.Item{ID: existing.ID, Name: "Milk", Active: true, CategoryID: produce},
+		},
+		{
+			name: "Given_CategoryRemoval_When_Patch_Then_CategoryIsCleared",
+			change: func(ctx context.Context, repo repository.ItemRepository) (repository.Item, error) {
+				return repo.Patch(ctx, existing.ID, repository.ItemPatch{SetCategory: true})
+			},
+			wantStored: repository.Item{ID: existing.ID, Name: "Milk", Active: true},
+		},
+		{
+			name: "Given_OtherFields_When_Patch_Then_CategoryIsKept",
+			change: func(ctx context.Context, repo repository.ItemRepository) (repository.Item, error) {
+				return repo.Patch(ctx, existing.ID, repository.ItemPatch{Active: ptrTo(false)})
+			},
+			wantStored: repository.Item{ID: existing.ID, Name: "Milk", Active: false, CategoryID: dairy},
+		},
+	}
+
+	t.Run("Given_Category_When_Create_Then_CategoryIsStored", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		created, err := repo.Create(ctx, existing)
+		require.NoError(t, err)
+		requireSameContent(t, existing, created)
+
+		storedItem, err := repo.GetByID(ctx, existing.ID)
+		require.NoError(t, err)
+		requireSameContent(t, existing, storedItem)
+
+		items := listAll(t, repo)
+		require.Len(t, items, 1)
+		requireSameContent(t, existing, items[0])
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := factory(t)
+
+			_, err := repo.Create(ctx, existing)
+			require.NoError(t, err)
+
+			changedItem, err := tt.change(ctx, repo)
+			require.NoError(t, err)
+
+			storedItem, err := repo.GetByID(ctx, existing.ID)
+			require.NoError(t, err)
+			requireSameContent(t, tt.wantStored, storedItem)
+			requireSameContent(t, tt.wantStored, changedItem)
+		})
+	}
+
+	t.Run("Given_ItemsOfTwoCategories_When_ClearCategory_Then_OnlyItsItemsAreUncategorized", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		cheese := NewItem("Cheese", true, nil)
+		cheese.CategoryID = dairy
+		apple := NewItem("Apple", true, nil)
+		apple.CategoryID = produce
+		bread := NewItem("Bread", true, nil)
+		for _, item := range []repository.Item{existing, cheese, apple, bread} {
+			_, err := repo.Create(ctx, item)
+			require.NoError(t, err)
+		}
+
+		changedCount, err := repo.ClearCategory(ctx, dairy)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), changedCount)
+
+		for _, item := range []repository.Item{existing, cheese} {
+			storedItem, err := repo.GetByID(ctx, item.ID)
+			require.NoError(t, err)
+			require.Empty(t, storedItem.CategoryID)
+			require.Equal(t, int64(2), storedItem.Version)
+		}
+		storedApple, err := repo.GetByID(ctx, apple.ID)
+		require.NoError(t, err)
+		require.Equal(t, produce, storedApple.CategoryID)
+		require.Equal(t, int64(1), storedApple.Version)
+	})
+}
+
+func testPrice(t *testing.T, factory Factory) {
+	existing := NewItem("Coffee", true, nil)
+	existing.UnitPrice = ptrTo(int64(1890))
+	existing.Currency = "BRL"
+
+	tests := []struct {
+		name       string
+		change     func(ctx context.Context, repo repository.ItemRepository) (repository.Item, error)
+		wantStored repository.Item
+	}{
+		{
+			name: "Given_NilUnitPrice_When_Update_Then_StoredPriceIsKept",
+			change: func(ctx context.Context, repo repository.ItemRepository) (repository.Item, error) {
+				return repo.Update(ctx, repository.Item{ID: existing.ID, Name: "Ground coffee", Active: true})
+			},
+			wantStored: repository.Item{ID: existing.ID, Name: "Ground coffee", Active: true, UnitPrice: ptrTo(int64(1890)), Currency: "BRL"},
+		},
+		{
+			name: "Given_UnitPrice_When_Update_Then_PriceIsReplaced",
+			change: func(ctx context.Context, repo repository.ItemRepository) (repository.Item, error) {
+				return repo.Update(ctx, repository.Item{ID: existing.ID, Name: "Coffee", Active: true, UnitPrice: ptrTo(int64(450)), Currency: "USD"})
+			},
+			wantStored: repository.Item{ID: existing.ID, Name: "Coffee", Active: true, UnitPrice: ptrTo(int64(450)), Currency: "USD"},
+		},
+		{
+			name: "Given_UnitPrice_When_Patch_Then_PriceIsReplaced",
+			change: func(ctx context.Context, repo repository.ItemRepository) (repository.Item, error) {
+				return repo.Patch(ctx, existing.ID, repository.ItemPatch{UnitPrice: ptrTo(int64(0)), Currency: "BRL", SetPrice: true})
+			},
+			wantStored: repository.Item{ID: existing.ID, Name: "Coffee", Active: true, UnitPrice: ptrTo(int64(0)), Currency: "BRL"},
+		},
+		{
+			name: "Given_PriceRemoval_When_Patch_Then_UnitPriceAndCurrencyAreCleared",
+			change: func(ctx context.Context, repo repository.ItemRepository) (repository.Item, error) {
+				return repo.Patch(ctx, existing.ID, repository.ItemPatch{SetPrice: true})
+			},
+			wantStored: repository.Item{ID: existing.ID, Name: "Coffee", Active: true},
+		},
+		{
+			name: "Given_OtherFields_When_Patch_Then_PriceIsKept",
+			change: func(ctx context.Context, repo repository.ItemRepository) (repository.Item, error) {
+				return repo.Patch(ctx, existing.ID, repository.ItemPatch{Active: ptrTo(false)})
+			},
+			wantStored: repository.Item{ID: existing.ID, Name: "Coffee", Active: false, UnitPrice: ptrTo(int64(1890)), Currency: "BRL"},
+		},
+	}
+
+	t.Run("Given_UnitPrice_When_Create_Then_PriceIsStored", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		created, err := repo.Create(ctx, existing)
+		require.NoError(t, err)
+		requireSameContent(t, existing, created)
+
+		storedItem, err := repo.GetByID(ctx, existing.ID)
+		require.NoError(t, err)
+		requireSameContent(t, existing, storedItem)
+
+		items := listAll(t, repo)
+		require.Len(t, items, 1)
+		requireSameContent(t, existing, items[0])
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := factory(t)
+
+			_, err := repo.Create(ctx, existing)
+			require.NoError(t, err)
+
+			changedItem, err := tt.change(ctx, repo)
+			require.NoError(t, err)
+
+			storedItem, err := repo.GetByID(ctx, existing.ID)
+			require.NoError(t, err)
+			requireSameContent(t, tt.wantStored, storedItem)
+			requireSameContent(t, tt.wantStored, changedItem)
+		})
+	}
+
+	t.Run("Given_PricedItems_When_SumPrices_Then_LineTotalsAreSummedExactly", func(t *testing.T) {
+		const (
+			supermarket = "000000000000000000000010"
+			pharmacy    = "000000000000000000000020"
+		)
+		ctx := context.Background()
+		repo := factory(t)
+		dairy := primitive.NewObjectID().Hex()
+
+		newItem := func(name string, active bool, unitPrice *int64, currency string, quantity *int64) repository.Item {
+			item := NewItem(name, active, nil)
+			item.ListID = supermarket
+			item.UnitPrice = unitPrice
+			item.Currency = currency
+			item.Quantity = quantity
+			if quantity != nil {
+				item.Unit = "kg"
+			}
+			return item
+		}
+		cheese := newItem("Cheese", true, ptrTo(int64(1290)), "BRL", ptrTo(int64(1500)))
+		cheese.CategoryID = dairy
+		apples := newItem("Apples", true, ptrTo(int64(999)), "BRL", ptrTo(int64(100)))
+		bread := newItem("Bread", false, ptrTo(int64(500)), "BRL", nil)
+		tea := newItem("Tea", true, ptrTo(int64(333)), "USD", ptrTo(int64(333)))
+		salt := newItem("Salt", true, nil, "", nil)
+		aspirin := newItem("Aspirin", true, ptrTo(int64(1000)), "BRL", nil)
+		aspirin.ListID = pharmacy
+		for _, item := range []repository.Item{cheese, apples, bread, tea, salt, aspirin} {
+			_, err := repo.Create(ctx, item)
+			require.NoError(t, err)
+		}
+
+		totals, err := repo.SumPrices(ctx, repository.ItemFilter{ListID: supermarket})
+		require.NoError(t, err)
+		requireSamePriceTotals(t, []domain.PriceTotal{
+			{Currency: "BRL", Active: false, Cents: big.NewRat(500, 1)},
+			{Currency: "BRL", Active: true, Cents: big.NewRat(999, 10)},
+			{Currency: "BRL", CategoryID: dairy, Active: true, Cents: big.NewRat(1935, 1)},
+			{Currency: "USD", Active: true, Cents: big.NewRat(110889, 1000)},
+		}, totals)
+
+		totals, err = repo.SumPrices(ctx, repository.ItemFilter{ListID: supermarket, Active: ptrTo(false)})
+		require.NoError(t, err)
+		requireSamePriceTotals(t, []domain.PriceTotal{{Currency: "BRL", Active: false, Cents: big.NewRat(500, 1)}}, totals)
+
+		totals, err = repo.SumPrices(ctx, repository.ItemFilter{ListID: primitive.NewObjectID().Hex()})
+		require.NoError(t, err)
+		require.Empty(t, totals)
+	})
+}
+
+// requireSamePriceTotals compares totals in any order with want, in the order of repository.SortPriceTotals
+func requireSamePriceTotals(t *testing.T, want, got []domain.PriceTotal) {
+	t.Helper()
+
+	repository.SortPriceTotals(got)
+	require.Len(t, got, len(want))
+	for i := range want {
+		require.Equal(t, want[i].Currency, got[i].Currency)
+		require.Equal(t, want[i].CategoryID, got[i].CategoryID)
+		require.Equal(t, want[i].Active, got[i].Active)
+		require.Zero(t, want[i].Cents.Cmp(got[i].Cents), "%s: want %s, got %s", want[i].Currency, want[i].Cents.RatString(), got[i].Cents.RatString())
+	}
+}
+
+func testPosition(t *testing.T, factory Factory) {
+	const otherList = "000000000000000000000010"
+	newItems := func() []repository.Item {
+		// Created in another order than their positions
+		items := []repository.Item{NewItem("Bread", true, nil), NewItem("Milk", true, nil), NewItem("Eggs", true, nil)}
+		items[0].Position = "i1"
+		items[1].Position = "i0"
+		items[2].Position = "i0i"
+		return items
+	}
+	create := func(t *testing.T, repo repository.ItemRepository, items ...repository.Item) {
+		t.Helper()
+		for _, item := range items {
+			created, err := repo.Create(context.Background(), item)
+			require.NoError(t, err)
+			requireSameContent(t, item, created)
+		}
+	}
+	names := func(items []repository.Item) []string {
+		found := []string{}
+		for _, item := range items {
+			found = append(found, item.Name)
+		}
+		return found
+	}
+
+	t.Run("Given_PositionedItems_When_List_Then_ItemsAreListedByPosition", func(t *testing.T) {
+		repo := factory(t)
+		create(t, repo, newItems()...)
+
+		require.Equal(t, []string{"Milk", "Eggs", "Bread"}, names(listAll(t, repo)))
+
+		page, err := repo.List(context.Background(), repository.ListOptions{Limit: 2})
+		require.NoError(t, err)
+		require.Equal(t, []string{"Milk", "Eggs"}, names(page.Items))
+		page, err = repo.List(context.Background(), repository.ListOptions{Limit: 2, Cursor: page.NextCursor})
+		require.NoError(t, err)
+		require.Equal(t, []string{"Bread"}, names(page.Items))
+
+		page, err = repo.List(context.Background(), repository.ListOptions{Sort: []repository.SortField{{Field: repository.SortFieldPosition, Desc: true}}})
+		require.NoError(t, err)
+		require.Equal(t, []string{"Bread", "Eggs", "Milk"}, names(page.Items))
+	})
+
+	t.Run("Given_ItemsWithoutPosition_When_List_Then_TheyComeFirstInCreationOrder", func(t *testing.T) {
+		repo := factory(t)
+		create(t, repo, newItems()[0], NewItem("Rice", true, nil), NewItem("Beans", true, nil))
+
+		require.Equal(t, []string{"Rice", "Beans", "Bread"}, names(listAll(t, repo)))
+	})
+
+	t.Run("Given_TakenPosition_When_Create_Then_ReturnsPositionConflictError", func(t *testing.T) {
+		repo := factory(t)
+		items := newItems()
+		create(t, repo, items...)
+
+		taken := NewItem("Coffee", true, nil)
+		taken.Position = items[0].Position
+		_, err := repo.Create(context.Background(), taken)
+		RequireRepositoryError(t, err, http.StatusConflict)
+		require.ErrorIs(t, err, repository.ErrPositionConflict)
+
+		taken.ListID = otherList
+		create(t, repo, taken)
+	})
+
+	t.Run("Given_FreePosition_When_Patch_Then_ItemIsMoved", func(t *testing.T) {
+		repo := factory(t)
+		items := newItems()
+		create(t, repo, items...)
+
+		moved, err := repo.Patch(context.Background(), items[0].ID, repository.ItemPatch{Position: ptr("hz")})
+		require.NoError(t, err)
+		require.Equal(t, "hz", moved.Position)
+		require.Equal(t, int64(2), moved.Version)
+		require.Equal(t, []string{"Bread", "Milk", "Eggs"}, names(listAll(t, repo)))
+	})
+
+	t.Run("Given_TakenPosition_When_Patch_Then_ReturnsPositionConflictErrorAndKeepsTheOrder", func(t *testing.T) {
+		repo := factory(t)
+		items := newItems()
+		create(t, repo, items...)
+
+		_, err := repo.Patch(context.Background(), items[0].ID, repository.ItemPatch{Position: ptr(items[1].Position)})
+		RequireRepositoryError(t, err, http.StatusConflict)
+
+		stored, err := repo.GetByID(context.Background(), items[0].ID)
+		require.NoError(t, err)
+		requireSameContent(t, items[0], stored)
+		require.Equal(t, []string{"Milk", "Eggs", "Bread"}, names(listAll(t, repo)))
+	})
+
+	t.Run("Given_PositionedItem_When_Update_Then_PositionIsKept", func(t *testing.T) {
+		repo := factory(t)
+		items := newItems()
+		create(t, repo, items...)
+
+		updated, err := repo.Update(context.Background(), repository.Item{ID: items[0].ID, Name: "White bread", Active: true})
+		require.NoError(t, err)
+		require.Equal(t, items[0].Position, updated.Position)
+	})
+
+	t.Run("Given_ConcurrentMovesToTheSamePosition_When_Patch_Then_OnlyOneSucceeds", func(t *testing.T) {
+		repo := factory(t)
+		items := newItems()
+		create(t, repo, items...)
+
+		var (
+			wg        sync.WaitGroup
+			mu        sync.Mutex
+			succeeded int
+		)
+		for _, item := range items[:2] {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := repo.Patch(context.Background(), item.ID, repository.ItemPatch{Position: ptr("j0")})
+				if err == nil {
+					mu.Lock()
+					succeeded++
+					mu.Unlock()
+					return
+				}
+				RequireRepositoryError(t, err, http.StatusConflict)
+			}()
+		}
+		wg.Wait()
+
+		require.Equal(t, 1, succeeded)
+		require.Len(t, listAll(t, repo), 3)
+	})
+}
+
+func testTimestamps(t *testing.T, factory Factory) {
+	t.Run("Given_NewItem_When_Create_Then_CreatedAtAndUpdatedAtAreSetToNow", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		before := time.Now()
+		item := NewItem("Eggs", true, nil)
+		_, err := repo.Create(ctx, item)
+		require.NoError(t, err)
+		after := time.Now()
+
+		storedItem, err := repo.GetByID(ctx, item.ID)
+		require.NoError(t, err)
+		requireBetween(t, before, after, storedItem.CreatedAt)
+		require.Equal(t, storedItem.CreatedAt, storedItem.UpdatedAt)
+		require.Equal(t, time.UTC, storedItem.CreatedAt.Location())
+	})
+
+	t.Run("Given_ExistingItem_When_Update_Then_CreatedAtIsKeptAndUpdatedAtMovesForward", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		item := NewItem("Eggs", true, nil)
+		_, err := repo.Create(ctx, item)
+		require.NoError(t, err)
+		createdItem, err := repo.GetByID(ctx, item.ID)
+		require.NoError(t, err)
+
+		// Make sure the clock moves past the backend precision
+		time.Sleep(5 * time.Millisecond)
+
+		before := time.Now()
+		_, err = repo.Update(ctx, repository.Item{ID: item.ID, Name: "Free-range eggs", Active: true})
+		require.NoError(t, err)
+		after := time.Now()
+
+		updatedItem, err := repo.GetByID(ctx, item.ID)
+		require.NoError(t, err)
+		require.True(t, createdItem.CreatedAt.Equal(updatedItem.CreatedAt))
+		require.True(t, updatedItem.UpdatedAt.After(createdItem.UpdatedAt))
+		requireBetween(t, before, after, updatedItem.UpdatedAt)
+	})
+
+	t.Run("Given_ExistingItems_When_BulkUpdateActive_Then_UpdatedAtMovesForward", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		item := NewItem("Eggs", true, nil)
+		_, err := repo.Create(ctx, item)
+		require.NoError(t, err)
+		createdItem, err := repo.GetByID(ctx, item.ID)
+		require.NoError(t, err)
+
+		time.Sleep(5 * time.Millisecond)
+
+		_, err = repo.BulkUpdateActive(ctx, repository.ActiveUpdate{Active: false})
+		require.NoError(t, err)
+
+		updatedItem, err := repo.GetByID(ctx, item.ID)
+		require.NoError(t, err)
+		require.True(t, createdItem.CreatedAt.Equal(updatedItem.CreatedAt))
+		require.True(t, updatedItem.UpdatedAt.After(createdItem.UpdatedAt))
+	})
+}
+
+func testVersion(t *testing.T, factory Factory) {
+	t.Run("Given_Writes_When_Applied_Then_VersionIsIncremented", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		existing := NewItem("Milk", true, nil)
+		createdItem, err := repo.Create(ctx, existing)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), createdItem.Version)
+
+		existing.Name = "Whole milk"
+		updatedItem, err := repo.Update(ctx, existing)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), updatedItem.Version)
+
+		patchedItem, err := repo.Patch(ctx, existing.ID, repository.ItemPatch{Active: ptrTo(false)})
+		require.NoError(t, err)
+		require.Equal(t, int64(3), patchedItem.Version)
+
+		_, err = repo.BulkUpdateActive(ctx, repository.ActiveUpdate{Active: true})
+		require.NoError(t, err)
+
+		storedItem, err := repo.GetByID(ctx, existing.ID)
+		require.NoError(t, err)
+		require.Equal(t, int64(4), storedItem.Version)
+	})
+
+	t.Run("Given_CurrentVersion_When_Written_Then_ChangeIsApplied", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		existing := NewItem("Milk", true, nil)
+		_, err := repo.Create(ctx, existing)
+		require.NoError(t, err)
+
+		updatedItem, err := repo.Update(ctx, repository.Item{ID: existing.ID, Name: "Whole milk", Active: true, Version: 1})
+		require.NoError(t, err)
+		require.Equal(t, int64(2), updatedItem.Version)
+
+		patchedItem, err := repo.Patch(ctx, existing.ID, repository.ItemPatch{Name: ptr("Oat milk"), Version: 2})
+		require.NoError(t, err)
+		require.Equal(t, int64(3), patchedItem.Version)
+
+		require.NoError(t, repo.Delete(ctx, existing.ID, 3))
+		require.Empty(t, listAll(t, repo))
+	})
+
+	t.Run("Given_StaleVersion_When_Written_Then_ReturnsConflictAndNothingChanges", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		existing := NewItem("Milk", true, ptr("skimmed"))
+		_, err := repo.Create(ctx, existing)
+		require.NoError(t, err)
+		_, err = repo.Patch(ctx, existing.ID, repository.ItemPatch{Active: ptrTo(false)})
+		require.NoError(t, err)
+
+		_, err = repo.Update(ctx, repository.Item{ID: existing.ID, Name: "Whole milk", Active: true, Version: 1})
+		RequireRepositoryError(t, err, http.StatusPreconditionFailed)
+		require.ErrorIs(t, err, repository.ErrVersionConflict)
+
+		_, err = repo.Patch(ctx, existing.ID, repository.ItemPatch{Name: ptr("Oat milk"), Version: 1})
+		RequireRepositoryError(t, err, http.StatusPreconditionFailed)
+
+		err = repo.Delete(ctx, existing.ID, 1)
+		RequireRepositoryError(t, err, http.StatusPreconditionFailed)
+
+		storedItem, err := repo.GetByID(ctx, existing.ID)
+		require.NoError(t, err)
+		requireSameContent(t, repository.Item{ID: existing.ID, Name: "Milk", Active: false, Observation: ptr("skimmed")}, storedItem)
+		require.Equal(t, int64(2), storedItem.Version)
+	})
+
+	t.Run("Given_VersionOfUnknownItem_When_Written_Then_ReturnsNotFoundError", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		missing := NewItem("Missing", true, nil)
+		missing.Version = 1
+
+		_, err := repo.Update(ctx, missing)
+		RequireRepositoryError(t, err, http.StatusNotFound)
+
+		err = repo.Delete(ctx, missing.ID, 1)
+		RequireRepositoryError(t, err, http.StatusNotFound)
+	})
+
+	t.Run("Given_ConcurrentWritesOfSameVersion_When_Update_Then_OnlyOneSucceeds", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		existing := NewItem("Milk", true, nil)
+		_, err := repo.Create(ctx, existing)
+		require.NoError(t, err)
+
+		const writers = 5
+
+		var wg sync.WaitGroup
+		errs := make(chan error, writers)
+		for i := 0; i < writers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := repo.Update(ctx, repository.Item{ID: existing.ID, Name: "Whole milk", Active: true, Version: 1})
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		var succeeded int
+		for err := range errs {
+			if err == nil {
+				succeeded++
+				continue
+			}
+			RequireRepositoryError(t, err, http.StatusPreconditionFailed)
+		}
+		require.Equal(t, 1, succeeded)
+	})
+}
+
+func testConcurrency(t *testing.T, factory Factory) {
+	t.Run("Given_ConcurrentWriters_When_CreateUpdateAndList_Then_NoItemIsLost", func(t *testing.T) {
+		ctx := context.Background()
+		repo := factory(t)
+
+		const workers = 20
+
+		var (
+			wg   sync.WaitGroup
+			mu   sync.Mutex
+			errs []error
+		)
+		record := func(err error) {
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}
+
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				item := NewItem("Concurrent item", true, nil)
+				_, err := repo.Create(ctx, item)
+				record(err)
+				item.Active = false
+				_, err = repo.Update(ctx, item)
+				record(err)
+				_, err = repo.List(ctx, repository.ListOptions{Limit: 10})
+				record(err)
+				_, err = repo.BulkUpdateActive(ctx, repository.ActiveUpdate{Active: true})
+				record(err)
+			}()
+		}
+		wg.Wait()
+
+		require.Empty(t, errs)
+
+		require.Len(t, listAll(t, repo), workers)
+	})
+}
+
+func testCanceledContext(t *testing.T, factory Factory) {
+	t.Run("Given_CanceledContext_When_Create_Then_ReturnsErrorAndNothingIsStored", func(t *testing.T) {
+		repo := factory(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		item := NewItem("Never stored", true, nil)
+		_, err := repo.Create(ctx, item)
+		require.Error(t, err)
+
+		_, err = repo.GetByID(context.Background(), item.ID)
+		RequireRepositoryError(t, err, http.StatusNotFound)
+	})
+}
+
+// NewItem returns a repository item with a fresh, valid hexadecimal ID.
+func NewItem(name string, active bool, observation *string) repository.Item {
+	return repository.Item{
+		ID:          primitive.NewObjectID().Hex(),
+		Name:        name,
+		Active:      active,
+		Observation: observation,
+	}
+}
+
+// RequireRepositoryError asserts err is a repository.Error with the given HTTP status.
+func RequireRepositoryError(t *testing.T, err error, wantHTTP int) {
+	t.Helper()
+
+	var errRepository repository.Error
+	require.True(t, errors.As(err, &errRepository), "expected repository.Error, got %v", err)
+	require.Equal(t, wantHTTP, errRepository.HTTP)
+}
+
+func requireSameContent(t *testing.T, want, got repository.Item) {
+	t.Helper()
+
+	require.Equal(t, want.ID, got.ID)
+	require.Equal(t, want.Name, got.Name)
+	require.Equal(t, want.Active, got.Active)
+	require.Equal(t, want.Observation, got.Observation)
+	require.Equal(t, want.Quantity, got.Quantity)
+	require.Equal(t, want.Unit, got.Unit)
+	require.Equal(t, want.CategoryID, got.CategoryID)
+	require.Equal(t, want.UnitPrice, got.UnitPrice)
+	require.Equal(t, want.Currency, got.Currency)
+	require.Equal(t, want.Position, got.Position)
+}
+
+func requireSameTimestamps(t *testing.T, want, got repository.Item) {
+	t.Helper()
+	require.True(t, want.CreatedAt.Equal(got.CreatedAt), "createdAt: want %v, got %v", want.CreatedAt, got.CreatedAt)
+	require.True(t, want.UpdatedAt.Equal(got.UpdatedAt), "updatedAt: want %v, got %v", want.UpdatedAt, got.UpdatedAt)
+}
+
+func requireBetween(t *testing.T, before, after, got time.Time) {
+	t.Helper()
+
+	require.False(t, got.Before(before.Add(-timestampTolerance)), "%v is before %v", got, before)
+	require.False(t, got.After(after.Add(timestampTolerance)), "%v is after %v", got, after)
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -0,0 +1,136 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+// TxFactory returns a new, empty repository and the transaction manager bound to it.
+type TxFactory func(t *testing.T) (repository.ItemRepository, repository.TxManager)
+
+var errAbortTx = errors.New("abort transaction")
+
+// RunTx executes the transaction conformance suite against the repositories built by factory.
+func RunTx(t *testing.T, factory TxFactory) {
+	t.Helper()
+
+	t.Run("Given_SuccessfulUnitOfWork_When_WithinTransaction_Then_AllChangesAreCommitted", func(t *testing.T) {
+		ctx := context.Background()
+		repo, txManager := factory(t)
+
+		existing := NewItem("Bread", true, nil)
+		_, err := repo.Create(ctx, existing)
+		require.NoError(t, err)
+
+		created := NewItem("Butter", true, nil)
+		err = txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			if _, err := repo.Create(ctx, created); err != nil {
+				return err
+			}
+			return repo.Delete(ctx, existing.ID, 0)
+		})
+		require.NoError(t, err)
+
+		_, err = repo.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		_, err = repo.GetByID(ctx, existing.ID)
+		RequireRepositoryError(t, err, http.StatusNotFound)
+	})
+
+	t.Run("Given_FailingUnitOfWork_When_WithinTransaction_Then_AllChangesAreRolledBack", func(t *testing.T) {
+		ctx := context.Background()
+		repo, txManager := factory(t)
+
+		existing := NewItem("Bread", true, ptr("white"))
+		_, err := repo.Create(ctx, existing)
+		require.NoError(t, err)
+
+		created := NewItem("Butter", true, nil)
+		err = txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			if _, err := repo.Create(ctx, created); err != nil {
+				return err
+			}
+			if _, err := repo.Update(ctx, repository.Item{ID: existing.ID, Name: "Rye bread", Active: false}); err != nil {
+				return err
+			}
+			return errAbortTx
+		})
+		require.ErrorIs(t, err, errAbortTx)
+
+		_, err = repo.GetByID(ctx, created.ID)
+		RequireRepositoryError(t, err, http.StatusNotFound)
+
+		storedItem, err := repo.GetByID(ctx, existing.ID)
+		require.NoError(t, err)
+		requireSameContent(t, existing, storedItem)
+	})
+
+	t.Run("Given_RepositoryError_When_WithinTransaction_Then_ErrorIsReturnedAndChangesAreRolledBack", func(t *testing.T) {
+		ctx := context.Background()
+		repo, txManager := factory(t)
+
+		created := NewItem("Butter", true, nil)
+		err := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			if _, err := repo.Create(ctx, created); err != nil {
+				return err
+			}
+			return repo.Delete(ctx, NewItem("Missing", true, nil).ID, 0)
+		})
+		RequireRepositoryError(t, err, http.StatusNotFound)
+
+		require.Empty(t, listAll(t, repo))
+	})
+
+	t.Run("Given_NestedTransaction_When_OuterFails_Then_InnerChangesAreRolledBack", func(t *testing.T) {
+		ctx := context.Background()
+		repo, txManager := factory(t)
+
+		created := NewItem("Butter", true, nil)
+		err := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			err := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+				_, err := repo.Create(ctx, created)
+				return err
+			})
+			require.NoError(t, err)
+			return errAbortTx
+		})
+		require.ErrorIs(t, err, errAbortTx)
+
+		_, err = repo.GetByID(ctx, created.ID)
+		RequireRepositoryError(t, err, http.StatusNotFound)
+	})
+
+	t.Run("Given_FailedBatchOperation_When_WithinTransaction_Then_TheWholeBatchIsRolledBack", func(t *testing.T) {
+		ctx := context.Background()
+		repo, txManager := factory(t)
+
+		existing := NewItem("Bread", true, nil)
+		_, err := repo.Create(ctx, existing)
+		require.NoError(t, err)
+
+		created := NewItem("Butter", true, nil)
+		err = txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			results, err := repo.Batch(ctx, []repository.BatchOperation{
+				{Type: repository.BatchCreate, Item: created},
+				{Type: repository.BatchDelete, Item: repository.Item{ID: existing.ID}},
+				{Type: repository.BatchUpdate, Item: NewItem("Missing", true, nil)},
+			}, true)
+			if err != nil {
+				return err
+			}
+			return results[len(results)-1].Err
+		})
+		RequireRepositoryError(t, err, http.StatusNotFound)
+
+		_, err = repo.GetByID(ctx, created.ID)
+		RequireRepositoryError(t, err, http.StatusNotFound)
+		_, err = repo.GetByID(ctx, existing.ID)
+		require.NoError(t, err)
+	})
+}
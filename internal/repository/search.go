@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/collation"
+)
+
+// Weights of the searched fields: a word found in the name counts three times
+// as much as one found in the observation (same weights as the MongoDB text index)
+const (
+	SearchWeightName        = 3
+	SearchWeightObservation = 1
+)
+
+// SearchTerms returns the distinct folded words of a search query.
+// A query without words matches nothing.
+func SearchTerms(query string) []string {
+	var terms []string
+	for _, word := range collation.Words(query) {
+		if !slices.Contains(terms, word) {
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// SearchItems is the pure-Go search used by the backends without a text index.
+// It returns up to limit items (all when limit <= 0) whose name or observation
+// contain the words of query, ignoring case and diacritics, most relevant first
+// and then by ID. A word matches a whole word of the item or, with half the
+// score, its beginning ("tomate" finds "tomates").
+func SearchItems(items []Item, query string, limit int) []Item {
+	terms := SearchTerms(query)
+	if len(terms) == 0 {
+		return []Item{}
+	}
+
+	type scoredItem struct {
+		item  Item
+		score float64
+	}
+	var scored []scoredItem
+	for _, item := range items {
+		score := SearchWeightName * matchScore(terms, collation.Words(item.Name))
+		if item.Observation != nil {
+			score += SearchWeightObservation * matchScore(terms, collation.Words(*item.Observation))
+		}
+		if score > 0 {
+			scored = append(scored, scoredItem{item: item, score: score})
+		}
+	}
+
+	sort.Slice(scored, func(i, j int) bool {
+		if scored[i].score != scored[j].score {
+			return scored[i].score > scored[j].score
+		}
+		return scored[i].item.ID < scored[j].item.ID
+	})
+	if limit > 0 && len(scored) > limit {
+		scored = scored[:limit]
+	}
+
+	result := make([]Item, len(scored))
+	for i, s := range scored {
+		result[i] = s.item
+	}
+	return result
+}
+
+func matchScore(terms, words []string) float64 {
+	var score float64
+	for _, term := range terms {
+		for _, word := range words {
+			switch {
+			case word == term:
+				score++
+			case strings.HasPrefix(word, term):
+				score += 0.5
+			}
+		}
+	}
+	return score
+}
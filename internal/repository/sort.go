@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/collation"
+)
+
+// Fields the items can be sorted by
+const (
+	SortFieldName      = "name"
+	SortFieldActive    = "active"
+	SortFieldCreatedAt = "createdAt"
+	SortFieldUpdatedAt = "updatedAt"
+	SortFieldPosition  = "position"
+)
+
+// SortField is one key of the listing order. Items with equal keys are always
+// ordered by ascending ID, so the order is deterministic.
+type SortField struct {
+	Field string `json:"field"`
+	Desc  bool   `json:"desc,omitempty"`
+}
+
+// DefaultSort is the listing order when none is given: by position, then the
+// items without a position (stored before there were positions) oldest first
+var DefaultSort = []SortField{{Field: SortFieldPosition}, {Field: SortFieldCreatedAt}}
+
+// NormalizeSort validates the sort fields and returns DefaultSort when there is none.
+func NormalizeSort(sort []SortField) ([]SortField, error) {
+	if len(sort) == 0 {
+		return DefaultSort, nil
+	}
+
+	seen := make(map[string]bool, len(sort))
+	for _, s := range sort {
+		switch s.Field {
+		case SortFieldName, SortFieldActive, SortFieldCreatedAt, SortFieldUpdatedAt, SortFieldPosition:
+		default:
+			return nil, NewInvalidSortError(fmt.Errorf("unknown sort field %q", s.Field))
+		}
+		if seen[s.Field] {
+			return nil, NewInvalidSortError(fmt.Errorf("duplicated sort field %q", s.Field))
+		}
+		seen[s.Field] = true
+	}
+
+	return sort, nil
+}
+
+// SortString returns the sort in the query parameter format, e.g. "-updatedAt,name".
+func SortString(sort []SortField) string {
+	fields := make([]string, len(sort))
+	for i, s := range sort {
+		fields[i] = s.Field
+		if s.Desc {
+			fields[i] = "-" + s.Field
+		}
+	}
+	return strings.Join(fields, ",")
+}
+
+// CompareItems returns -1, 0 or +1 depending on whether a is listed before, with or
+// after b in the given order. Names are compared ignoring case and accents.
+func CompareItems(a, b Item, sort []SortField) int {
+	for _, s := range sort {
+		c := compareField(a, b, s.Field)
+		if s.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return strings.Compare(a.ID, b.ID)
+}
+
+func compareField(a, b Item, field string) int {
+	switch field {
+	case SortFieldName:
+		return collation.Compare(a.Name, b.Name)
+	case SortFieldActive:
+		switch {
+		case a.Active == b.Active:
+			return 0
+		case b.Active:
+			return -1
+		default:
+			return 1
+		}
+	case SortFieldCreatedAt:
+		return a.CreatedAt.Compare(b.CreatedAt)
+	case SortFieldUpdatedAt:
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	case SortFieldPosition:
+		return strings.Compare(a.Position, b.Position)
+	}
+	return 0
+}
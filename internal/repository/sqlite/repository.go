@@ -0,0 +1,22 @@
+package sqlite
+
+import (
+	"github.com/lucaspereirasilva0/list-manager-api/internal/database/sqldb"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository/sqlstore"
+)
+
+// NewSQLiteItemRepository creates a repository.ItemRepository backed by SQLite
+func NewSQLiteItemRepository(db sqldb.DBOperations) repository.ItemRepository {
+	return sqlstore.NewSQLItemRepository(db)
+}
+
+// NewSQLiteListRepository creates a repository.ListRepository backed by SQLite
+func NewSQLiteListRepository(db sqldb.DBOperations) repository.ListRepository {
+	return sqlstore.NewSQLListRepository(db)
+}
+
+// NewSQLiteCategoryRepository creates a repository.CategoryRepository backed by SQLite
+func NewSQLiteCategoryRepository(db sqldb.DBOperations) repository.CategoryRepository {
+	return sqlstore.NewSQLCategoryRepository(db)
+}
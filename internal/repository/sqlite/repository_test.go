@@ -0,0 +1,437 @@
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	dbsqlite "github.com/lucaspereirasilva0/list-manager-api/internal/database/sqlite"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository/repositorytest"
+	sqliterepo "github.com/lucaspereirasilva0/list-manager-api/internal/repository/sqlite"
+)
+
+var (
+	testObjectID = primitive.NewObjectID()
+)
+
+// newTestRepository creates a repository on a fresh database file in a temporary directory.
+func newTestRepository(t *testing.T) repository.ItemRepository {
+	t.Helper()
+
+	repo, _ := newTestTxRepository(t)
+	return repo
+}
+
+func newTestTxRepository(t *testing.T) (repository.ItemRepository, repository.TxManager) {
+	t.Helper()
+
+	client, err := dbsqlite.NewClient(context.Background(), filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Disconnect(context.Background()))
+	})
+
+	return sqliterepo.NewSQLiteItemRepository(client.DB()), client.TxManager()
+}
+
+func newTestListRepository(t *testing.T) repository.ListRepository {
+	t.Helper()
+
+	client, err := dbsqlite.NewClient(context.Background(), filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Disconnect(context.Background()))
+	})
+
+	return sqliterepo.NewSQLiteListRepository(client.DB())
+}
+
+func newTestCategoryRepository(t *testing.T) repository.CategoryRepository {
+	t.Helper()
+
+	client, err := dbsqlite.NewClient(context.Background(), filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Disconnect(context.Background()))
+	})
+
+	return sqliterepo.NewSQLiteCategoryRepository(client.DB())
+}
+
+func mockItem() repository.Item {
+	obs := "mock observation"
+	return repository.Item{ID: testObjectID.Hex(), Name: "Test Item", Active: true, Observation: &obs}
+}
+
+func mockUpdateItemInput() repository.Item {
+	return repository.Item{ID: testObjectID.Hex(), Name: "Updated Item", Active: false}
+}
+
+func mockInvalidHexIDItem() repository.Item {
+	return repository.Item{ID: "invalid-hex-id", Name: "Test Item", Active: true}
+}
+
+func TestCreate(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		givenExisting   []repository.Item
+		givenItem       repository.Item
+		wantErr         error
+		wantCreatedItem repository.Item
+	}{
+		{
+			name:            "Given_ValidItem_When_Create_Then_ExpectedSuccess",
+			givenItem:       mockItem(),
+			wantCreatedItem: mockItem(),
+		},
+		{
+			name:      "Given_ItemWithInvalidHexID_When_Create_Then_ExpectedInvalidIDError",
+			givenItem: mockInvalidHexIDItem(),
+			wantErr:   repository.NewInvalidHexIDError(),
+		},
+		{
+			name:          "Given_ExistingID_When_Create_Then_ExpectedGenericError",
+			givenExisting: []repository.Item{mockItem()},
+			givenItem:     mockItem(),
+			wantErr:       repository.NewGenericRepositoryError(nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepository(t)
+			for _, item := range tt.givenExisting {
+				_, err := repo.Create(ctx, item)
+				require.NoError(t, err)
+			}
+
+			createdItem, err := repo.Create(ctx, tt.givenItem)
+
+			if tt.wantErr != nil {
+				require.ErrorAs(t, err, new(repository.Error))
+				require.Equal(t, tt.wantErr.(repository.Error).HTTP, err.(repository.Error).HTTP)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantCreatedItem.Name, createdItem.Name)
+
+			storedItem, err := repo.GetByID(ctx, tt.givenItem.ID)
+			require.NoError(t, err)
+			require.Equal(t, storedItem, createdItem)
+			require.Equal(t, tt.givenItem.Name, storedItem.Name)
+			require.Equal(t, tt.givenItem.Observation, storedItem.Observation)
+			require.False(t, storedItem.CreatedAt.IsZero())
+			require.Equal(t, storedItem.CreatedAt, storedItem.UpdatedAt)
+		})
+	}
+}
+
+func TestGetByID(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		givenExisting []repository.Item
+		givenID       string
+		wantErr       error
+		wantName      string
+	}{
+		{
+			name:          "Given_ValidID_When_GetByID_Then_ExpectedSuccess",
+			givenExisting: []repository.Item{mockItem()},
+			givenID:       testObjectID.Hex(),
+			wantName:      mockItem().Name,
+		},
+		{
+			name:    "Given_ValidID_When_GetByID_And_ItemNotFound_Then_ExpectedNotFoundError",
+			givenID: testObjectID.Hex(),
+			wantErr: repository.NewItemNotFoundError(),
+		},
+		{
+			name:    "Given_InvalidID_When_GetByID_Then_ExpectedInvalidIDError",
+			givenID: "invalid-id",
+			wantErr: repository.NewInvalidHexIDError(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepository(t)
+			for _, item := range tt.givenExisting {
+				_, err := repo.Create(ctx, item)
+				require.NoError(t, err)
+			}
+
+			item, err := repo.GetByID(ctx, tt.givenID)
+
+			if tt.wantErr != nil {
+				require.Equal(t, tt.wantErr, err)
+				require.Equal(t, repository.Item{}, item)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.givenID, item.ID)
+			require.Equal(t, tt.wantName, item.Name)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		givenExisting   []repository.Item
+		givenItem       repository.Item
+		wantErr         error
+		wantObservation *string
+	}{
+		{
+			name:            "Given_ValidItem_When_Update_Then_ExpectedSuccessKeepingObservation",
+			givenExisting:   []repository.Item{mockItem()},
+			givenItem:       mockUpdateItemInput(),
+			wantObservation: mockItem().Observation,
+		},
+		{
+			name:      "Given_ValidItem_When_Update_And_ItemNotFound_Then_ExpectedNotFoundError",
+			givenItem: mockUpdateItemInput(),
+			wantErr:   repository.NewItemNotFoundError(),
+		},
+		{
+			name:      "Given_InvalidID_When_Update_Then_ExpectedInvalidIDError",
+			givenItem: mockInvalidHexIDItem(),
+			wantErr:   repository.NewInvalidHexIDError(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepository(t)
+			for _, item := range tt.givenExisting {
+				_, err := repo.Create(ctx, item)
+				require.NoError(t, err)
+			}
+
+			updatedItem, err := repo.Update(ctx, tt.givenItem)
+
+			if tt.wantErr != nil {
+				require.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+
+			storedItem, err := repo.GetByID(ctx, tt.givenItem.ID)
+			require.NoError(t, err)
+			require.Equal(t, storedItem, updatedItem)
+			require.Equal(t, tt.givenItem.Name, storedItem.Name)
+			require.Equal(t, tt.givenItem.Active, storedItem.Active)
+			require.Equal(t, tt.wantObservation, storedItem.Observation)
+			require.False(t, storedItem.UpdatedAt.Before(storedItem.CreatedAt))
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		givenExisting []repository.Item
+		givenID       string
+		wantErr       error
+	}{
+		{
+			name:          "Given_ValidID_When_Delete_Then_ExpectedSuccess",
+			givenExisting: []repository.Item{mockItem()},
+			givenID:       testObjectID.Hex(),
+		},
+		{
+			name:    "Given_ValidID_When_Delete_And_ItemNotFound_Then_ExpectedNotFoundError",
+			givenID: testObjectID.Hex(),
+			wantErr: repository.NewItemNotFoundError(),
+		},
+		{
+			name:    "Given_InvalidID_When_Delete_Then_ExpectedInvalidIDError",
+			givenID: "invalid-id",
+			wantErr: repository.NewInvalidHexIDError(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepository(t)
+			for _, item := range tt.givenExisting {
+				_, err := repo.Create(ctx, item)
+				require.NoError(t, err)
+			}
+
+			err := repo.Delete(ctx, tt.givenID, 0)
+
+			if tt.wantErr != nil {
+				require.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+
+			_, err = repo.GetByID(ctx, tt.givenID)
+			require.Equal(t, repository.NewItemNotFoundError(), err)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	ctx := context.Background()
+
+	first := repository.Item{ID: primitive.NewObjectID().Hex(), Name: "First Item", Active: true}
+	second := repository.Item{ID: primitive.NewObjectID().Hex(), Name: "Second Item", Active: false}
+
+	tests := []struct {
+		name          string
+		givenExisting []repository.Item
+		wantNames     []string
+	}{
+		{
+			name:          "Given_ItemsExist_When_List_Then_ExpectedItemsInCreationOrder",
+			givenExisting: []repository.Item{first, second},
+			wantNames:     []string{"First Item", "Second Item"},
+		},
+		{
+			name:      "Given_NoItemsExist_When_List_Then_ExpectedEmptyList",
+			wantNames: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepository(t)
+			for _, item := range tt.givenExisting {
+				_, err := repo.Create(ctx, item)
+				require.NoError(t, err)
+			}
+
+			page, err := repo.List(ctx, repository.ListOptions{})
+			items := page.Items
+			require.NoError(t, err)
+			require.NotNil(t, items)
+
+			names := make([]string, len(items))
+			for i, item := range items {
+				names[i] = item.Name
+			}
+			require.Equal(t, tt.wantNames, names)
+		})
+	}
+}
+
+func TestBulkUpdateActive(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name              string
+		givenExisting     []repository.Item
+		givenActive       bool
+		wantMatchedCount  int64
+		wantModifiedCount int64
+	}{
+		{
+			name: "Given_Items_When_BulkUpdateActive_Then_ReturnsCounts",
+			givenExisting: []repository.Item{
+				{ID: primitive.NewObjectID().Hex(), Name: "First Item", Active: true},
+				{ID: primitive.NewObjectID().Hex(), Name: "Second Item", Active: false},
+			},
+			givenActive:       true,
+			wantMatchedCount:  2,
+			wantModifiedCount: 2,
+		},
+		{
+			name:              "Given_EmptyRepository_When_BulkUpdateActive_Then_ReturnsZeroCounts",
+			givenActive:       false,
+			wantMatchedCount:  0,
+			wantModifiedCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepository(t)
+			for _, item := range tt.givenExisting {
+				_, err := repo.Create(ctx, item)
+				require.NoError(t, err)
+			}
+
+			result, err := repo.BulkUpdateActive(ctx, repository.ActiveUpdate{Active: tt.givenActive})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantMatchedCount, result.MatchedCount)
+			require.Equal(t, tt.wantModifiedCount, result.ModifiedCount)
+
+			page, err := repo.List(ctx, repository.ListOptions{})
+			items := page.Items
+			require.NoError(t, err)
+			for _, item := range items {
+				require.Equal(t, tt.givenActive, item.Active)
+			}
+		})
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	const workers = 50
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			item := repository.Item{ID: primitive.NewObjectID().Hex(), Name: "Concurrent Item", Active: true}
+			_, err := repo.Create(ctx, item)
+			require.NoError(t, err)
+			_, err = repo.Update(ctx, item)
+			require.NoError(t, err)
+			_, err = repo.BulkUpdateActive(ctx, repository.ActiveUpdate{Active: false})
+			require.NoError(t, err)
+			_, err = repo.List(ctx, repository.ListOptions{})
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	page, err := repo.List(ctx, repository.ListOptions{})
+	items := page.Items
+	require.NoError(t, err)
+	require.Len(t, items, workers)
+}
+
+func TestConformance(t *testing.T) {
+	repositorytest.Run(t, newTestRepository)
+}
+
+func TestTxConformance(t *testing.T) {
+	repositorytest.RunTx(t, newTestTxRepository)
+}
+
+func TestListConformance(t *testing.T) {
+	repositorytest.RunList(t, newTestListRepository)
+}
+
+func TestCategoryConformance(t *testing.T) {
+	repositorytest.RunCategory(t, newTestCategoryRepository)
+}
+
+func TestDefaultList(t *testing.T) {
+	repo := newTestListRepository(t)
+
+	list, err := repo.GetByID(context.Background(), repository.DefaultListID)
+	require.NoError(t, err)
+	require.Equal(t, repository.DefaultListName, list.Name)
+	require.False(t, list.CreatedAt.IsZero())
+}
@@ -0,0 +1,190 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/database/sqldb"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+const (
+	selectCategoryColumns = "id, name, position, created_at, updated_at"
+)
+
+// SQLCategoryRepository implements repository.CategoryRepository on top of database/sql.
+// It shares the transactions of sqldb.TxManager with SQLItemRepository.
+type SQLCategoryRepository struct {
+	db sqldb.DBOperations
+}
+
+// NewSQLCategoryRepository creates a new instance of SQLCategoryRepository
+func NewSQLCategoryRepository(db sqldb.DBOperations) repository.CategoryRepository {
+	return &SQLCategoryRepository{
+		db: db,
+	}
+}
+
+// conn returns the transaction started by sqldb.TxManager, if any, or the pool.
+func (r *SQLCategoryRepository) conn(ctx context.Context) sqldb.DBOperations {
+	return sqldb.Conn(ctx, r.db)
+}
+
+// Create inserts a new category in the SQL repository
+func (r *SQLCategoryRepository) Create(ctx context.Context, category repository.Category) (repository.Category, error) {
+	id, err := normalizeID(category.ID)
+	if err != nil {
+		return repository.Category{}, err
+	}
+
+	now := now()
+	_, err = r.conn(ctx).ExecContext(ctx,
+		`INSERT INTO categories (id, name, position, created_at, updated_at) VALUES ($1, $2, $3, $4, $5)`,
+		id, category.Name, category.Position, now, now,
+	)
+	if err != nil {
+		return repository.Category{}, repository.HandleError(err)
+	}
+
+	return repository.Category{
+		ID:        id,
+		Name:      category.Name,
+		Position:  category.Position,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Update renames and moves a category and returns the stored row (UPDATE ... RETURNING).
+// A zero position keeps the stored one.
+func (r *SQLCategoryRepository) Update(ctx context.Context, category repository.Category) (repository.Category, error) {
+	id, err := normalizeID(category.ID)
+	if err != nil {
+		return repository.Category{}, err
+	}
+
+	var where whereBuilder
+	sets := `name = ` + where.arg(category.Name) + `, updated_at = ` + where.arg(now())
+	if category.Position != 0 {
+		sets += `, position = ` + where.arg(category.Position)
+	}
+	where.add("id = " + where.arg(id))
+
+	row := r.conn(ctx).QueryRowContext(ctx,
+		`UPDATE categories SET `+sets+where.String()+` RETURNING `+selectCategoryColumns,
+		where.args...,
+	)
+
+	updatedCategory, err := scanCategory(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.Category{}, repository.NewCategoryNotFoundError()
+	} else if err != nil {
+		return repository.Category{}, repository.HandleError(err)
+	}
+
+	return updatedCategory, nil
+}
+
+// Delete removes a category from the SQL repository
+func (r *SQLCategoryRepository) Delete(ctx context.Context, id string) error {
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.conn(ctx).ExecContext(ctx, `DELETE FROM categories WHERE id = $1`, id)
+	if err != nil {
+		return repository.HandleError(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return repository.HandleError(err)
+	}
+	if affected == 0 {
+		return repository.NewCategoryNotFoundError()
+	}
+
+	return nil
+}
+
+// Lock reports a missing category and locks its row until the end of the
+// transaction, if any, with an UPDATE that changes nothing like SQLListRepository.Lock
+func (r *SQLCategoryRepository) Lock(ctx context.Context, id string) error {
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.conn(ctx).ExecContext(ctx, `UPDATE categories SET id = id WHERE id = $1`, id)
+	if err != nil {
+		return repository.HandleError(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return repository.HandleError(err)
+	}
+	if affected == 0 {
+		return repository.NewCategoryNotFoundError()
+	}
+
+	return nil
+}
+
+// GetByID retrieves a category by its ID from the SQL repository
+func (r *SQLCategoryRepository) GetByID(ctx context.Context, id string) (repository.Category, error) {
+	id, err := normalizeID(id)
+	if err != nil {
+		return repository.Category{}, err
+	}
+
+	row := r.conn(ctx).QueryRowContext(ctx, `SELECT `+selectCategoryColumns+` FROM categories WHERE id = $1`, id)
+
+	category, err := scanCategory(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.Category{}, repository.NewCategoryNotFoundError()
+	} else if err != nil {
+		return repository.Category{}, repository.HandleError(err)
+	}
+
+	return category, nil
+}
+
+// List retrieves every category from the SQL repository, ordered by position, creation time and id
+func (r *SQLCategoryRepository) List(ctx context.Context) ([]repository.Category, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, `SELECT `+selectCategoryColumns+` FROM categories ORDER BY position, created_at, id`)
+	if err != nil {
+		return nil, repository.HandleError(err)
+	}
+	defer rows.Close()
+
+	categories := make([]repository.Category, 0)
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, repository.HandleError(err)
+		}
+		categories = append(categories, category)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, repository.HandleError(err)
+	}
+
+	return categories, nil
+}
+
+func scanCategory(row rowScanner) (repository.Category, error) {
+	var category repository.Category
+
+	err := row.Scan(&category.ID, &category.Name, &category.Position, &category.CreatedAt, &category.UpdatedAt)
+	if err != nil {
+		return repository.Category{}, err
+	}
+
+	category.CreatedAt = category.CreatedAt.UTC()
+	category.UpdatedAt = category.UpdatedAt.UTC()
+
+	return category, nil
+}
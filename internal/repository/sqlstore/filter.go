@@ -0,0 +1,164 @@
+package sqlstore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/collation"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+// whereBuilder accumulates the conditions of a WHERE clause and their
+// numbered ($1, $2, ...) arguments.
+type whereBuilder struct {
+	conditions []string
+	args       []any
+}
+
+// arg adds an argument and returns its placeholder.
+func (w *whereBuilder) arg(value any) string {
+	w.args = append(w.args, value)
+	return fmt.Sprintf("$%d", len(w.args))
+}
+
+func (w *whereBuilder) add(condition string) {
+	w.conditions = append(w.conditions, condition)
+}
+
+func (w *whereBuilder) String() string {
+	if len(w.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(w.conditions, " AND ")
+}
+
+// addVersion selects the item by id and, when version is not zero, only if it
+// still has that version.
+func (w *whereBuilder) addVersion(id string, version int64) {
+	w.add("id = " + w.arg(id))
+	if version != 0 {
+		w.add("version = " + w.arg(version))
+	}
+}
+
+// addIDs selects the rows with one of the given ids.
+func (w *whereBuilder) addIDs(ids []string) {
+	placeholders := make([]string, len(ids))
+	for i, id := range ids {
+		placeholders[i] = w.arg(id)
+	}
+	w.add("id IN (" + strings.Join(placeholders, ", ") + ")")
+}
+
+// addFilter adds the conditions of a repository.ItemFilter.
+func (w *whereBuilder) addFilter(f repository.ItemFilter) {
+	if f.ListID != "" {
+		w.add("list_id = " + w.arg(f.ListID))
+	}
+	if f.Active != nil {
+		w.add("active = " + w.arg(*f.Active))
+	}
+	if f.Name != "" {
+		// LOWER only folds ASCII letters in SQLite
+		w.add(`LOWER(name) LIKE ` + w.arg("%"+escapeLike(strings.ToLower(f.Name))+"%") + ` ESCAPE '\'`)
+	}
+	if f.HasObservation != nil {
+		if *f.HasObservation {
+			w.add("(observation IS NOT NULL AND observation <> '')")
+		} else {
+			w.add("(observation IS NULL OR observation = '')")
+		}
+	}
+	if f.CreatedAfter != nil {
+		w.add("created_at > " + w.arg(f.CreatedAfter.UTC()))
+	}
+	if f.UpdatedBefore != nil {
+		w.add("updated_at < " + w.arg(f.UpdatedBefore.UTC()))
+	}
+}
+
+// addSearchTerms selects the rows whose folded name or observation contain one
+// of the folded search terms, the only ones the pure-Go search can score
+func (w *whereBuilder) addSearchTerms(terms []string) {
+	conditions := make([]string, 0, 2*len(terms))
+	for _, term := range terms {
+		pattern := w.arg("%" + escapeLike(term) + "%")
+		for _, column := range []string{"name", "observation"} {
+			conditions = append(conditions, fmt.Sprintf(`%s(%s) LIKE %s ESCAPE '\'`, collation.FoldFunction, column, pattern))
+		}
+	}
+	w.add("(" + strings.Join(conditions, " OR ") + ")")
+}
+
+// addCursor restricts the rows to the ones after the cursor position in the given
+// order: (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ... with id as the last key.
+func (w *whereBuilder) addCursor(cursor *repository.Cursor, sort []repository.SortField) {
+	if cursor == nil {
+		return
+	}
+
+	position := cursor.Item()
+	var (
+		equal       []string
+		alternative []string
+	)
+	keys := append(append([]repository.SortField{}, sort...), repository.SortField{Field: sortFieldID})
+	for _, s := range keys {
+		column, value := sortColumns[s.Field], w.arg(sortValue(position, s.Field))
+		operator := " > "
+		if s.Desc {
+			operator = " < "
+		}
+		alternative = append(alternative, "("+strings.Join(append(equal, column+operator+value), " AND ")+")")
+		equal = append(equal, column+" = "+value)
+	}
+	w.add("(" + strings.Join(alternative, " OR ") + ")")
+}
+
+// sortFieldID is the id tiebreaker that ends every order.
+const sortFieldID = "id"
+
+// sortColumns maps the sort fields to their columns; names use the collation
+// registered by the client (case and accent insensitive).
+var sortColumns = map[string]string{
+	repository.SortFieldName:      "name COLLATE " + collation.Name,
+	repository.SortFieldActive:    "active",
+	repository.SortFieldCreatedAt: "created_at",
+	repository.SortFieldUpdatedAt: "updated_at",
+	repository.SortFieldPosition:  "position",
+	sortFieldID:                   "id",
+}
+
+func sortValue(item repository.Item, field string) any {
+	switch field {
+	case repository.SortFieldName:
+		return item.Name
+	case repository.SortFieldActive:
+		return item.Active
+	case repository.SortFieldCreatedAt:
+		return item.CreatedAt.UTC()
+	case repository.SortFieldUpdatedAt:
+		return item.UpdatedAt.UTC()
+	case repository.SortFieldPosition:
+		return item.Position
+	}
+	return item.ID
+}
+
+// orderBy returns the ORDER BY expressions of the sort, ending with id.
+func orderBy(sort []repository.SortField) string {
+	terms := make([]string, 0, len(sort)+1)
+	for _, s := range sort {
+		term := sortColumns[s.Field]
+		if s.Desc {
+			term += " DESC"
+		}
+		terms = append(terms, term)
+	}
+	return strings.Join(append(terms, "id"), ", ")
+}
+
+// escapeLike escapes the LIKE wildcards so the name is matched literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,181 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/database/sqldb"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+const (
+	selectListColumns = "id, name, created_at, updated_at"
+)
+
+// SQLListRepository implements repository.ListRepository on top of database/sql.
+// It shares the transactions of sqldb.TxManager with SQLItemRepository.
+type SQLListRepository struct {
+	db sqldb.DBOperations
+}
+
+// NewSQLListRepository creates a new instance of SQLListRepository
+func NewSQLListRepository(db sqldb.DBOperations) repository.ListRepository {
+	return &SQLListRepository{
+		db: db,
+	}
+}
+
+// conn returns the transaction started by sqldb.TxManager, if any, or the pool.
+func (r *SQLListRepository) conn(ctx context.Context) sqldb.DBOperations {
+	return sqldb.Conn(ctx, r.db)
+}
+
+// Create inserts a new list in the SQL repository
+func (r *SQLListRepository) Create(ctx context.Context, list repository.List) (repository.List, error) {
+	id, err := normalizeID(list.ID)
+	if err != nil {
+		return repository.List{}, err
+	}
+
+	now := now()
+	_, err = r.conn(ctx).ExecContext(ctx,
+		`INSERT INTO lists (id, name, created_at, updated_at) VALUES ($1, $2, $3, $4)`,
+		id, list.Name, now, now,
+	)
+	if err != nil {
+		return repository.List{}, repository.HandleError(err)
+	}
+
+	return repository.List{
+		ID:        id,
+		Name:      list.Name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Update renames a list and returns the stored row (UPDATE ... RETURNING)
+func (r *SQLListRepository) Update(ctx context.Context, list repository.List) (repository.List, error) {
+	id, err := normalizeID(list.ID)
+	if err != nil {
+		return repository.List{}, err
+	}
+
+	row := r.conn(ctx).QueryRowContext(ctx,
+		`UPDATE lists SET name = $1, updated_at = $2 WHERE id = $3 RETURNING `+selectListColumns,
+		list.Name, now(), id,
+	)
+
+	updatedList, err := scanList(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.List{}, repository.NewListNotFoundError()
+	} else if err != nil {
+		return repository.List{}, repository.HandleError(err)
+	}
+
+	return updatedList, nil
+}
+
+// Delete removes a list from the SQL repository
+func (r *SQLListRepository) Delete(ctx context.Context, id string) error {
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.conn(ctx).ExecContext(ctx, `DELETE FROM lists WHERE id = $1`, id)
+	if err != nil {
+		return repository.HandleError(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return repository.HandleError(err)
+	}
+	if affected == 0 {
+		return repository.NewListNotFoundError()
+	}
+
+	return nil
+}
+
+// Lock reports a missing list and locks its row until the end of the transaction,
+// if any, with an UPDATE that changes nothing (SQLite has no SELECT ... FOR SHARE)
+func (r *SQLListRepository) Lock(ctx context.Context, id string) error {
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.conn(ctx).ExecContext(ctx, `UPDATE lists SET id = id WHERE id = $1`, id)
+	if err != nil {
+		return repository.HandleError(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return repository.HandleError(err)
+	}
+	if affected == 0 {
+		return repository.NewListNotFoundError()
+	}
+
+	return nil
+}
+
+// GetByID retrieves a list by its ID from the SQL repository
+func (r *SQLListRepository) GetByID(ctx context.Context, id string) (repository.List, error) {
+	id, err := normalizeID(id)
+	if err != nil {
+		return repository.List{}, err
+	}
+
+	row := r.conn(ctx).QueryRowContext(ctx, `SELECT `+selectListColumns+` FROM lists WHERE id = $1`, id)
+
+	list, err := scanList(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.List{}, repository.NewListNotFoundError()
+	} else if err != nil {
+		return repository.List{}, repository.HandleError(err)
+	}
+
+	return list, nil
+}
+
+// List retrieves every list from the SQL repository, ordered by creation time and id
+func (r *SQLListRepository) List(ctx context.Context) ([]repository.List, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, `SELECT `+selectListColumns+` FROM lists ORDER BY created_at, id`)
+	if err != nil {
+		return nil, repository.HandleError(err)
+	}
+	defer rows.Close()
+
+	lists := make([]repository.List, 0)
+	for rows.Next() {
+		list, err := scanList(rows)
+		if err != nil {
+			return nil, repository.HandleError(err)
+		}
+		lists = append(lists, list)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, repository.HandleError(err)
+	}
+
+	return lists, nil
+}
+
+func scanList(row rowScanner) (repository.List, error) {
+	var list repository.List
+
+	err := row.Scan(&list.ID, &list.Name, &list.CreatedAt, &list.UpdatedAt)
+	if err != nil {
+		return repository.List{}, err
+	}
+
+	list.CreatedAt = list.CreatedAt.UTC()
+	list.UpdatedAt = list.UpdatedAt.UTC()
+
+	return list, nil
+}
@@ -0,0 +1,523 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/database/sqldb"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+const (
+	selectItemColumns = "id, list_id, name, active, observation, quantity, unit, category_id, unit_price, currency, position, created_at, updated_at, version"
+)
+
+// SQLItemRepository implements repository.ItemRepository on top of database/sql.
+// The queries only use SQL understood by both PostgreSQL and SQLite.
+type SQLItemRepository struct {
+	db sqldb.DBOperations
+}
+
+// NewSQLItemRepository creates a new instance of SQLItemRepository
+func NewSQLItemRepository(db sqldb.DBOperations) repository.ItemRepository {
+	return &SQLItemRepository{
+		db: db,
+	}
+}
+
+// conn returns the transaction started by sqldb.TxManager, if any, or the pool.
+func (r *SQLItemRepository) conn(ctx context.Context) sqldb.DBOperations {
+	return sqldb.Conn(ctx, r.db)
+}
+
+// Create inserts a new item in the SQL repository
+func (r *SQLItemRepository) Create(ctx context.Context, item repository.Item) (repository.Item, error) {
+	id, err := normalizeID(item.ID)
+	if err != nil {
+		return repository.Item{}, err
+	}
+
+	now := now()
+	_, err = r.conn(ctx).ExecContext(ctx,
+		`INSERT INTO items (id, list_id, name, active, observation, quantity, unit, category_id, unit_price, currency, position, created_at, updated_at, version) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, 1)`,
+		id, item.ListID, item.Name, item.Active, item.Observation, item.Quantity, nullUnit(item.Quantity, item.Unit), nullString(item.CategoryID),
+		item.UnitPrice, nullCurrency(item.UnitPrice, item.Currency), item.Position, now, now,
+	)
+	if err != nil {
+		return repository.Item{}, handleWriteError(err)
+	}
+
+	return repository.Item{
+		ID:          id,
+		ListID:      item.ListID,
+		Name:        item.Name,
+		Active:      item.Active,
+		Observation: item.Observation,
+		Quantity:    item.Quantity,
+		Unit:        nullUnit(item.Quantity, item.Unit).String,
+		CategoryID:  item.CategoryID,
+		UnitPrice:   item.UnitPrice,
+		Currency:    nullCurrency(item.UnitPrice, item.Currency).String,
+		Position:    item.Position,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Version:     1,
+	}, nil
+}
+
+// Update modifies an existing item in the SQL repository and returns the stored
+// row (UPDATE ... RETURNING). A nil observation, quantity or unit price, or an empty category, keeps the stored one, as in
+// the MongoDB repository.
+func (r *SQLItemRepository) Update(ctx context.Context, item repository.Item) (repository.Item, error) {
+	id, err := normalizeID(item.ID)
+	if err != nil {
+		return repository.Item{}, err
+	}
+
+	var where whereBuilder
+	sets := fmt.Sprintf(`name = %s, active = %s, observation = COALESCE(%s, observation), updated_at = %s, version = version + 1`,
+		where.arg(item.Name), where.arg(item.Active), where.arg(item.Observation), where.arg(now()))
+	if item.Quantity != nil {
+		sets += fmt.Sprintf(`, quantity = %s, unit = %s`, where.arg(*item.Quantity), where.arg(item.Unit))
+	}
+	if item.CategoryID != "" {
+		sets += `, category_id = ` + where.arg(item.CategoryID)
+	}
+	if item.UnitPrice != nil {
+		sets += fmt.Sprintf(`, unit_price = %s, currency = %s`, where.arg(*item.UnitPrice), where.arg(item.Currency))
+	}
+	where.addVersion(id, item.Version)
+
+	row := r.conn(ctx).QueryRowContext(ctx,
+		`UPDATE items SET `+sets+where.String()+` RETURNING `+selectItemColumns,
+		where.args...,
+	)
+
+	updatedItem, err := scanItem(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.Item{}, r.missingOrConflict(ctx, id, item.Version)
+	} else if err != nil {
+		return repository.Item{}, repository.HandleError(err)
+	}
+
+	return updatedItem, nil
+}
+
+// Patch changes only the fields set in patch with a single UPDATE ... RETURNING,
+// so concurrent patches of different fields do not overwrite each other
+func (r *SQLItemRepository) Patch(ctx context.Context, id string, patch repository.ItemPatch) (repository.Item, error) {
+	id, err := normalizeID(id)
+	if err != nil {
+		return repository.Item{}, err
+	}
+
+	var where whereBuilder
+	sets := []string{"updated_at = " + where.arg(now()), "version = version + 1"}
+	if patch.Name != nil {
+		sets = append(sets, "name = "+where.arg(*patch.Name))
+	}
+	if patch.Active != nil {
+		sets = append(sets, "active = "+where.arg(*patch.Active))
+	}
+	if patch.Position != nil {
+		sets = append(sets, "position = "+where.arg(*patch.Position))
+	}
+	if patch.SetObservation {
+		sets = append(sets, "observation = "+where.arg(patch.Observation))
+	}
+	if patch.SetQuantity {
+		sets = append(sets, "quantity = "+where.arg(patch.Quantity), "unit = "+where.arg(nullUnit(patch.Quantity, patch.Unit)))
+	}
+	if patch.SetCategory {
+		sets = append(sets, "category_id = "+where.arg(patch.CategoryID))
+	}
+	if patch.SetPrice {
+		sets = append(sets, "unit_price = "+where.arg(patch.UnitPrice), "currency = "+where.arg(nullCurrency(patch.UnitPrice, patch.Currency)))
+	}
+	where.addVersion(id, patch.Version)
+
+	row := r.conn(ctx).QueryRowContext(ctx,
+		`UPDATE items SET `+strings.Join(sets, ", ")+where.String()+` RETURNING `+selectItemColumns,
+		where.args...,
+	)
+
+	patchedItem, err := scanItem(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.Item{}, r.missingOrConflict(ctx, id, patch.Version)
+	} else if err != nil {
+		return repository.Item{}, handleWriteError(err)
+	}
+
+	return patchedItem, nil
+}
+
+// Delete removes an item from the SQL repository
+func (r *SQLItemRepository) Delete(ctx context.Context, id string, version int64) error {
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
+	}
+
+	var where whereBuilder
+	where.addVersion(id, version)
+
+	result, err := r.conn(ctx).ExecContext(ctx, `DELETE FROM items`+where.String(), where.args...)
+	if err != nil {
+		return repository.HandleError(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return repository.HandleError(err)
+	}
+	if affected == 0 {
+		return r.missingOrConflict(ctx, id, version)
+	}
+
+	return nil
+}
+
+// positionIndex is the unique index of the positions of the items of a list
+const positionIndex = "idx_items_list_position"
+
+// handleWriteError returns the position conflict error when the write breaks the
+// unique index of the positions, and handles the other errors like repository.HandleError.
+// PostgreSQL names the index in the error, SQLite its columns.
+func handleWriteError(err error) error {
+	if message := err.Error(); strings.Contains(message, `"`+positionIndex+`"`) || strings.Contains(message, "items.list_id, items.position") {
+		return repository.NewPositionConflictError()
+	}
+	return repository.HandleError(err)
+}
+
+// missingOrConflict tells why a write conditioned on the id and version of an
+// item changed no row: the item does not exist, or it has another version
+func (r *SQLItemRepository) missingOrConflict(ctx context.Context, id string, version int64) error {
+	if version == 0 {
+		return repository.NewItemNotFoundError()
+	}
+
+	var exists int
+	err := r.conn(ctx).QueryRowContext(ctx, `SELECT 1 FROM items WHERE id = $1`, id).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.NewItemNotFoundError()
+	} else if err != nil {
+		return repository.HandleError(err)
+	}
+
+	return repository.NewVersionConflictError()
+}
+
+// GetByID retrieves an item by its ID from the SQL repository
+func (r *SQLItemRepository) GetByID(ctx context.Context, id string) (repository.Item, error) {
+	id, err := normalizeID(id)
+	if err != nil {
+		return repository.Item{}, err
+	}
+
+	row := r.conn(ctx).QueryRowContext(ctx, `SELECT `+selectItemColumns+` FROM items WHERE id = $1`, id)
+
+	item, err := scanItem(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.Item{}, repository.NewItemNotFoundError()
+	} else if err != nil {
+		return repository.Item{}, repository.HandleError(err)
+	}
+
+	return item, nil
+}
+
+// List retrieves a page of the items matching opts.Filter from the SQL repository,
+// ordered by opts.Sort and id and starting after opts.Cursor
+func (r *SQLItemRepository) List(ctx context.Context, opts repository.ListOptions) (repository.ItemPage, error) {
+	sortFields, err := repository.NormalizeSort(opts.Sort)
+	if err != nil {
+		return repository.ItemPage{}, err
+	}
+	cursor, err := repository.DecodeCursor(opts.Cursor, sortFields)
+	if err != nil {
+		return repository.ItemPage{}, err
+	}
+
+	var where whereBuilder
+	where.addFilter(opts.Filter)
+	where.addCursor(cursor, sortFields)
+
+	query := `SELECT ` + selectItemColumns + ` FROM items` + where.String() + ` ORDER BY ` + orderBy(sortFields)
+	if opts.Limit > 0 {
+		// One more item tells whether there is a next page
+		query += fmt.Sprintf(` LIMIT %d`, opts.Limit+1)
+	}
+
+	rows, err := r.conn(ctx).QueryContext(ctx, query, where.args...)
+	if err != nil {
+		return repository.ItemPage{}, repository.HandleError(err)
+	}
+	defer rows.Close()
+
+	items := make([]repository.Item, 0)
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return repository.ItemPage{}, repository.HandleError(err)
+		}
+		items = append(items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return repository.ItemPage{}, repository.HandleError(err)
+	}
+
+	return repository.NewItemPage(items, opts.Limit, sortFields), nil
+}
+
+// Search retrieves the items matching query from the SQL repository. Neither
+// SQLite nor PostgreSQL fold Portuguese diacritics out of the box, so the rows
+// containing one of the folded words (see collation.FoldFunction) are selected
+// with LIKE and scored with the pure-Go search.
+func (r *SQLItemRepository) Search(ctx context.Context, query, listID string, limit int) ([]repository.Item, error) {
+	terms := repository.SearchTerms(query)
+	if len(terms) == 0 {
+		return []repository.Item{}, nil
+	}
+
+	var where whereBuilder
+	where.addFilter(repository.ItemFilter{ListID: listID})
+	where.addSearchTerms(terms)
+
+	rows, err := r.conn(ctx).QueryContext(ctx, `SELECT `+selectItemColumns+` FROM items`+where.String(), where.args...)
+	if err != nil {
+		return nil, repository.HandleError(err)
+	}
+	defer rows.Close()
+
+	var items []repository.Item
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, repository.HandleError(err)
+		}
+		items = append(items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, repository.HandleError(err)
+	}
+
+	return repository.SearchItems(items, query, limit), nil
+}
+
+// BulkUpdateActive updates the active field of the selected rows with a single UPDATE.
+// Every matched row is reported as modified because updated_at always changes.
+func (r *SQLItemRepository) BulkUpdateActive(ctx context.Context, update repository.ActiveUpdate) (repository.ActiveUpdateResult, error) {
+	ids := make([]string, len(update.IDs))
+	for i, id := range update.IDs {
+		normalized, err := normalizeID(id)
+		if err != nil {
+			return repository.ActiveUpdateResult{}, err
+		}
+		ids[i] = normalized
+	}
+
+	var where whereBuilder
+	sets := `active = ` + where.arg(update.Active) + `, updated_at = ` + where.arg(now()) + `, version = version + 1`
+
+	if len(ids) == 0 {
+		where.addFilter(update.Filter)
+		result, err := r.conn(ctx).ExecContext(ctx, `UPDATE items SET `+sets+where.String(), where.args...)
+		if err != nil {
+			return repository.ActiveUpdateResult{}, repository.HandleError(err)
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return repository.ActiveUpdateResult{}, repository.HandleError(err)
+		}
+		return repository.ActiveUpdateResult{MatchedCount: affected, ModifiedCount: affected}, nil
+	}
+
+	// The updated ids tell which of the requested ones don't exist
+	where.addIDs(ids)
+	if update.Filter.ListID != "" {
+		where.add("list_id = " + where.arg(update.Filter.ListID))
+	}
+	rows, err := r.conn(ctx).QueryContext(ctx, `UPDATE items SET `+sets+where.String()+` RETURNING id`, where.args...)
+	if err != nil {
+		return repository.ActiveUpdateResult{}, repository.HandleError(err)
+	}
+	defer rows.Close()
+
+	updated := make(map[string]bool, len(ids))
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return repository.ActiveUpdateResult{}, repository.HandleError(err)
+		}
+		updated[id] = true
+	}
+	if err = rows.Err(); err != nil {
+		return repository.ActiveUpdateResult{}, repository.HandleError(err)
+	}
+
+	result := repository.ActiveUpdateResult{MatchedCount: int64(len(updated)), ModifiedCount: int64(len(updated))}
+	for i, id := range ids {
+		if !updated[id] {
+			result.NotFoundIDs = append(result.NotFoundIDs, update.IDs[i])
+		}
+	}
+	return result, nil
+}
+
+// DeleteMany removes the rows matching filter with a single DELETE
+func (r *SQLItemRepository) DeleteMany(ctx context.Context, filter repository.ItemFilter) (int64, error) {
+	var where whereBuilder
+	where.addFilter(filter)
+
+	result, err := r.conn(ctx).ExecContext(ctx, `DELETE FROM items`+where.String(), where.args...)
+	if err != nil {
+		return 0, repository.HandleError(err)
+	}
+
+	deletedCount, err := result.RowsAffected()
+	if err != nil {
+		return 0, repository.HandleError(err)
+	}
+
+	return deletedCount, nil
+}
+
+// ClearCategory removes the category of the rows of categoryID with a single UPDATE
+func (r *SQLItemRepository) ClearCategory(ctx context.Context, categoryID string) (int64, error) {
+	result, err := r.conn(ctx).ExecContext(ctx,
+		`UPDATE items SET category_id = NULL, updated_at = $1, version = version + 1 WHERE category_id = $2`,
+		now(), categoryID,
+	)
+	if err != nil {
+		return 0, repository.HandleError(err)
+	}
+
+	changedCount, err := result.RowsAffected()
+	if err != nil {
+		return 0, repository.HandleError(err)
+	}
+
+	return changedCount, nil
+}
+
+// SumPrices sums the line totals of the priced rows matching filter with a
+// single GROUP BY. The quantities are stored in thousandths, so the sums are
+// integers (cents times QuantityScale) in both databases and stay exact.
+func (r *SQLItemRepository) SumPrices(ctx context.Context, filter repository.ItemFilter) ([]domain.PriceTotal, error) {
+	var where whereBuilder
+	where.addFilter(filter)
+	where.add("unit_price IS NOT NULL")
+
+	query := fmt.Sprintf(`SELECT currency, category_id, active, SUM(unit_price * COALESCE(quantity, %d)) FROM items%s GROUP BY currency, category_id, active`,
+		repository.QuantityScale, where.String())
+	rows, err := r.conn(ctx).QueryContext(ctx, query, where.args...)
+	if err != nil {
+		return nil, repository.HandleError(err)
+	}
+	defer rows.Close()
+
+	totals := make([]domain.PriceTotal, 0)
+	for rows.Next() {
+		var (
+			total      domain.PriceTotal
+			categoryID sql.NullString
+			sum        string
+		)
+		if err = rows.Scan(&total.Currency, &categoryID, &total.Active, &sum); err != nil {
+			return nil, repository.HandleError(err)
+		}
+		total.CategoryID = categoryID.String
+		if total.Cents, err = repository.ParseScaledCents(sum, repository.QuantityScale); err != nil {
+			return nil, repository.HandleError(err)
+		}
+		totals = append(totals, total)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, repository.HandleError(err)
+	}
+
+	return totals, nil
+}
+
+// Batch applies ops one after the other, each one like its single item method.
+// Inside a transaction they all run on its connection.
+func (r *SQLItemRepository) Batch(ctx context.Context, ops []repository.BatchOperation, stopOnError bool) ([]repository.BatchResult, error) {
+	return repository.RunBatch(ctx, r, ops, stopOnError)
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanItem(row rowScanner) (repository.Item, error) {
+	var (
+		item        repository.Item
+		observation sql.NullString
+		quantity    sql.NullInt64
+		unit        sql.NullString
+		categoryID  sql.NullString
+		unitPrice   sql.NullInt64
+		currency    sql.NullString
+	)
+
+	err := row.Scan(&item.ID, &item.ListID, &item.Name, &item.Active, &observation, &quantity, &unit, &categoryID, &unitPrice, &currency, &item.Position, &item.CreatedAt, &item.UpdatedAt, &item.Version)
+	if err != nil {
+		return repository.Item{}, err
+	}
+
+	if observation.Valid {
+		item.Observation = &observation.String
+	}
+	if quantity.Valid {
+		item.Quantity = &quantity.Int64
+		item.Unit = unit.String
+	}
+	item.CategoryID = categoryID.String
+	if unitPrice.Valid {
+		item.UnitPrice = &unitPrice.Int64
+		item.Currency = currency.String
+	}
+	item.CreatedAt = item.CreatedAt.UTC()
+	item.UpdatedAt = item.UpdatedAt.UTC()
+
+	return item, nil
+}
+
+// nullUnit is the unit column of an item: NULL when it has no quantity
+func nullUnit(quantity *int64, unit string) sql.NullString {
+	return sql.NullString{String: unit, Valid: quantity != nil}
+}
+
+// nullCurrency is the currency column of an item: NULL when it has no unit price
+func nullCurrency(unitPrice *int64, currency string) sql.NullString {
+	return sql.NullString{String: currency, Valid: unitPrice != nil}
+}
+
+// nullString stores an empty string as NULL
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// normalizeID validates the ID the same way the MongoDB repository does and
+// returns its canonical (lowercase) hexadecimal form.
+func normalizeID(id string) (string, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return "", repository.NewInvalidHexIDError()
+	}
+	return objectID.Hex(), nil
+}
+
+// now returns the current time with the precision MongoDB stores (milliseconds).
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+)
+
+// TxManager runs a unit of work atomically. Repository calls made with the
+// context received by fn take part in the transaction; if fn returns an error
+// every change is rolled back. Transient failures (e.g. write conflicts) are
+// retried, so fn may run more than once and must not have side effects outside
+// the repositories. Nested calls join the outer transaction.
+type TxManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
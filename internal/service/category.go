@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+)
+
+type CategoryService interface {
+	CreateCategory(ctx context.Context, category domain.Category) (domain.Category, error)
+	GetCategory(ctx context.Context, id string) (domain.Category, error)
+	UpdateCategory(ctx context.Context, category domain.Category) (domain.Category, error)
+	DeleteCategory(ctx context.Context, id string) error
+	ListCategories(ctx context.Context) ([]domain.Category, error)
+}
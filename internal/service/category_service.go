@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+type categoryService struct {
+	categories repository.CategoryRepository
+	// items lose their category when it is deleted
+	items repository.ItemRepository
+	// txManager deletes a category and uncategorizes its items atomically
+	txManager repository.TxManager
+	parser    parser
+}
+
+func NewCategoryService(categories repository.CategoryRepository, items repository.ItemRepository, txManager repository.TxManager) CategoryService {
+	return &categoryService{
+		categories: categories,
+		items:      items,
+		txManager:  txManager,
+		parser:     parser{},
+	}
+}
+
+// CreateCategory creates a category, after the last one when it has no position
+func (s *categoryService) CreateCategory(ctx context.Context, category domain.Category) (domain.Category, error) {
+	newCategory := domain.NewCategory(strings.TrimSpace(category.Name), category.Position)
+	if err := newCategory.Validate(); err != nil {
+		return domain.Category{}, NewErrorInvalidCategory(err)
+	}
+
+	if newCategory.Position == 0 {
+		categories, err := s.ListCategories(ctx)
+		if err != nil {
+			return domain.Category{}, err
+		}
+		newCategory.Position = domain.NextCategoryPosition(categories)
+	}
+
+	createdCategory, err := s.categories.Create(ctx, s.parser.toRepositoryCategory(newCategory))
+	if err != nil {
+		log.Printf("failed to create category: %s: %v", category.Name, err)
+		return domain.Category{}, handleError(err)
+	}
+
+	return s.parser.toDomainCategory(createdCategory), nil
+}
+
+func (s *categoryService) GetCategory(ctx context.Context, id string) (domain.Category, error) {
+	category, err := s.categories.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("failed to get category: %s: %v", id, err)
+		return domain.Category{}, handleError(err)
+	}
+
+	return s.parser.toDomainCategory(category), nil
+}
+
+// UpdateCategory renames or moves a category; a zero position keeps the stored one
+func (s *categoryService) UpdateCategory(ctx context.Context, category domain.Category) (domain.Category, error) {
+	category.Name = strings.TrimSpace(category.Name)
+	if err := category.Validate(); err != nil {
+		return domain.Category{}, NewErrorInvalidCategory(err)
+	}
+
+	updatedCategory, err := s.categories.Update(ctx, s.parser.toRepositoryCategory(category))
+	if err != nil {
+		log.Printf("failed to update category: %s: %v", category.ID, err)
+		return domain.Category{}, handleError(err)
+	}
+
+	return s.parser.toDomainCategory(updatedCategory), nil
+}
+
+// DeleteCategory removes a category. Its items are kept, without a category.
+func (s *categoryService) DeleteCategory(ctx context.Context, id string) error {
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.categories.Delete(ctx, id); err != nil {
+			return err
+		}
+		_, err := s.items.ClearCategory(ctx, id)
+		return err
+	})
+	if err != nil {
+		log.Printf("failed to delete category: %s: %v", id, err)
+		return handleError(err)
+	}
+	return nil
+}
+
+// ListCategories returns every category, ordered by position
+func (s *categoryService) ListCategories(ctx context.Context) ([]domain.Category, error) {
+	categories, err := s.categories.List(ctx)
+	if err != nil {
+		log.Printf("failed to list categories: %v", err)
+		return nil, handleError(err)
+	}
+
+	domainCategories := make([]domain.Category, len(categories))
+	for i, category := range categories {
+		domainCategories[i] = s.parser.toDomainCategory(category)
+	}
+	return domainCategories, nil
+}
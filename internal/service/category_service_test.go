@@ -0,0 +1,205 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/service"
+)
+
+const (
+	_dummyCategoryID = "789"
+)
+
+func TestCreateCategory(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenCategory domain.Category
+		givenStored   []repository.Category
+		wantListed    bool
+		wantPosition  int
+		wantCategory  domain.Category
+		wantErr       error
+	}{
+		{
+			name:          "Given_CategoryWithoutPosition_When_CreateCategory_Then_ExpectedAfterTheLastOne",
+			givenCategory: domain.Category{Name: " Dairy "},
+			givenStored:   []repository.Category{{ID: "1", Name: "Produce", Position: 1}, {ID: "2", Name: "Bakery", Position: 4}},
+			wantListed:    true,
+			wantPosition:  5,
+			wantCategory:  domain.Category{ID: _dummyCategoryID, Name: "Dairy", Position: 5},
+		},
+		{
+			name:          "Given_CategoryWithPosition_When_CreateCategory_Then_ExpectedGivenPosition",
+			givenCategory: domain.Category{Name: "Dairy", Position: 2},
+			wantPosition:  2,
+			wantCategory:  domain.Category{ID: _dummyCategoryID, Name: "Dairy", Position: 2},
+		},
+		{
+			name:          "Given_BlankName_When_CreateCategory_Then_ExpectedInvalidCategoryError",
+			givenCategory: domain.Category{Name: "  "},
+			wantErr:       service.NewErrorInvalidCategory(domain.ErrEmptyCategoryName),
+		},
+		{
+			name:          "Given_NegativePosition_When_CreateCategory_Then_ExpectedInvalidCategoryError",
+			givenCategory: domain.Category{Name: "Dairy", Position: -1},
+			wantErr:       service.NewErrorInvalidCategory(domain.ErrInvalidCategoryPosition),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockCategories := &repository.CategoryRepositoryMock{}
+			if tt.wantListed {
+				mockCategories.On("List", ctx).Return(tt.givenStored, nil)
+			}
+			if tt.wantPosition != 0 {
+				mockCategories.On("Create", ctx, mock.MatchedBy(func(category repository.Category) bool {
+					return category.ID != "" && category.Name == "Dairy" && category.Position == tt.wantPosition
+				})).Return(repository.Category{ID: _dummyCategoryID, Name: "Dairy", Position: tt.wantPosition}, nil)
+			}
+
+			svc := service.NewCategoryService(mockCategories, &repository.RepositoryMock{}, &repository.TxManagerMock{})
+			category, err := svc.CreateCategory(ctx, tt.givenCategory)
+
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantCategory, category)
+			mockCategories.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateCategory(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenCategory domain.Category
+		givenErr      error
+		wantUpdated   bool
+		wantCategory  domain.Category
+		wantErr       error
+	}{
+		{
+			name:          "Given_NewName_When_UpdateCategory_Then_ExpectedRenamedCategory",
+			givenCategory: domain.Category{ID: _dummyCategoryID, Name: "Dairy "},
+			wantUpdated:   true,
+			wantCategory:  domain.Category{ID: _dummyCategoryID, Name: "Dairy", Position: 3},
+		},
+		{
+			name:          "Given_EmptyName_When_UpdateCategory_Then_ExpectedInvalidCategoryError",
+			givenCategory: domain.Category{ID: _dummyCategoryID},
+			wantErr:       service.NewErrorInvalidCategory(domain.ErrEmptyCategoryName),
+		},
+		{
+			name:          "Given_MissingCategory_When_UpdateCategory_Then_ExpectedNotFoundError",
+			givenCategory: domain.Category{ID: _dummyCategoryID, Name: "Dairy"},
+			givenErr:      repository.NewCategoryNotFoundError(),
+			wantUpdated:   true,
+			wantErr:       service.NewErrorService(repository.NewCategoryNotFoundError(), "category not found", service.RepositorySource, http.StatusNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockCategories := &repository.CategoryRepositoryMock{}
+			if tt.wantUpdated {
+				stored := repository.Category{}
+				if tt.givenErr == nil {
+					stored = repository.Category{ID: _dummyCategoryID, Name: "Dairy", Position: 3}
+				}
+				mockCategories.On("Update", ctx, repository.Category{ID: _dummyCategoryID, Name: "Dairy"}).Return(stored, tt.givenErr)
+			}
+
+			svc := service.NewCategoryService(mockCategories, &repository.RepositoryMock{}, &repository.TxManagerMock{})
+			category, err := svc.UpdateCategory(ctx, tt.givenCategory)
+
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantCategory, category)
+			mockCategories.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteCategory(t *testing.T) {
+	tests := []struct {
+		name           string
+		givenDeleteErr error
+		wantCleared    bool
+		wantErr        error
+	}{
+		{
+			name:        "Given_ExistingCategory_When_DeleteCategory_Then_ItsItemsAreUncategorized",
+			wantCleared: true,
+		},
+		{
+			name:           "Given_MissingCategory_When_DeleteCategory_Then_ExpectedNotFoundErrorAndItemsKept",
+			givenDeleteErr: repository.NewCategoryNotFoundError(),
+			wantErr:        service.NewErrorService(repository.NewCategoryNotFoundError(), "category not found", service.RepositorySource, http.StatusNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockCategories := &repository.CategoryRepositoryMock{}
+			mockCategories.On("Delete", ctx, _dummyCategoryID).Return(tt.givenDeleteErr)
+			mockRepo := &repository.RepositoryMock{}
+			if tt.wantCleared {
+				mockRepo.On("ClearCategory", ctx, _dummyCategoryID).Return(int64(2), nil)
+			}
+
+			svc := service.NewCategoryService(mockCategories, mockRepo, &repository.TxManagerMock{})
+			err := svc.DeleteCategory(ctx, _dummyCategoryID)
+
+			require.Equal(t, tt.wantErr, err)
+			mockCategories.AssertExpectations(t)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListCategories(t *testing.T) {
+	tests := []struct {
+		name           string
+		givenStored    []repository.Category
+		givenErr       error
+		wantCategories []domain.Category
+		wantErr        error
+	}{
+		{
+			name:           "Given_Categories_When_ListCategories_Then_ExpectedEveryCategory",
+			givenStored:    []repository.Category{{ID: "1", Name: "Produce", Position: 1}, {ID: "2", Name: "Dairy", Position: 2}},
+			wantCategories: []domain.Category{{ID: "1", Name: "Produce", Position: 1}, {ID: "2", Name: "Dairy", Position: 2}},
+		},
+		{
+			name:     "Given_DatabaseError_When_ListCategories_Then_ExpectedInternalError",
+			givenErr: repository.NewGenericRepositoryError(errDummy),
+			wantErr:  service.NewErrorService(repository.NewGenericRepositoryError(errDummy), "internal server error", service.RepositorySource, http.StatusInternalServerError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockCategories := &repository.CategoryRepositoryMock{}
+			mockCategories.On("List", ctx).Return(tt.givenStored, tt.givenErr)
+
+			svc := service.NewCategoryService(mockCategories, &repository.RepositoryMock{}, &repository.TxManagerMock{})
+			categories, err := svc.ListCategories(ctx)
+
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantCategories, categories)
+		})
+	}
+}
@@ -12,7 +12,16 @@ const (
 	RepositorySource = "repository"
 	ServiceSource    = "service"
 
-	_errEmptyItem = "item is empty"
+	_errEmptyItem           = "item is empty"
+	_errEmptyFilter         = "at least one filter is required to delete items"
+	_errInvalidBatch        = "invalid batch"
+	_errInvalidActiveUpdate = "invalid bulk active update"
+	_errInvalidOperation    = "invalid batch operation"
+	_errFailedDependency    = "not applied because another operation of the atomic batch failed"
+	_errConflict            = "item was changed by someone else"
+	_errDefaultList         = "the default list cannot be deleted"
+	_errUnknownCategory     = "category not found"
+	_errStaleNeighbours     = "after no longer comes before before, read the list again"
 )
 
 type ErrorService struct {
@@ -47,12 +56,163 @@ func NewErrorEmptyItem() error {
 	}
 }
 
+// NewErrorInvalidItem reports a change that would leave the item invalid
+func NewErrorInvalidItem(cause error) error {
+	return ErrorService{
+		Cause:   cause,
+		Message: cause.Error(),
+		Source:  ServiceSource,
+		HTTP:    http.StatusUnprocessableEntity,
+	}
+}
+
+// NewErrorInvalidList reports a list that would be left invalid, like one without a name
+func NewErrorInvalidList(cause error) error {
+	return ErrorService{
+		Cause:   cause,
+		Message: cause.Error(),
+		Source:  ServiceSource,
+		HTTP:    http.StatusUnprocessableEntity,
+	}
+}
+
+// NewErrorInvalidTotal reports a total of the items too large to be computed
+func NewErrorInvalidTotal(cause error) error {
+	return ErrorService{
+		Cause:   cause,
+		Message: cause.Error(),
+		Source:  ServiceSource,
+		HTTP:    http.StatusUnprocessableEntity,
+	}
+}
+
+// NewErrorDefaultList is returned when deleting the default list
+func NewErrorDefaultList() error {
+	return ErrorService{
+		Message: _errDefaultList,
+		Source:  ServiceSource,
+		HTTP:    http.StatusConflict,
+	}
+}
+
+// NewErrorInvalidCategory reports a category that would be left invalid, like one without a name
+func NewErrorInvalidCategory(cause error) error {
+	return ErrorService{
+		Cause:   cause,
+		Message: cause.Error(),
+		Source:  ServiceSource,
+		HTTP:    http.StatusUnprocessableEntity,
+	}
+}
+
+// NewErrorUnknownCategory is returned when an item is put in a category that doesn't exist
+func NewErrorUnknownCategory(cause error) error {
+	return ErrorService{
+		Cause:   cause,
+		Message: _errUnknownCategory,
+		Source:  ServiceSource,
+		HTTP:    http.StatusUnprocessableEntity,
+	}
+}
+
+// NewErrorInvalidMove reports a move that doesn't say where the item goes, or
+// next to items that aren't in its list
+func NewErrorInvalidMove(cause error) error {
+	return ErrorService{
+		Cause:   cause,
+		Message: cause.Error(),
+		Source:  ServiceSource,
+		HTTP:    http.StatusUnprocessableEntity,
+	}
+}
+
+// NewErrorStaleNeighbours is returned for a move between two items that were
+// reordered since the client read them
+func NewErrorStaleNeighbours() error {
+	return ErrorService{
+		Message: _errStaleNeighbours,
+		Source:  ServiceSource,
+		HTTP:    http.StatusConflict,
+	}
+}
+
+// NewErrorEmptyFilter is returned when deleting items without any filter, which would delete them all
+func NewErrorEmptyFilter() error {
+	return ErrorService{
+		Message: _errEmptyFilter,
+		Source:  ServiceSource,
+		HTTP:    http.StatusBadRequest,
+	}
+}
+
+// NewErrorInvalidActiveUpdate reports a bulk active update whose selection of items is invalid
+func NewErrorInvalidActiveUpdate(cause error) error {
+	return ErrorService{
+		Cause:   cause,
+		Message: _errInvalidActiveUpdate,
+		Source:  ServiceSource,
+		HTTP:    http.StatusBadRequest,
+	}
+}
+
+// NewErrorInvalidBatch reports a batch that can't be run at all, like an empty one
+func NewErrorInvalidBatch(cause error) error {
+	return ErrorService{
+		Cause:   cause,
+		Message: _errInvalidBatch,
+		Source:  ServiceSource,
+		HTTP:    http.StatusBadRequest,
+	}
+}
+
+// NewErrorInvalidOperation reports a batch operation that is rejected before reaching the repository
+func NewErrorInvalidOperation(cause error) error {
+	return ErrorService{
+		Cause:   cause,
+		Message: _errInvalidOperation,
+		Source:  ServiceSource,
+		HTTP:    http.StatusBadRequest,
+	}
+}
+
+// NewErrorFailedDependency is the result of the operations of an atomic batch rolled back by the failure of another one
+func NewErrorFailedDependency() error {
+	return ErrorService{
+		Message: _errFailedDependency,
+		Source:  ServiceSource,
+		HTTP:    http.StatusFailedDependency,
+	}
+}
+
+// ErrorConflict is returned when a change was based on a version of the item
+// that is no longer the current one. The client should read the item again.
+type ErrorConflict struct {
+	Cause error
+}
+
+func (e ErrorConflict) Error() string {
+	return fmt.Sprintf("message: %s, cause: %s", _errConflict, e.Cause)
+}
+
+func (e ErrorConflict) Unwrap() error {
+	return e.Cause
+}
+
+func NewErrorConflict(cause error) error {
+	return ErrorConflict{Cause: cause}
+}
+
 func handleError(err error) error {
 	var (
 		errService    ErrorService
+		errConflict   ErrorConflict
 		errRepository repository.Error
 	)
 	switch {
+	case errors.As(err, &errConflict):
+		return err
+	case errors.Is(err, repository.ErrVersionConflict):
+		return NewErrorConflict(err)
 	case errors.As(err, &errRepository):
 		// For generic repository errors (HTTP 500), use "internal server error"
 		// For specific repository errors (like 404), keep the original message
@@ -10,7 +10,16 @@ type ItemService interface {
 	CreateItem(ctx context.Context, item domain.Item) (domain.Item, error)
 	GetItem(ctx context.Context, id string) (domain.Item, error)
 	UpdateItem(ctx context.Context, item domain.Item) (domain.Item, error)
-	DeleteItem(ctx context.Context, id string) error
-	ListItems(ctx context.Context) ([]domain.Item, error)
-	BulkUpdateActive(ctx context.Context, active bool) (matchedCount int64, modifiedCount int64, err error)
+	PatchItem(ctx context.Context, id string, patch domain.ItemPatch) (domain.Item, error)
+	MoveItem(ctx context.Context, id string, move domain.ItemMove) (domain.Item, error)
+	DeleteItem(ctx context.Context, id string, version int64) error
+	ListItems(ctx context.Context, opts domain.ListOptions) (domain.ItemPage, error)
+	SearchItems(ctx context.Context, query, listID string, limit int) ([]domain.Item, error)
+	SuggestItemNames(ctx context.Context, prefix string, limit int) ([]domain.Suggestion, error)
+	BulkUpdateActive(ctx context.Context, update domain.ActiveUpdate) (domain.ActiveUpdateResult, error)
+	DeleteItems(ctx context.Context, filter domain.ItemFilter) (deletedCount int64, err error)
+	BatchItems(ctx context.Context, ops []domain.BatchOperation, atomic bool) ([]domain.BatchResult, error)
+	TotalQuantities(ctx context.Context, filter domain.ItemFilter) ([]domain.Quantity, error)
+	GroupItems(ctx context.Context, opts domain.ListOptions) ([]domain.ItemGroup, error)
+	SummarizePrices(ctx context.Context, filter domain.ItemFilter) ([]domain.PriceSummary, error)
 }
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+)
+
+type ListService interface {
+	CreateList(ctx context.Context, list domain.List) (domain.List, error)
+	GetList(ctx context.Context, id string) (domain.List, error)
+	UpdateList(ctx context.Context, list domain.List) (domain.List, error)
+	DeleteList(ctx context.Context, id string) error
+	ListLists(ctx context.Context) ([]domain.List, error)
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+)
+
+type listService struct {
+	lists repository.ListRepository
+	// items are removed along with their list
+	items repository.ItemRepository
+	// txManager deletes a list and its items atomically
+	txManager repository.TxManager
+	parser    parser
+}
+
+func NewListService(lists repository.ListRepository, items repository.ItemRepository, txManager repository.TxManager) ListService {
+	return &listService{
+		lists:     lists,
+		items:     items,
+		txManager: txManager,
+		parser:    parser{},
+	}
+}
+
+func (s *listService) CreateList(ctx context.Context, list domain.List) (domain.List, error) {
+	newList := domain.NewList(strings.TrimSpace(list.Name))
+	if err := newList.Validate(); err != nil {
+		return domain.List{}, NewErrorInvalidList(err)
+	}
+
+	createdList, err := s.lists.Create(ctx, s.parser.toRepositoryList(newList))
+	if err != nil {
+		log.Printf("failed to create list: %s: %v", list.Name, err)
+		return domain.List{}, handleError(err)
+	}
+
+	return s.parser.toDomainList(createdList), nil
+}
+
+func (s *listService) GetList(ctx context.Context, id string) (domain.List, error) {
+	list, err := s.lists.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("failed to get list: %s: %v", id, err)
+		return domain.List{}, handleError(err)
+	}
+
+	return s.parser.toDomainList(list), nil
+}
+
+// UpdateList renames a list, the default one included
+func (s *listService) UpdateList(ctx context.Context, list domain.List) (domain.List, error) {
+	list.Name = strings.TrimSpace(list.Name)
+	if err := list.Validate(); err != nil {
+		return domain.List{}, NewErrorInvalidList(err)
+	}
+
+	updatedList, err := s.lists.Update(ctx, s.parser.toRepositoryList(list))
+	if err != nil {
+		log.Printf("failed to update list: %s: %v", list.ID, err)
+		return domain.List{}, handleError(err)
+	}
+
+	return s.parser.toDomainList(updatedList), nil
+}
+
+// DeleteList removes a list with all of its items. The default list, which
+// holds the items of the routes that don't name a list, cannot be deleted.
+func (s *listService) DeleteList(ctx context.Context, id string) error {
+	if (domain.List{ID: id}).IsDefault() {
+		return NewErrorDefaultList()
+	}
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.lists.Delete(ctx, id); err != nil {
+			return err
+		}
+		_, err := s.items.DeleteMany(ctx, repository.ItemFilter{ListID: id})
+		return err
+	})
+	if err != nil {
+		log.Printf("failed to delete list: %s: %v", id, err)
+		return handleError(err)
+	}
+	return nil
+}
+
+func (s *listService) ListLists(ctx context.Context) ([]domain.List, error) {
+	lists, err := s.lists.List(ctx)
+	if err != nil {
+		log.Printf("failed to list lists: %v", err)
+		return nil, handleError(err)
+	}
+
+	domainLists := make([]domain.List, len(lists))
+	for i, list := range lists {
+		domainLists[i] = s.parser.toDomainList(list)
+	}
+	return domainLists, nil
+}
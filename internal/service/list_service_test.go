@@ -0,0 +1,244 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/service"
+)
+
+const (
+	_dummyListID = "456"
+)
+
+func TestCreateList(t *testing.T) {
+	tests := []struct {
+		name        string
+		givenList   domain.List
+		givenStored repository.List
+		givenErr    error
+		wantCreated bool
+		wantList    domain.List
+		wantErr     error
+	}{
+		{
+			name:        "Given_List_When_CreateList_Then_ExpectedTrimmedName",
+			givenList:   domain.List{Name: " Pharmacy "},
+			givenStored: repository.List{ID: _dummyListID, Name: "Pharmacy"},
+			wantCreated: true,
+			wantList:    domain.List{ID: _dummyListID, Name: "Pharmacy"},
+		},
+		{
+			name:      "Given_BlankName_When_CreateList_Then_ExpectedInvalidListError",
+			givenList: domain.List{Name: "  "},
+			wantErr:   service.NewErrorInvalidList(domain.ErrEmptyListName),
+		},
+		{
+			name:        "Given_DatabaseError_When_CreateList_Then_ExpectedInternalError",
+			givenList:   domain.List{Name: "Pharmacy"},
+			givenErr:    repository.NewGenericRepositoryError(errDummy),
+			wantCreated: true,
+			wantErr:     service.NewErrorService(repository.NewGenericRepositoryError(errDummy), "internal server error", service.RepositorySource, http.StatusInternalServerError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockLists := &repository.ListRepositoryMock{}
+			if tt.wantCreated {
+				mockLists.On("Create", ctx, mock.MatchedBy(func(list repository.List) bool {
+					return list.ID != "" && list.Name == "Pharmacy"
+				})).Return(tt.givenStored, tt.givenErr)
+			}
+
+			svc := service.NewListService(mockLists, &repository.RepositoryMock{}, &repository.TxManagerMock{})
+			list, err := svc.CreateList(ctx, tt.givenList)
+
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantList, list)
+			mockLists.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetList(t *testing.T) {
+	tests := []struct {
+		name        string
+		givenStored repository.List
+		givenErr    error
+		wantList    domain.List
+		wantErr     error
+	}{
+		{
+			name:        "Given_ExistingList_When_GetList_Then_ExpectedList",
+			givenStored: repository.List{ID: _dummyListID, Name: "Pharmacy"},
+			wantList:    domain.List{ID: _dummyListID, Name: "Pharmacy"},
+		},
+		{
+			name:     "Given_MissingList_When_GetList_Then_ExpectedNotFoundError",
+			givenErr: repository.NewListNotFoundError(),
+			wantErr:  service.NewErrorService(repository.NewListNotFoundError(), "list not found", service.RepositorySource, http.StatusNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockLists := &repository.ListRepositoryMock{}
+			mockLists.On("GetByID", ctx, _dummyListID).Return(tt.givenStored, tt.givenErr)
+
+			svc := service.NewListService(mockLists, &repository.RepositoryMock{}, &repository.TxManagerMock{})
+			list, err := svc.GetList(ctx, _dummyListID)
+
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantList, list)
+		})
+	}
+}
+
+func TestUpdateList(t *testing.T) {
+	tests := []struct {
+		name        string
+		givenList   domain.List
+		givenStored repository.List
+		givenErr    error
+		wantUpdated bool
+		wantList    domain.List
+		wantErr     error
+	}{
+		{
+			name:        "Given_NewName_When_UpdateList_Then_ExpectedRenamedList",
+			givenList:   domain.List{ID: _dummyListID, Name: "Drugstore "},
+			givenStored: repository.List{ID: _dummyListID, Name: "Drugstore"},
+			wantUpdated: true,
+			wantList:    domain.List{ID: _dummyListID, Name: "Drugstore"},
+		},
+		{
+			name:      "Given_EmptyName_When_UpdateList_Then_ExpectedInvalidListError",
+			givenList: domain.List{ID: _dummyListID},
+			wantErr:   service.NewErrorInvalidList(domain.ErrEmptyListName),
+		},
+		{
+			name:        "Given_MissingList_When_UpdateList_Then_ExpectedNotFoundError",
+			givenList:   domain.List{ID: _dummyListID, Name: "Drugstore"},
+			givenErr:    repository.NewListNotFoundError(),
+			wantUpdated: true,
+			wantErr:     service.NewErrorService(repository.NewListNotFoundError(), "list not found", service.RepositorySource, http.StatusNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockLists := &repository.ListRepositoryMock{}
+			if tt.wantUpdated {
+				mockLists.On("Update", ctx, repository.List{ID: _dummyListID, Name: "Drugstore"}).Return(tt.givenStored, tt.givenErr)
+			}
+
+			svc := service.NewListService(mockLists, &repository.RepositoryMock{}, &repository.TxManagerMock{})
+			list, err := svc.UpdateList(ctx, tt.givenList)
+
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantList, list)
+			mockLists.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteList(t *testing.T) {
+	tests := []struct {
+		name           string
+		givenID        string
+		givenDeleteErr error
+		wantDeleted    bool
+		wantItems      bool
+		wantErr        error
+	}{
+		{
+			name:        "Given_ExistingList_When_DeleteList_Then_ItsItemsAreDeleted",
+			givenID:     _dummyListID,
+			wantDeleted: true,
+			wantItems:   true,
+		},
+		{
+			name:    "Given_DefaultList_When_DeleteList_Then_ExpectedDefaultListError",
+			givenID: domain.DefaultListID,
+			wantErr: service.NewErrorDefaultList(),
+		},
+		{
+			name:           "Given_MissingList_When_DeleteList_Then_ExpectedNotFoundErrorAndItemsKept",
+			givenID:        _dummyListID,
+			givenDeleteErr: repository.NewListNotFoundError(),
+			wantDeleted:    true,
+			wantErr:        service.NewErrorService(repository.NewListNotFoundError(), "list not found", service.RepositorySource, http.StatusNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockLists := &repository.ListRepositoryMock{}
+			if tt.wantDeleted {
+				mockLists.On("Delete", ctx, tt.givenID).Return(tt.givenDeleteErr)
+			}
+			mockRepo := &repository.RepositoryMock{}
+			if tt.wantItems {
+				mockRepo.On("DeleteMany", ctx, repository.ItemFilter{ListID: tt.givenID}).Return(int64(3), nil)
+			}
+
+			svc := service.NewListService(mockLists, mockRepo, &repository.TxManagerMock{})
+			err := svc.DeleteList(ctx, tt.givenID)
+
+			require.Equal(t, tt.wantErr, err)
+			mockLists.AssertExpectations(t)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListLists(t *testing.T) {
+	tests := []struct {
+		name        string
+		givenStored []repository.List
+		givenErr    error
+		wantLists   []domain.List
+		wantErr     error
+	}{
+		{
+			name:        "Given_Lists_When_ListLists_Then_ExpectedEveryList",
+			givenStored: []repository.List{{ID: domain.DefaultListID, Name: domain.DefaultListName}, {ID: _dummyListID, Name: "Pharmacy"}},
+			wantLists:   []domain.List{{ID: domain.DefaultListID, Name: domain.DefaultListName}, {ID: _dummyListID, Name: "Pharmacy"}},
+		},
+		{
+			name:     "Given_DatabaseError_When_ListLists_Then_ExpectedInternalError",
+			givenErr: repository.NewGenericRepositoryError(errDummy),
+			wantErr:  service.NewErrorService(repository.NewGenericRepositoryError(errDummy), "internal server error", service.RepositorySource, http.StatusInternalServerError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockLists := &repository.ListRepositoryMock{}
+			mockLists.On("List", ctx).Return(tt.givenStored, tt.givenErr)
+
+			svc := service.NewListService(mockLists, &repository.RepositoryMock{}, &repository.TxManagerMock{})
+			lists, err := svc.ListLists(ctx)
+
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantLists, lists)
+		})
+	}
+}
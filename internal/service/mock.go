@@ -26,17 +26,126 @@ func (m *ItemServiceMock) UpdateItem(ctx context.Context, item domain.Item) (dom
 	return args.Get(0).(domain.Item), args.Error(1)
 }
 
-func (m *ItemServiceMock) DeleteItem(ctx context.Context, id string) error {
+func (m *ItemServiceMock) PatchItem(ctx context.Context, id string, patch domain.ItemPatch) (domain.Item, error) {
+	args := m.Called(ctx, id, patch)
+	return args.Get(0).(domain.Item), args.Error(1)
+}
+
+func (m *ItemServiceMock) MoveItem(ctx context.Context, id string, move domain.ItemMove) (domain.Item, error) {
+	args := m.Called(ctx, id, move)
+	return args.Get(0).(domain.Item), args.Error(1)
+}
+
+func (m *ItemServiceMock) DeleteItem(ctx context.Context, id string, version int64) error {
+	args := m.Called(ctx, id, version)
+	return args.Error(0)
+}
+
+func (m *ItemServiceMock) ListItems(ctx context.Context, opts domain.ListOptions) (domain.ItemPage, error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).(domain.ItemPage), args.Error(1)
+}
+
+func (m *ItemServiceMock) SearchItems(ctx context.Context, query, listID string, limit int) ([]domain.Item, error) {
+	args := m.Called(ctx, query, listID, limit)
+	return args.Get(0).([]domain.Item), args.Error(1)
+}
+
+func (m *ItemServiceMock) SuggestItemNames(ctx context.Context, prefix string, limit int) ([]domain.Suggestion, error) {
+	args := m.Called(ctx, prefix, limit)
+	return args.Get(0).([]domain.Suggestion), args.Error(1)
+}
+
+func (m *ItemServiceMock) BulkUpdateActive(ctx context.Context, update domain.ActiveUpdate) (domain.ActiveUpdateResult, error) {
+	args := m.Called(ctx, update)
+	return args.Get(0).(domain.ActiveUpdateResult), args.Error(1)
+}
+
+func (m *ItemServiceMock) DeleteItems(ctx context.Context, filter domain.ItemFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *ItemServiceMock) BatchItems(ctx context.Context, ops []domain.BatchOperation, atomic bool) ([]domain.BatchResult, error) {
+	args := m.Called(ctx, ops, atomic)
+	results, _ := args.Get(0).([]domain.BatchResult)
+	return results, args.Error(1)
+}
+
+func (m *ItemServiceMock) TotalQuantities(ctx context.Context, filter domain.ItemFilter) ([]domain.Quantity, error) {
+	args := m.Called(ctx, filter)
+	totals, _ := args.Get(0).([]domain.Quantity)
+	return totals, args.Error(1)
+}
+
+func (m *ItemServiceMock) GroupItems(ctx context.Context, opts domain.ListOptions) ([]domain.ItemGroup, error) {
+	args := m.Called(ctx, opts)
+	groups, _ := args.Get(0).([]domain.ItemGroup)
+	return groups, args.Error(1)
+}
+
+func (m *ItemServiceMock) SummarizePrices(ctx context.Context, filter domain.ItemFilter) ([]domain.PriceSummary, error) {
+	args := m.Called(ctx, filter)
+	summaries, _ := args.Get(0).([]domain.PriceSummary)
+	return summaries, args.Error(1)
+}
+
+type ListServiceMock struct {
+	mock.Mock
+}
+
+func (m *ListServiceMock) CreateList(ctx context.Context, list domain.List) (domain.List, error) {
+	args := m.Called(ctx, list)
+	return args.Get(0).(domain.List), args.Error(1)
+}
+
+func (m *ListServiceMock) GetList(ctx context.Context, id string) (domain.List, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.List), args.Error(1)
+}
+
+func (m *ListServiceMock) UpdateList(ctx context.Context, list domain.List) (domain.List, error) {
+	args := m.Called(ctx, list)
+	return args.Get(0).(domain.List), args.Error(1)
+}
+
+func (m *ListServiceMock) DeleteList(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *ItemServiceMock) ListItems(ctx context.Context) ([]domain.Item, error) {
+func (m *ListServiceMock) ListLists(ctx context.Context) ([]domain.List, error) {
 	args := m.Called(ctx)
-	return args.Get(0).([]domain.Item), args.Error(1)
+	lists, _ := args.Get(0).([]domain.List)
+	return lists, args.Error(1)
 }
 
-func (m *ItemServiceMock) BulkUpdateActive(ctx context.Context, active bool) (int64, int64, error) {
-	args := m.Called(ctx, active)
-	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+type CategoryServiceMock struct {
+	mock.Mock
+}
+
+func (m *CategoryServiceMock) CreateCategory(ctx context.Context, category domain.Category) (domain.Category, error) {
+	args := m.Called(ctx, category)
+	return args.Get(0).(domain.Category), args.Error(1)
+}
+
+func (m *CategoryServiceMock) GetCategory(ctx context.Context, id string) (domain.Category, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Category), args.Error(1)
+}
+
+func (m *CategoryServiceMock) UpdateCategory(ctx context.Context, category domain.Category) (domain.Category, error) {
+	args := m.Called(ctx, category)
+	return args.Get(0).(domain.Category), args.Error(1)
+}
+
+func (m *CategoryServiceMock) DeleteCategory(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *CategoryServiceMock) ListCategories(ctx context.Context) ([]domain.Category, error) {
+	args := m.Called(ctx)
+	categories, _ := args.Get(0).([]domain.Category)
+	return categories, args.Error(1)
 }
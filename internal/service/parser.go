@@ -13,8 +13,16 @@ func (p parser) toRepositoryModel(item domain.Item) repository.Item {
 		Name:        item.Name,
 		Active:      item.Active,
 		Observation: item.Observation,
+		ListID:      item.ListID,
+		Quantity:    p.toRepositoryQuantity(item.Quantity),
+		Unit:        string(item.Unit),
+		CategoryID:  item.CategoryID,
+		UnitPrice:   p.toRepositoryPrice(item.UnitPrice),
+		Currency:    string(item.Currency),
+		Position:    item.Position,
 		CreatedAt:   item.CreatedAt,
 		UpdatedAt:   item.UpdatedAt,
+		Version:     item.Version,
 	}
 }
 
@@ -24,7 +32,140 @@ func (p parser) toDomainModel(item repository.Item) domain.Item {
 		Name:        item.Name,
 		Active:      item.Active,
 		Observation: item.Observation,
+		ListID:      item.ListID,
+		Quantity:    p.toDomainQuantity(item.Quantity),
+		Unit:        domain.Unit(item.Unit),
+		CategoryID:  item.CategoryID,
+		UnitPrice:   p.toDomainPrice(item.UnitPrice),
+		Currency:    domain.Currency(item.Currency),
+		Position:    item.Position,
 		CreatedAt:   item.CreatedAt,
 		UpdatedAt:   item.UpdatedAt,
+		Version:     item.Version,
 	}
 }
+
+func (p parser) toRepositoryPatch(patch domain.ItemPatch) repository.ItemPatch {
+	return repository.ItemPatch{
+		Name:           patch.Name,
+		Active:         patch.Active,
+		Observation:    patch.Observation,
+		SetObservation: patch.SetObservation,
+		Quantity:       p.toRepositoryQuantity(patch.Quantity),
+		Unit:           string(patch.Unit),
+		SetQuantity:    patch.SetQuantity,
+		CategoryID:     patch.CategoryID,
+		SetCategory:    patch.SetCategory,
+		UnitPrice:      p.toRepositoryPrice(patch.UnitPrice),
+		Currency:       string(patch.Currency),
+		SetPrice:       patch.SetPrice,
+		Version:        patch.Version,
+	}
+}
+
+func (p parser) toRepositoryListOptions(opts domain.ListOptions) repository.ListOptions {
+	var sort []repository.SortField
+	for _, s := range opts.Sort {
+		sort = append(sort, repository.SortField{Field: s.Field, Desc: s.Desc})
+	}
+
+	return repository.ListOptions{
+		Limit:  opts.Limit,
+		Cursor: opts.Cursor,
+		Filter: p.toRepositoryFilter(opts.Filter),
+		Sort:   sort,
+	}
+}
+
+func (p parser) toRepositoryFilter(filter domain.ItemFilter) repository.ItemFilter {
+	return repository.ItemFilter{
+		Active:         filter.Active,
+		Name:           filter.Name,
+		HasObservation: filter.HasObservation,
+		CreatedAfter:   filter.CreatedAfter,
+		UpdatedBefore:  filter.UpdatedBefore,
+		ListID:         filter.ListID,
+	}
+}
+
+func (p parser) toDomainPage(page repository.ItemPage) domain.ItemPage {
+	items := make([]domain.Item, len(page.Items))
+	for i, item := range page.Items {
+		items[i] = p.toDomainModel(item)
+	}
+
+	return domain.ItemPage{
+		Items:      items,
+		NextCursor: page.NextCursor,
+	}
+}
+
+func (p parser) toRepositoryList(list domain.List) repository.List {
+	return repository.List{
+		ID:        list.ID,
+		Name:      list.Name,
+		CreatedAt: list.CreatedAt,
+		UpdatedAt: list.UpdatedAt,
+	}
+}
+
+func (p parser) toDomainList(list repository.List) domain.List {
+	return domain.List{
+		ID:        list.ID,
+		Name:      list.Name,
+		CreatedAt: list.CreatedAt,
+		UpdatedAt: list.UpdatedAt,
+	}
+}
+
+func (p parser) toRepositoryCategory(category domain.Category) repository.Category {
+	return repository.Category{
+		ID:        category.ID,
+		Name:      category.Name,
+		Position:  category.Position,
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}
+}
+
+func (p parser) toDomainCategory(category repository.Category) domain.Category {
+	return domain.Category{
+		ID:        category.ID,
+		Name:      category.Name,
+		Position:  category.Position,
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}
+}
+
+func (p parser) toRepositoryPrice(price *domain.Amount) *int64 {
+	if price == nil {
+		return nil
+	}
+	cents := int64(*price)
+	return &cents
+}
+
+func (p parser) toDomainPrice(cents *int64) *domain.Amount {
+	if cents == nil {
+		return nil
+	}
+	price := domain.Amount(*cents)
+	return &price
+}
+
+func (p parser) toRepositoryQuantity(quantity *domain.Thousandths) *int64 {
+	if quantity == nil {
+		return nil
+	}
+	thousandths := int64(*quantity)
+	return &thousandths
+}
+
+func (p parser) toDomainQuantity(quantity *int64) *domain.Thousandths {
+	if quantity == nil {
+		return nil
+	}
+	thousandths := domain.Thousandths(*quantity)
+	return &thousandths
+}
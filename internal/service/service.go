@@ -2,32 +2,112 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"net/http"
 
 	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
 	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/suggest"
 )
 
+// errBatchRolledBack makes the transaction of an atomic batch roll back when one of its operations failed
+var errBatchRolledBack = errors.New("atomic batch rolled back")
+
+// maxPositionAttempts bounds the attempts of a create or a move whose position
+// is taken by a concurrent one in the meantime
+const maxPositionAttempts = 5
+
 type itemService struct {
 	repository repository.ItemRepository
-	parser     parser
+	// lists is checked for the list an item use case is scoped to
+	lists repository.ListRepository
+	// categories is checked for the category an item is put in, and orders the grouped listings
+	categories repository.CategoryRepository
+	// txManager runs the use cases that span several repository calls atomically
+	txManager repository.TxManager
+	// names is the autocomplete index, fed with the names of the created and updated items
+	names  *suggest.Index
+	parser parser
 }
 
-func NewItemService(repository repository.ItemRepository) ItemService {
+func NewItemService(repository repository.ItemRepository, lists repository.ListRepository, categories repository.CategoryRepository, txManager repository.TxManager, names *suggest.Index) ItemService {
 	return &itemService{
 		repository: repository,
+		lists:      lists,
+		categories: categories,
+		txManager:  txManager,
+		names:      names,
 		parser:     parser{},
 	}
 }
 
-func (s *itemService) CreateItem(ctx context.Context, item domain.Item) (domain.Item, error) {
-	repositoryItem := s.parser.toRepositoryModel(domain.NewItem(item.Name, item.Active, item.Observation))
+// LoadNames adds the names of the stored items to the autocomplete index. It can
+// run while the index is in use: the items already recorded are left as they are.
+func LoadNames(ctx context.Context, itemRepository repository.ItemRepository, names *suggest.Index) error {
+	opts := repository.ListOptions{Limit: domain.MaxListLimit}
+	for {
+		page, err := itemRepository.List(ctx, opts)
+		if err != nil {
+			return handleError(err)
+		}
+		for _, item := range page.Items {
+			names.RecordNew(item.ID, item.Name)
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		opts.Cursor = page.NextCursor
+	}
+}
 
-	createdRepositoryItem, err := s.repository.Create(ctx, repositoryItem)
+func (s *itemService) CreateItem(ctx context.Context, item domain.Item) (domain.Item, error) {
+	newItem := domain.NewItem(item.Name, item.Active, item.Observation)
+	newItem.ListID = domain.ListIDOrDefault(item.ListID)
+	newItem.Quantity, newItem.Unit = item.Quantity, item.Unit
+	newItem.CategoryID = item.CategoryID
+	newItem.UnitPrice, newItem.Currency = item.UnitPrice, item.Currency
+	newItem, err := normalizeItem(newItem)
 	if err != nil {
-		log.Printf("failed to create item: %s: %v", item.Name, err)
-		return domain.Item{}, handleError(err)
+		return domain.Item{}, NewErrorInvalidItem(err)
+	}
+	repositoryItem := s.parser.toRepositoryModel(newItem)
+
+	// The list is checked and the item inserted at its end in one transaction, so
+	// that a concurrent DeleteList cannot leave the item in a deleted list. When a
+	// concurrent create takes the position first, the new last position is read again.
+	var createdRepositoryItem repository.Item
+	for attempt := 1; ; attempt++ {
+		err := s.withinTransaction(ctx, func(ctx context.Context) error {
+			if err := s.lockList(ctx, newItem.ListID); err != nil {
+				return err
+			}
+			if err := s.lockCategory(ctx, newItem.CategoryID); err != nil {
+				return err
+			}
+			last, err := s.lastPosition(ctx, newItem.ListID)
+			if err == nil {
+				repositoryItem.Position, err = domain.PositionBetween(last, "")
+			}
+			if err == nil {
+				createdRepositoryItem, err = s.repository.Create(ctx, repositoryItem)
+			}
+			if err != nil {
+				log.Printf("failed to create item: %s: %v", item.Name, err)
+				return handleError(err)
+			}
+			return nil
+		})
+		if errors.Is(err, repository.ErrPositionConflict) && attempt < maxPositionAttempts {
+			continue
+		}
+		if err != nil {
+			return domain.Item{}, err
+		}
+		break
 	}
+	s.names.Record(createdRepositoryItem.ID, createdRepositoryItem.Name)
 
 	return s.parser.toDomainModel(createdRepositoryItem), nil
 }
@@ -36,23 +116,115 @@ func (s *itemService) UpdateItem(ctx context.Context, item domain.Item) (domain.
 	if item.IsEmpty() {
 		return domain.Item{}, NewErrorEmptyItem()
 	}
-	_, err := s.repository.GetByID(ctx, item.ID)
+	item, err := normalizeItem(item)
 	if err != nil {
-		log.Printf("failed to get item: %s: %v", item.ID, err)
-		return domain.Item{}, handleError(err)
+		return domain.Item{}, NewErrorInvalidItem(err)
 	}
 
+	// The category is checked and the item updated in one transaction, so that a
+	// concurrent DeleteCategory cannot leave the item in a deleted category. A
+	// missing item is reported by the repository.
 	repositoryItem := s.parser.toRepositoryModel(item)
 
-	updatedItem, err := s.repository.Update(ctx, repositoryItem)
+	var updatedItem repository.Item
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.lockCategory(ctx, item.CategoryID); err != nil {
+			return err
+		}
+		var err error
+		updatedItem, err = s.repository.Update(ctx, repositoryItem)
+		if err != nil {
+			log.Printf("failed to update item: %s: %v", item.ID, err)
+			return handleError(err)
+		}
+		return nil
+	})
 	if err != nil {
-		log.Printf("failed to update item: %s: %v", item.ID, err)
-		return domain.Item{}, handleError(err)
+		return domain.Item{}, err
 	}
+	s.names.Record(updatedItem.ID, updatedItem.Name)
 
 	return s.parser.toDomainModel(updatedItem), nil
 }
 
+// PatchItem changes only the fields set in patch, so concurrent patches of different fields don't overwrite each other
+func (s *itemService) PatchItem(ctx context.Context, id string, patch domain.ItemPatch) (domain.Item, error) {
+	if err := patch.Validate(); err != nil {
+		return domain.Item{}, NewErrorInvalidItem(err)
+	}
+	patch = patch.NormalizeQuantity().NormalizePrice()
+	if patch.IsEmpty() {
+		item, err := s.GetItem(ctx, id)
+		if err == nil && patch.Version != 0 && patch.Version != item.Version {
+			return domain.Item{}, NewErrorConflict(repository.NewVersionConflictError())
+		}
+		return item, err
+	}
+
+	// Like in UpdateItem, the category is checked in the transaction of the patch
+	var patchedItem repository.Item
+	err := s.withinTransaction(ctx, func(ctx context.Context) error {
+		if patch.SetCategory && patch.CategoryID != nil {
+			if err := s.lockCategory(ctx, *patch.CategoryID); err != nil {
+				return err
+			}
+		}
+		var err error
+		patchedItem, err = s.repository.Patch(ctx, id, s.parser.toRepositoryPatch(patch))
+		if err != nil {
+			log.Printf("failed to patch item: %s: %v", id, err)
+			return handleError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.Item{}, err
+	}
+	s.names.Record(patchedItem.ID, patchedItem.Name)
+
+	return s.parser.toDomainModel(patchedItem), nil
+}
+
+// MoveItem gives the item a position between its new neighbours, changing no
+// other item. When a concurrent move takes that position first, the neighbours
+// are read again, so the order is never left with two items at the same place.
+func (s *itemService) MoveItem(ctx context.Context, id string, move domain.ItemMove) (domain.Item, error) {
+	if err := move.Validate(id); err != nil {
+		return domain.Item{}, NewErrorInvalidMove(err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		item, err := s.repository.GetByID(ctx, id)
+		if err != nil {
+			log.Printf("failed to get item: %s: %v", id, err)
+			return domain.Item{}, handleError(err)
+		}
+		if move.Version != 0 && move.Version != item.Version {
+			return domain.Item{}, NewErrorConflict(repository.NewVersionConflictError())
+		}
+
+		lower, upper, err := s.neighbourPositions(ctx, item, move)
+		if err != nil {
+			return domain.Item{}, err
+		}
+		position, err := domain.PositionBetween(lower, upper)
+		if err != nil {
+			log.Printf("failed to move item: %s: %v", id, err)
+			return domain.Item{}, handleError(err)
+		}
+
+		movedItem, err := s.repository.Patch(ctx, id, repository.ItemPatch{Position: &position, Version: move.Version})
+		if errors.Is(err, repository.ErrPositionConflict) && attempt < maxPositionAttempts {
+			continue
+		}
+		if err != nil {
+			log.Printf("failed to move item: %s: %v", id, err)
+			return domain.Item{}, handleError(err)
+		}
+		return s.parser.toDomainModel(movedItem), nil
+	}
+}
+
 func (s *itemService) GetItem(ctx context.Context, id string) (domain.Item, error) {
 	item, err := s.repository.GetByID(ctx, id)
 	if err != nil {
@@ -63,8 +235,9 @@ func (s *itemService) GetItem(ctx context.Context, id string) (domain.Item, erro
 	return s.parser.toDomainModel(item), nil
 }
 
-func (s *itemService) DeleteItem(ctx context.Context, id string) error {
-	err := s.repository.Delete(ctx, id)
+// DeleteItem removes an item; a non-zero version must still be the current one
+func (s *itemService) DeleteItem(ctx context.Context, id string, version int64) error {
+	err := s.repository.Delete(ctx, id, version)
 	if err != nil {
 		log.Printf("failed to delete item: %s: %v", id, err)
 		return handleError(err)
@@ -72,10 +245,42 @@ func (s *itemService) DeleteItem(ctx context.Context, id string) error {
 	return nil
 }
 
-func (s *itemService) ListItems(ctx context.Context) ([]domain.Item, error) {
-	items, err := s.repository.List(ctx)
+func (s *itemService) ListItems(ctx context.Context, opts domain.ListOptions) (domain.ItemPage, error) {
+	if opts.Limit <= 0 {
+		opts.Limit = domain.DefaultListLimit
+	} else if opts.Limit > domain.MaxListLimit {
+		opts.Limit = domain.MaxListLimit
+	}
+	opts.Filter.ListID = domain.ListIDOrDefault(opts.Filter.ListID)
+	if err := s.checkList(ctx, opts.Filter.ListID); err != nil {
+		return domain.ItemPage{}, err
+	}
+
+	page, err := s.repository.List(ctx, s.parser.toRepositoryListOptions(opts))
 	if err != nil {
 		log.Printf("failed to list items: %v", err)
+		return domain.ItemPage{}, handleError(err)
+	}
+
+	return s.parser.toDomainPage(page), nil
+}
+
+// SearchItems searches the items of listID, or of every list when it is empty
+func (s *itemService) SearchItems(ctx context.Context, query, listID string, limit int) ([]domain.Item, error) {
+	if limit <= 0 {
+		limit = domain.DefaultSearchLimit
+	} else if limit > domain.MaxSearchLimit {
+		limit = domain.MaxSearchLimit
+	}
+	if listID != "" {
+		if err := s.checkList(ctx, listID); err != nil {
+			return nil, err
+		}
+	}
+
+	items, err := s.repository.Search(ctx, query, listID, limit)
+	if err != nil {
+		log.Printf("failed to search items: %q: %v", query, err)
 		return nil, handleError(err)
 	}
 
@@ -83,16 +288,537 @@ func (s *itemService) ListItems(ctx context.Context) ([]domain.Item, error) {
 	for i, item := range items {
 		domainItems[i] = s.parser.toDomainModel(item)
 	}
-
 	return domainItems, nil
 }
 
-func (s *itemService) BulkUpdateActive(ctx context.Context, active bool) (int64, int64, error) {
-	matchedCount, modifiedCount, err := s.repository.BulkUpdateActive(ctx, active)
+func (s *itemService) SuggestItemNames(_ context.Context, prefix string, limit int) ([]domain.Suggestion, error) {
+	if limit <= 0 {
+		limit = domain.DefaultSuggestLimit
+	} else if limit > domain.MaxSuggestLimit {
+		limit = domain.MaxSuggestLimit
+	}
+
+	found := s.names.Suggest(prefix, limit)
+
+	suggestions := make([]domain.Suggestion, len(found))
+	for i, suggestion := range found {
+		suggestions[i] = domain.Suggestion{Name: suggestion.Name, Count: suggestion.Count}
+	}
+	return suggestions, nil
+}
+
+// BulkUpdateActive sets the active field of the items with the given IDs, or of
+// the ones matching the filter, every item when there are neither
+func (s *itemService) BulkUpdateActive(ctx context.Context, update domain.ActiveUpdate) (domain.ActiveUpdateResult, error) {
+	if len(update.IDs) > 0 && !update.Filter.IsEmpty() {
+		return domain.ActiveUpdateResult{}, NewErrorInvalidActiveUpdate(errors.New("ids and filter cannot be combined"))
+	}
+	if len(update.IDs) > domain.MaxActiveUpdateIDs {
+		return domain.ActiveUpdateResult{}, NewErrorInvalidActiveUpdate(fmt.Errorf("more than %d ids", domain.MaxActiveUpdateIDs))
+	}
+
+	ids := make([]string, 0, len(update.IDs))
+	seen := make(map[string]bool, len(update.IDs))
+	for _, id := range update.IDs {
+		if id == "" {
+			return domain.ActiveUpdateResult{}, NewErrorInvalidActiveUpdate(errors.New("ids cannot be empty"))
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	update.Filter.ListID = domain.ListIDOrDefault(update.Filter.ListID)
+	if err := s.checkList(ctx, update.Filter.ListID); err != nil {
+		return domain.ActiveUpdateResult{}, err
+	}
+
+	result, err := s.repository.BulkUpdateActive(ctx, repository.ActiveUpdate{
+		Active: update.Active,
+		IDs:    ids,
+		Filter: s.parser.toRepositoryFilter(update.Filter),
+	})
 	if err != nil {
 		log.Printf("failed to bulk update active: %v", err)
-		return 0, 0, handleError(err)
+		return domain.ActiveUpdateResult{}, handleError(err)
+	}
+
+	return domain.ActiveUpdateResult{
+		MatchedCount:  result.MatchedCount,
+		ModifiedCount: result.ModifiedCount,
+		NotFoundIDs:   result.NotFoundIDs,
+	}, nil
+}
+
+// DeleteItems removes every item matching filter, such as the checked ones after
+// shopping. An empty filter is rejected rather than deleting the whole list.
+func (s *itemService) DeleteItems(ctx context.Context, filter domain.ItemFilter) (int64, error) {
+	if filter.IsEmpty() {
+		return 0, NewErrorEmptyFilter()
+	}
+	filter.ListID = domain.ListIDOrDefault(filter.ListID)
+	if err := s.checkList(ctx, filter.ListID); err != nil {
+		return 0, err
+	}
+
+	deletedCount, err := s.repository.DeleteMany(ctx, s.parser.toRepositoryFilter(filter))
+	if err != nil {
+		log.Printf("failed to delete items: %v", err)
+		return 0, handleError(err)
+	}
+
+	return deletedCount, nil
+}
+
+// BatchItems applies ops in order and returns the result of each one. The items
+// of the operations are in the list of their ListID, the default one when empty:
+// creates go in it, and updates and deletes of items of other lists are not found.
+// Every operation runs in its own transaction with its checks, so a failed one
+// doesn't prevent the others, unless atomic is true: then they all run in one
+// transaction and, when one fails, nothing is changed and the others are
+// reported as not applied.
+func (s *itemService) BatchItems(ctx context.Context, ops []domain.BatchOperation, atomic bool) ([]domain.BatchResult, error) {
+	if len(ops) == 0 {
+		return nil, NewErrorInvalidBatch(errors.New("the batch has no operations"))
+	}
+	if len(ops) > domain.MaxBatchOperations {
+		return nil, NewErrorInvalidBatch(fmt.Errorf("the batch has more than %d operations", domain.MaxBatchOperations))
+	}
+	if atomic {
+		return s.batchItemsAtomically(ctx, ops)
+	}
+
+	results := make([]domain.BatchResult, len(ops))
+	for i := range ops {
+		var result repository.BatchResult
+		err := s.withinTransaction(ctx, func(ctx context.Context) error {
+			repositoryOps, _, err := s.toRepositoryOperations(ctx, ops[i:i+1])
+			if err != nil {
+				return err
+			}
+			repositoryResults, err := s.repository.Batch(ctx, repositoryOps, false)
+			if err != nil {
+				log.Printf("failed to run batch operation %d: %v", i, err)
+				return handleError(err)
+			}
+			result = repositoryResults[0]
+			if result.Err != nil {
+				return handleError(result.Err)
+			}
+			return nil
+		})
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		if ops[i].Type != domain.BatchDelete {
+			s.names.Record(result.Item.ID, result.Item.Name)
+			results[i].Item = s.parser.toDomainModel(result.Item)
+		}
+	}
+
+	return results, nil
+}
+
+// batchItemsAtomically runs ops and their checks in one transaction
+func (s *itemService) batchItemsAtomically(ctx context.Context, ops []domain.BatchOperation) ([]domain.BatchResult, error) {
+	var (
+		repositoryResults []repository.BatchResult
+		// failed is the index in ops of the operation that failed with failedErr
+		failed    int
+		failedErr error
+	)
+	err := s.withinTransaction(ctx, func(ctx context.Context) error {
+		repositoryOps, i, err := s.toRepositoryOperations(ctx, ops)
+		if err != nil {
+			failed, failedErr = i, err
+			return errBatchRolledBack
+		}
+		repositoryResults, err = s.repository.Batch(ctx, repositoryOps, true)
+		if err != nil {
+			log.Printf("failed to run batch: %v", err)
+			return handleError(err)
+		}
+		if last := len(repositoryResults) - 1; len(repositoryResults) < len(repositoryOps) || repositoryResults[last].Err != nil {
+			failed, failedErr = last, handleError(repositoryResults[last].Err)
+			return errBatchRolledBack
+		}
+		return nil
+	})
+	if errors.Is(err, errBatchRolledBack) {
+		log.Printf("atomic batch rolled back: operation %d: %v", failed, failedErr)
+		return failedBatch(len(ops), failed, failedErr), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	results := make([]domain.BatchResult, len(ops))
+	for i, result := range repositoryResults {
+		if ops[i].Type != domain.BatchDelete {
+			s.names.Record(result.Item.ID, result.Item.Name)
+			results[i].Item = s.parser.toDomainModel(result.Item)
+		}
+	}
+
+	return results, nil
+}
+
+// TotalQuantities sums the quantities of the items matching filter, those in
+// compatible units together (see domain.SumByUnit). Items without quantity are skipped.
+func (s *itemService) TotalQuantities(ctx context.Context, filter domain.ItemFilter) ([]domain.Quantity, error) {
+	filter.ListID = domain.ListIDOrDefault(filter.ListID)
+	if err := s.checkList(ctx, filter.ListID); err != nil {
+		return nil, err
+	}
+
+	var quantities []domain.Quantity
+	opts := repository.ListOptions{Limit: domain.MaxListLimit, Filter: s.parser.toRepositoryFilter(filter)}
+	for {
+		page, err := s.repository.List(ctx, opts)
+		if err != nil {
+			log.Printf("failed to list items to total: %v", err)
+			return nil, handleError(err)
+		}
+		for _, item := range page.Items {
+			if quantity, ok := s.parser.toDomainModel(item).TotalQuantity(); ok {
+				quantities = append(quantities, quantity)
+			}
+		}
+		if page.NextCursor == "" {
+			totals, err := domain.SumByUnit(quantities)
+			if err != nil {
+				return nil, NewErrorInvalidTotal(err)
+			}
+			return totals, nil
+		}
+		opts.Cursor = page.NextCursor
+	}
+}
+
+// GroupItems returns every item matching opts.Filter, in the order of opts.Sort,
+// grouped by category in the order of the categories (see domain.GroupItems).
+// opts.Limit and opts.Cursor are ignored: the groups are not paged.
+func (s *itemService) GroupItems(ctx context.Context, opts domain.ListOptions) ([]domain.ItemGroup, error) {
+	opts.Filter.ListID = domain.ListIDOrDefault(opts.Filter.ListID)
+	if err := s.checkList(ctx, opts.Filter.ListID); err != nil {
+		return nil, err
+	}
+
+	var items []domain.Item
+	repositoryOpts := s.parser.toRepositoryListOptions(opts)
+	repositoryOpts.Limit, repositoryOpts.Cursor = domain.MaxListLimit, ""
+	for {
+		page, err := s.repository.List(ctx, repositoryOpts)
+		if err != nil {
+			log.Printf("failed to list items to group: %v", err)
+			return nil, handleError(err)
+		}
+		items = append(items, s.parser.toDomainPage(page).Items...)
+		if page.NextCursor == "" {
+			break
+		}
+		repositoryOpts.Cursor = page.NextCursor
+	}
+
+	categories, err := s.categories.List(ctx)
+	if err != nil {
+		log.Printf("failed to list categories: %v", err)
+		return nil, handleError(err)
+	}
+	domainCategories := make([]domain.Category, len(categories))
+	for i, category := range categories {
+		domainCategories[i] = s.parser.toDomainCategory(category)
+	}
+
+	return domain.GroupItems(domainCategories, items), nil
+}
+
+// SummarizePrices adds up the prices of the items matching filter per currency
+// and category (see domain.SummarizePrices). The sums are computed by the
+// repository; items without a unit price are skipped.
+func (s *itemService) SummarizePrices(ctx context.Context, filter domain.ItemFilter) ([]domain.PriceSummary, error) {
+	filter.ListID = domain.ListIDOrDefault(filter.ListID)
+	if err := s.checkList(ctx, filter.ListID); err != nil {
+		return nil, err
+	}
+
+	totals, err := s.repository.SumPrices(ctx, s.parser.toRepositoryFilter(filter))
+	if err != nil {
+		log.Printf("failed to sum prices: %v", err)
+		return nil, handleError(err)
+	}
+
+	categories, err := s.categories.List(ctx)
+	if err != nil {
+		log.Printf("failed to list categories: %v", err)
+		return nil, handleError(err)
+	}
+	domainCategories := make([]domain.Category, len(categories))
+	for i, category := range categories {
+		domainCategories[i] = s.parser.toDomainCategory(category)
 	}
 
-	return matchedCount, modifiedCount, nil
+	summaries, err := domain.SummarizePrices(domainCategories, totals)
+	if err != nil {
+		return nil, NewErrorInvalidTotal(err)
+	}
+	return summaries, nil
+}
+
+// toRepositoryOperations checks ops and gives the created items positions at the
+// end of their list, in the order of ops. It returns the index of the operation
+// that failed with err.
+func (s *itemService) toRepositoryOperations(ctx context.Context, ops []domain.BatchOperation) ([]repository.BatchOperation, int, error) {
+	repositoryOps := make([]repository.BatchOperation, len(ops))
+	positions := make(map[string]string)
+	for i, op := range ops {
+		repositoryOp, err := s.toRepositoryOperation(ctx, op)
+		if err != nil {
+			return nil, i, err
+		}
+		if repositoryOp.Type == repository.BatchCreate {
+			listID := repositoryOp.Item.ListID
+			position, ok := positions[listID]
+			if !ok {
+				position, err = s.lastPosition(ctx, listID)
+			}
+			if err == nil {
+				position, err = domain.PositionBetween(position, "")
+			}
+			if err != nil {
+				log.Printf("failed to position batch items: %v", err)
+				return nil, i, handleError(err)
+			}
+			positions[listID] = position
+			repositoryOp.Item.Position = position
+		}
+		repositoryOps[i] = repositoryOp
+	}
+	return repositoryOps, 0, nil
+}
+
+// toRepositoryOperation checks op like the single item use case it stands for
+func (s *itemService) toRepositoryOperation(ctx context.Context, op domain.BatchOperation) (repository.BatchOperation, error) {
+	switch op.Type {
+	case domain.BatchCreate:
+		item := domain.NewItem(op.Item.Name, op.Item.Active, op.Item.Observation)
+		item.ListID = domain.ListIDOrDefault(op.Item.ListID)
+		item.Quantity, item.Unit = op.Item.Quantity, op.Item.Unit
+		item.CategoryID = op.Item.CategoryID
+		item.UnitPrice, item.Currency = op.Item.UnitPrice, op.Item.Currency
+		item, err := normalizeItem(item)
+		if err != nil {
+			return repository.BatchOperation{}, NewErrorInvalidItem(err)
+		}
+		if err := s.lockList(ctx, item.ListID); err != nil {
+			return repository.BatchOperation{}, err
+		}
+		if err := s.lockCategory(ctx, item.CategoryID); err != nil {
+			return repository.BatchOperation{}, err
+		}
+		return repository.BatchOperation{Type: repository.BatchCreate, Item: s.parser.toRepositoryModel(item)}, nil
+	case domain.BatchUpdate:
+		if op.Item.IsEmpty() {
+			return repository.BatchOperation{}, NewErrorEmptyItem()
+		}
+		item, err := normalizeItem(op.Item)
+		if err != nil {
+			return repository.BatchOperation{}, NewErrorInvalidItem(err)
+		}
+		if err := s.checkItemList(ctx, item); err != nil {
+			return repository.BatchOperation{}, err
+		}
+		if err := s.lockCategory(ctx, item.CategoryID); err != nil {
+			return repository.BatchOperation{}, err
+		}
+		return repository.BatchOperation{Type: repository.BatchUpdate, Item: s.parser.toRepositoryModel(item)}, nil
+	case domain.BatchDelete:
+		if op.Item.IsEmpty() {
+			return repository.BatchOperation{}, NewErrorEmptyItem()
+		}
+		if err := s.checkItemList(ctx, op.Item); err != nil {
+			return repository.BatchOperation{}, err
+		}
+		return repository.BatchOperation{Type: repository.BatchDelete, Item: repository.Item{ID: op.Item.ID}}, nil
+	}
+	return repository.BatchOperation{}, NewErrorInvalidOperation(fmt.Errorf("unknown operation %q", op.Type))
+}
+
+// lastPosition returns the position of the last item of the list, empty when it has none
+func (s *itemService) lastPosition(ctx context.Context, listID string) (string, error) {
+	page, err := s.repository.List(ctx, repository.ListOptions{
+		Limit:  1,
+		Filter: repository.ItemFilter{ListID: listID},
+		Sort:   []repository.SortField{{Field: repository.SortFieldPosition, Desc: true}},
+	})
+	if err != nil || len(page.Items) == 0 {
+		return "", err
+	}
+	return page.Items[0].Position, nil
+}
+
+// neighbourPositions returns the positions the moved item goes between: the one
+// of move.After and the next one, or the one of move.Before and the previous
+// one. The moved item is skipped, as it leaves its current position.
+func (s *itemService) neighbourPositions(ctx context.Context, item repository.Item, move domain.ItemMove) (lower, upper string, err error) {
+	if move.After == "" {
+		before, err := s.neighbour(ctx, item, move.Before)
+		if err != nil {
+			return "", "", err
+		}
+		lower, err = s.adjacentPosition(ctx, item, before, true)
+		return lower, before.Position, err
+	}
+
+	after, err := s.neighbour(ctx, item, move.After)
+	if err != nil {
+		return "", "", err
+	}
+	if move.Before != "" {
+		before, err := s.neighbour(ctx, item, move.Before)
+		if err != nil {
+			return "", "", err
+		}
+		if before.Position <= after.Position {
+			return "", "", NewErrorStaleNeighbours()
+		}
+	}
+	// The next item comes at the latest at move.Before
+	upper, err = s.adjacentPosition(ctx, item, after, false)
+	return after.Position, upper, err
+}
+
+// neighbour returns the item id the moved item goes next to, which must be in its list
+func (s *itemService) neighbour(ctx context.Context, item repository.Item, id string) (repository.Item, error) {
+	neighbour, err := s.repository.GetByID(ctx, id)
+	var errRepository repository.Error
+	if errors.As(err, &errRepository) && errRepository.HTTP != http.StatusInternalServerError {
+		return repository.Item{}, NewErrorInvalidMove(fmt.Errorf("neighbour %q: %s", id, errRepository.Message))
+	} else if err != nil {
+		log.Printf("failed to get item: %s: %v", id, err)
+		return repository.Item{}, handleError(err)
+	}
+	if neighbour.ListID != item.ListID {
+		return repository.Item{}, NewErrorInvalidMove(fmt.Errorf("neighbour %q is in another list", id))
+	}
+	return neighbour, nil
+}
+
+// adjacentPosition returns the position of the item of the list right after
+// neighbour, or right before it when previous is true, skipping the moved item.
+// It is empty when there is none.
+func (s *itemService) adjacentPosition(ctx context.Context, item, neighbour repository.Item, previous bool) (string, error) {
+	sort := []repository.SortField{{Field: repository.SortFieldPosition, Desc: previous}}
+	page, err := s.repository.List(ctx, repository.ListOptions{
+		Limit:  2,
+		Cursor: repository.EncodeCursor(neighbour, sort),
+		Filter: repository.ItemFilter{ListID: item.ListID},
+		Sort:   sort,
+	})
+	if err != nil {
+		log.Printf("failed to list the neighbours of item: %s: %v", neighbour.ID, err)
+		return "", handleError(err)
+	}
+	for _, adjacent := range page.Items {
+		if adjacent.ID != item.ID {
+			return adjacent.Position, nil
+		}
+	}
+	return "", nil
+}
+
+// normalizeItem validates and normalizes the quantity and the price of item
+func normalizeItem(item domain.Item) (domain.Item, error) {
+	item, err := item.NormalizeQuantity()
+	if err != nil {
+		return domain.Item{}, err
+	}
+	return item.NormalizePrice()
+}
+
+// checkList reports a missing list, the default one always exists
+func (s *itemService) checkList(ctx context.Context, listID string) error {
+	if listID == domain.DefaultListID {
+		return nil
+	}
+	if _, err := s.lists.GetByID(ctx, listID); err != nil {
+		log.Printf("failed to get list: %s: %v", listID, err)
+		return handleError(err)
+	}
+	return nil
+}
+
+// lockList reports a missing list and keeps it from being deleted until the
+// transaction of ctx ends (see repository.ListRepository.Lock). The default
+// list always exists.
+func (s *itemService) lockList(ctx context.Context, listID string) error {
+	if listID == domain.DefaultListID {
+		return nil
+	}
+	if err := s.lists.Lock(ctx, listID); err != nil {
+		log.Printf("failed to lock list: %s: %v", listID, err)
+		return handleError(err)
+	}
+	return nil
+}
+
+// withinTransaction runs fn in a transaction of txManager and returns the error of
+// fn as it is, where the TxManager of MongoDB would make it a repository error.
+// The errors of the transaction itself are handled like the repository ones.
+func (s *itemService) withinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	var fnErr error
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		fnErr = fn(ctx)
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		log.Printf("failed to run transaction: %v", err)
+		return handleError(err)
+	}
+	return nil
+}
+
+// checkItemList reports an item that isn't in item.ListID, the default list when
+// empty, as not found, like the ids of other lists of the list-scoped routes
+func (s *itemService) checkItemList(ctx context.Context, item domain.Item) error {
+	stored, err := s.repository.GetByID(ctx, item.ID)
+	if err != nil {
+		log.Printf("failed to get item: %s: %v", item.ID, err)
+		return handleError(err)
+	}
+	if domain.ListIDOrDefault(stored.ListID) != domain.ListIDOrDefault(item.ListID) {
+		return handleError(repository.NewItemNotFoundError())
+	}
+	return nil
+}
+
+// lockCategory reports a category that doesn't exist and keeps it from being
+// deleted until the transaction of ctx ends (see repository.CategoryRepository.Lock).
+// An empty one leaves the item uncategorized.
+func (s *itemService) lockCategory(ctx context.Context, categoryID string) error {
+	if categoryID == "" {
+		return nil
+	}
+	err := s.categories.Lock(ctx, categoryID)
+	var errRepository repository.Error
+	if errors.As(err, &errRepository) && errRepository.HTTP != http.StatusInternalServerError {
+		return NewErrorUnknownCategory(err)
+	} else if err != nil {
+		log.Printf("failed to lock category: %s: %v", categoryID, err)
+		return handleError(err)
+	}
+	return nil
+}
+
+// failedBatch is the result of an atomic batch of size operations where the one at index failed with err
+func failedBatch(size, index int, err error) []domain.BatchResult {
+	results := make([]domain.BatchResult, size)
+	for i := range results {
+		results[i].Err = NewErrorFailedDependency()
+	}
+	results[index].Err = err
+	return results
 }
@@ -3,12 +3,17 @@ package service_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
+	"math/big"
 	"net/http"
+	"reflect"
 	"testing"
 
 	"github.com/lucaspereirasilva0/list-manager-api/internal/domain"
 	"github.com/lucaspereirasilva0/list-manager-api/internal/repository"
 	"github.com/lucaspereirasilva0/list-manager-api/internal/service"
+	"github.com/lucaspereirasilva0/list-manager-api/internal/suggest"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -17,8 +22,18 @@ const (
 	_dummyID = "123"
 )
 
+var (
+	_true  = true
+	_false = false
+	// _six is 6 in thousandths, in the service and in the repository
+	_six           domain.Thousandths = 6000
+	_sixRepository int64              = 6000
+)
+
 var (
 	errDummy = errors.New("dummy error")
+	// listFilter is the filter of the routes that don't name a list
+	listFilter = repository.ItemFilter{ListID: repository.DefaultListID}
 )
 
 func TestCreateItem(t *testing.T) {
@@ -35,6 +50,17 @@ func TestCreateItem(t *testing.T) {
 			givenRepositoryItem: mockOutputRepositoryItem(),
 			wantServiceItem:     mockServiceItem(),
 		},
+		{
+			name:                "Given_QuantityWithoutUnit_When_CreateItem_Then_ItemIsCreatedInPieces",
+			givenItem:           domain.Item{Name: "Eggs", Active: true, Quantity: &_six},
+			givenRepositoryItem: repository.Item{ID: _dummyID, Name: "Eggs", Active: true, Quantity: &_sixRepository, Unit: "un"},
+			wantServiceItem:     domain.Item{ID: _dummyID, Name: "Eggs", Active: true, Quantity: &_six, Unit: domain.UnitPiece},
+		},
+		{
+			name:      "Given_UnknownUnit_When_CreateItem_Then_ExpectedInvalidItemError",
+			givenItem: domain.Item{Name: "Milk", Active: true, Quantity: &_six, Unit: "gallon"},
+			wantErr:   service.NewErrorInvalidItem(fmt.Errorf("%w %q", domain.ErrUnknownUnit, "gallon")),
+		},
 		{
 			name:                "Given_Item_When_CreateItem_Then_ExpectedInternalError",
 			givenItem:           mockServiceItem(),
@@ -47,9 +73,10 @@ func TestCreateItem(t *testing.T) {
 			ctx := context.Background()
 
 			mockRepo := &repository.RepositoryMock{}
+			mockLastPosition(ctx, mockRepo, repository.DefaultListID, "")
 			mockRepo.On("Create", ctx, mock.MatchedBy(validateRepositoryItem(tt.givenRepositoryItem))).Return(tt.givenRepositoryItem, tt.wantErr)
 
-			service := service.NewItemService(mockRepo)
+			service := service.NewItemService(mockRepo, &repository.ListRepositoryMock{}, &repository.CategoryRepositoryMock{}, &repository.TxManagerMock{}, suggest.NewIndex())
 			item, err := service.CreateItem(ctx, tt.givenItem)
 
 			if tt.wantErr != nil {
@@ -89,7 +116,7 @@ func TestGetItem(t *testing.T) {
 			mockRepo := &repository.RepositoryMock{}
 			mockRepo.On("GetByID", ctx, tt.givenID).Return(tt.givenRepositoryItem, tt.wantErr)
 
-			service := service.NewItemService(mockRepo)
+			service := service.NewItemService(mockRepo, &repository.ListRepositoryMock{}, &repository.CategoryRepositoryMock{}, &repository.TxManagerMock{}, suggest.NewIndex())
 			item, err := service.GetItem(ctx, tt.givenID)
 
 			require.Equal(t, tt.wantItem, item)
@@ -108,50 +135,45 @@ func TestUpdateItem(t *testing.T) {
 		givenUpdateErr  error
 	}
 
-	type mockGetByID struct {
-		givenItem       repository.Item
-		givenGetByIDErr error
-	}
-
 	tests := []struct {
 		name      string
 		givenItem domain.Item
 		mockUpdate
-		mockGetByID
 		wantServiceItem domain.Item
 		wantErr         error
 	}{
 		{
-			name:      "Given_ValidItem_When_UpdateItem_Then_ExpectedSuccess",
+			name:      "Given_ValidItem_When_UpdateItem_Then_ExpectedStoredItem",
 			givenItem: domain.Item{ID: _dummyID, Name: "updated-name", Active: false},
 			mockUpdate: mockUpdate{
 				givenOutputItem: mockOutputRepositoryItem(),
 			},
-			mockGetByID: mockGetByID{
-				givenItem: mockRepositoryItem(),
-			},
 			wantServiceItem: mockServiceItem(),
 		},
 		{
 			name:      "Given_ItemNotFound_When_UpdateItem_Then_ExpectedNotFoundError",
 			givenItem: domain.Item{ID: _dummyID, Name: "updated-name", Active: false},
-			mockGetByID: mockGetByID{
-				givenGetByIDErr: mockNotFoundRepositoryError(),
+			mockUpdate: mockUpdate{
+				givenUpdateErr: mockNotFoundRepositoryError(),
 			},
 			wantErr: mockNotFoundRepositoryError(),
 		},
 		{
 			name:      "Given_InternalError_When_UpdateItem_Then_ExpectedInternalServerError",
 			givenItem: domain.Item{ID: _dummyID, Name: "updated-name", Active: false},
-			mockGetByID: mockGetByID{
-				givenItem: mockRepositoryItem(),
-			},
 			mockUpdate: mockUpdate{
-				givenOutputItem: mockOutputRepositoryItem(),
-				givenUpdateErr:  repository.NewGenericRepositoryError(errDummy),
+				givenUpdateErr: repository.NewGenericRepositoryError(errDummy),
 			},
 			wantErr: mockInternalServerError(repository.NewGenericRepositoryError(errDummy)),
 		},
+		{
+			name:      "Given_StaleVersion_When_UpdateItem_Then_ExpectedErrorConflict",
+			givenItem: domain.Item{ID: _dummyID, Name: "updated-name", Active: false, Version: 1},
+			mockUpdate: mockUpdate{
+				givenUpdateErr: repository.NewVersionConflictError(),
+			},
+			wantErr: service.NewErrorConflict(repository.NewVersionConflictError()),
+		},
 		{
 			name:      "Given_EmptyItem_When_UpdateItem_Then_ExpectedEmptyItemError",
 			givenItem: domain.Item{ID: "", Name: "any-name", Active: true},
@@ -164,15 +186,12 @@ func TestUpdateItem(t *testing.T) {
 			ctx := context.Background()
 
 			mockRepo := &repository.RepositoryMock{}
-			mockRepo.On("GetByID", ctx, mock.AnythingOfType("string")).
-				Return(tt.mockGetByID.givenItem, tt.givenGetByIDErr)
-
 			if tt.givenOutputItem.ID != "" || tt.givenUpdateErr != nil {
 				mockRepo.On("Update", ctx, mock.AnythingOfType("repository.Item")).
 					Return(tt.givenOutputItem, tt.givenUpdateErr)
 			}
 
-			itemService := service.NewItemService(mockRepo)
+			itemService := service.NewItemService(mockRepo, &repository.ListRepositoryMock{}, &repository.CategoryRepositoryMock{}, &repository.TxManagerMock{}, suggest.NewIndex())
 			item, err := itemService.UpdateItem(ctx, tt.givenItem)
 
 			if tt.wantErr != nil {
@@ -181,24 +200,352 @@ func TestUpdateItem(t *testing.T) {
 				require.NoError(t, err)
 				require.Equal(t, tt.wantServiceItem, item)
 			}
+			// The item is no longer read before the update
+			mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPatchItem(t *testing.T) {
+	name := "updated-name"
+	blank := "  "
+
+	tests := []struct {
+		name            string
+		givenPatch      domain.ItemPatch
+		mockPatchItem   repository.Item
+		mockPatchErr    error
+		mockGetItem     repository.Item
+		wantPatch       repository.ItemPatch
+		wantServiceItem domain.Item
+		wantErr         error
+	}{
+		{
+			name:            "Given_NameAndRemovedObservation_When_PatchItem_Then_OnlyThoseFieldsArePatched",
+			givenPatch:      domain.ItemPatch{Name: &name, SetObservation: true},
+			mockPatchItem:   mockOutputRepositoryItem(),
+			wantPatch:       repository.ItemPatch{Name: &name, SetObservation: true},
+			wantServiceItem: mockServiceItem(),
+		},
+		{
+			name:            "Given_QuantityInLowercaseUnit_When_PatchItem_Then_UnitIsNormalized",
+			givenPatch:      domain.ItemPatch{Quantity: &_six, Unit: "ml", SetQuantity: true},
+			mockPatchItem:   mockOutputRepositoryItem(),
+			wantPatch:       repository.ItemPatch{Quantity: &_sixRepository, Unit: "mL", SetQuantity: true},
+			wantServiceItem: mockServiceItem(),
+		},
+		{
+			name:       "Given_UnitWithoutQuantity_When_PatchItem_Then_ExpectedInvalidItemError",
+			givenPatch: domain.ItemPatch{Unit: "kg"},
+			wantErr:    service.NewErrorInvalidItem(domain.ErrUnitWithoutQuantity),
+		},
+		{
+			name:         "Given_ItemNotFound_When_PatchItem_Then_ExpectedNotFoundError",
+			givenPatch:   domain.ItemPatch{Name: &name},
+			mockPatchErr: mockNotFoundRepositoryError(),
+			wantPatch:    repository.ItemPatch{Name: &name},
+			wantErr:      mockNotFoundRepositoryError(),
+		},
+		{
+			name:       "Given_BlankName_When_PatchItem_Then_ExpectedInvalidItemError",
+			givenPatch: domain.ItemPatch{Name: &blank},
+			wantErr:    service.NewErrorInvalidItem(domain.ErrEmptyName),
+		},
+		{
+			name:            "Given_EmptyPatch_When_PatchItem_Then_StoredItemIsReturned",
+			givenPatch:      domain.ItemPatch{},
+			mockGetItem:     mockOutputRepositoryItem(),
+			wantServiceItem: mockServiceItem(),
+		},
+		{
+			name:         "Given_StaleVersion_When_PatchItem_Then_ExpectedErrorConflict",
+			givenPatch:   domain.ItemPatch{Name: &name, Version: 1},
+			mockPatchErr: repository.NewVersionConflictError(),
+			wantPatch:    repository.ItemPatch{Name: &name, Version: 1},
+			wantErr:      service.NewErrorConflict(repository.NewVersionConflictError()),
+		},
+		{
+			name:        "Given_EmptyPatchWithStaleVersion_When_PatchItem_Then_ExpectedErrorConflict",
+			givenPatch:  domain.ItemPatch{Version: 7},
+			mockGetItem: mockOutputRepositoryItem(),
+			wantErr:     service.NewErrorConflict(repository.NewVersionConflictError()),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockRepo := &repository.RepositoryMock{}
+			if !tt.givenPatch.IsEmpty() && tt.givenPatch.Validate() == nil {
+				mockRepo.On("Patch", ctx, _dummyID, tt.wantPatch).Return(tt.mockPatchItem, tt.mockPatchErr)
+			}
+			if tt.mockGetItem.ID != "" {
+				mockRepo.On("GetByID", ctx, _dummyID).Return(tt.mockGetItem, nil)
+			}
+
+			itemService := service.NewItemService(mockRepo, &repository.ListRepositoryMock{}, &repository.CategoryRepositoryMock{}, &repository.TxManagerMock{}, suggest.NewIndex())
+			item, err := itemService.PatchItem(ctx, _dummyID, tt.givenPatch)
+
+			if tt.wantErr != nil {
+				require.ErrorContains(t, err, tt.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantServiceItem, item)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateItemPosition(t *testing.T) {
+	tests := []struct {
+		name              string
+		givenLastPosition string
+		mockCreateErrs    []error
+		wantPositions     []string
+		wantErr           error
+	}{
+		{
+			name:          "Given_EmptyList_When_CreateItem_Then_ItemGetsTheFirstPosition",
+			wantPositions: []string{domain.FirstPosition},
+		},
+		{
+			name:              "Given_ListWithItems_When_CreateItem_Then_ItemGoesAfterTheLastOne",
+			givenLastPosition: "i0",
+			wantPositions:     []string{"i1"},
+		},
+		{
+			name:              "Given_PositionTakenByAConcurrentCreate_When_CreateItem_Then_ItIsRetried",
+			givenLastPosition: "i0",
+			mockCreateErrs:    []error{repository.NewPositionConflictError()},
+			wantPositions:     []string{"i1", "i1"},
+		},
+		{
+			name:              "Given_PositionAlwaysTaken_When_CreateItem_Then_ExpectedConflictError",
+			givenLastPosition: "i0",
+			mockCreateErrs: []error{
+				repository.NewPositionConflictError(), repository.NewPositionConflictError(), repository.NewPositionConflictError(),
+				repository.NewPositionConflictError(), repository.NewPositionConflictError(),
+			},
+			wantPositions: []string{"i1", "i1", "i1", "i1", "i1"},
+			wantErr:       repository.NewPositionConflictError(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockRepo := &repository.RepositoryMock{}
+			mockLastPosition(ctx, mockRepo, repository.DefaultListID, tt.givenLastPosition).Times(len(tt.wantPositions))
+			for i, position := range tt.wantPositions {
+				var err error
+				if i < len(tt.mockCreateErrs) {
+					err = tt.mockCreateErrs[i]
+				}
+				created := repository.Item{ID: _dummyID, ListID: repository.DefaultListID, Name: "Eggs", Active: true, Position: position}
+				mockRepo.On("Create", ctx, mock.MatchedBy(func(item repository.Item) bool {
+					return item.Position == position
+				})).Return(created, err).Once()
+			}
+
+			itemService := service.NewItemService(mockRepo, &repository.ListRepositoryMock{}, &repository.CategoryRepositoryMock{}, &repository.TxManagerMock{}, suggest.NewIndex())
+			item, err := itemService.CreateItem(ctx, domain.Item{Name: "Eggs", Active: true})
+
+			if tt.wantErr != nil {
+				require.ErrorContains(t, err, tt.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantPositions[len(tt.wantPositions)-1], item.Position)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMoveItem(t *testing.T) {
+	// The list holds a, b and c, then the moved item
+	listItems := []repository.Item{
+		{ID: "a", ListID: repository.DefaultListID, Position: "i0"},
+		{ID: "b", ListID: repository.DefaultListID, Position: "i1"},
+		{ID: "c", ListID: repository.DefaultListID, Position: "i2"},
+		{ID: _dummyID, ListID: repository.DefaultListID, Position: "i3", Version: 4},
+	}
+	otherListItem := repository.Item{ID: "other", ListID: "groceries", Position: "i0"}
+
+	tests := []struct {
+		name           string
+		givenMove      domain.ItemMove
+		mockPatchErrs  []error
+		mockListErr    error
+		wantPosition   string
+		wantPatchCalls int
+		wantErr        error
+	}{
+		{
+			name:           "Given_After_When_MoveItem_Then_ItemGoesBetweenItAndTheNextOne",
+			givenMove:      domain.ItemMove{After: "a"},
+			wantPosition:   "i0i",
+			wantPatchCalls: 1,
+		},
+		{
+			name:           "Given_AfterTheItemBeforeIt_When_MoveItem_Then_ItemItselfIsSkipped",
+			givenMove:      domain.ItemMove{After: "c"},
+			wantPosition:   "i3",
+			wantPatchCalls: 1,
+		},
+		{
+			name:           "Given_BeforeTheFirstItem_When_MoveItem_Then_ItemGoesFirst",
+			givenMove:      domain.ItemMove{Before: "a"},
+			wantPosition:   "hz",
+			wantPatchCalls: 1,
+		},
+		{
+			name:           "Given_Before_When_MoveItem_Then_ItemGoesBetweenItAndThePreviousOne",
+			givenMove:      domain.ItemMove{Before: "c"},
+			wantPosition:   "i1i",
+			wantPatchCalls: 1,
+		},
+		{
+			name:           "Given_AfterAndBefore_When_MoveItem_Then_ItemGoesBetweenThem",
+			givenMove:      domain.ItemMove{After: "a", Before: "b", Version: 4},
+			wantPosition:   "i0i",
+			wantPatchCalls: 1,
+		},
+		{
+			name:      "Given_BeforeThatNoLongerComesAfterAfter_When_MoveItem_Then_ExpectedStaleNeighboursError",
+			givenMove: domain.ItemMove{After: "b", Before: "a"},
+			wantErr:   service.NewErrorStaleNeighbours(),
+		},
+		{
+			name:      "Given_NoNeighbour_When_MoveItem_Then_ExpectedInvalidMoveError",
+			givenMove: domain.ItemMove{},
+			wantErr:   service.NewErrorInvalidMove(domain.ErrMoveWithoutNeighbour),
+		},
+		{
+			name:      "Given_NeighbourInAnotherList_When_MoveItem_Then_ExpectedInvalidMoveError",
+			givenMove: domain.ItemMove{After: "other"},
+			wantErr:   service.NewErrorInvalidMove(fmt.Errorf("neighbour %q is in another list", "other")),
+		},
+		{
+			name:      "Given_MissingNeighbour_When_MoveItem_Then_ExpectedInvalidMoveError",
+			givenMove: domain.ItemMove{Before: "missing"},
+			wantErr:   service.NewErrorInvalidMove(fmt.Errorf("neighbour %q: %s", "missing", "item not found")),
+		},
+		{
+			name:      "Given_StaleVersion_When_MoveItem_Then_ExpectedErrorConflict",
+			givenMove: domain.ItemMove{After: "a", Version: 3},
+			wantErr:   service.NewErrorConflict(repository.NewVersionConflictError()),
+		},
+		{
+			name:        "Given_ListFails_When_MoveItem_Then_ExpectedInternalError",
+			givenMove:   domain.ItemMove{After: "a"},
+			mockListErr: repository.NewGenericRepositoryError(errDummy),
+			wantErr:     mockInternalServerError(repository.NewGenericRepositoryError(errDummy)),
+		},
+		{
+			name:           "Given_PositionTakenByAConcurrentMove_When_MoveItem_Then_NeighboursAreReadAgain",
+			givenMove:      domain.ItemMove{After: "a"},
+			mockPatchErrs:  []error{repository.NewPositionConflictError()},
+			wantPosition:   "i0i",
+			wantPatchCalls: 2,
+		},
+		{
+			name:      "Given_PositionAlwaysTaken_When_MoveItem_Then_ExpectedConflictError",
+			givenMove: domain.ItemMove{After: "a"},
+			mockPatchErrs: []error{
+				repository.NewPositionConflictError(), repository.NewPositionConflictError(), repository.NewPositionConflictError(),
+				repository.NewPositionConflictError(), repository.NewPositionConflictError(),
+			},
+			wantPosition:   "i0i",
+			wantPatchCalls: 5,
+			wantErr:        repository.NewPositionConflictError(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockRepo := &repository.RepositoryMock{}
+			for i, item := range listItems {
+				mockRepo.On("GetByID", ctx, item.ID).Return(item, nil).Maybe()
+				for _, previous := range []bool{false, true} {
+					sort := []repository.SortField{{Field: repository.SortFieldPosition, Desc: previous}}
+					adjacent := []repository.Item{}
+					if previous {
+						for j := i - 1; j >= 0 && len(adjacent) < 2; j-- {
+							adjacent = append(adjacent, listItems[j])
+						}
+					} else {
+						for j := i + 1; j < len(listItems) && len(adjacent) < 2; j++ {
+							adjacent = append(adjacent, listItems[j])
+						}
+					}
+					mockRepo.On("List", ctx, repository.ListOptions{
+						Limit:  2,
+						Cursor: repository.EncodeCursor(item, sort),
+						Filter: listFilter,
+						Sort:   sort,
+					}).Return(repository.ItemPage{Items: adjacent}, tt.mockListErr).Maybe()
+				}
+			}
+			mockRepo.On("GetByID", ctx, otherListItem.ID).Return(otherListItem, nil).Maybe()
+			mockRepo.On("GetByID", ctx, "missing").Return(repository.Item{}, repository.NewItemNotFoundError()).Maybe()
+			for i := range tt.wantPatchCalls {
+				var err error
+				if i < len(tt.mockPatchErrs) {
+					err = tt.mockPatchErrs[i]
+				}
+				moved := listItems[3]
+				moved.Position = tt.wantPosition
+				mockRepo.On("Patch", ctx, _dummyID, repository.ItemPatch{Position: &tt.wantPosition, Version: tt.givenMove.Version}).
+					Return(moved, err).Once()
+			}
+
+			itemService := service.NewItemService(mockRepo, &repository.ListRepositoryMock{}, &repository.CategoryRepositoryMock{}, &repository.TxManagerMock{}, suggest.NewIndex())
+			item, err := itemService.MoveItem(ctx, _dummyID, tt.givenMove)
+
+			if tt.wantErr != nil {
+				require.ErrorContains(t, err, tt.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantPosition, item.Position)
+			}
+			mockRepo.AssertExpectations(t)
 		})
 	}
 }
 
 func TestDeleteItem(t *testing.T) {
 	tests := []struct {
-		name    string
-		givenID string
-		wantErr error
+		name               string
+		givenID            string
+		givenVersion       int64
+		givenRepositoryErr error
+		wantErr            error
 	}{
 		{
 			name:    "Given_Item_When_DeleteItem_Then_ExpectedSuccess",
 			givenID: _dummyID,
 		},
 		{
-			name:    "Given_Item_When_DeleteItem_Then_ExpectedErrFailedDeleteItem",
-			givenID: _dummyID,
-			wantErr: mockInternalServerError(repository.NewGenericRepositoryError(errDummy)),
+			name:         "Given_CurrentVersion_When_DeleteItem_Then_ExpectedSuccess",
+			givenID:      _dummyID,
+			givenVersion: 3,
+		},
+		{
+			name:               "Given_Item_When_DeleteItem_Then_ExpectedErrFailedDeleteItem",
+			givenID:            _dummyID,
+			givenRepositoryErr: mockInternalServerError(repository.NewGenericRepositoryError(errDummy)),
+			wantErr:            mockInternalServerError(repository.NewGenericRepositoryError(errDummy)),
+		},
+		{
+			name:               "Given_StaleVersion_When_DeleteItem_Then_ExpectedErrorConflict",
+			givenID:            _dummyID,
+			givenVersion:       2,
+			givenRepositoryErr: repository.NewVersionConflictError(),
+			wantErr:            service.NewErrorConflict(repository.NewVersionConflictError()),
 		},
 	}
 
@@ -207,13 +554,14 @@ func TestDeleteItem(t *testing.T) {
 			ctx := context.Background()
 
 			mockRepo := &repository.RepositoryMock{}
-			mockRepo.On("Delete", ctx, tt.givenID).Return(tt.wantErr)
+			mockRepo.On("Delete", ctx, tt.givenID, tt.givenVersion).Return(tt.givenRepositoryErr)
 
-			service := service.NewItemService(mockRepo)
-			err := service.DeleteItem(ctx, tt.givenID)
+			service := service.NewItemService(mockRepo, &repository.ListRepositoryMock{}, &repository.CategoryRepositoryMock{}, &repository.TxManagerMock{}, suggest.NewIndex())
+			err := service.DeleteItem(ctx, tt.givenID, tt.givenVersion)
 
 			if tt.wantErr != nil {
 				require.ErrorContains(t, err, tt.wantErr.Error())
+				require.IsType(t, tt.wantErr, err)
 			} else {
 				require.NoError(t, err)
 			}
@@ -223,26 +571,66 @@ func TestDeleteItem(t *testing.T) {
 
 func TestListItems(t *testing.T) {
 	tests := []struct {
-		name                 string
-		givenRepositoryItems []repository.Item
-		wantServiceItems     []domain.Item
-		wantErr              error
+		name                  string
+		givenOptions          domain.ListOptions
+		givenRepositoryPage   repository.ItemPage
+		givenRepositoryErr    error
+		wantRepositoryOptions repository.ListOptions
+		wantServicePage       domain.ItemPage
+		wantErr               error
 	}{
 		{
-			name:                 "Given_Items_When_ListItems_Then_ExpectedSuccess",
-			givenRepositoryItems: []repository.Item{mockOutputRepositoryItem()},
-			wantServiceItems:     []domain.Item{mockServiceItem()},
+			name:                  "Given_Items_When_ListItems_Then_ExpectedSuccess",
+			givenRepositoryPage:   repository.ItemPage{Items: []repository.Item{mockOutputRepositoryItem()}},
+			wantRepositoryOptions: repository.ListOptions{Limit: domain.DefaultListLimit, Filter: listFilter},
+			wantServicePage:       domain.ItemPage{Items: []domain.Item{mockServiceItem()}},
 		},
 		{
-			name:                 "Given_NoItems_When_ListItems_Then_ExpectedEmptyList",
-			givenRepositoryItems: []repository.Item{},
-			wantServiceItems:     []domain.Item{},
+			name:                  "Given_NoItems_When_ListItems_Then_ExpectedEmptyList",
+			givenRepositoryPage:   repository.ItemPage{Items: []repository.Item{}},
+			wantRepositoryOptions: repository.ListOptions{Limit: domain.DefaultListLimit, Filter: listFilter},
+			wantServicePage:       domain.ItemPage{Items: []domain.Item{}},
 		},
 		{
-			name:                 "Given_Error_When_ListItems_Then_ExpectedInternalError",
-			givenRepositoryItems: []repository.Item{},
-			wantServiceItems:     []domain.Item{},
-			wantErr:              mockInternalServerError(repository.NewGenericRepositoryError(errDummy)),
+			name:                  "Given_LimitAndCursor_When_ListItems_Then_ExpectedPageWithNextCursor",
+			givenOptions:          domain.ListOptions{Limit: 1, Cursor: "cursor"},
+			givenRepositoryPage:   repository.ItemPage{Items: []repository.Item{mockOutputRepositoryItem()}, NextCursor: "next"},
+			wantRepositoryOptions: repository.ListOptions{Limit: 1, Cursor: "cursor", Filter: listFilter},
+			wantServicePage:       domain.ItemPage{Items: []domain.Item{mockServiceItem()}, NextCursor: "next"},
+		},
+		{
+			name:                  "Given_Filter_When_ListItems_Then_FilterIsPassedToRepository",
+			givenOptions:          domain.ListOptions{Filter: domain.ItemFilter{Active: &_false, Name: "milk", HasObservation: &_true}},
+			givenRepositoryPage:   repository.ItemPage{Items: []repository.Item{}},
+			wantRepositoryOptions: repository.ListOptions{Limit: domain.DefaultListLimit, Filter: repository.ItemFilter{ListID: repository.DefaultListID, Active: &_false, Name: "milk", HasObservation: &_true}},
+			wantServicePage:       domain.ItemPage{Items: []domain.Item{}},
+		},
+		{
+			name:                  "Given_Sort_When_ListItems_Then_SortIsPassedToRepository",
+			givenOptions:          domain.ListOptions{Sort: []domain.SortField{{Field: domain.SortByUpdatedAt, Desc: true}, {Field: domain.SortByName}}},
+			givenRepositoryPage:   repository.ItemPage{Items: []repository.Item{}},
+			wantRepositoryOptions: repository.ListOptions{Limit: domain.DefaultListLimit, Filter: listFilter, Sort: []repository.SortField{{Field: repository.SortFieldUpdatedAt, Desc: true}, {Field: repository.SortFieldName}}},
+			wantServicePage:       domain.ItemPage{Items: []domain.Item{}},
+		},
+		{
+			name:                  "Given_LimitAboveMax_When_ListItems_Then_LimitIsCapped",
+			givenOptions:          domain.ListOptions{Limit: domain.MaxListLimit + 1},
+			givenRepositoryPage:   repository.ItemPage{Items: []repository.Item{}},
+			wantRepositoryOptions: repository.ListOptions{Limit: domain.MaxListLimit, Filter: listFilter},
+			wantServicePage:       domain.ItemPage{Items: []domain.Item{}},
+		},
+		{
+			name:                  "Given_InvalidCursor_When_ListItems_Then_ExpectedBadRequestError",
+			givenOptions:          domain.ListOptions{Cursor: "invalid"},
+			givenRepositoryErr:    repository.NewInvalidCursorError(errDummy),
+			wantRepositoryOptions: repository.ListOptions{Limit: domain.DefaultListLimit, Cursor: "invalid", Filter: listFilter},
+			wantErr:               service.NewErrorService(repository.NewInvalidCursorError(errDummy), "invalid cursor", service.RepositorySource, http.StatusBadRequest),
+		},
+		{
+			name:                  "Given_Error_When_ListItems_Then_ExpectedInternalError",
+			givenRepositoryErr:    repository.NewGenericRepositoryError(errDummy),
+			wantRepositoryOptions: repository.ListOptions{Limit: domain.DefaultListLimit, Filter: listFilter},
+			wantErr:               mockInternalServerError(repository.NewGenericRepositoryError(errDummy)),
 		},
 	}
 
@@ -251,10 +639,86 @@ func TestListItems(t *testing.T) {
 			ctx := context.Background()
 
 			mockRepo := &repository.RepositoryMock{}
-			mockRepo.On("List", ctx).Return(tt.givenRepositoryItems, tt.wantErr)
+			mockRepo.On("List", ctx, tt.wantRepositoryOptions).Return(tt.givenRepositoryPage, tt.givenRepositoryErr)
 
-			service := service.NewItemService(mockRepo)
-			items, err := service.ListItems(ctx)
+			service := service.NewItemService(mockRepo, &repository.ListRepositoryMock{}, &repository.CategoryRepositoryMock{}, &repository.TxManagerMock{}, suggest.NewIndex())
+			page, err := service.ListItems(ctx, tt.givenOptions)
+
+			if tt.wantErr != nil {
+				require.ErrorContains(t, err, tt.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantServicePage, page)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSearchItems(t *testing.T) {
+	const listID = "000000000000000000000010"
+	tests := []struct {
+		name                string
+		givenListID         string
+		givenList           error
+		givenLimit          int
+		givenRepositoryErr  error
+		givenRepositoryItem []repository.Item
+		wantRepositoryLimit int
+		wantServiceItems    []domain.Item
+		wantErr             error
+	}{
+		{
+			name:                "Given_Matches_When_SearchItems_Then_ExpectedItems",
+			givenRepositoryItem: []repository.Item{mockOutputRepositoryItem()},
+			wantRepositoryLimit: domain.DefaultSearchLimit,
+			wantServiceItems:    []domain.Item{mockServiceItem()},
+		},
+		{
+			name:                "Given_LimitAboveMax_When_SearchItems_Then_LimitIsCapped",
+			givenLimit:          domain.MaxSearchLimit + 1,
+			givenRepositoryItem: []repository.Item{},
+			wantRepositoryLimit: domain.MaxSearchLimit,
+			wantServiceItems:    []domain.Item{},
+		},
+		{
+			name:                "Given_List_When_SearchItems_Then_ExpectedItemsOfTheList",
+			givenListID:         listID,
+			givenRepositoryItem: []repository.Item{mockOutputRepositoryItem()},
+			wantRepositoryLimit: domain.DefaultSearchLimit,
+			wantServiceItems:    []domain.Item{mockServiceItem()},
+		},
+		{
+			name:        "Given_UnknownList_When_SearchItems_Then_ExpectedNotFoundError",
+			givenListID: listID,
+			givenList:   repository.NewListNotFoundError(),
+			wantErr:     service.NewErrorService(repository.NewListNotFoundError(), "list not found", service.RepositorySource, http.StatusNotFound),
+		},
+		{
+			name:                "Given_Error_When_SearchItems_Then_ExpectedInternalError",
+			givenLimit:          5,
+			givenRepositoryErr:  repository.NewGenericRepositoryError(errDummy),
+			givenRepositoryItem: []repository.Item(nil),
+			wantRepositoryLimit: 5,
+			wantErr:             mockInternalServerError(repository.NewGenericRepositoryError(errDummy)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockRepo := &repository.RepositoryMock{}
+			if tt.wantRepositoryLimit != 0 {
+				mockRepo.On("Search", ctx, "feijao", tt.givenListID, tt.wantRepositoryLimit).Return(tt.givenRepositoryItem, tt.givenRepositoryErr)
+			}
+			mockLists := &repository.ListRepositoryMock{}
+			if tt.givenListID != "" {
+				mockLists.On("GetByID", ctx, tt.givenListID).Return(repository.List{ID: tt.givenListID, Name: "Pharmacy"}, tt.givenList)
+			}
+
+			service := service.NewItemService(mockRepo, mockLists, &repository.CategoryRepositoryMock{}, &repository.TxManagerMock{}, suggest.NewIndex())
+			items, err := service.SearchItems(ctx, "feijao", tt.givenListID, tt.givenLimit)
 
 			if tt.wantErr != nil {
 				require.ErrorContains(t, err, tt.wantErr.Error())
@@ -262,51 +726,720 @@ func TestListItems(t *testing.T) {
 				require.NoError(t, err)
 				require.Equal(t, tt.wantServiceItems, items)
 			}
+			mockRepo.AssertExpectations(t)
+			mockLists.AssertExpectations(t)
 		})
 	}
 }
 
+func TestSuggestItemNames(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepo := &repository.RepositoryMock{}
+	mockLastPosition(ctx, mockRepo, repository.DefaultListID, "")
+	mockRepo.On("Create", ctx, mock.AnythingOfType("repository.Item")).Return(repository.Item{ID: "1", Name: "Feijão"}, nil).Once()
+	mockRepo.On("Create", ctx, mock.AnythingOfType("repository.Item")).Return(repository.Item{ID: "2", Name: "Feijão"}, nil).Once()
+	mockRepo.On("Update", ctx, mock.AnythingOfType("repository.Item")).Return(repository.Item{ID: "2", Name: "Feijão preto"}, nil).Once()
+
+	itemService := service.NewItemService(mockRepo, &repository.ListRepositoryMock{}, &repository.CategoryRepositoryMock{}, &repository.TxManagerMock{}, suggest.NewIndex())
+
+	_, err := itemService.CreateItem(ctx, domain.Item{Name: "Feijão"})
+	require.NoError(t, err)
+	_, err = itemService.CreateItem(ctx, domain.Item{Name: "Feijão"})
+	require.NoError(t, err)
+	_, err = itemService.UpdateItem(ctx, domain.Item{ID: "2", Name: "Feijão preto"})
+	require.NoError(t, err)
+
+	suggestions, err := itemService.SuggestItemNames(ctx, "fejao", 0)
+	require.NoError(t, err)
+	require.Equal(t, []domain.Suggestion{{Name: "Feijão", Count: 2}, {Name: "Feijão preto", Count: 1}}, suggestions)
+
+	suggestions, err = itemService.SuggestItemNames(ctx, "feijão p", 1)
+	require.NoError(t, err)
+	require.Equal(t, []domain.Suggestion{{Name: "Feijão preto", Count: 1}}, suggestions)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestLoadNames(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Given_SeveralPages_When_LoadNames_Then_EveryNameIsIndexed", func(t *testing.T) {
+		mockRepo := &repository.RepositoryMock{}
+		mockRepo.On("List", ctx, repository.ListOptions{Limit: domain.MaxListLimit}).
+			Return(repository.ItemPage{Items: []repository.Item{{ID: "1", Name: "Arroz"}}, NextCursor: "next"}, nil).Once()
+		mockRepo.On("List", ctx, repository.ListOptions{Limit: domain.MaxListLimit, Cursor: "next"}).
+			Return(repository.ItemPage{Items: []repository.Item{{ID: "2", Name: "Arroz"}, {ID: "3", Name: "Leite"}}}, nil).Once()
+
+		names := suggest.NewIndex()
+		err := service.LoadNames(ctx, mockRepo, names)
+		require.NoError(t, err)
+		require.Equal(t, 2, names.Len())
+		require.Equal(t, []suggest.Suggestion{{Name: "Arroz", Count: 2}}, names.Suggest("arr", 10))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Given_RepositoryError_When_LoadNames_Then_ExpectedInternalError", func(t *testing.T) {
+		mockRepo := &repository.RepositoryMock{}
+		mockRepo.On("List", ctx, mock.Anything).Return(repository.ItemPage{}, repository.NewGenericRepositoryError(errDummy)).Once()
+
+		err := service.LoadNames(ctx, mockRepo, suggest.NewIndex())
+		require.ErrorContains(t, err, mockInternalServerError(repository.NewGenericRepositoryError(errDummy)).Error())
+	})
+}
+
 func TestBulkUpdateActive(t *testing.T) {
+	tooManyIDs := make([]string, domain.MaxActiveUpdateIDs+1)
+
+	tests := []struct {
+		name                  string
+		givenUpdate           domain.ActiveUpdate
+		givenRepositoryResult repository.ActiveUpdateResult
+		givenRepositoryErr    error
+		wantRepositoryUpdate  *repository.ActiveUpdate
+		wantResult            domain.ActiveUpdateResult
+		wantErr               error
+	}{
+		{
+			name:                  "Given_TrueActive_When_BulkUpdateActive_Then_ReturnsSuccess",
+			givenUpdate:           domain.ActiveUpdate{Active: true},
+			givenRepositoryResult: repository.ActiveUpdateResult{MatchedCount: 5, ModifiedCount: 5},
+			wantRepositoryUpdate:  &repository.ActiveUpdate{Active: true, IDs: []string{}, Filter: listFilter},
+			wantResult:            domain.ActiveUpdateResult{MatchedCount: 5, ModifiedCount: 5},
+		},
+		{
+			name:                  "Given_EmptyCollection_When_BulkUpdateActive_Then_ReturnsZeroCounts",
+			givenUpdate:           domain.ActiveUpdate{Active: false},
+			givenRepositoryResult: repository.ActiveUpdateResult{},
+			wantRepositoryUpdate:  &repository.ActiveUpdate{Active: false, IDs: []string{}, Filter: listFilter},
+		},
+		{
+			name:                  "Given_RepeatedIDs_When_BulkUpdateActive_Then_EachIDIsSentOnceAndNotFoundAreReturned",
+			givenUpdate:           domain.ActiveUpdate{Active: false, IDs: []string{_dummyID, "456", _dummyID}},
+			givenRepositoryResult: repository.ActiveUpdateResult{MatchedCount: 1, ModifiedCount: 1, NotFoundIDs: []string{"456"}},
+			wantRepositoryUpdate:  &repository.ActiveUpdate{Active: false, IDs: []string{_dummyID, "456"}, Filter: listFilter},
+			wantResult:            domain.ActiveUpdateResult{MatchedCount: 1, ModifiedCount: 1, NotFoundIDs: []string{"456"}},
+		},
+		{
+			name:                  "Given_Filter_When_BulkUpdateActive_Then_FilterIsSentToRepository",
+			givenUpdate:           domain.ActiveUpdate{Active: true, Filter: domain.ItemFilter{Active: &_false}},
+			givenRepositoryResult: repository.ActiveUpdateResult{MatchedCount: 2, ModifiedCount: 2},
+			wantRepositoryUpdate:  &repository.ActiveUpdate{Active: true, IDs: []string{}, Filter: repository.ItemFilter{ListID: repository.DefaultListID, Active: &_false}},
+			wantResult:            domain.ActiveUpdateResult{MatchedCount: 2, ModifiedCount: 2},
+		},
+		{
+			name:        "Given_IDsAndFilter_When_BulkUpdateActive_Then_ReturnsInvalidActiveUpdateError",
+			givenUpdate: domain.ActiveUpdate{Active: true, IDs: []string{_dummyID}, Filter: domain.ItemFilter{Name: "milk"}},
+			wantErr:     service.NewErrorInvalidActiveUpdate(errors.New("ids and filter cannot be combined")),
+		},
+		{
+			name:        "Given_EmptyID_When_BulkUpdateActive_Then_ReturnsInvalidActiveUpdateError",
+			givenUpdate: domain.ActiveUpdate{Active: true, IDs: []string{_dummyID, ""}},
+			wantErr:     service.NewErrorInvalidActiveUpdate(errors.New("ids cannot be empty")),
+		},
+		{
+			name:        "Given_TooManyIDs_When_BulkUpdateActive_Then_ReturnsInvalidActiveUpdateError",
+			givenUpdate: domain.ActiveUpdate{Active: true, IDs: tooManyIDs},
+			wantErr:     service.NewErrorInvalidActiveUpdate(errors.New("more than 500 ids")),
+		},
+		{
+			name:                 "Given_DatabaseError_When_BulkUpdateActive_Then_ReturnsError",
+			givenUpdate:          domain.ActiveUpdate{Active: true},
+			givenRepositoryErr:   mockInternalServerError(repository.NewGenericRepositoryError(errDummy)),
+			wantRepositoryUpdate: &repository.ActiveUpdate{Active: true, IDs: []string{}, Filter: listFilter},
+			wantErr:              mockInternalServerError(repository.NewGenericRepositoryError(errDummy)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockRepo := &repository.RepositoryMock{}
+			if tt.wantRepositoryUpdate != nil {
+				mockRepo.On("BulkUpdateActive", ctx, *tt.wantRepositoryUpdate).Return(tt.givenRepositoryResult, tt.givenRepositoryErr)
+			}
+
+			svc := service.NewItemService(mockRepo, &repository.ListRepositoryMock{}, &repository.CategoryRepositoryMock{}, &repository.TxManagerMock{}, suggest.NewIndex())
+			result, err := svc.BulkUpdateActive(ctx, tt.givenUpdate)
+
+			if tt.wantErr != nil {
+				require.ErrorContains(t, err, tt.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteItems(t *testing.T) {
+	tests := []struct {
+		name               string
+		givenFilter        domain.ItemFilter
+		givenDeletedCount  int64
+		givenRepositoryErr error
+		wantDeletedCount   int64
+		wantErr            error
+	}{
+		{
+			name:              "Given_InactiveFilter_When_DeleteItems_Then_ReturnsDeletedCount",
+			givenFilter:       domain.ItemFilter{Active: &_false},
+			givenDeletedCount: 4,
+			wantDeletedCount:  4,
+		},
+		{
+			name:    "Given_EmptyFilter_When_DeleteItems_Then_ReturnsEmptyFilterError",
+			wantErr: service.NewErrorEmptyFilter(),
+		},
+		{
+			name:               "Given_DatabaseError_When_DeleteItems_Then_ReturnsError",
+			givenFilter:        domain.ItemFilter{Name: "milk"},
+			givenRepositoryErr: repository.NewGenericRepositoryError(errDummy),
+			wantErr:            mockInternalServerError(repository.NewGenericRepositoryError(errDummy)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockRepo := &repository.RepositoryMock{}
+			if !tt.givenFilter.IsEmpty() {
+				wantFilter := repository.ItemFilter{ListID: repository.DefaultListID, Active: tt.givenFilter.Active, Name: tt.givenFilter.Name}
+				mockRepo.On("DeleteMany", ctx, wantFilter).Return(tt.givenDeletedCount, tt.givenRepositoryErr)
+			}
+
+			svc := service.NewItemService(mockRepo, &repository.ListRepositoryMock{}, &repository.CategoryRepositoryMock{}, &repository.TxManagerMock{}, suggest.NewIndex())
+			deletedCount, err := svc.DeleteItems(ctx, tt.givenFilter)
+
+			if tt.wantErr != nil {
+				require.ErrorContains(t, err, tt.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantDeletedCount, deletedCount)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTotalQuantities(t *testing.T) {
+	rice := func(quantity int64, unit string) repository.Item {
+		return repository.Item{ID: _dummyID, Name: "Rice", Active: true, Quantity: &quantity, Unit: unit}
+	}
+
 	tests := []struct {
 		name               string
-		givenActive        bool
-		givenMatchedCount  int64
-		givenModifiedCount int64
+		givenPages         []repository.ItemPage
 		givenRepositoryErr error
-		wantMatchedCount   int64
-		wantModifiedCount  int64
+		wantTotals         []domain.Quantity
 		wantErr            error
 	}{
 		{
-			name:               "Given_TrueActive_When_BulkUpdateActive_Then_ReturnsSuccess",
-			givenActive:        true,
-			givenMatchedCount:  5,
-			givenModifiedCount: 5,
-			wantMatchedCount:   5,
-			wantModifiedCount:  5,
+			name: "Given_ItemsOverSeveralPages_When_TotalQuantities_Then_CompatibleUnitsAreSummed",
+			givenPages: []repository.ItemPage{
+				{Items: []repository.Item{rice(1000, "kg"), rice(500_000, "g")}, NextCursor: "next"},
+				{Items: []repository.Item{mockOutputRepositoryItem(), rice(2000, "pack")}},
+			},
+			wantTotals: []domain.Quantity{{Value: 1500, Unit: domain.UnitKilogram}, {Value: 2000, Unit: domain.UnitPack}},
 		},
 		{
-			name:               "Given_FalseActive_When_BulkUpdateActive_Then_ReturnsSuccess",
-			givenActive:        false,
-			givenMatchedCount:  3,
-			givenModifiedCount: 3,
-			wantMatchedCount:   3,
-			wantModifiedCount:  3,
+			name:       "Given_ItemsWithoutQuantity_When_TotalQuantities_Then_ExpectedNoTotals",
+			givenPages: []repository.ItemPage{{Items: []repository.Item{mockOutputRepositoryItem()}}},
+			wantTotals: []domain.Quantity{},
 		},
 		{
-			name:               "Given_EmptyCollection_When_BulkUpdateActive_Then_ReturnsZeroCounts",
-			givenActive:        true,
-			givenMatchedCount:  0,
-			givenModifiedCount: 0,
-			wantMatchedCount:   0,
-			wantModifiedCount:  0,
+			name:       "Given_TotalOverflowingThousandths_When_TotalQuantities_Then_ExpectedInvalidTotalError",
+			givenPages: []repository.ItemPage{{Items: []repository.Item{rice(math.MaxInt64, "kg"), rice(1000, "kg")}}},
+			wantErr:    service.NewErrorInvalidTotal(fmt.Errorf("total in kg: %w", domain.ErrQuantityOutOfRange)),
 		},
 		{
-			name:               "Given_DatabaseError_When_BulkUpdateActive_Then_ReturnsError",
-			givenActive:        true,
-			givenMatchedCount:  0,
-			givenModifiedCount: 0,
-			givenRepositoryErr: mockInternalServerError(repository.NewGenericRepositoryError(errDummy)),
+			name:               "Given_DatabaseError_When_TotalQuantities_Then_ExpectedInternalError",
+			givenPages:         []repository.ItemPage{{}},
+			givenRepositoryErr: repository.NewGenericRepositoryError(errDummy),
+			wantErr:            mockInternalServerError(repository.NewGenericRepositoryError(errDummy)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			filter := repository.ItemFilter{ListID: repository.DefaultListID, Active: &_true}
+
+			mockRepo := &repository.RepositoryMock{}
+			cursor := ""
+			for _, page := range tt.givenPages {
+				mockRepo.On("List", ctx, repository.ListOptions{Limit: domain.MaxListLimit, Cursor: cursor, Filter: filter}).Return(page, tt.givenRepositoryErr).Once()
+				cursor = page.NextCursor
+			}
+
+			svc := service.NewItemService(mockRepo, &repository.ListRepositoryMock{}, &repository.CategoryRepositoryMock{}, &repository.TxManagerMock{}, suggest.NewIndex())
+			totals, err := svc.TotalQuantities(ctx, domain.ItemFilter{Active: &_true})
+
+			if tt.wantErr != nil {
+				require.ErrorContains(t, err, tt.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantTotals, totals)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemsOfList(t *testing.T) {
+	const listID = "list-1"
+	listIDFilter := repository.ItemFilter{ListID: listID}
+
+	tests := []struct {
+		name       string
+		givenList  error
+		wantCalled bool
+		wantErr    error
+	}{
+		{
+			name:       "Given_ExistingList_When_ItemsOfList_Then_RepositoryIsScopedToList",
+			wantCalled: true,
+		},
+		{
+			name:      "Given_MissingList_When_ItemsOfList_Then_ExpectedListNotFoundError",
+			givenList: repository.NewListNotFoundError(),
+			wantErr:   service.NewErrorService(repository.NewListNotFoundError(), "list not found", service.RepositorySource, http.StatusNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockLists := &repository.ListRepositoryMock{}
+			mockLists.On("GetByID", ctx, listID).Return(repository.List{ID: listID, Name: "Pharmacy"}, tt.givenList)
+			mockLists.On("Lock", ctx, listID).Return(tt.givenList)
+
+			mockRepo := &repository.RepositoryMock{}
+			if tt.wantCalled {
+				mockLastPosition(ctx, mockRepo, listID, "")
+				mockRepo.On("Create", ctx, mock.MatchedBy(func(item repository.Item) bool { return item.ListID == listID })).Return(repository.Item{ID: _dummyID, ListID: listID}, nil)
+				mockRepo.On("List", ctx, repository.ListOptions{Limit: domain.DefaultListLimit, Filter: listIDFilter}).Return(repository.ItemPage{}, nil)
+				mockRepo.On("BulkUpdateActive", ctx, repository.ActiveUpdate{Active: true, IDs: []string{_dummyID}, Filter: listIDFilter}).Return(repository.ActiveUpdateResult{}, nil)
+				mockRepo.On("DeleteMany", ctx, repository.ItemFilter{ListID: listID, Active: &_true}).Return(int64(0), nil)
+			}
+
+			svc := service.NewItemService(mockRepo, mockLists, &repository.CategoryRepositoryMock{}, &repository.TxManagerMock{}, suggest.NewIndex())
+
+			item, err := svc.CreateItem(ctx, domain.Item{ListID: listID, Name: "Aspirin"})
+			if tt.wantErr == nil {
+				require.Equal(t, listID, item.ListID)
+			}
+			require.Equal(t, tt.wantErr, err)
+
+			_, err = svc.ListItems(ctx, domain.ListOptions{Filter: domain.ItemFilter{ListID: listID}})
+			require.Equal(t, tt.wantErr, err)
+
+			_, err = svc.BulkUpdateActive(ctx, domain.ActiveUpdate{Active: true, IDs: []string{_dummyID}, Filter: domain.ItemFilter{ListID: listID}})
+			require.Equal(t, tt.wantErr, err)
+
+			_, err = svc.DeleteItems(ctx, domain.ItemFilter{ListID: listID, Active: &_true})
+			require.Equal(t, tt.wantErr, err)
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemCategory(t *testing.T) {
+	const categoryID = "category-1"
+
+	tests := []struct {
+		name          string
+		givenCategory error
+		wantCalled    bool
+		wantErr       error
+	}{
+		{
+			name:       "Given_ExistingCategory_When_ItemIsPutInIt_Then_RepositoryStoresIt",
+			wantCalled: true,
+		},
+		{
+			name:          "Given_MissingCategory_When_ItemIsPutInIt_Then_ExpectedUnknownCategoryError",
+			givenCategory: repository.NewCategoryNotFoundError(),
+			wantErr:       service.NewErrorUnknownCategory(repository.NewCategoryNotFoundError()),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockCategories := &repository.CategoryRepositoryMock{}
+			mockCategories.On("Lock", ctx, categoryID).Return(tt.givenCategory)
+
+			mockRepo := &repository.RepositoryMock{}
+			if tt.wantCalled {
+				mockLastPosition(ctx, mockRepo, repository.DefaultListID, "")
+				mockRepo.On("Create", ctx, mock.MatchedBy(func(item repository.Item) bool { return item.CategoryID == categoryID })).
+					Return(repository.Item{ID: _dummyID, CategoryID: categoryID}, nil)
+				mockRepo.On("Update", ctx, mock.MatchedBy(func(item repository.Item) bool { return item.CategoryID == categoryID })).
+					Return(repository.Item{ID: _dummyID, CategoryID: categoryID}, nil)
+				mockRepo.On("Patch", ctx, _dummyID, mock.MatchedBy(func(patch repository.ItemPatch) bool {
+					return patch.SetCategory && *patch.CategoryID == categoryID
+				})).Return(repository.Item{ID: _dummyID, CategoryID: categoryID}, nil)
+			}
+
+			svc := service.NewItemService(mockRepo, &repository.ListRepositoryMock{}, mockCategories, &repository.TxManagerMock{}, suggest.NewIndex())
+
+			item, err := svc.CreateItem(ctx, domain.Item{Name: "Milk", CategoryID: categoryID})
+			if tt.wantErr == nil {
+				require.Equal(t, categoryID, item.CategoryID)
+			}
+			require.Equal(t, tt.wantErr, err)
+
+			_, err = svc.UpdateItem(ctx, domain.Item{ID: _dummyID, Name: "Milk", CategoryID: categoryID})
+			require.Equal(t, tt.wantErr, err)
+
+			categoryIDValue := categoryID
+			_, err = svc.PatchItem(ctx, _dummyID, domain.ItemPatch{CategoryID: &categoryIDValue, SetCategory: true})
+			require.Equal(t, tt.wantErr, err)
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGroupItems(t *testing.T) {
+	produce := repository.Category{ID: "category-1", Name: "Produce", Position: 1}
+	dairy := repository.Category{ID: "category-2", Name: "Dairy", Position: 2}
+	firstPage := repository.ListOptions{Limit: domain.MaxListLimit, Sort: []repository.SortField{{Field: repository.SortFieldName}}, Filter: repository.ItemFilter{ListID: repository.DefaultListID}}
+	secondPage := firstPage
+	secondPage.Cursor = "next"
+
+	tests := []struct {
+		name            string
+		givenCategories error
+		wantGroups      []domain.ItemGroup
+		wantErr         error
+	}{
+		{
+			name: "Given_ItemsOnTwoPages_When_GroupItems_Then_ExpectedGroupsInCategoryOrder",
+			wantGroups: []domain.ItemGroup{
+				{Category: &domain.Category{ID: "category-1", Name: "Produce", Position: 1}, Items: []domain.Item{{ID: "2", Name: "Apple", CategoryID: "category-1"}}},
+				{Category: &domain.Category{ID: "category-2", Name: "Dairy", Position: 2}, Items: []domain.Item{{ID: "3", Name: "Milk", CategoryID: "category-2"}}},
+				{Items: []domain.Item{{ID: "1", Name: "Bread"}}},
+			},
+		},
+		{
+			name:            "Given_CategoriesError_When_GroupItems_Then_ExpectedInternalError",
+			givenCategories: repository.NewGenericRepositoryError(errDummy),
+			wantErr:         service.NewErrorService(repository.NewGenericRepositoryError(errDummy), "internal server error", service.RepositorySource, http.StatusInternalServerError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockRepo := &repository.RepositoryMock{}
+			mockRepo.On("List", ctx, firstPage).Return(repository.ItemPage{
+				Items:      []repository.Item{{ID: "1", Name: "Bread"}, {ID: "2", Name: "Apple", CategoryID: "category-1"}},
+				NextCursor: "next",
+			}, nil)
+			mockRepo.On("List", ctx, secondPage).Return(repository.ItemPage{
+				Items: []repository.Item{{ID: "3", Name: "Milk", CategoryID: "category-2"}},
+			}, nil)
+			mockCategories := &repository.CategoryRepositoryMock{}
+			mockCategories.On("List", ctx).Return([]repository.Category{produce, dairy}, tt.givenCategories)
+
+			svc := service.NewItemService(mockRepo, &repository.ListRepositoryMock{}, mockCategories, &repository.TxManagerMock{}, suggest.NewIndex())
+			groups, err := svc.GroupItems(ctx, domain.ListOptions{Limit: 5, Cursor: "ignored", Sort: []domain.SortField{{Field: domain.SortByName}}})
+
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantGroups, groups)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemPrice(t *testing.T) {
+	price := func(cents domain.Amount) *domain.Amount { return &cents }
+	cents := func(cents int64) *int64 { return &cents }
+
+	tests := []struct {
+		name          string
+		givenPrice    *domain.Amount
+		givenCurrency domain.Currency
+		wantStored    *int64
+		wantCurrency  string
+		wantErr       error
+	}{
+		{
+			name:         "Given_PriceWithoutCurrency_When_ItemIsPriced_Then_DefaultCurrencyIsStored",
+			givenPrice:   price(1290),
+			wantStored:   cents(1290),
+			wantCurrency: "BRL",
+		},
+		{
+			name:          "Given_LowerCaseCurrency_When_ItemIsPriced_Then_CurrencyIsStoredInUpperCase",
+			givenPrice:    price(0),
+			givenCurrency: "usd",
+			wantStored:    cents(0),
+			wantCurrency:  "USD",
+		},
+		{
+			name:       "Given_NegativePrice_When_ItemIsPriced_Then_ExpectedInvalidItemError",
+			givenPrice: price(-1),
+			wantErr:    service.NewErrorInvalidItem(domain.ErrInvalidPrice),
+		},
+		{
+			name:          "Given_CurrencyWithoutPrice_When_ItemIsPriced_Then_ExpectedInvalidItemError",
+			givenCurrency: "BRL",
+			wantErr:       service.NewErrorInvalidItem(domain.ErrCurrencyWithoutPrice),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			stored := repository.Item{ID: _dummyID, Name: "Cheese", UnitPrice: tt.wantStored, Currency: tt.wantCurrency}
+
+			mockRepo := &repository.RepositoryMock{}
+			if tt.wantErr == nil {
+				hasPrice := func(unitPrice *int64, currency string) bool {
+					return reflect.DeepEqual(unitPrice, tt.wantStored) && currency == tt.wantCurrency
+				}
+				mockLastPosition(ctx, mockRepo, repository.DefaultListID, "")
+				mockRepo.On("Create", ctx, mock.MatchedBy(func(item repository.Item) bool { return hasPrice(item.UnitPrice, item.Currency) })).Return(stored, nil)
+				mockRepo.On("Update", ctx, mock.MatchedBy(func(item repository.Item) bool { return hasPrice(item.UnitPrice, item.Currency) })).Return(stored, nil)
+				mockRepo.On("Patch", ctx, _dummyID, mock.MatchedBy(func(patch repository.ItemPatch) bool {
+					return patch.SetPrice && hasPrice(patch.UnitPrice, patch.Currency)
+				})).Return(stored, nil)
+			}
+
+			svc := service.NewItemService(mockRepo, &repository.ListRepositoryMock{}, &repository.CategoryRepositoryMock{}, &repository.TxManagerMock{}, suggest.NewIndex())
+
+			item, err := svc.CreateItem(ctx, domain.Item{Name: "Cheese", UnitPrice: tt.givenPrice, Currency: tt.givenCurrency})
+			require.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				require.Equal(t, tt.givenPrice, item.UnitPrice)
+				require.Equal(t, domain.Currency(tt.wantCurrency), item.Currency)
+			}
+
+			_, err = svc.UpdateItem(ctx, domain.Item{ID: _dummyID, Name: "Cheese", UnitPrice: tt.givenPrice, Currency: tt.givenCurrency})
+			require.Equal(t, tt.wantErr, err)
+
+			_, err = svc.PatchItem(ctx, _dummyID, domain.ItemPatch{UnitPrice: tt.givenPrice, Currency: tt.givenCurrency, SetPrice: true})
+			require.Equal(t, tt.wantErr, err)
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSummarizePrices(t *testing.T) {
+	dairy := repository.Category{ID: "category-1", Name: "Dairy", Position: 1}
+
+	tests := []struct {
+		name            string
+		givenTotals     []domain.PriceTotal
+		givenSumErr     error
+		givenCategories error
+		wantSummaries   []domain.PriceSummary
+		wantErr         error
+	}{
+		{
+			name: "Given_PriceTotals_When_SummarizePrices_Then_ExpectedSummaryPerCurrency",
+			givenTotals: []domain.PriceTotal{
+				{Currency: "BRL", CategoryID: "category-1", Active: true, Cents: big.NewRat(19355, 10)},
+				{Currency: "BRL", Active: false, Cents: big.NewRat(500, 1)},
+			},
+			wantSummaries: []domain.PriceSummary{{
+				Currency:  "BRL",
+				Estimated: 1936,
+				Checked:   500,
+				Categories: []domain.CategorySubtotal{
+					{CategoryID: "category-1", Estimated: 1936},
+					{Checked: 500},
+				},
+			}},
+		},
+		{
+			name:          "Given_NoPricedItem_When_SummarizePrices_Then_ExpectedNoSummary",
+			givenTotals:   []domain.PriceTotal{},
+			wantSummaries: []domain.PriceSummary{},
+		},
+		{
+			name:        "Given_DatabaseError_When_SummarizePrices_Then_ExpectedInternalError",
+			givenSumErr: repository.NewGenericRepositoryError(errDummy),
+			wantErr:     service.NewErrorService(repository.NewGenericRepositoryError(errDummy), "internal server error", service.RepositorySource, http.StatusInternalServerError),
+		},
+		{
+			name: "Given_TotalOverflowingAmount_When_SummarizePrices_Then_ExpectedInvalidTotalError",
+			givenTotals: []domain.PriceTotal{
+				{Currency: "BRL", Active: true, Cents: big.NewRat(math.MaxInt64, 1)},
+				{Currency: "BRL", Active: true, Cents: big.NewRat(1, 1)},
+			},
+			wantErr: service.NewErrorInvalidTotal(fmt.Errorf("total in BRL: %w", domain.ErrAmountOutOfRange)),
+		},
+		{
+			name:            "Given_CategoriesError_When_SummarizePrices_Then_ExpectedInternalError",
+			givenTotals:     []domain.PriceTotal{},
+			givenCategories: repository.NewGenericRepositoryError(errDummy),
+			wantErr:         service.NewErrorService(repository.NewGenericRepositoryError(errDummy), "internal server error", service.RepositorySource, http.StatusInternalServerError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockRepo := &repository.RepositoryMock{}
+			mockRepo.On("SumPrices", ctx, repository.ItemFilter{ListID: repository.DefaultListID, Active: &_true}).Return(tt.givenTotals, tt.givenSumErr)
+			mockCategories := &repository.CategoryRepositoryMock{}
+			mockCategories.On("List", ctx).Return([]repository.Category{dairy}, tt.givenCategories)
+
+			svc := service.NewItemService(mockRepo, &repository.ListRepositoryMock{}, mockCategories, &repository.TxManagerMock{}, suggest.NewIndex())
+			summaries, err := svc.SummarizePrices(ctx, domain.ItemFilter{Active: &_true})
+
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantSummaries, summaries)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBatchItems(t *testing.T) {
+	createdItem := mockOutputRepositoryItem()
+	tooManyOps := make([]domain.BatchOperation, domain.MaxBatchOperations+1)
+
+	tests := []struct {
+		name               string
+		givenOps           []domain.BatchOperation
+		givenAtomic        bool
+		givenResults       []repository.BatchResult
+		givenRepositoryErr error
+		// givenStoredListID is the list of the stored item, the default one when empty
+		givenStoredListID string
+		wantRepositoryOps []repository.BatchOperationType
+		wantResults       []domain.BatchResult
+		wantErr           error
+	}{
+		{
+			name: "Given_MixedOperations_When_BatchItems_Then_ExpectedResultPerOperation",
+			givenOps: []domain.BatchOperation{
+				{Type: domain.BatchCreate, Item: domain.Item{Name: "updated-name", Active: true}},
+				{Type: domain.BatchUpdate, Item: mockServiceItem()},
+				{Type: domain.BatchDelete, Item: domain.Item{ID: _dummyID}},
+			},
+			givenResults: []repository.BatchResult{
+				{Item: createdItem},
+				{Err: repository.NewItemNotFoundError()},
+				{},
+			},
+			wantRepositoryOps: []repository.BatchOperationType{repository.BatchCreate, repository.BatchUpdate, repository.BatchDelete},
+			wantResults: []domain.BatchResult{
+				{Item: mockServiceItem()},
+				{Err: mockNotFoundRepositoryError()},
+				{},
+			},
+		},
+		{
+			name: "Given_InvalidOperations_When_BatchItems_Then_OnlyValidOnesAreSentToRepository",
+			givenOps: []domain.BatchOperation{
+				{Type: domain.BatchUpdate, Item: domain.Item{Name: "no-id"}},
+				{Type: "rename", Item: mockServiceItem()},
+				{Type: domain.BatchDelete, Item: domain.Item{ID: _dummyID}},
+			},
+			givenResults:      []repository.BatchResult{{}},
+			wantRepositoryOps: []repository.BatchOperationType{repository.BatchDelete},
+			wantResults: []domain.BatchResult{
+				{Err: service.NewErrorEmptyItem()},
+				{Err: service.NewErrorInvalidOperation(errors.New(`unknown operation "rename"`))},
+				{},
+			},
+		},
+		{
+			name: "Given_FailedOperationAndAtomic_When_BatchItems_Then_OthersAreReportedAsNotApplied",
+			givenOps: []domain.BatchOperation{
+				{Type: domain.BatchCreate, Item: domain.Item{Name: "updated-name", Active: true}},
+				{Type: domain.BatchDelete, Item: domain.Item{ID: _dummyID}},
+				{Type: domain.BatchUpdate, Item: mockServiceItem()},
+			},
+			givenAtomic: true,
+			givenResults: []repository.BatchResult{
+				{Item: createdItem},
+				{Err: repository.NewItemNotFoundError()},
+			},
+			wantRepositoryOps: []repository.BatchOperationType{repository.BatchCreate, repository.BatchDelete, repository.BatchUpdate},
+			wantResults: []domain.BatchResult{
+				{Err: service.NewErrorFailedDependency()},
+				{Err: mockNotFoundRepositoryError()},
+				{Err: service.NewErrorFailedDependency()},
+			},
+		},
+		{
+			name: "Given_InvalidOperationAndAtomic_When_BatchItems_Then_NothingIsSentToRepository",
+			givenOps: []domain.BatchOperation{
+				{Type: domain.BatchDelete, Item: domain.Item{ID: _dummyID}},
+				{Type: domain.BatchDelete},
+			},
+			givenAtomic: true,
+			wantResults: []domain.BatchResult{
+				{Err: service.NewErrorFailedDependency()},
+				{Err: service.NewErrorEmptyItem()},
+			},
+		},
+		{
+			name: "Given_OperationsOnAnotherList_When_BatchItems_Then_ItemsAreCreatedInItAndItsItemsChanged",
+			givenOps: []domain.BatchOperation{
+				{Type: domain.BatchCreate, Item: domain.Item{ListID: "groceries", Name: "updated-name", Active: true}},
+				{Type: domain.BatchDelete, Item: domain.Item{ListID: "groceries", ID: _dummyID}},
+			},
+			givenResults:      []repository.BatchResult{{Item: createdItem}, {}},
+			givenStoredListID: "groceries",
+			wantRepositoryOps: []repository.BatchOperationType{repository.BatchCreate, repository.BatchDelete},
+			wantResults:       []domain.BatchResult{{Item: mockServiceItem()}, {}},
+		},
+		{
+			name: "Given_ItemsOfAnotherList_When_BatchItems_Then_ExpectedNotFoundErrors",
+			givenOps: []domain.BatchOperation{
+				{Type: domain.BatchUpdate, Item: mockServiceItem()},
+				{Type: domain.BatchDelete, Item: domain.Item{ID: _dummyID}},
+			},
+			givenStoredListID: "groceries",
+			wantResults: []domain.BatchResult{
+				{Err: mockNotFoundRepositoryError()},
+				{Err: mockNotFoundRepositoryError()},
+			},
+		},
+		{
+			name:    "Given_NoOperations_When_BatchItems_Then_ExpectedInvalidBatchError",
+			wantErr: service.NewErrorInvalidBatch(errors.New("the batch has no operations")),
+		},
+		{
+			name:     "Given_TooManyOperations_When_BatchItems_Then_ExpectedInvalidBatchError",
+			givenOps: tooManyOps,
+			wantErr:  service.NewErrorInvalidBatch(errors.New("the batch has more than 100 operations")),
+		},
+		{
+			name: "Given_RepositoryError_When_BatchItems_Then_ExpectedInternalErrorResult",
+			givenOps: []domain.BatchOperation{
+				{Type: domain.BatchDelete, Item: domain.Item{ID: _dummyID}},
+			},
+			givenRepositoryErr: repository.NewGenericRepositoryError(errDummy),
+			wantRepositoryOps:  []repository.BatchOperationType{repository.BatchDelete},
+			wantResults: []domain.BatchResult{
+				{Err: service.NewErrorService(repository.NewGenericRepositoryError(errDummy), "internal server error", service.RepositorySource, http.StatusInternalServerError)},
+			},
+		},
+		{
+			name: "Given_RepositoryErrorAndAtomic_When_BatchItems_Then_ExpectedInternalError",
+			givenOps: []domain.BatchOperation{
+				{Type: domain.BatchDelete, Item: domain.Item{ID: _dummyID}},
+			},
+			givenAtomic:        true,
+			givenRepositoryErr: repository.NewGenericRepositoryError(errDummy),
+			wantRepositoryOps:  []repository.BatchOperationType{repository.BatchDelete},
 			wantErr:            mockInternalServerError(repository.NewGenericRepositoryError(errDummy)),
 		},
 	}
@@ -315,19 +1448,43 @@ func TestBulkUpdateActive(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
 
+			storedListID := repository.DefaultListID
+			if tt.givenStoredListID != "" {
+				storedListID = tt.givenStoredListID
+			}
+
 			mockRepo := &repository.RepositoryMock{}
-			mockRepo.On("BulkUpdateActive", ctx, tt.givenActive).Return(tt.givenMatchedCount, tt.givenModifiedCount, tt.givenRepositoryErr)
+			mockLastPosition(ctx, mockRepo, storedListID, "").Maybe()
+			mockRepo.On("GetByID", ctx, _dummyID).Return(repository.Item{ID: _dummyID, ListID: storedListID}, nil).Maybe()
+			if tt.givenAtomic && tt.wantRepositoryOps != nil {
+				mockRepo.On("Batch", ctx, mock.MatchedBy(validateBatchOperations(tt.wantRepositoryOps)), true).
+					Return(tt.givenResults, tt.givenRepositoryErr)
+			}
+			// Without atomic, every operation is sent on its own
+			for i, opType := range tt.wantRepositoryOps {
+				if tt.givenAtomic {
+					break
+				}
+				var results []repository.BatchResult
+				if i < len(tt.givenResults) {
+					results = tt.givenResults[i : i+1]
+				}
+				mockRepo.On("Batch", ctx, mock.MatchedBy(validateBatchOperations([]repository.BatchOperationType{opType})), false).
+					Return(results, tt.givenRepositoryErr).Once()
+			}
+			mockLists := &repository.ListRepositoryMock{}
+			mockLists.On("Lock", ctx, "groceries").Return(nil).Maybe()
 
-			svc := service.NewItemService(mockRepo)
-			matchedCount, modifiedCount, err := svc.BulkUpdateActive(ctx, tt.givenActive)
+			svc := service.NewItemService(mockRepo, mockLists, &repository.CategoryRepositoryMock{}, &repository.TxManagerMock{}, suggest.NewIndex())
+			results, err := svc.BatchItems(ctx, tt.givenOps, tt.givenAtomic)
 
 			if tt.wantErr != nil {
 				require.ErrorContains(t, err, tt.wantErr.Error())
 			} else {
 				require.NoError(t, err)
-				require.Equal(t, tt.wantMatchedCount, matchedCount)
-				require.Equal(t, tt.wantModifiedCount, modifiedCount)
+				require.Equal(t, tt.wantResults, results)
 			}
+			mockRepo.AssertExpectations(t)
 		})
 	}
 }
@@ -374,8 +1531,36 @@ func mockInternalServerError(err error) error {
 	)
 }
 
+// mockLastPosition mocks the read of the last position of the list, which the created items go after
+func mockLastPosition(ctx context.Context, mockRepo *repository.RepositoryMock, listID, position string) *mock.Call {
+	page := repository.ItemPage{Items: []repository.Item{}}
+	if position != "" {
+		page.Items = append(page.Items, repository.Item{ID: "last", ListID: listID, Position: position})
+	}
+	return mockRepo.On("List", ctx, repository.ListOptions{
+		Limit:  1,
+		Filter: repository.ItemFilter{ListID: listID},
+		Sort:   []repository.SortField{{Field: repository.SortFieldPosition, Desc: true}},
+	}).Return(page, nil)
+}
+
 func validateRepositoryItem(expected repository.Item) func(item repository.Item) bool {
 	return func(actual repository.Item) bool {
-		return actual.Name == expected.Name && actual.Active == expected.Active
+		return actual.Name == expected.Name && actual.Active == expected.Active &&
+			reflect.DeepEqual(actual.Quantity, expected.Quantity) && actual.Unit == expected.Unit
+	}
+}
+
+func validateBatchOperations(expected []repository.BatchOperationType) func(ops []repository.BatchOperation) bool {
+	return func(ops []repository.BatchOperation) bool {
+		if len(ops) != len(expected) {
+			return false
+		}
+		for i, op := range ops {
+			if op.Type != expected[i] || op.Item.ID == "" {
+				return false
+			}
+		}
+		return true
 	}
 }
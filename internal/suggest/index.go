@@ -0,0 +1,233 @@
+// Package suggest autocompletes item names while the user types. Index keeps
+// every name ever given to an item in a prefix tree of folded names (case and
+// diacritics removed), with the number of items that used it, and looks up
+// the names starting with a prefix or with a slightly mistyped one.
+package suggest
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/collation"
+)
+
+// Suggestion is a known item name and how many times it was used
+type Suggestion struct {
+	Name  string
+	Count int
+	// Distance is the number of edits between the prefix and the name's beginning
+	Distance int
+}
+
+// Index is an in-memory prefix index of item names, safe for concurrent use.
+type Index struct {
+	mu   sync.RWMutex
+	root *node
+	// names holds the folded name last recorded for each item, so updates that
+	// keep the name are not counted again
+	names map[string]string
+	size  int
+}
+
+type node struct {
+	children []edge
+	// name is the spelling suggested for the folded key ending at this node
+	// (the most used one) and count its number of uses; count is zero on the
+	// inner nodes
+	name      string
+	count     int
+	spellings map[string]int
+}
+
+type edge struct {
+	r    rune
+	node *node
+}
+
+// child returns the child of n reached with r, or nil
+func (n *node) child(r rune) *node {
+	for _, e := range n.children {
+		if e.r == r {
+			return e.node
+		}
+	}
+	return nil
+}
+
+// NewIndex returns an empty index
+func NewIndex() *Index {
+	return &Index{root: &node{}, names: map[string]string{}}
+}
+
+// Record counts a use of name by the item id when the item is new or was renamed
+func (x *Index) Record(id, name string) {
+	x.record(id, name, true)
+}
+
+// RecordNew counts a use of name by the item id only when the index doesn't know
+// the item yet, so that loading the stored items never undoes a newer Record
+func (x *Index) RecordNew(id, name string) {
+	x.record(id, name, false)
+}
+
+// record counts a use of name by the item id, when the item is new or, with
+// rename, was renamed
+func (x *Index) record(id, name string, rename bool) {
+	key := strings.TrimSpace(collation.Fold(name))
+	if key == "" {
+		return
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if known, ok := x.names[id]; known == key || ok && !rename {
+		return
+	}
+	x.names[id] = key
+
+	n := x.root
+	for _, r := range key {
+		child := n.child(r)
+		if child == nil {
+			child = &node{}
+			n.children = append(n.children, edge{r: r, node: child})
+		}
+		n = child
+	}
+
+	if n.count == 0 {
+		x.size++
+		n.spellings = map[string]int{}
+	}
+	n.count++
+	n.spellings[name]++
+	if n.spellings[name] > n.spellings[n.name] || n.name == "" {
+		n.name = name
+	}
+}
+
+// Len returns the number of distinct names in the index
+func (x *Index) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return x.size
+}
+
+// Suggest returns up to limit names starting with prefix, allowing MaxEdits(prefix)
+// typos in it. The closest names come first, then the most used ones.
+func (x *Index) Suggest(prefix string, limit int) []Suggestion {
+	key := []rune(strings.TrimSpace(collation.Fold(prefix)))
+	if len(key) == 0 || limit <= 0 {
+		return []Suggestion{}
+	}
+	maxEdits := MaxEdits(prefix)
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	// Levenshtein rows of the prefix against the path from the root: row[i] is
+	// the distance between key[:i] and the current path. The rows of each depth
+	// are reused between siblings.
+	row := make([]int, len(key)+1)
+	for i := range row {
+		row[i] = i
+	}
+
+	s := searcher{key: key, maxEdits: maxEdits, found: map[*node]int{}}
+	s.search(x.root, row, len(key), 0)
+	found := s.found
+
+	suggestions := make([]Suggestion, 0, len(found))
+	for n, distance := range found {
+		suggestions = append(suggestions, Suggestion{Name: n.name, Count: n.count, Distance: distance})
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.Distance != b.Distance {
+			return a.Distance < b.Distance
+		}
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if c := collation.Compare(a.Name, b.Name); c != 0 {
+			return c < 0
+		}
+		return a.Name < b.Name
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// MaxEdits is the number of typos tolerated in a prefix: none for the first two
+// letters, where any name would match, then one, and two from six letters on.
+func MaxEdits(prefix string) int {
+	switch n := utf8.RuneCountInString(prefix); {
+	case n < 3:
+		return 0
+	case n < 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+type searcher struct {
+	key      []rune
+	maxEdits int
+	rows     [][]int
+	found    map[*node]int
+}
+
+// search walks the subtree of n, whose path has the distance row row. A name
+// matches with the smallest distance between the whole prefix and any
+// beginning of it (best carries that distance down from the ancestors).
+// Subtrees that can no longer match are pruned.
+func (s *searcher) search(n *node, row []int, best, depth int) {
+	best = min(best, row[len(s.key)])
+	if best == 0 {
+		// No descendant can do better than an exact prefix
+		n.collect(best, s.found)
+		return
+	}
+	if best <= s.maxEdits && n.count > 0 {
+		s.found[n] = best
+	}
+	if len(n.children) == 0 {
+		return
+	}
+
+	if len(s.rows) == depth {
+		s.rows = append(s.rows, make([]int, len(row)))
+	}
+	next := s.rows[depth]
+	for _, e := range n.children {
+		next[0] = row[0] + 1
+		smallest := next[0]
+		for i := 1; i < len(row); i++ {
+			cost := 1
+			if s.key[i-1] == e.r {
+				cost = 0
+			}
+			next[i] = min(next[i-1]+1, row[i]+1, row[i-1]+cost)
+			smallest = min(smallest, next[i])
+		}
+		if smallest <= s.maxEdits || best <= s.maxEdits {
+			s.search(e.node, next, best, depth+1)
+		}
+	}
+}
+
+// collect records every name of the subtree with the given distance.
+func (n *node) collect(distance int, found map[*node]int) {
+	if n.count > 0 {
+		found[n] = distance
+	}
+	for _, e := range n.children {
+		e.node.collect(distance, found)
+	}
+}
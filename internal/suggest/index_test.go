@@ -0,0 +1,160 @@
+package suggest_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lucaspereirasilva0/list-manager-api/internal/suggest"
+)
+
+func newIndex(names ...string) *suggest.Index {
+	index := suggest.NewIndex()
+	for i, name := range names {
+		index.Record(fmt.Sprintf("id-%d", i), name)
+	}
+	return index
+}
+
+func TestIndex_Suggest(t *testing.T) {
+	index := newIndex(
+		"Feijão", "feijão", "Feijão preto", "Feijão", "Farinha",
+		"Arroz", "Arroz integral", "Arroz", "Arroz", "Arroba",
+		"Leite", "Leite condensado",
+	)
+
+	tests := []struct {
+		name        string
+		givenPrefix string
+		givenLimit  int
+		want        []suggest.Suggestion
+	}{
+		{
+			name:        "Given_Prefix_When_Suggest_Then_MostUsedNamesComeFirst",
+			givenPrefix: "arr",
+			givenLimit:  10,
+			want: []suggest.Suggestion{
+				{Name: "Arroz", Count: 3},
+				{Name: "Arroba", Count: 1},
+				{Name: "Arroz integral", Count: 1},
+			},
+		},
+		{
+			name:        "Given_PrefixWithTypo_When_Suggest_Then_ExactPrefixesComeBeforeCloseOnes",
+			givenPrefix: "arroz",
+			givenLimit:  10,
+			want: []suggest.Suggestion{
+				{Name: "Arroz", Count: 3},
+				{Name: "Arroz integral", Count: 1},
+				// "arrob" is one edit away from "arroz"
+				{Name: "Arroba", Count: 1, Distance: 1},
+			},
+		},
+		{
+			name:        "Given_PrefixWithoutAccents_When_Suggest_Then_MostUsedSpellingIsSuggested",
+			givenPrefix: "FEIJAO",
+			givenLimit:  10,
+			want: []suggest.Suggestion{
+				{Name: "Feijão", Count: 3},
+				{Name: "Feijão preto", Count: 1},
+			},
+		},
+		{
+			name:        "Given_MistypedPrefix_When_Suggest_Then_CloseNamesAreSuggested",
+			givenPrefix: "fejao",
+			givenLimit:  10,
+			want: []suggest.Suggestion{
+				{Name: "Feijão", Count: 3, Distance: 1},
+				{Name: "Feijão preto", Count: 1, Distance: 1},
+			},
+		},
+		{
+			name:        "Given_ShortPrefix_When_Suggest_Then_NoTypoIsTolerated",
+			givenPrefix: "fa",
+			givenLimit:  10,
+			want:        []suggest.Suggestion{{Name: "Farinha", Count: 1}},
+		},
+		{
+			name:        "Given_Limit_When_Suggest_Then_OnlyTheBestNamesAreReturned",
+			givenPrefix: "lei",
+			givenLimit:  1,
+			want:        []suggest.Suggestion{{Name: "Leite", Count: 1}},
+		},
+		{
+			name:        "Given_UnknownPrefix_When_Suggest_Then_ExpectedEmptySuggestions",
+			givenPrefix: "chocolate",
+			givenLimit:  10,
+			want:        []suggest.Suggestion{},
+		},
+		{
+			name:        "Given_EmptyPrefix_When_Suggest_Then_ExpectedEmptySuggestions",
+			givenPrefix: " ",
+			givenLimit:  10,
+			want:        []suggest.Suggestion{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, index.Suggest(tt.givenPrefix, tt.givenLimit))
+		})
+	}
+}
+
+func TestIndex_Record(t *testing.T) {
+	index := suggest.NewIndex()
+
+	index.Record("1", "Café")
+	index.Record("1", "café") // same folded name: an update that keeps the name
+	index.Record("2", "Café")
+	require.Equal(t, []suggest.Suggestion{{Name: "Café", Count: 2}}, index.Suggest("caf", 10))
+
+	// Renaming counts the new name; the old one stays in the history
+	index.Record("2", "Cacau")
+	require.Equal(t, []suggest.Suggestion{{Name: "Café", Count: 2}, {Name: "Cacau", Count: 1}}, index.Suggest("ca", 10))
+	require.Equal(t, 2, index.Len())
+
+	index.Record("3", " ")
+	require.Equal(t, 2, index.Len())
+}
+
+func TestIndex_RecordNew(t *testing.T) {
+	index := suggest.NewIndex()
+
+	index.RecordNew("1", "Café")
+	index.Record("2", "Cacau")
+	// A stale name of a known item, read before it was renamed, is not counted
+	index.RecordNew("2", "Café")
+	require.Equal(t, []suggest.Suggestion{{Name: "Cacau", Count: 1}, {Name: "Café", Count: 1}}, index.Suggest("ca", 10))
+}
+
+func TestMaxEdits(t *testing.T) {
+	require.Equal(t, 0, suggest.MaxEdits("ár"))
+	require.Equal(t, 1, suggest.MaxEdits("arr"))
+	require.Equal(t, 2, suggest.MaxEdits("feijão"))
+}
+
+func BenchmarkIndex_Suggest(b *testing.B) {
+	// Tens of thousands of random names of two words
+	random := rand.New(rand.NewSource(1))
+	word := func() string {
+		letters := []rune("abcdefghijlmnopqrstuvxzãçéêíóõú")
+		w := make([]rune, 4+random.Intn(6))
+		for i := range w {
+			w[i] = letters[random.Intn(len(letters))]
+		}
+		return string(w)
+	}
+	index := suggest.NewIndex()
+	for i := 0; i < 50000; i++ {
+		index.Record(fmt.Sprint(i), word()+" "+word())
+	}
+
+	prefixes := []string{"ab", "fej", "arroz", "leite con", "macarrao"}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		index.Suggest(prefixes[i%len(prefixes)], 10)
+	}
+}